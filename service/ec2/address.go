@@ -0,0 +1,194 @@
+/*
+Copyright 2018 The Avi Networks.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package ec2
+
+import (
+	randomdata "github.com/Pallinder/go-randomdata"
+	aws "github.com/aws/aws-sdk-go/aws"
+	ec2 "github.com/aws/aws-sdk-go/service/ec2"
+)
+
+// addressOf looks an elastic ip up by allocation id, or by public ip when no
+// allocation id is given.
+func (_m *EC2API) addressOf(allocationId *string, publicIp *string) (*ec2.Address, error) {
+	if allocationId != nil {
+		address, ok := _m.assignedelasticIps[*allocationId]
+		if !ok {
+			return nil, newAwsError("InvalidAllocationID.NotFound", "The allocation ID '"+*allocationId+"' does not exist")
+		}
+		return address, nil
+	}
+	if publicIp != nil {
+		for _, address := range _m.assignedelasticIps {
+			if aws.StringValue(address.PublicIp) == *publicIp {
+				return address, nil
+			}
+		}
+		return nil, newAwsError("InvalidAddress.NotFound", "Address '"+*publicIp+"' not found.")
+	}
+	return nil, newAwsError("MissingParameter", "Either public IP or allocation id must be specified")
+}
+
+// addressOfAssociation looks an associated elastic ip up by association id,
+// or by public ip when no association id is given.
+func (_m *EC2API) addressOfAssociation(associationId *string, publicIp *string) (*ec2.Address, error) {
+	for _, address := range _m.assignedelasticIps {
+		if address.AssociationId == nil {
+			continue
+		}
+		if associationId != nil && *address.AssociationId == *associationId {
+			return address, nil
+		}
+		if associationId == nil && publicIp != nil && aws.StringValue(address.PublicIp) == *publicIp {
+			return address, nil
+		}
+	}
+	if associationId == nil && publicIp == nil {
+		return nil, newAwsError("MissingParameter", "Either public IP or association id must be specified")
+	}
+	return nil, newAwsError("InvalidAssociationID.NotFound", "The association ID '"+aws.StringValue(associationId)+"' does not exist")
+}
+
+// addressOnPrivateIp returns the elastic ip associated with a private ip of
+// an interface, if any.
+func (_m *EC2API) addressOnPrivateIp(networkInterfaceId string, privateIp string) *ec2.Address {
+	for _, address := range _m.assignedelasticIps {
+		if aws.StringValue(address.NetworkInterfaceId) == networkInterfaceId && aws.StringValue(address.PrivateIpAddress) == privateIp {
+			return address
+		}
+	}
+	return nil
+}
+
+// associateAddress links an elastic ip to a private ip of an interface and
+// mirrors the association onto the interface and the instance it is attached
+// to.
+func (_m *EC2API) associateAddress(address *ec2.Address, ntwInterface *ec2.NetworkInterface, privateIp *ec2.NetworkInterfacePrivateIpAddress) {
+	associationId := GiveRandomId("eipassoc-")
+	address.AssociationId = aws.String(associationId)
+	address.NetworkInterfaceId = ntwInterface.NetworkInterfaceId
+	address.NetworkInterfaceOwnerId = ntwInterface.OwnerId
+	address.PrivateIpAddress = privateIp.PrivateIpAddress
+	// an elastic ip on the primary ip takes the place of the auto assigned one
+	if aws.BoolValue(privateIp.Primary) {
+		_m.releasePublicIp(ntwInterface)
+	}
+	privateIp.Association = &ec2.NetworkInterfaceAssociation{
+		AllocationId:  address.AllocationId,
+		AssociationId: address.AssociationId,
+		IpOwnerId:     aws.String(defaultOwnerId),
+		PublicDnsName: _m.publicDnsNameForIp(aws.StringValue(ntwInterface.VpcId), *address.PublicIp),
+		PublicIp:      address.PublicIp,
+	}
+	if aws.BoolValue(privateIp.Primary) {
+		ntwInterface.Association = privateIp.Association
+	}
+	_m.refreshInstanceNetworkInterface(ntwInterface)
+}
+
+// disassociateAddress breaks the link set up by associateAddress. A running
+// instance launched with a public ip gets a new one in place of the elastic
+// ip on its primary ip.
+func (_m *EC2API) disassociateAddress(address *ec2.Address) {
+	ntwInterface, ok := _m.networkinterfaces[aws.StringValue(address.NetworkInterfaceId)]
+	if ok {
+		primary := false
+		for _, privateIp := range ntwInterface.PrivateIpAddresses {
+			if aws.StringValue(privateIp.PrivateIpAddress) != aws.StringValue(address.PrivateIpAddress) {
+				continue
+			}
+			privateIp.Association = nil
+			if aws.BoolValue(privateIp.Primary) {
+				ntwInterface.Association = nil
+				primary = true
+			}
+		}
+		_m.refreshInstanceNetworkInterface(ntwInterface)
+		if primary && ntwInterface.Attachment != nil {
+			if instance, ok := _m.getInstance(aws.StringValue(ntwInterface.Attachment.InstanceId)); ok && aws.Int64Value(instance.State.Code) == RUNNING {
+				_m.assignInstancePublicIp(instance)
+			}
+		}
+	}
+	address.AssociationId = nil
+	address.InstanceId = nil
+	address.NetworkInterfaceId = nil
+	address.NetworkInterfaceOwnerId = nil
+	address.PrivateIpAddress = nil
+}
+
+// describeAddress returns the view of an elastic ip DescribeAddresses reports,
+// with the instance the interface is attached to filled in.
+func (_m *EC2API) describeAddress(address *ec2.Address) *ec2.Address {
+	described := *address
+	described.InstanceId = nil
+	if ntwInterface, ok := _m.networkinterfaces[aws.StringValue(address.NetworkInterfaceId)]; ok && ntwInterface.Attachment != nil {
+		described.InstanceId = ntwInterface.Attachment.InstanceId
+	}
+	return &described
+}
+
+// pickPublicIp returns a public ip that is neither allocated as an elastic ip
+// nor auto assigned to an interface.
+func (_m *EC2API) pickPublicIp() string {
+	for {
+		ip := randomdata.IpV4Address()
+		taken := _m.assignedPublicIps[ip]
+		for _, address := range _m.assignedelasticIps {
+			if *address.PublicIp == ip {
+				taken = true
+			}
+		}
+		if !taken {
+			return ip
+		}
+	}
+}
+
+// assignPublicIp gives the primary ip of an interface a public ip from the
+// amazon pool, unless it already has a public ip.
+func (_m *EC2API) assignPublicIp(ntwInterface *ec2.NetworkInterface) {
+	if ntwInterface.Association != nil {
+		return
+	}
+	ip := _m.pickPublicIp()
+	_m.assignedPublicIps[ip] = true
+	association := &ec2.NetworkInterfaceAssociation{
+		IpOwnerId:     aws.String("amazon"),
+		PublicDnsName: _m.publicDnsNameForIp(aws.StringValue(ntwInterface.VpcId), ip),
+		PublicIp:      aws.String(ip),
+	}
+	ntwInterface.Association = association
+	for _, privateIp := range ntwInterface.PrivateIpAddresses {
+		if aws.BoolValue(privateIp.Primary) {
+			privateIp.Association = association
+		}
+	}
+	_m.refreshInstanceNetworkInterface(ntwInterface)
+}
+
+// releasePublicIp hands the auto assigned public ip of an interface back to
+// the pool, an elastic ip stays where it is.
+func (_m *EC2API) releasePublicIp(ntwInterface *ec2.NetworkInterface) {
+	if ntwInterface.Association == nil || ntwInterface.Association.AllocationId != nil {
+		return
+	}
+	delete(_m.assignedPublicIps, aws.StringValue(ntwInterface.Association.PublicIp))
+	ntwInterface.Association = nil
+	for _, privateIp := range ntwInterface.PrivateIpAddresses {
+		if aws.BoolValue(privateIp.Primary) {
+			privateIp.Association = nil
+		}
+	}
+	_m.refreshInstanceNetworkInterface(ntwInterface)
+}
@@ -0,0 +1,270 @@
+/*
+Copyright 2018 The Avi Networks.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package ec2
+
+import (
+	"strings"
+	"testing"
+
+	aws "github.com/aws/aws-sdk-go/aws"
+	ec2 "github.com/aws/aws-sdk-go/service/ec2"
+)
+
+func allocateTestAddress(t *testing.T, m *EC2API) *string {
+	t.Helper()
+	output, err := m.AllocateAddress(&ec2.AllocateAddressInput{})
+	if err != nil {
+		t.Fatalf("AllocateAddress: %v", err)
+	}
+	return output.AllocationId
+}
+
+func describeTestAddress(t *testing.T, m *EC2API, allocationId *string) *ec2.Address {
+	t.Helper()
+	output, err := m.DescribeAddresses(&ec2.DescribeAddressesInput{AllocationIds: []*string{allocationId}})
+	if err != nil {
+		t.Fatalf("DescribeAddresses(%s): %v", aws.StringValue(allocationId), err)
+	}
+	return output.Addresses[0]
+}
+
+func TestAssociateAddressMovesOnlyWhenAllowed(t *testing.T) {
+	m := newSeededMock(t)
+	first := createTestInterface(t, m, aws.String(m.GetDefaultSubnetID()))
+	second := createTestInterface(t, m, aws.String(m.GetDefaultSubnetID()))
+	allocationId := allocateTestAddress(t, m)
+
+	associated, err := m.AssociateAddress(&ec2.AssociateAddressInput{AllocationId: allocationId, NetworkInterfaceId: first.NetworkInterfaceId})
+	if err != nil {
+		t.Fatalf("AssociateAddress: %v", err)
+	}
+	address := describeTestAddress(t, m, allocationId)
+	if aws.StringValue(address.NetworkInterfaceId) != *first.NetworkInterfaceId || aws.StringValue(address.PrivateIpAddress) != *first.PrivateIpAddress {
+		t.Fatalf("address is %v, want it on %s", address, *first.NetworkInterfaceId)
+	}
+	again, err := m.AssociateAddress(&ec2.AssociateAddressInput{AllocationId: allocationId, NetworkInterfaceId: first.NetworkInterfaceId})
+	if err != nil || aws.StringValue(again.AssociationId) != *associated.AssociationId {
+		t.Fatalf("repeating the association gave %v, %v", again, err)
+	}
+
+	_, err = m.AssociateAddress(&ec2.AssociateAddressInput{AllocationId: allocationId, NetworkInterfaceId: second.NetworkInterfaceId})
+	expectErrorCode(t, err, "Resource.AlreadyAssociated")
+	if _, err := m.AssociateAddress(&ec2.AssociateAddressInput{
+		AllocationId:       allocationId,
+		NetworkInterfaceId: second.NetworkInterfaceId,
+		AllowReassociation: aws.Bool(true),
+	}); err != nil {
+		t.Fatalf("AssociateAddress with reassociation: %v", err)
+	}
+	interfaces, err := m.DescribeNetworkInterfaces(&ec2.DescribeNetworkInterfacesInput{NetworkInterfaceIds: []*string{first.NetworkInterfaceId}})
+	if err != nil {
+		t.Fatalf("DescribeNetworkInterfaces: %v", err)
+	}
+	if interfaces.NetworkInterfaces[0].Association != nil {
+		t.Fatalf("the address stayed on the interface it moved from: %v", interfaces.NetworkInterfaces[0].Association)
+	}
+
+	_, err = m.ReleaseAddress(&ec2.ReleaseAddressInput{AllocationId: allocationId})
+	expectErrorCode(t, err, "InvalidIPAddress.InUse")
+	if _, err := m.DisassociateAddress(&ec2.DisassociateAddressInput{AssociationId: describeTestAddress(t, m, allocationId).AssociationId}); err != nil {
+		t.Fatalf("DisassociateAddress: %v", err)
+	}
+	if _, err := m.ReleaseAddress(&ec2.ReleaseAddressInput{AllocationId: allocationId}); err != nil {
+		t.Fatalf("ReleaseAddress: %v", err)
+	}
+	_, err = m.DescribeAddresses(&ec2.DescribeAddressesInput{AllocationIds: []*string{allocationId}})
+	expectErrorCode(t, err, "InvalidAllocationID.NotFound")
+}
+
+func TestAssociateAddressWithAnInstanceId(t *testing.T) {
+	m := newSeededMock(t)
+	instance := runTestInstances(t, m, aws.String(m.GetDefaultSubnetID()), 1)[0]
+	m.CompleteTransitions()
+	allocationId := allocateTestAddress(t, m)
+	if _, err := m.AssociateAddress(&ec2.AssociateAddressInput{AllocationId: allocationId, InstanceId: instance.InstanceId}); err != nil {
+		t.Fatalf("AssociateAddress: %v", err)
+	}
+	if address := describeTestAddress(t, m, allocationId); aws.StringValue(address.InstanceId) != *instance.InstanceId {
+		t.Fatalf("address is on instance %s, want %s", aws.StringValue(address.InstanceId), *instance.InstanceId)
+	}
+	if publicIp := describeTestInstance(t, m, instance.InstanceId).PublicIpAddress; aws.StringValue(publicIp) != aws.StringValue(describeTestAddress(t, m, allocationId).PublicIp) {
+		t.Fatalf("instance has public ip %s", aws.StringValue(publicIp))
+	}
+
+	m.AppendInstance(&ec2.Instance{InstanceId: aws.String("i-without-interfaces")})
+	m.AppendInstance(&ec2.Instance{
+		InstanceId:        aws.String("i-with-a-lost-interface"),
+		NetworkInterfaces: []*ec2.InstanceNetworkInterface{{NetworkInterfaceId: aws.String("eni-missing")}},
+	})
+	for _, instanceId := range []string{"i-without-interfaces", "i-with-a-lost-interface"} {
+		_, err := m.AssociateAddress(&ec2.AssociateAddressInput{AllocationId: allocationId, InstanceId: aws.String(instanceId), AllowReassociation: aws.Bool(true)})
+		expectErrorCode(t, err, "InvalidInstanceID")
+	}
+	_, err := m.AssociateAddress(&ec2.AssociateAddressInput{AllocationId: allocationId, InstanceId: aws.String("i-missing")})
+	expectErrorCode(t, err, "InvalidInstanceID.NotFound")
+}
+
+func launchWithPublicIp(t *testing.T, m *EC2API, subnetId *string) *ec2.Instance {
+	t.Helper()
+	output, err := m.RunInstances(&ec2.RunInstancesInput{
+		ImageId:  aws.String("ami-test"),
+		MinCount: aws.Int64(1),
+		MaxCount: aws.Int64(1),
+		NetworkInterfaces: []*ec2.InstanceNetworkInterfaceSpecification{
+			{DeviceIndex: aws.Int64(0), SubnetId: subnetId, AssociatePublicIpAddress: aws.Bool(true)},
+		},
+	})
+	if err != nil {
+		t.Fatalf("RunInstances: %v", err)
+	}
+	return output.Instances[0]
+}
+
+func TestPublicIpFollowsTheInstanceState(t *testing.T) {
+	m := newSeededMock(t)
+	subnet := createTestSubnet(t, m, "10.0.1.0/24")
+	instance := launchWithPublicIp(t, m, subnet.SubnetId)
+	launched := describeTestInstance(t, m, instance.InstanceId)
+	if launched.PublicIpAddress == nil {
+		t.Fatal("instance launched with a public ip has none")
+	}
+	if want := "ip-10-0-1-4.ec2.internal"; aws.StringValue(launched.PrivateDnsName) != want {
+		t.Errorf("private dns name is %s, want %s", aws.StringValue(launched.PrivateDnsName), want)
+	}
+
+	ids := []*string{instance.InstanceId}
+	if _, err := m.StopInstances(&ec2.StopInstancesInput{InstanceIds: ids}); err != nil {
+		t.Fatalf("StopInstances: %v", err)
+	}
+	m.CompleteTransitions()
+	if publicIp := describeTestInstance(t, m, instance.InstanceId).PublicIpAddress; publicIp != nil {
+		t.Fatalf("stopped instance kept public ip %s", *publicIp)
+	}
+	if _, err := m.StartInstances(&ec2.StartInstancesInput{InstanceIds: ids}); err != nil {
+		t.Fatalf("StartInstances: %v", err)
+	}
+	m.CompleteTransitions()
+	if describeTestInstance(t, m, instance.InstanceId).PublicIpAddress == nil {
+		t.Fatal("restarted instance got no public ip")
+	}
+
+	// an elastic ip replaces the public ip and survives a stop
+	allocationId := allocateTestAddress(t, m)
+	if _, err := m.AssociateAddress(&ec2.AssociateAddressInput{AllocationId: allocationId, InstanceId: instance.InstanceId}); err != nil {
+		t.Fatalf("AssociateAddress: %v", err)
+	}
+	if _, err := m.StopInstances(&ec2.StopInstancesInput{InstanceIds: ids}); err != nil {
+		t.Fatalf("StopInstances: %v", err)
+	}
+	m.CompleteTransitions()
+	elasticIp := describeTestAddress(t, m, allocationId).PublicIp
+	if publicIp := describeTestInstance(t, m, instance.InstanceId).PublicIpAddress; aws.StringValue(publicIp) != aws.StringValue(elasticIp) {
+		t.Fatalf("stopped instance has public ip %s, want elastic ip %s", aws.StringValue(publicIp), aws.StringValue(elasticIp))
+	}
+}
+
+func TestDisassociateAddressRestoresThePublicIp(t *testing.T) {
+	m := newSeededMock(t)
+	subnet := createTestSubnet(t, m, "10.0.1.0/24")
+	withPublicIp := launchWithPublicIp(t, m, subnet.SubnetId)
+	withoutPublicIp := runTestInstances(t, m, subnet.SubnetId, 1)[0]
+	m.CompleteTransitions()
+
+	for _, test := range []struct {
+		name         string
+		instance     *ec2.Instance
+		wantPublicIp bool
+	}{
+		{"launched with a public ip", withPublicIp, true},
+		{"launched without a public ip", withoutPublicIp, false},
+	} {
+		allocationId := allocateTestAddress(t, m)
+		association, err := m.AssociateAddress(&ec2.AssociateAddressInput{AllocationId: allocationId, InstanceId: test.instance.InstanceId})
+		if err != nil {
+			t.Fatalf("%s: AssociateAddress: %v", test.name, err)
+		}
+		if _, err := m.DisassociateAddress(&ec2.DisassociateAddressInput{AssociationId: association.AssociationId}); err != nil {
+			t.Fatalf("%s: DisassociateAddress: %v", test.name, err)
+		}
+		elasticIp := aws.StringValue(describeTestAddress(t, m, allocationId).PublicIp)
+		described := describeTestInstance(t, m, test.instance.InstanceId)
+		publicIp := aws.StringValue(described.PublicIpAddress)
+		switch {
+		case publicIp == elasticIp:
+			t.Errorf("%s: instance kept the disassociated elastic ip %s", test.name, elasticIp)
+		case test.wantPublicIp && publicIp == "":
+			t.Errorf("%s: instance got no public ip back", test.name)
+		case !test.wantPublicIp && publicIp != "":
+			t.Errorf("%s: instance got public ip %s", test.name, publicIp)
+		}
+		ntwAssociation := describeTestInterface(t, m, described.NetworkInterfaces[0].NetworkInterfaceId).Association
+		if test.wantPublicIp && (ntwAssociation == nil || aws.StringValue(ntwAssociation.IpOwnerId) != "amazon" || aws.StringValue(ntwAssociation.PublicIp) != publicIp) {
+			t.Errorf("%s: interface association is %v, want the amazon owned %s", test.name, ntwAssociation, publicIp)
+		}
+	}
+
+	// a stopped instance waits for its start
+	ids := []*string{withPublicIp.InstanceId}
+	allocationId := allocateTestAddress(t, m)
+	association, err := m.AssociateAddress(&ec2.AssociateAddressInput{AllocationId: allocationId, InstanceId: withPublicIp.InstanceId})
+	if err != nil {
+		t.Fatalf("AssociateAddress: %v", err)
+	}
+	if _, err := m.StopInstances(&ec2.StopInstancesInput{InstanceIds: ids}); err != nil {
+		t.Fatalf("StopInstances: %v", err)
+	}
+	m.CompleteTransitions()
+	if _, err := m.DisassociateAddress(&ec2.DisassociateAddressInput{AssociationId: association.AssociationId}); err != nil {
+		t.Fatalf("DisassociateAddress: %v", err)
+	}
+	if publicIp := describeTestInstance(t, m, withPublicIp.InstanceId).PublicIpAddress; publicIp != nil {
+		t.Fatalf("stopped instance got public ip %s", *publicIp)
+	}
+}
+
+func TestPublicIpOnlyOnASingleNewInterface(t *testing.T) {
+	m := newSeededMock(t)
+	subnet := createTestSubnet(t, m, "10.0.1.0/24")
+	_, err := m.RunInstances(&ec2.RunInstancesInput{
+		ImageId:  aws.String("ami-test"),
+		MinCount: aws.Int64(1),
+		MaxCount: aws.Int64(1),
+		NetworkInterfaces: []*ec2.InstanceNetworkInterfaceSpecification{
+			{DeviceIndex: aws.Int64(0), SubnetId: subnet.SubnetId, AssociatePublicIpAddress: aws.Bool(true)},
+			{DeviceIndex: aws.Int64(1), SubnetId: subnet.SubnetId},
+		},
+	})
+	expectErrorCode(t, err, "InvalidParameterCombination")
+	if publicIp := runTestInstances(t, m, subnet.SubnetId, 1)[0].PublicIpAddress; publicIp != nil {
+		t.Fatalf("instance of a subnet without MapPublicIpOnLaunch got public ip %s", *publicIp)
+	}
+}
+
+func TestDnsNamesFollowTheRegion(t *testing.T) {
+	m := newSeededMock(t)
+	m.SetRegion("eu-west-1")
+	if _, err := m.ModifyVpcAttribute(&ec2.ModifyVpcAttributeInput{
+		VpcId:              aws.String(m.GetDefaultVPCID()),
+		EnableDnsHostnames: &ec2.AttributeBooleanValue{Value: aws.Bool(true)},
+	}); err != nil {
+		t.Fatalf("ModifyVpcAttribute: %v", err)
+	}
+	subnet := createTestSubnet(t, m, "10.0.1.0/24")
+	instance := describeTestInstance(t, m, launchWithPublicIp(t, m, subnet.SubnetId).InstanceId)
+	if want := "ip-10-0-1-4.eu-west-1.compute.internal"; aws.StringValue(instance.PrivateDnsName) != want {
+		t.Errorf("private dns name is %s, want %s", aws.StringValue(instance.PrivateDnsName), want)
+	}
+	if want := "ec2-" + strings.Replace(aws.StringValue(instance.PublicIpAddress), ".", "-", -1) + ".eu-west-1.compute.amazonaws.com"; aws.StringValue(instance.PublicDnsName) != want {
+		t.Errorf("public dns name is %s, want %s", aws.StringValue(instance.PublicDnsName), want)
+	}
+}
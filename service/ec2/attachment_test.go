@@ -0,0 +1,93 @@
+/*
+Copyright 2018 The Avi Networks.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package ec2
+
+import (
+	"testing"
+
+	aws "github.com/aws/aws-sdk-go/aws"
+	ec2 "github.com/aws/aws-sdk-go/service/ec2"
+)
+
+func createTestInterface(t *testing.T, m *EC2API, subnetId *string) *ec2.NetworkInterface {
+	t.Helper()
+	output, err := m.CreateNetworkInterface(&ec2.CreateNetworkInterfaceInput{SubnetId: subnetId})
+	if err != nil {
+		t.Fatalf("CreateNetworkInterface: %v", err)
+	}
+	return output.NetworkInterface
+}
+
+func TestAttachNetworkInterfaceKeepsDeviceIndexesApart(t *testing.T) {
+	m := newSeededMock(t)
+	engine := m.GetDefaultServiceEngine()
+	ntwInterface := createTestInterface(t, m, aws.String(m.GetDefaultSubnetID()))
+
+	// the seeded primary interface has no attachment but holds index 0
+	_, err := m.AttachNetworkInterface(&ec2.AttachNetworkInterfaceInput{
+		DeviceIndex:        aws.Int64(0),
+		InstanceId:         engine.InstanceId,
+		NetworkInterfaceId: ntwInterface.NetworkInterfaceId,
+	})
+	expectErrorCode(t, err, "InvalidParameterValue")
+
+	if _, err := m.AttachNetworkInterface(&ec2.AttachNetworkInterfaceInput{
+		DeviceIndex:        aws.Int64(1),
+		InstanceId:         engine.InstanceId,
+		NetworkInterfaceId: ntwInterface.NetworkInterfaceId,
+	}); err != nil {
+		t.Fatalf("AttachNetworkInterface: %v", err)
+	}
+	m.CompleteTransitions()
+	described, err := m.DescribeNetworkInterfaces(&ec2.DescribeNetworkInterfacesInput{NetworkInterfaceIds: []*string{ntwInterface.NetworkInterfaceId}})
+	if err != nil {
+		t.Fatalf("DescribeNetworkInterfaces: %v", err)
+	}
+	if attachment := described.NetworkInterfaces[0].Attachment; attachment == nil || aws.StringValue(attachment.Status) != "attached" {
+		t.Fatalf("interface attachment is %v, want attached", attachment)
+	}
+	if len(describeTestInstance(t, m, engine.InstanceId).NetworkInterfaces) != 2 {
+		t.Fatal("instance does not list the attached interface")
+	}
+
+	second := createTestInterface(t, m, aws.String(m.GetDefaultSubnetID()))
+	_, err = m.AttachNetworkInterface(&ec2.AttachNetworkInterfaceInput{
+		DeviceIndex:        aws.Int64(1),
+		InstanceId:         engine.InstanceId,
+		NetworkInterfaceId: second.NetworkInterfaceId,
+	})
+	expectErrorCode(t, err, "InvalidParameterValue")
+}
+
+func TestAttachNetworkInterfaceRejectsAnotherVpc(t *testing.T) {
+	m := newSeededMock(t)
+	vpc, err := m.CreateVpc(&ec2.CreateVpcInput{CidrBlock: aws.String("10.1.0.0/16")})
+	if err != nil {
+		t.Fatalf("CreateVpc: %v", err)
+	}
+	subnet, err := m.CreateSubnet(&ec2.CreateSubnetInput{
+		VpcId:            vpc.Vpc.VpcId,
+		CidrBlock:        aws.String("10.1.1.0/24"),
+		AvailabilityZone: aws.String(m.GetDefaultAvailabiltyZone()),
+	})
+	if err != nil {
+		t.Fatalf("CreateSubnet: %v", err)
+	}
+	ntwInterface := createTestInterface(t, m, subnet.Subnet.SubnetId)
+	_, err = m.AttachNetworkInterface(&ec2.AttachNetworkInterfaceInput{
+		DeviceIndex:        aws.Int64(1),
+		InstanceId:         m.GetDefaultServiceEngine().InstanceId,
+		NetworkInterfaceId: ntwInterface.NetworkInterfaceId,
+	})
+	expectErrorCode(t, err, "InvalidParameterCombination")
+}
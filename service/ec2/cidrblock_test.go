@@ -0,0 +1,261 @@
+/*
+Copyright 2018 The Avi Networks.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package ec2
+
+import (
+	"net"
+	"testing"
+
+	aws "github.com/aws/aws-sdk-go/aws"
+	ec2 "github.com/aws/aws-sdk-go/service/ec2"
+)
+
+// mainTestRoute gives the route of the vpc's main route table to the ipv4 or
+// ipv6 destination, nil if there is none.
+func mainTestRoute(t *testing.T, m *EC2API, vpcId *string, destination string) *ec2.Route {
+	t.Helper()
+	for _, routeTable := range describeTestRouteTables(t, m, filter("vpc-id", *vpcId), filter("association.main", "true")) {
+		for _, route := range routeTable.Routes {
+			if aws.StringValue(route.DestinationCidrBlock) == destination || aws.StringValue(route.DestinationIpv6CidrBlock) == destination {
+				return route
+			}
+		}
+	}
+	return nil
+}
+
+func describeTestVpc(t *testing.T, m *EC2API, vpcId *string) *ec2.Vpc {
+	t.Helper()
+	output, err := m.DescribeVpcs(&ec2.DescribeVpcsInput{VpcIds: []*string{vpcId}})
+	if err != nil {
+		t.Fatalf("DescribeVpcs(%s): %v", *vpcId, err)
+	}
+	return output.Vpcs[0]
+}
+
+// testIpv6Subnet gives the nth /64 of the vpc's amazon provided /56.
+func testIpv6Subnet(t *testing.T, m *EC2API, vpcId *string, nth byte) string {
+	t.Helper()
+	vpc := describeTestVpc(t, m, vpcId)
+	_, block, err := net.ParseCIDR(aws.StringValue(vpc.Ipv6CidrBlockAssociationSet[0].Ipv6CidrBlock))
+	if err != nil {
+		t.Fatalf("vpc %s has no ipv6 block: %v", *vpcId, err)
+	}
+	block.IP[7] += nth
+	block.Mask = net.CIDRMask(64, 128)
+	return block.String()
+}
+
+func TestAssociateVpcCidrBlockErrors(t *testing.T) {
+	m := newSeededMock(t)
+	vpc, _ := createTestVpc(t, m, "10.1.0.0/16", "10.1.1.0/24")
+	for _, test := range []struct {
+		name  string
+		input *ec2.AssociateVpcCidrBlockInput
+		code  string
+	}{
+		{"unknown vpc", &ec2.AssociateVpcCidrBlockInput{VpcId: aws.String("vpc-unknown"), CidrBlock: aws.String("10.2.0.0/16")}, "InvalidVpcID.NotFound"},
+		{"no block", &ec2.AssociateVpcCidrBlockInput{VpcId: vpc.VpcId}, "MissingParameter"},
+		{"both blocks", &ec2.AssociateVpcCidrBlockInput{VpcId: vpc.VpcId, CidrBlock: aws.String("10.2.0.0/16"), AmazonProvidedIpv6CidrBlock: aws.Bool(true)}, "InvalidParameterCombination"},
+		{"not a cidr", &ec2.AssociateVpcCidrBlockInput{VpcId: vpc.VpcId, CidrBlock: aws.String("10.2.0.0")}, "InvalidParameterValue"},
+		{"ipv6 cidr", &ec2.AssociateVpcCidrBlockInput{VpcId: vpc.VpcId, CidrBlock: aws.String("2600:1f18::/56")}, "InvalidParameterValue"},
+		{"too large", &ec2.AssociateVpcCidrBlockInput{VpcId: vpc.VpcId, CidrBlock: aws.String("10.0.0.0/15")}, "InvalidVpc.Range"},
+		{"too small", &ec2.AssociateVpcCidrBlockInput{VpcId: vpc.VpcId, CidrBlock: aws.String("10.2.0.0/29")}, "InvalidVpc.Range"},
+		{"overlaps the primary block", &ec2.AssociateVpcCidrBlockInput{VpcId: vpc.VpcId, CidrBlock: aws.String("10.1.128.0/17")}, "CidrConflict"},
+		{"another rfc1918 range", &ec2.AssociateVpcCidrBlockInput{VpcId: vpc.VpcId, CidrBlock: aws.String("192.168.0.0/16")}, "InvalidVpc.Range"},
+		{"another rfc1918 range", &ec2.AssociateVpcCidrBlockInput{VpcId: vpc.VpcId, CidrBlock: aws.String("172.16.0.0/16")}, "InvalidVpc.Range"},
+	} {
+		output, err := m.AssociateVpcCidrBlock(test.input)
+		if errorCode(err) != test.code {
+			t.Errorf("%s: got error %v, want code %s", test.name, err, test.code)
+		}
+		if output == nil || output.CidrBlockAssociation != nil || output.Ipv6CidrBlockAssociation != nil {
+			t.Errorf("%s: got output %v, want no association", test.name, output)
+		}
+	}
+	if len(describeTestVpc(t, m, vpc.VpcId).CidrBlockAssociationSet) != 1 {
+		t.Fatal("a rejected block was associated")
+	}
+}
+
+func TestAssociateVpcCidrBlockLimits(t *testing.T) {
+	m := newSeededMock(t)
+	vpc, _ := createTestVpc(t, m, "10.1.0.0/16", "10.1.1.0/24")
+	// a publicly routable block may join an rfc1918 vpc
+	for _, cidrBlock := range []string{"10.2.0.0/16", "10.3.0.0/16", "10.4.0.0/16", "100.64.0.0/16"} {
+		if _, err := m.AssociateVpcCidrBlock(&ec2.AssociateVpcCidrBlockInput{VpcId: vpc.VpcId, CidrBlock: aws.String(cidrBlock)}); err != nil {
+			t.Fatalf("AssociateVpcCidrBlock(%s): %v", cidrBlock, err)
+		}
+	}
+	_, err := m.AssociateVpcCidrBlock(&ec2.AssociateVpcCidrBlockInput{VpcId: vpc.VpcId, CidrBlock: aws.String("10.5.0.0/16")})
+	expectErrorCode(t, err, "CidrLimitExceeded")
+
+	if _, err := m.AssociateVpcCidrBlock(&ec2.AssociateVpcCidrBlockInput{VpcId: vpc.VpcId, AmazonProvidedIpv6CidrBlock: aws.Bool(true)}); err != nil {
+		t.Fatalf("AssociateVpcCidrBlock(ipv6): %v", err)
+	}
+	_, err = m.AssociateVpcCidrBlock(&ec2.AssociateVpcCidrBlockInput{VpcId: vpc.VpcId, AmazonProvidedIpv6CidrBlock: aws.Bool(true)})
+	expectErrorCode(t, err, "CidrLimitExceeded")
+}
+
+func TestVpcCidrBlocksCarryLocalRoutes(t *testing.T) {
+	m := newSeededMock(t)
+	m.SetManualTransitions(true)
+	vpc, _ := createTestVpc(t, m, "10.1.0.0/16", "10.1.1.0/24")
+	m.CompleteTransitions()
+
+	associated, err := m.AssociateVpcCidrBlock(&ec2.AssociateVpcCidrBlockInput{VpcId: vpc.VpcId, CidrBlock: aws.String("10.2.0.0/16")})
+	if err != nil {
+		t.Fatalf("AssociateVpcCidrBlock: %v", err)
+	}
+	if state := aws.StringValue(associated.CidrBlockAssociation.CidrBlockState.State); state != ec2.VpcCidrBlockStateCodeAssociating {
+		t.Fatalf("new block is %s, want associating", state)
+	}
+	ipv6, err := m.AssociateVpcCidrBlock(&ec2.AssociateVpcCidrBlockInput{VpcId: vpc.VpcId, AmazonProvidedIpv6CidrBlock: aws.Bool(true)})
+	if err != nil {
+		t.Fatalf("AssociateVpcCidrBlock(ipv6): %v", err)
+	}
+	ipv6Block := aws.StringValue(ipv6.Ipv6CidrBlockAssociation.Ipv6CidrBlock)
+	for _, destination := range []string{"10.1.0.0/16", "10.2.0.0/16", ipv6Block} {
+		if route := mainTestRoute(t, m, vpc.VpcId, destination); route == nil || aws.StringValue(route.GatewayId) != "local" {
+			t.Errorf("main route table has no local route to %s", destination)
+		}
+	}
+
+	// a block can't go before it is associated
+	_, err = m.DisassociateVpcCidrBlock(&ec2.DisassociateVpcCidrBlockInput{AssociationId: associated.CidrBlockAssociation.AssociationId})
+	expectErrorCode(t, err, "IncorrectState")
+	m.CompleteTransitions()
+	for _, associationId := range []*string{associated.CidrBlockAssociation.AssociationId, ipv6.Ipv6CidrBlockAssociation.AssociationId} {
+		if _, err := m.DisassociateVpcCidrBlock(&ec2.DisassociateVpcCidrBlockInput{AssociationId: associationId}); err != nil {
+			t.Fatalf("DisassociateVpcCidrBlock(%s): %v", *associationId, err)
+		}
+	}
+	if mainTestRoute(t, m, vpc.VpcId, "10.2.0.0/16") == nil {
+		t.Fatal("local route left before the block is disassociated")
+	}
+	m.CompleteTransitions()
+	for _, destination := range []string{"10.2.0.0/16", ipv6Block} {
+		if mainTestRoute(t, m, vpc.VpcId, destination) != nil {
+			t.Errorf("local route to %s left after the block is gone", destination)
+		}
+	}
+	if mainTestRoute(t, m, vpc.VpcId, "10.1.0.0/16") == nil {
+		t.Error("local route to the primary block is gone")
+	}
+	if blocks := describeTestVpc(t, m, vpc.VpcId); len(blocks.CidrBlockAssociationSet) != 1 || len(blocks.Ipv6CidrBlockAssociationSet) != 0 {
+		t.Fatalf("vpc keeps blocks %v and %v", blocks.CidrBlockAssociationSet, blocks.Ipv6CidrBlockAssociationSet)
+	}
+}
+
+func TestDisassociateVpcCidrBlockErrors(t *testing.T) {
+	m := newSeededMock(t)
+	vpc, _ := createTestVpc(t, m, "10.1.0.0/16", "10.1.1.0/24")
+	secondary, err := m.AssociateVpcCidrBlock(&ec2.AssociateVpcCidrBlockInput{VpcId: vpc.VpcId, CidrBlock: aws.String("10.2.0.0/16")})
+	if err != nil {
+		t.Fatalf("AssociateVpcCidrBlock: %v", err)
+	}
+	ipv6, err := m.AssociateVpcCidrBlock(&ec2.AssociateVpcCidrBlockInput{VpcId: vpc.VpcId, AmazonProvidedIpv6CidrBlock: aws.Bool(true)})
+	if err != nil {
+		t.Fatalf("AssociateVpcCidrBlock(ipv6): %v", err)
+	}
+	m.CompleteTransitions()
+	if _, err := m.CreateSubnet(&ec2.CreateSubnetInput{VpcId: vpc.VpcId, CidrBlock: aws.String("10.2.1.0/24")}); err != nil {
+		t.Fatalf("CreateSubnet: %v", err)
+	}
+	if _, err := m.CreateSubnet(&ec2.CreateSubnetInput{
+		VpcId:         vpc.VpcId,
+		CidrBlock:     aws.String("10.1.2.0/24"),
+		Ipv6CidrBlock: aws.String(testIpv6Subnet(t, m, vpc.VpcId, 0)),
+	}); err != nil {
+		t.Fatalf("CreateSubnet(ipv6): %v", err)
+	}
+
+	for _, test := range []struct {
+		name          string
+		associationId *string
+		code          string
+	}{
+		{"unknown association", aws.String("vpc-cidr-assoc-unknown"), "InvalidVpcCidrBlockAssociationID.NotFound"},
+		{"primary block", vpc.CidrBlockAssociationSet[0].AssociationId, "OperationNotPermitted"},
+		{"block with a subnet", secondary.CidrBlockAssociation.AssociationId, "DependencyViolation"},
+		{"ipv6 block with a subnet", ipv6.Ipv6CidrBlockAssociation.AssociationId, "DependencyViolation"},
+	} {
+		output, err := m.DisassociateVpcCidrBlock(&ec2.DisassociateVpcCidrBlockInput{AssociationId: test.associationId})
+		if errorCode(err) != test.code {
+			t.Errorf("%s: got error %v, want code %s", test.name, err, test.code)
+		}
+		if output == nil || output.CidrBlockAssociation != nil || output.Ipv6CidrBlockAssociation != nil {
+			t.Errorf("%s: got output %v, want no association", test.name, output)
+		}
+	}
+}
+
+func TestAssociateSubnetCidrBlock(t *testing.T) {
+	m := newSeededMock(t)
+	vpc, err := m.CreateVpc(&ec2.CreateVpcInput{CidrBlock: aws.String("10.1.0.0/16"), AmazonProvidedIpv6CidrBlock: aws.Bool(true)})
+	if err != nil {
+		t.Fatalf("CreateVpc: %v", err)
+	}
+	vpcId := vpc.Vpc.VpcId
+	m.CompleteTransitions()
+	subnets := []*string{}
+	for _, cidrBlock := range []string{"10.1.1.0/24", "10.1.2.0/24"} {
+		subnet, err := m.CreateSubnet(&ec2.CreateSubnetInput{VpcId: vpcId, CidrBlock: aws.String(cidrBlock)})
+		if err != nil {
+			t.Fatalf("CreateSubnet(%s): %v", cidrBlock, err)
+		}
+		subnets = append(subnets, subnet.Subnet.SubnetId)
+	}
+	first := testIpv6Subnet(t, m, vpcId, 1)
+	_, wide, _ := net.ParseCIDR(first)
+	wide.Mask = net.CIDRMask(56, 128)
+
+	associated, err := m.AssociateSubnetCidrBlock(&ec2.AssociateSubnetCidrBlockInput{SubnetId: subnets[0], Ipv6CidrBlock: aws.String(first)})
+	if err != nil {
+		t.Fatalf("AssociateSubnetCidrBlock: %v", err)
+	}
+	if aws.StringValue(associated.Ipv6CidrBlockAssociation.Ipv6CidrBlock) != first {
+		t.Fatalf("associated %s, want %s", aws.StringValue(associated.Ipv6CidrBlockAssociation.Ipv6CidrBlock), first)
+	}
+	for _, test := range []struct {
+		name      string
+		subnetId  *string
+		cidrBlock string
+		code      string
+	}{
+		{"unknown subnet", aws.String("subnet-unknown"), testIpv6Subnet(t, m, vpcId, 2), "InvalidSubnetID.NotFound"},
+		{"second block", subnets[0], testIpv6Subnet(t, m, vpcId, 2), "CidrLimitExceeded"},
+		{"ipv4 block", subnets[1], "10.1.3.0/24", "InvalidParameterValue"},
+		{"not a /64", subnets[1], wide.String(), "InvalidSubnet.Range"},
+		{"outside the vpc", subnets[1], "2600:1f18::/64", "InvalidSubnet.Range"},
+		{"block of another subnet", subnets[1], first, "InvalidSubnet.Conflict"},
+	} {
+		output, err := m.AssociateSubnetCidrBlock(&ec2.AssociateSubnetCidrBlockInput{SubnetId: test.subnetId, Ipv6CidrBlock: aws.String(test.cidrBlock)})
+		if errorCode(err) != test.code {
+			t.Errorf("%s: got error %v, want code %s", test.name, err, test.code)
+		}
+		if output == nil || output.Ipv6CidrBlockAssociation != nil {
+			t.Errorf("%s: got output %v, want no association", test.name, output)
+		}
+	}
+
+	// a released block can be associated again
+	m.CompleteTransitions()
+	if _, err := m.DisassociateSubnetCidrBlock(&ec2.DisassociateSubnetCidrBlockInput{AssociationId: associated.Ipv6CidrBlockAssociation.AssociationId}); err != nil {
+		t.Fatalf("DisassociateSubnetCidrBlock: %v", err)
+	}
+	m.CompleteTransitions()
+	if _, err := m.AssociateSubnetCidrBlock(&ec2.AssociateSubnetCidrBlockInput{SubnetId: subnets[1], Ipv6CidrBlock: aws.String(first)}); err != nil {
+		t.Fatalf("AssociateSubnetCidrBlock after the block was released: %v", err)
+	}
+}
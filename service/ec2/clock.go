@@ -0,0 +1,137 @@
+/*
+Copyright 2018 The Avi Networks.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package ec2
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	aws "github.com/aws/aws-sdk-go/aws"
+)
+
+// Clock is the source of time for every delay, timed error and state
+// transition of the mock. Use SetClock on the EC2API to swap it.
+type Clock interface {
+	Now() time.Time
+	Sleep(d time.Duration)
+	// SleepWithContext is Sleep cut short by the context, it then gives the
+	// context's error.
+	SleepWithContext(ctx aws.Context, d time.Duration) error
+	// AfterFunc calls f once d has elapsed.
+	AfterFunc(d time.Duration, f func())
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) Sleep(d time.Duration) {
+	time.Sleep(d)
+}
+
+func (realClock) SleepWithContext(ctx aws.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (realClock) AfterFunc(d time.Duration, f func()) {
+	time.AfterFunc(d, f)
+}
+
+type fakeTimer struct {
+	due time.Time
+	f   func()
+}
+
+// FakeClock is a Clock that only moves when told to. Sleep advances the clock
+// instead of blocking, so recorder delays cost no wall time while timed
+// errors and transitions still observe the elapsed time.
+type FakeClock struct {
+	mutex  sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+}
+
+// NewFakeClock gives a FakeClock starting at now.
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{
+		now:    now,
+		timers: make([]*fakeTimer, 0),
+	}
+}
+
+func (c *FakeClock) Now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.now
+}
+
+func (c *FakeClock) Sleep(d time.Duration) {
+	c.Advance(d)
+}
+
+// SleepWithContext advances the clock like Sleep unless the context is
+// already done. Fake time passes at once, so only a context done before the
+// call, or by one of the timers that fired, cuts it short.
+func (c *FakeClock) SleepWithContext(ctx aws.Context, d time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	c.Advance(d)
+	return ctx.Err()
+}
+
+func (c *FakeClock) AfterFunc(d time.Duration, f func()) {
+	if d <= 0 {
+		f()
+		return
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.timers = append(c.timers, &fakeTimer{
+		due: c.now.Add(d),
+		f:   f,
+	})
+}
+
+// Advance moves the clock forward and fires, in due order, every timer that
+// became due.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mutex.Lock()
+	c.now = c.now.Add(d)
+	sort.SliceStable(c.timers, func(i, j int) bool {
+		return c.timers[i].due.Before(c.timers[j].due)
+	})
+	due := []*fakeTimer{}
+	for len(c.timers) != 0 && !c.timers[0].due.After(c.now) {
+		due = append(due, c.timers[0])
+		c.timers = c.timers[1:]
+	}
+	c.mutex.Unlock()
+	// timers run unlocked so they may use the clock themselves
+	for _, timer := range due {
+		timer.f()
+	}
+}
@@ -0,0 +1,500 @@
+/*
+Copyright 2018 The Avi Networks.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package ec2
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	aws "github.com/aws/aws-sdk-go/aws"
+	ec2 "github.com/aws/aws-sdk-go/service/ec2"
+)
+
+const workers = 8
+const rounds = 10
+
+// TestConcurrentApiCalls hammers every implemented api of the mock from many
+// goroutines while the recorder is reconfigured under it. Run it with the
+// race detector:
+//
+//	go test -race ./service/ec2
+func TestConcurrentApiCalls(t *testing.T) {
+	mockedEC2 := New()
+	mockedEC2.InitialSeeding()
+	vpcID := mockedEC2.GetDefaultVPCID()
+	subnetID := mockedEC2.GetDefaultSubnetID()
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < rounds; i++ {
+				if err := round(mockedEC2, vpcID, subnetID, w, i); err != nil {
+					t.Errorf("worker %d round %d: %v", w, i, err)
+				}
+			}
+		}(w)
+	}
+
+	// reconfigure the recorder and the lifecycle while the apis run
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		recorder := mockedEC2.EXPECT()
+		for i := 0; i < rounds; i++ {
+			recorder.SetDelayByApiName("DescribeVpcs", time.Microsecond)
+			recorder.SetApiInactive("DescribeAvailabilityZones", "inactive")
+			recorder.SetApiActive("DescribeAvailabilityZones")
+			recorder.SetDelay(0)
+			recorder.SetAssignIpFail("")
+			mockedEC2.SetTransitionDelay(InstancePending, 0)
+			mockedEC2.CompleteTransitions()
+			mockedEC2.GetDefaultServiceEngine()
+		}
+	}()
+
+	wg.Wait()
+}
+
+// TestConcurrentClockAndTimedErrors swaps the clock while the timed error
+// injectors schedule on it.
+func TestConcurrentClockAndTimedErrors(t *testing.T) {
+	mockedEC2 := New()
+	recorder := mockedEC2.EXPECT()
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < rounds; i++ {
+			mockedEC2.SetClock(NewFakeClock(time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)))
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < rounds; i++ {
+			recorder.GiveErrorByTimeOut(time.Hour, "timed out")
+			recorder.GiveErrorByApiNameByTimeOut("DescribeVpcs", time.Hour, "timed out")
+			recorder.GiveErrorForApiByTime("DescribeSubnets", "timed out", time.Hour)
+		}
+	}()
+	wg.Wait()
+}
+
+// round drives one worker through every implemented api once.
+func round(mockedEC2 *EC2API, vpcID, subnetID string, w, i int) error {
+	name := fmt.Sprintf("worker-%d-%d", w, i)
+
+	if _, err := mockedEC2.DescribeVpcs(&ec2.DescribeVpcsInput{VpcIds: []*string{aws.String(vpcID)}}); err != nil {
+		return err
+	}
+	if _, err := mockedEC2.DescribeSubnets(&ec2.DescribeSubnetsInput{}); err != nil {
+		return err
+	}
+	if _, err := mockedEC2.DescribeAvailabilityZones(&ec2.DescribeAvailabilityZonesInput{}); err != nil && errorCode(err) != defaultErrorCode {
+		return err
+	}
+	if _, err := mockedEC2.DescribeRouteTables(&ec2.DescribeRouteTablesInput{}); err != nil {
+		return err
+	}
+
+	group, err := mockedEC2.CreateSecurityGroup(&ec2.CreateSecurityGroupInput{
+		GroupName:   aws.String(name),
+		Description: aws.String(name),
+		VpcId:       aws.String(vpcID),
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := mockedEC2.AuthorizeSecurityGroupIngress(&ec2.AuthorizeSecurityGroupIngressInput{
+		GroupId:    group.GroupId,
+		IpProtocol: aws.String("tcp"),
+		FromPort:   aws.Int64(443),
+		ToPort:     aws.Int64(443),
+		CidrIp:     aws.String("0.0.0.0/0"),
+	}); err != nil {
+		return err
+	}
+	dnsEgress := []*ec2.IpPermission{{
+		IpProtocol: aws.String("udp"),
+		FromPort:   aws.Int64(53),
+		ToPort:     aws.Int64(53),
+		IpRanges:   []*ec2.IpRange{{CidrIp: aws.String("10.0.0.2/32")}},
+	}}
+	if _, err := mockedEC2.AuthorizeSecurityGroupEgress(&ec2.AuthorizeSecurityGroupEgressInput{
+		GroupId:       group.GroupId,
+		IpPermissions: dnsEgress,
+	}); err != nil {
+		return err
+	}
+	if _, err := mockedEC2.UpdateSecurityGroupRuleDescriptionsEgress(&ec2.UpdateSecurityGroupRuleDescriptionsEgressInput{
+		GroupId: group.GroupId,
+		IpPermissions: []*ec2.IpPermission{{
+			IpProtocol: aws.String("udp"),
+			FromPort:   aws.Int64(53),
+			ToPort:     aws.Int64(53),
+			IpRanges:   []*ec2.IpRange{{CidrIp: aws.String("10.0.0.2/32"), Description: aws.String("dns")}},
+		}},
+	}); err != nil {
+		return err
+	}
+	if _, err := mockedEC2.DescribeSecurityGroups(&ec2.DescribeSecurityGroupsInput{GroupIds: []*string{group.GroupId}}); err != nil {
+		return err
+	}
+	if _, err := mockedEC2.RevokeSecurityGroupEgress(&ec2.RevokeSecurityGroupEgressInput{
+		GroupId:       group.GroupId,
+		IpPermissions: dnsEgress,
+	}); err != nil {
+		return err
+	}
+	if _, err := mockedEC2.RevokeSecurityGroupIngress(&ec2.RevokeSecurityGroupIngressInput{
+		GroupId:    group.GroupId,
+		IpProtocol: aws.String("tcp"),
+		FromPort:   aws.Int64(443),
+		ToPort:     aws.Int64(443),
+		CidrIp:     aws.String("0.0.0.0/0"),
+	}); err != nil {
+		return err
+	}
+
+	eni, err := mockedEC2.CreateNetworkInterface(&ec2.CreateNetworkInterfaceInput{
+		SubnetId: aws.String(subnetID),
+		Groups:   []*string{group.GroupId},
+	})
+	if err != nil {
+		return err
+	}
+	eniID := eni.NetworkInterface.NetworkInterfaceId
+	if _, err := mockedEC2.CreateTags(&ec2.CreateTagsInput{
+		Resources: []*string{eniID},
+		Tags:      []*ec2.Tag{{Key: aws.String("Name"), Value: aws.String(name)}},
+	}); err != nil {
+		return err
+	}
+	assigned, err := mockedEC2.AssignPrivateIpAddresses(&ec2.AssignPrivateIpAddressesInput{
+		NetworkInterfaceId:             eniID,
+		SecondaryPrivateIpAddressCount: aws.Int64(1),
+	})
+	if err != nil {
+		return err
+	}
+	address, err := mockedEC2.AllocateAddress(&ec2.AllocateAddressInput{})
+	if err != nil {
+		return err
+	}
+	association, err := mockedEC2.AssociateAddress(&ec2.AssociateAddressInput{
+		AllocationId:       address.AllocationId,
+		NetworkInterfaceId: eniID,
+		PrivateIpAddress:   eni.NetworkInterface.PrivateIpAddress,
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := mockedEC2.DescribeAddresses(&ec2.DescribeAddressesInput{AllocationIds: []*string{address.AllocationId}}); err != nil {
+		return err
+	}
+	if _, err := mockedEC2.DescribeNetworkInterfaces(&ec2.DescribeNetworkInterfacesInput{NetworkInterfaceIds: []*string{eniID}}); err != nil {
+		return err
+	}
+	if _, err := mockedEC2.DisassociateAddress(&ec2.DisassociateAddressInput{AssociationId: association.AssociationId}); err != nil {
+		return err
+	}
+	if _, err := mockedEC2.ReleaseAddress(&ec2.ReleaseAddressInput{AllocationId: address.AllocationId}); err != nil {
+		return err
+	}
+	secondaryIps := []*string{}
+	for _, ip := range assigned.AssignedPrivateIpAddresses {
+		secondaryIps = append(secondaryIps, ip.PrivateIpAddress)
+	}
+	if _, err := mockedEC2.UnassignPrivateIpAddresses(&ec2.UnassignPrivateIpAddressesInput{
+		NetworkInterfaceId: eniID,
+		PrivateIpAddresses: secondaryIps,
+	}); err != nil {
+		return err
+	}
+	if _, err := mockedEC2.DeleteNetworkInterface(&ec2.DeleteNetworkInterfaceInput{NetworkInterfaceId: eniID}); err != nil {
+		return err
+	}
+
+	reservation, err := mockedEC2.RunInstances(&ec2.RunInstancesInput{
+		ImageId:          aws.String("ami-12345678"),
+		MinCount:         aws.Int64(1),
+		MaxCount:         aws.Int64(1),
+		SubnetId:         aws.String(subnetID),
+		SecurityGroupIds: []*string{group.GroupId},
+		UserData:         aws.String(name),
+	})
+	if err != nil {
+		return err
+	}
+	instanceIds := []*string{reservation.Instances[0].InstanceId}
+	if _, err := mockedEC2.DescribeInstances(&ec2.DescribeInstancesInput{InstanceIds: instanceIds}); err != nil {
+		return err
+	}
+	attachedEni, err := mockedEC2.CreateNetworkInterface(&ec2.CreateNetworkInterfaceInput{SubnetId: aws.String(subnetID)})
+	if err != nil {
+		return err
+	}
+	attachment, err := mockedEC2.AttachNetworkInterface(&ec2.AttachNetworkInterfaceInput{
+		InstanceId:         instanceIds[0],
+		NetworkInterfaceId: attachedEni.NetworkInterface.NetworkInterfaceId,
+		DeviceIndex:        aws.Int64(1),
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := mockedEC2.ModifyNetworkInterfaceAttribute(&ec2.ModifyNetworkInterfaceAttributeInput{
+		NetworkInterfaceId: attachedEni.NetworkInterface.NetworkInterfaceId,
+		SourceDestCheck:    &ec2.AttributeBooleanValue{Value: aws.Bool(false)},
+	}); err != nil {
+		return err
+	}
+	if _, err := mockedEC2.DescribeNetworkInterfaceAttribute(&ec2.DescribeNetworkInterfaceAttributeInput{
+		NetworkInterfaceId: attachedEni.NetworkInterface.NetworkInterfaceId,
+		Attribute:          aws.String("sourceDestCheck"),
+	}); err != nil {
+		return err
+	}
+	if _, err := mockedEC2.ResetNetworkInterfaceAttribute(&ec2.ResetNetworkInterfaceAttributeInput{
+		NetworkInterfaceId: attachedEni.NetworkInterface.NetworkInterfaceId,
+		SourceDestCheck:    aws.String("sourceDestCheck"),
+	}); err != nil {
+		return err
+	}
+	if _, err := mockedEC2.DetachNetworkInterface(&ec2.DetachNetworkInterfaceInput{AttachmentId: attachment.AttachmentId}); err != nil {
+		return err
+	}
+	if _, err := mockedEC2.DeleteNetworkInterface(&ec2.DeleteNetworkInterfaceInput{NetworkInterfaceId: attachedEni.NetworkInterface.NetworkInterfaceId}); err != nil {
+		return err
+	}
+	if _, err := mockedEC2.DescribeInstanceAttribute(&ec2.DescribeInstanceAttributeInput{
+		InstanceId: instanceIds[0],
+		Attribute:  aws.String("userData"),
+	}); err != nil {
+		return err
+	}
+	if _, err := mockedEC2.RebootInstances(&ec2.RebootInstancesInput{InstanceIds: instanceIds}); err != nil {
+		return err
+	}
+	if _, err := mockedEC2.StopInstances(&ec2.StopInstancesInput{InstanceIds: instanceIds}); err != nil {
+		return err
+	}
+	if _, err := mockedEC2.StartInstances(&ec2.StartInstancesInput{InstanceIds: instanceIds}); err != nil {
+		return err
+	}
+	if _, err := mockedEC2.TerminateInstances(&ec2.TerminateInstancesInput{InstanceIds: instanceIds}); err != nil {
+		return err
+	}
+	if _, err := mockedEC2.DescribeSecurityGroupReferences(&ec2.DescribeSecurityGroupReferencesInput{GroupId: []*string{group.GroupId}}); err != nil {
+		return err
+	}
+	if _, err := mockedEC2.DescribeStaleSecurityGroups(&ec2.DescribeStaleSecurityGroupsInput{VpcId: aws.String(vpcID)}); err != nil {
+		return err
+	}
+	if _, err := mockedEC2.DeleteSecurityGroup(&ec2.DeleteSecurityGroupInput{GroupId: group.GroupId}); err != nil {
+		return err
+	}
+
+	vpc, err := mockedEC2.CreateVpc(&ec2.CreateVpcInput{CidrBlock: aws.String("10.1.0.0/16")})
+	if err != nil {
+		return err
+	}
+	if _, err := mockedEC2.ModifyVpcAttribute(&ec2.ModifyVpcAttributeInput{
+		VpcId:              vpc.Vpc.VpcId,
+		EnableDnsHostnames: &ec2.AttributeBooleanValue{Value: aws.Bool(true)},
+	}); err != nil {
+		return err
+	}
+	if _, err := mockedEC2.DescribeVpcAttribute(&ec2.DescribeVpcAttributeInput{
+		VpcId:     vpc.Vpc.VpcId,
+		Attribute: aws.String("enableDnsHostnames"),
+	}); err != nil {
+		return err
+	}
+	if _, err := mockedEC2.DescribeNetworkAcls(&ec2.DescribeNetworkAclsInput{}); err != nil {
+		return err
+	}
+	cidr, err := mockedEC2.AssociateVpcCidrBlock(&ec2.AssociateVpcCidrBlockInput{
+		VpcId:     vpc.Vpc.VpcId,
+		CidrBlock: aws.String("10.2.0.0/16"),
+	})
+	if err != nil {
+		return err
+	}
+	mockedEC2.CompleteTransitions()
+	if _, err := mockedEC2.DisassociateVpcCidrBlock(&ec2.DisassociateVpcCidrBlockInput{
+		AssociationId: cidr.CidrBlockAssociation.AssociationId,
+	}); err != nil {
+		return err
+	}
+	subnet, err := mockedEC2.CreateSubnet(&ec2.CreateSubnetInput{
+		VpcId:     vpc.Vpc.VpcId,
+		CidrBlock: aws.String("10.1.0.0/24"),
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := mockedEC2.ModifySubnetAttribute(&ec2.ModifySubnetAttributeInput{
+		SubnetId:            subnet.Subnet.SubnetId,
+		MapPublicIpOnLaunch: &ec2.AttributeBooleanValue{Value: aws.Bool(true)},
+	}); err != nil {
+		return err
+	}
+	internetGateway, err := mockedEC2.CreateInternetGateway(&ec2.CreateInternetGatewayInput{})
+	if err != nil {
+		return err
+	}
+	if _, err := mockedEC2.AttachInternetGateway(&ec2.AttachInternetGatewayInput{
+		InternetGatewayId: internetGateway.InternetGateway.InternetGatewayId,
+		VpcId:             vpc.Vpc.VpcId,
+	}); err != nil {
+		return err
+	}
+	egressOnlyInternetGateway, err := mockedEC2.CreateEgressOnlyInternetGateway(&ec2.CreateEgressOnlyInternetGatewayInput{VpcId: vpc.Vpc.VpcId})
+	if err != nil {
+		return err
+	}
+	routeTable, err := mockedEC2.CreateRouteTable(&ec2.CreateRouteTableInput{VpcId: vpc.Vpc.VpcId})
+	if err != nil {
+		return err
+	}
+	if _, err := mockedEC2.CreateRoute(&ec2.CreateRouteInput{
+		RouteTableId:         routeTable.RouteTable.RouteTableId,
+		DestinationCidrBlock: aws.String("0.0.0.0/0"),
+		GatewayId:            internetGateway.InternetGateway.InternetGatewayId,
+	}); err != nil {
+		return err
+	}
+	if _, err := mockedEC2.CreateRoute(&ec2.CreateRouteInput{
+		RouteTableId:                routeTable.RouteTable.RouteTableId,
+		DestinationIpv6CidrBlock:    aws.String("::/0"),
+		EgressOnlyInternetGatewayId: egressOnlyInternetGateway.EgressOnlyInternetGateway.EgressOnlyInternetGatewayId,
+	}); err != nil {
+		return err
+	}
+	natAddress, err := mockedEC2.AllocateAddress(&ec2.AllocateAddressInput{Domain: aws.String("vpc")})
+	if err != nil {
+		return err
+	}
+	natGateway, err := mockedEC2.CreateNatGateway(&ec2.CreateNatGatewayInput{
+		AllocationId: natAddress.AllocationId,
+		SubnetId:     subnet.Subnet.SubnetId,
+	})
+	if err != nil {
+		return err
+	}
+	if err := mockedEC2.WaitUntilNatGatewayAvailable(&ec2.DescribeNatGatewaysInput{
+		NatGatewayIds: []*string{natGateway.NatGateway.NatGatewayId},
+	}); err != nil {
+		return err
+	}
+	if _, err := mockedEC2.CreateRoute(&ec2.CreateRouteInput{
+		RouteTableId:         routeTable.RouteTable.RouteTableId,
+		DestinationCidrBlock: aws.String("172.16.0.0/12"),
+		NatGatewayId:         natGateway.NatGateway.NatGatewayId,
+	}); err != nil {
+		return err
+	}
+	if _, err := mockedEC2.CreateRoute(&ec2.CreateRouteInput{
+		RouteTableId:           routeTable.RouteTable.RouteTableId,
+		DestinationCidrBlock:   aws.String("192.168.0.0/16"),
+		VpcPeeringConnectionId: aws.String("pcx-" + name),
+	}); err != nil {
+		return err
+	}
+	if _, err := mockedEC2.ReplaceRoute(&ec2.ReplaceRouteInput{
+		RouteTableId:         routeTable.RouteTable.RouteTableId,
+		DestinationCidrBlock: aws.String("192.168.0.0/16"),
+		TransitGatewayId:     aws.String("tgw-" + name),
+	}); err != nil {
+		return err
+	}
+	routeTableAssociation, err := mockedEC2.AssociateRouteTable(&ec2.AssociateRouteTableInput{
+		RouteTableId: routeTable.RouteTable.RouteTableId,
+		SubnetId:     subnet.Subnet.SubnetId,
+	})
+	if err != nil {
+		return err
+	}
+	mainRouteTables, err := mockedEC2.DescribeRouteTables(&ec2.DescribeRouteTablesInput{Filters: []*ec2.Filter{
+		{Name: aws.String("vpc-id"), Values: []*string{vpc.Vpc.VpcId}},
+		{Name: aws.String("association.main"), Values: []*string{aws.String("true")}},
+	}})
+	if err != nil {
+		return err
+	}
+	replaced, err := mockedEC2.ReplaceRouteTableAssociation(&ec2.ReplaceRouteTableAssociationInput{
+		AssociationId: routeTableAssociation.AssociationId,
+		RouteTableId:  mainRouteTables.RouteTables[0].RouteTableId,
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := mockedEC2.DisassociateRouteTable(&ec2.DisassociateRouteTableInput{AssociationId: replaced.NewAssociationId}); err != nil {
+		return err
+	}
+	if _, err := mockedEC2.DeleteRoute(&ec2.DeleteRouteInput{
+		RouteTableId:         routeTable.RouteTable.RouteTableId,
+		DestinationCidrBlock: aws.String("192.168.0.0/16"),
+	}); err != nil {
+		return err
+	}
+	if _, err := mockedEC2.DeleteRouteTable(&ec2.DeleteRouteTableInput{RouteTableId: routeTable.RouteTable.RouteTableId}); err != nil {
+		return err
+	}
+	if _, err := mockedEC2.DeleteNatGateway(&ec2.DeleteNatGatewayInput{NatGatewayId: natGateway.NatGateway.NatGatewayId}); err != nil {
+		return err
+	}
+	mockedEC2.CompleteTransitions()
+	if _, err := mockedEC2.DescribeNatGateways(&ec2.DescribeNatGatewaysInput{Filter: []*ec2.Filter{
+		{Name: aws.String("vpc-id"), Values: []*string{vpc.Vpc.VpcId}},
+	}}); err != nil {
+		return err
+	}
+	if _, err := mockedEC2.ReleaseAddress(&ec2.ReleaseAddressInput{AllocationId: natAddress.AllocationId}); err != nil {
+		return err
+	}
+	if _, err := mockedEC2.DescribeInternetGateways(&ec2.DescribeInternetGatewaysInput{Filters: []*ec2.Filter{
+		{Name: aws.String("attachment.vpc-id"), Values: []*string{vpc.Vpc.VpcId}},
+	}}); err != nil {
+		return err
+	}
+	if _, err := mockedEC2.DetachInternetGateway(&ec2.DetachInternetGatewayInput{
+		InternetGatewayId: internetGateway.InternetGateway.InternetGatewayId,
+		VpcId:             vpc.Vpc.VpcId,
+	}); err != nil {
+		return err
+	}
+	if _, err := mockedEC2.DeleteInternetGateway(&ec2.DeleteInternetGatewayInput{
+		InternetGatewayId: internetGateway.InternetGateway.InternetGatewayId,
+	}); err != nil {
+		return err
+	}
+	if _, err := mockedEC2.DescribeEgressOnlyInternetGateways(&ec2.DescribeEgressOnlyInternetGatewaysInput{}); err != nil {
+		return err
+	}
+	if _, err := mockedEC2.DeleteEgressOnlyInternetGateway(&ec2.DeleteEgressOnlyInternetGatewayInput{
+		EgressOnlyInternetGatewayId: egressOnlyInternetGateway.EgressOnlyInternetGateway.EgressOnlyInternetGatewayId,
+	}); err != nil {
+		return err
+	}
+	if _, err := mockedEC2.DeleteSubnet(&ec2.DeleteSubnetInput{SubnetId: subnet.Subnet.SubnetId}); err != nil {
+		return err
+	}
+	if _, err := mockedEC2.DeleteVpc(&ec2.DeleteVpcInput{VpcId: vpc.Vpc.VpcId}); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2018 The Avi Networks.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package ec2
+
+import (
+	"net/http"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/google/uuid"
+)
+
+// errorStatusCodes holds the http status of the ec2 error codes that are not
+// client errors.
+var errorStatusCodes = map[string]int{
+	"AuthFailure":                  http.StatusUnauthorized,
+	"UnauthorizedOperation":        http.StatusForbidden,
+	"DryRunOperation":              http.StatusPreconditionFailed,
+	"InternalError":                http.StatusInternalServerError,
+	"InternalFailure":              http.StatusInternalServerError,
+	"InsufficientInstanceCapacity": http.StatusInternalServerError,
+	"Unavailable":                  http.StatusServiceUnavailable,
+	"ServiceUnavailable":           http.StatusServiceUnavailable,
+	"RequestLimitExceeded":         http.StatusServiceUnavailable,
+}
+
+// newAwsError gives the awserr.RequestFailure ec2 would answer with for the
+// code, so callers can switch on Code() and StatusCode() as against the real
+// service.
+func newAwsError(code, message string) error {
+	statusCode, ok := errorStatusCodes[code]
+	if !ok {
+		statusCode = http.StatusBadRequest
+	}
+	return awserr.NewRequestFailure(awserr.New(code, message, nil), statusCode, uuid.New().String())
+}
+
+// errorCode returns the ec2 error code err carries, empty when it has none.
+func errorCode(err error) string {
+	if awsErr, ok := err.(awserr.Error); ok {
+		return awsErr.Code()
+	}
+	return ""
+}
+
+// defaultErrorCode is the code of the errors injected without one.
+const defaultErrorCode = "InternalError"
+
+// mockedError is an error queued through one of the recorder injectors.
+// Without a code it fails as an ec2 internal error.
+type mockedError struct {
+	code    string
+	message string
+}
+
+func (e mockedError) err() error {
+	if e.code == "" {
+		return newAwsError(defaultErrorCode, e.message)
+	}
+	return newAwsError(e.code, e.message)
+}
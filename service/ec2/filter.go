@@ -0,0 +1,777 @@
+/*
+Copyright 2018 The Avi Networks.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package ec2
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	aws "github.com/aws/aws-sdk-go/aws"
+	ec2 "github.com/aws/aws-sdk-go/service/ec2"
+)
+
+// filterFields maps a filter name to the values of a resource it matches on.
+type filterFields map[string]func(resource interface{}) []string
+
+// resourceFilter implements the aws filter semantics for one resource type:
+// every filter has to match (AND), any value of a filter may match (OR),
+// values may carry * and ? wildcards, and tag:<key>, tag-key and tag-value
+// are matched against the resource's tags.
+type resourceFilter struct {
+	fields filterFields
+	tags   func(resource interface{}) []*ec2.Tag
+}
+
+// validate rejects the request if it uses a filter the resource doesn't know.
+func (f *resourceFilter) validate(filters []*ec2.Filter) error {
+	for _, filter := range filters {
+		name := aws.StringValue(filter.Name)
+		if _, ok := f.fields[name]; ok {
+			continue
+		}
+		if f.tags != nil && (name == "tag-key" || name == "tag-value" || strings.HasPrefix(name, "tag:")) {
+			continue
+		}
+		return newAwsError("InvalidParameterValue", "The filter '"+name+"' is invalid")
+	}
+	return nil
+}
+
+func (f *resourceFilter) match(resource interface{}, filters []*ec2.Filter) bool {
+	for _, filter := range filters {
+		if !matchAnyValue(filter.Values, f.values(resource, aws.StringValue(filter.Name))) {
+			return false
+		}
+	}
+	return true
+}
+
+func (f *resourceFilter) values(resource interface{}, name string) []string {
+	if extract, ok := f.fields[name]; ok {
+		return extract(resource)
+	}
+	values := []string{}
+	for _, tag := range f.tags(resource) {
+		switch {
+		case name == "tag-key":
+			values = append(values, aws.StringValue(tag.Key))
+		case name == "tag-value":
+			values = append(values, aws.StringValue(tag.Value))
+		case name == "tag:"+aws.StringValue(tag.Key):
+			values = append(values, aws.StringValue(tag.Value))
+		}
+	}
+	return values
+}
+
+func matchAnyValue(patterns []*string, values []string) bool {
+	for _, pattern := range patterns {
+		for _, value := range values {
+			if matchWildcard(aws.StringValue(pattern), value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchWildcard matches value against an aws filter pattern, where * is any
+// run of characters, ? a single character and \ escapes either of them.
+func matchWildcard(pattern, value string) bool {
+	if !strings.ContainsAny(pattern, `*?\`) {
+		return pattern == value
+	}
+	expression := "^"
+	for i := 0; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '*':
+			expression += ".*"
+		case '?':
+			expression += "."
+		case '\\':
+			if i+1 < len(pattern) {
+				i++
+			}
+			expression += regexp.QuoteMeta(string(pattern[i]))
+		default:
+			expression += regexp.QuoteMeta(string(pattern[i]))
+		}
+	}
+	matched, _ := regexp.MatchString(expression+"$", value)
+	return matched
+}
+
+// strs collects the values of the non nil pointers.
+func strs(values ...*string) []string {
+	collected := []string{}
+	for _, value := range values {
+		if value != nil {
+			collected = append(collected, *value)
+		}
+	}
+	return collected
+}
+
+func boolStrs(value *bool) []string {
+	if value == nil {
+		return []string{}
+	}
+	return []string{strconv.FormatBool(*value)}
+}
+
+func int64Strs(value *int64) []string {
+	if value == nil {
+		return []string{}
+	}
+	return []string{strconv.FormatInt(*value, 10)}
+}
+
+func groupFields(groups func(resource interface{}) []*ec2.GroupIdentifier) (ids, names func(resource interface{}) []string) {
+	ids = func(resource interface{}) []string {
+		values := []string{}
+		for _, group := range groups(resource) {
+			values = append(values, strs(group.GroupId)...)
+		}
+		return values
+	}
+	names = func(resource interface{}) []string {
+		values := []string{}
+		for _, group := range groups(resource) {
+			values = append(values, strs(group.GroupName)...)
+		}
+		return values
+	}
+	return
+}
+
+func ipPermissionFields(fields filterFields, prefix string, permissions func(resource interface{}) []*ec2.IpPermission) {
+	each := func(extract func(permission *ec2.IpPermission) []string) func(resource interface{}) []string {
+		return func(resource interface{}) []string {
+			values := []string{}
+			for _, permission := range permissions(resource) {
+				values = append(values, extract(permission)...)
+			}
+			return values
+		}
+	}
+	fields[prefix+"ip-permission.protocol"] = each(func(permission *ec2.IpPermission) []string {
+		return strs(permission.IpProtocol)
+	})
+	fields[prefix+"ip-permission.from-port"] = each(func(permission *ec2.IpPermission) []string {
+		return int64Strs(permission.FromPort)
+	})
+	fields[prefix+"ip-permission.to-port"] = each(func(permission *ec2.IpPermission) []string {
+		return int64Strs(permission.ToPort)
+	})
+	fields[prefix+"ip-permission.cidr"] = each(func(permission *ec2.IpPermission) []string {
+		values := []string{}
+		for _, ipRange := range permission.IpRanges {
+			values = append(values, strs(ipRange.CidrIp)...)
+		}
+		return values
+	})
+	fields[prefix+"ip-permission.ipv6-cidr"] = each(func(permission *ec2.IpPermission) []string {
+		values := []string{}
+		for _, ipRange := range permission.Ipv6Ranges {
+			values = append(values, strs(ipRange.CidrIpv6)...)
+		}
+		return values
+	})
+	fields[prefix+"ip-permission.prefix-list-id"] = each(func(permission *ec2.IpPermission) []string {
+		values := []string{}
+		for _, prefixList := range permission.PrefixListIds {
+			values = append(values, strs(prefixList.PrefixListId)...)
+		}
+		return values
+	})
+	fields[prefix+"ip-permission.group-id"] = each(func(permission *ec2.IpPermission) []string {
+		values := []string{}
+		for _, pair := range permission.UserIdGroupPairs {
+			values = append(values, strs(pair.GroupId)...)
+		}
+		return values
+	})
+	fields[prefix+"ip-permission.group-name"] = each(func(permission *ec2.IpPermission) []string {
+		values := []string{}
+		for _, pair := range permission.UserIdGroupPairs {
+			values = append(values, strs(pair.GroupName)...)
+		}
+		return values
+	})
+	fields[prefix+"ip-permission.user-id"] = each(func(permission *ec2.IpPermission) []string {
+		values := []string{}
+		for _, pair := range permission.UserIdGroupPairs {
+			values = append(values, strs(pair.UserId)...)
+		}
+		return values
+	})
+}
+
+var vpcFilter = &resourceFilter{
+	fields: filterFields{
+		"vpc-id": func(r interface{}) []string { return strs(r.(*ec2.Vpc).VpcId) },
+		"cidr":   func(r interface{}) []string { return strs(r.(*ec2.Vpc).CidrBlock) },
+		"cidr-block-association.cidr-block": func(r interface{}) []string {
+			values := []string{}
+			for _, association := range r.(*ec2.Vpc).CidrBlockAssociationSet {
+				values = append(values, strs(association.CidrBlock)...)
+			}
+			return values
+		},
+		"cidr-block-association.association-id": func(r interface{}) []string {
+			values := []string{}
+			for _, association := range r.(*ec2.Vpc).CidrBlockAssociationSet {
+				values = append(values, strs(association.AssociationId)...)
+			}
+			return values
+		},
+		"cidr-block-association.state": func(r interface{}) []string {
+			values := []string{}
+			for _, association := range r.(*ec2.Vpc).CidrBlockAssociationSet {
+				if association.CidrBlockState != nil {
+					values = append(values, strs(association.CidrBlockState.State)...)
+				}
+			}
+			return values
+		},
+		"ipv6-cidr-block-association.ipv6-cidr-block": func(r interface{}) []string {
+			values := []string{}
+			for _, association := range r.(*ec2.Vpc).Ipv6CidrBlockAssociationSet {
+				values = append(values, strs(association.Ipv6CidrBlock)...)
+			}
+			return values
+		},
+		"ipv6-cidr-block-association.association-id": func(r interface{}) []string {
+			values := []string{}
+			for _, association := range r.(*ec2.Vpc).Ipv6CidrBlockAssociationSet {
+				values = append(values, strs(association.AssociationId)...)
+			}
+			return values
+		},
+		"ipv6-cidr-block-association.state": func(r interface{}) []string {
+			values := []string{}
+			for _, association := range r.(*ec2.Vpc).Ipv6CidrBlockAssociationSet {
+				if association.Ipv6CidrBlockState != nil {
+					values = append(values, strs(association.Ipv6CidrBlockState.State)...)
+				}
+			}
+			return values
+		},
+		"dhcp-options-id":  func(r interface{}) []string { return strs(r.(*ec2.Vpc).DhcpOptionsId) },
+		"instance-tenancy": func(r interface{}) []string { return strs(r.(*ec2.Vpc).InstanceTenancy) },
+		"is-default":       func(r interface{}) []string { return boolStrs(r.(*ec2.Vpc).IsDefault) },
+		"owner-id":         func(r interface{}) []string { return strs(r.(*ec2.Vpc).OwnerId) },
+		"state":            func(r interface{}) []string { return strs(r.(*ec2.Vpc).State) },
+	},
+	tags: func(r interface{}) []*ec2.Tag { return r.(*ec2.Vpc).Tags },
+}
+
+var subnetFilter = func() *resourceFilter {
+	cidr := func(r interface{}) []string { return strs(r.(*ec2.Subnet).CidrBlock) }
+	availabilityZone := func(r interface{}) []string { return strs(r.(*ec2.Subnet).AvailabilityZone) }
+	return &resourceFilter{
+		fields: filterFields{
+			"subnet-id":            func(r interface{}) []string { return strs(r.(*ec2.Subnet).SubnetId) },
+			"vpc-id":               func(r interface{}) []string { return strs(r.(*ec2.Subnet).VpcId) },
+			"cidr":                 cidr,
+			"cidr-block":           cidr,
+			"cidrBlock":            cidr,
+			"availability-zone":    availabilityZone,
+			"availabilityZone":     availabilityZone,
+			"availability-zone-id": func(r interface{}) []string { return strs(r.(*ec2.Subnet).AvailabilityZoneId) },
+			"available-ip-address-count": func(r interface{}) []string {
+				return int64Strs(r.(*ec2.Subnet).AvailableIpAddressCount)
+			},
+			"default-for-az": func(r interface{}) []string { return boolStrs(r.(*ec2.Subnet).DefaultForAz) },
+			"map-public-ip-on-launch": func(r interface{}) []string {
+				return boolStrs(r.(*ec2.Subnet).MapPublicIpOnLaunch)
+			},
+			"ipv6-cidr-block-association.ipv6-cidr-block": func(r interface{}) []string {
+				values := []string{}
+				for _, association := range r.(*ec2.Subnet).Ipv6CidrBlockAssociationSet {
+					values = append(values, strs(association.Ipv6CidrBlock)...)
+				}
+				return values
+			},
+			"ipv6-cidr-block-association.association-id": func(r interface{}) []string {
+				values := []string{}
+				for _, association := range r.(*ec2.Subnet).Ipv6CidrBlockAssociationSet {
+					values = append(values, strs(association.AssociationId)...)
+				}
+				return values
+			},
+			"ipv6-cidr-block-association.state": func(r interface{}) []string {
+				values := []string{}
+				for _, association := range r.(*ec2.Subnet).Ipv6CidrBlockAssociationSet {
+					if association.Ipv6CidrBlockState != nil {
+						values = append(values, strs(association.Ipv6CidrBlockState.State)...)
+					}
+				}
+				return values
+			},
+			"owner-id":   func(r interface{}) []string { return strs(r.(*ec2.Subnet).OwnerId) },
+			"state":      func(r interface{}) []string { return strs(r.(*ec2.Subnet).State) },
+			"subnet-arn": func(r interface{}) []string { return strs(r.(*ec2.Subnet).SubnetArn) },
+		},
+		tags: func(r interface{}) []*ec2.Tag { return r.(*ec2.Subnet).Tags },
+	}
+}()
+
+var networkInterfaceFilter = func() *resourceFilter {
+	eni := func(r interface{}) *ec2.NetworkInterface { return r.(*ec2.NetworkInterface) }
+	availabilityZone := func(r interface{}) []string { return strs(eni(r).AvailabilityZone) }
+	privateIps := func(r interface{}) []string {
+		values := []string{}
+		for _, privateIp := range eni(r).PrivateIpAddresses {
+			values = append(values, strs(privateIp.PrivateIpAddress)...)
+		}
+		return values
+	}
+	ipv6Ips := func(r interface{}) []string {
+		values := []string{}
+		for _, ipv6Ip := range eni(r).Ipv6Addresses {
+			values = append(values, strs(ipv6Ip.Ipv6Address)...)
+		}
+		return values
+	}
+	attachment := func(extract func(attachment *ec2.NetworkInterfaceAttachment) []string) func(r interface{}) []string {
+		return func(r interface{}) []string {
+			if eni(r).Attachment == nil {
+				return []string{}
+			}
+			return extract(eni(r).Attachment)
+		}
+	}
+	association := func(extract func(association *ec2.NetworkInterfaceAssociation) []string) func(r interface{}) []string {
+		return func(r interface{}) []string {
+			values := []string{}
+			if eni(r).Association != nil {
+				values = append(values, extract(eni(r).Association)...)
+			}
+			for _, privateIp := range eni(r).PrivateIpAddresses {
+				if privateIp.Association != nil && privateIp.Association != eni(r).Association {
+					values = append(values, extract(privateIp.Association)...)
+				}
+			}
+			return values
+		}
+	}
+	groupIds, groupNames := groupFields(func(r interface{}) []*ec2.GroupIdentifier { return eni(r).Groups })
+	return &resourceFilter{
+		fields: filterFields{
+			"network-interface-id":                    func(r interface{}) []string { return strs(eni(r).NetworkInterfaceId) },
+			"subnet-id":                               func(r interface{}) []string { return strs(eni(r).SubnetId) },
+			"vpc-id":                                  func(r interface{}) []string { return strs(eni(r).VpcId) },
+			"availability-zone":                       availabilityZone,
+			"availabilityZone":                        availabilityZone,
+			"addresses.private-ip-address":            privateIps,
+			"private-ip-address":                      func(r interface{}) []string { return strs(eni(r).PrivateIpAddress) },
+			"private-dns-name":                        func(r interface{}) []string { return strs(eni(r).PrivateDnsName) },
+			"mac-address":                             func(r interface{}) []string { return strs(eni(r).MacAddress) },
+			"description":                             func(r interface{}) []string { return strs(eni(r).Description) },
+			"status":                                  func(r interface{}) []string { return strs(eni(r).Status) },
+			"owner-id":                                func(r interface{}) []string { return strs(eni(r).OwnerId) },
+			"requester-id":                            func(r interface{}) []string { return strs(eni(r).RequesterId) },
+			"requester-managed":                       func(r interface{}) []string { return boolStrs(eni(r).RequesterManaged) },
+			"source-dest-check":                       func(r interface{}) []string { return boolStrs(eni(r).SourceDestCheck) },
+			"interface-type":                          func(r interface{}) []string { return strs(eni(r).InterfaceType) },
+			"group-id":                                groupIds,
+			"group-name":                              groupNames,
+			"attachment.attachment-id":                attachment(func(a *ec2.NetworkInterfaceAttachment) []string { return strs(a.AttachmentId) }),
+			"attachment.instance-id":                  attachment(func(a *ec2.NetworkInterfaceAttachment) []string { return strs(a.InstanceId) }),
+			"attachment.instance-owner-id":            attachment(func(a *ec2.NetworkInterfaceAttachment) []string { return strs(a.InstanceOwnerId) }),
+			"attachment.device-index":                 attachment(func(a *ec2.NetworkInterfaceAttachment) []string { return int64Strs(a.DeviceIndex) }),
+			"attachment.status":                       attachment(func(a *ec2.NetworkInterfaceAttachment) []string { return strs(a.Status) }),
+			"attachment.delete-on-termination":        attachment(func(a *ec2.NetworkInterfaceAttachment) []string { return boolStrs(a.DeleteOnTermination) }),
+			"association.public-ip":                   association(func(a *ec2.NetworkInterfaceAssociation) []string { return strs(a.PublicIp) }),
+			"association.allocation-id":               association(func(a *ec2.NetworkInterfaceAssociation) []string { return strs(a.AllocationId) }),
+			"association.association-id":              association(func(a *ec2.NetworkInterfaceAssociation) []string { return strs(a.AssociationId) }),
+			"association.ip-owner-id":                 association(func(a *ec2.NetworkInterfaceAssociation) []string { return strs(a.IpOwnerId) }),
+			"association.public-dns-name":             association(func(a *ec2.NetworkInterfaceAssociation) []string { return strs(a.PublicDnsName) }),
+			"private-ip-addresses.private-ip-address": privateIps,
+			"ipv6-addresses.ipv6-address":             ipv6Ips,
+		},
+		tags: func(r interface{}) []*ec2.Tag { return eni(r).TagSet },
+	}
+}()
+
+var securityGroupFilter = func() *resourceFilter {
+	sg := func(r interface{}) *ec2.SecurityGroup { return r.(*ec2.SecurityGroup) }
+	fields := filterFields{
+		"group-id":    func(r interface{}) []string { return strs(sg(r).GroupId) },
+		"group-name":  func(r interface{}) []string { return strs(sg(r).GroupName) },
+		"vpc-id":      func(r interface{}) []string { return strs(sg(r).VpcId) },
+		"description": func(r interface{}) []string { return strs(sg(r).Description) },
+		"owner-id":    func(r interface{}) []string { return strs(sg(r).OwnerId) },
+	}
+	ipPermissionFields(fields, "", func(r interface{}) []*ec2.IpPermission { return sg(r).IpPermissions })
+	ipPermissionFields(fields, "egress.", func(r interface{}) []*ec2.IpPermission { return sg(r).IpPermissionsEgress })
+	return &resourceFilter{
+		fields: fields,
+		tags:   func(r interface{}) []*ec2.Tag { return sg(r).Tags },
+	}
+}()
+
+var instanceFilter = func() *resourceFilter {
+	instance := func(r interface{}) *ec2.Instance { return r.(*ec2.Instance) }
+	eachInterface := func(extract func(ntwInterface *ec2.InstanceNetworkInterface) []string) func(r interface{}) []string {
+		return func(r interface{}) []string {
+			values := []string{}
+			for _, ntwInterface := range instance(r).NetworkInterfaces {
+				values = append(values, extract(ntwInterface)...)
+			}
+			return values
+		}
+	}
+	groupIds, groupNames := groupFields(func(r interface{}) []*ec2.GroupIdentifier { return instance(r).SecurityGroups })
+	interfaceGroupIds, interfaceGroupNames := groupFields(func(r interface{}) []*ec2.GroupIdentifier {
+		groups := []*ec2.GroupIdentifier{}
+		for _, ntwInterface := range instance(r).NetworkInterfaces {
+			groups = append(groups, ntwInterface.Groups...)
+		}
+		return groups
+	})
+	return &resourceFilter{
+		fields: filterFields{
+			"instance-id":   func(r interface{}) []string { return strs(instance(r).InstanceId) },
+			"image-id":      func(r interface{}) []string { return strs(instance(r).ImageId) },
+			"instance-type": func(r interface{}) []string { return strs(instance(r).InstanceType) },
+			"instance-state-name": func(r interface{}) []string {
+				if instance(r).State == nil {
+					return []string{}
+				}
+				return strs(instance(r).State.Name)
+			},
+			"instance-state-code": func(r interface{}) []string {
+				if instance(r).State == nil {
+					return []string{}
+				}
+				return int64Strs(instance(r).State.Code)
+			},
+			"vpc-id":    func(r interface{}) []string { return strs(instance(r).VpcId) },
+			"subnet-id": func(r interface{}) []string { return strs(instance(r).SubnetId) },
+			"availability-zone": func(r interface{}) []string {
+				if instance(r).Placement == nil {
+					return []string{}
+				}
+				return strs(instance(r).Placement.AvailabilityZone)
+			},
+			"private-ip-address":  func(r interface{}) []string { return strs(instance(r).PrivateIpAddress) },
+			"private-dns-name":    func(r interface{}) []string { return strs(instance(r).PrivateDnsName) },
+			"ip-address":          func(r interface{}) []string { return strs(instance(r).PublicIpAddress) },
+			"dns-name":            func(r interface{}) []string { return strs(instance(r).PublicDnsName) },
+			"key-name":            func(r interface{}) []string { return strs(instance(r).KeyName) },
+			"architecture":        func(r interface{}) []string { return strs(instance(r).Architecture) },
+			"root-device-type":    func(r interface{}) []string { return strs(instance(r).RootDeviceType) },
+			"client-token":        func(r interface{}) []string { return strs(instance(r).ClientToken) },
+			"source-dest-check":   func(r interface{}) []string { return boolStrs(instance(r).SourceDestCheck) },
+			"instance.group-id":   groupIds,
+			"instance.group-name": groupNames,
+			"network-interface.network-interface-id": eachInterface(func(n *ec2.InstanceNetworkInterface) []string {
+				return strs(n.NetworkInterfaceId)
+			}),
+			"network-interface.subnet-id": eachInterface(func(n *ec2.InstanceNetworkInterface) []string {
+				return strs(n.SubnetId)
+			}),
+			"network-interface.vpc-id": eachInterface(func(n *ec2.InstanceNetworkInterface) []string {
+				return strs(n.VpcId)
+			}),
+			"network-interface.mac-address": eachInterface(func(n *ec2.InstanceNetworkInterface) []string {
+				return strs(n.MacAddress)
+			}),
+			"network-interface.private-dns-name": eachInterface(func(n *ec2.InstanceNetworkInterface) []string {
+				return strs(n.PrivateDnsName)
+			}),
+			"network-interface.status": eachInterface(func(n *ec2.InstanceNetworkInterface) []string {
+				return strs(n.Status)
+			}),
+			"network-interface.description": eachInterface(func(n *ec2.InstanceNetworkInterface) []string {
+				return strs(n.Description)
+			}),
+			"network-interface.source-dest-check": eachInterface(func(n *ec2.InstanceNetworkInterface) []string {
+				return boolStrs(n.SourceDestCheck)
+			}),
+			"network-interface.addresses.private-ip-address": eachInterface(func(n *ec2.InstanceNetworkInterface) []string {
+				values := []string{}
+				for _, privateIp := range n.PrivateIpAddresses {
+					values = append(values, strs(privateIp.PrivateIpAddress)...)
+				}
+				return values
+			}),
+			"network-interface.ipv6-addresses.ipv6-address": eachInterface(func(n *ec2.InstanceNetworkInterface) []string {
+				values := []string{}
+				for _, ipv6Ip := range n.Ipv6Addresses {
+					values = append(values, strs(ipv6Ip.Ipv6Address)...)
+				}
+				return values
+			}),
+			"network-interface.attachment.attachment-id": eachInterface(func(n *ec2.InstanceNetworkInterface) []string {
+				if n.Attachment == nil {
+					return []string{}
+				}
+				return strs(n.Attachment.AttachmentId)
+			}),
+			"network-interface.attachment.device-index": eachInterface(func(n *ec2.InstanceNetworkInterface) []string {
+				if n.Attachment == nil {
+					return []string{}
+				}
+				return int64Strs(n.Attachment.DeviceIndex)
+			}),
+			"network-interface.attachment.status": eachInterface(func(n *ec2.InstanceNetworkInterface) []string {
+				if n.Attachment == nil {
+					return []string{}
+				}
+				return strs(n.Attachment.Status)
+			}),
+			"network-interface.group-id":   interfaceGroupIds,
+			"network-interface.group-name": interfaceGroupNames,
+		},
+		tags: func(r interface{}) []*ec2.Tag { return instance(r).Tags },
+	}
+}()
+
+var routeTableFilter = func() *resourceFilter {
+	routeTable := func(r interface{}) *ec2.RouteTable { return r.(*ec2.RouteTable) }
+	eachRoute := func(extract func(route *ec2.Route) *string) func(r interface{}) []string {
+		return func(r interface{}) []string {
+			values := []string{}
+			for _, route := range routeTable(r).Routes {
+				values = append(values, strs(extract(route))...)
+			}
+			return values
+		}
+	}
+	eachAssociation := func(extract func(association *ec2.RouteTableAssociation) []string) func(r interface{}) []string {
+		return func(r interface{}) []string {
+			values := []string{}
+			for _, association := range routeTable(r).Associations {
+				values = append(values, extract(association)...)
+			}
+			return values
+		}
+	}
+	return &resourceFilter{
+		fields: filterFields{
+			"route-table-id": func(r interface{}) []string { return strs(routeTable(r).RouteTableId) },
+			"vpc-id":         func(r interface{}) []string { return strs(routeTable(r).VpcId) },
+			"owner-id":       func(r interface{}) []string { return strs(routeTable(r).OwnerId) },
+			"association.route-table-association-id": eachAssociation(func(a *ec2.RouteTableAssociation) []string {
+				return strs(a.RouteTableAssociationId)
+			}),
+			"association.route-table-id": eachAssociation(func(a *ec2.RouteTableAssociation) []string {
+				return strs(a.RouteTableId)
+			}),
+			"association.subnet-id": eachAssociation(func(a *ec2.RouteTableAssociation) []string {
+				return strs(a.SubnetId)
+			}),
+			"association.main": eachAssociation(func(a *ec2.RouteTableAssociation) []string {
+				return boolStrs(a.Main)
+			}),
+			"route.destination-cidr-block":          eachRoute(func(route *ec2.Route) *string { return route.DestinationCidrBlock }),
+			"route.destination-ipv6-cidr-block":     eachRoute(func(route *ec2.Route) *string { return route.DestinationIpv6CidrBlock }),
+			"route.destination-prefix-list-id":      eachRoute(func(route *ec2.Route) *string { return route.DestinationPrefixListId }),
+			"route.egress-only-internet-gateway-id": eachRoute(func(route *ec2.Route) *string { return route.EgressOnlyInternetGatewayId }),
+			"route.gateway-id":                      eachRoute(func(route *ec2.Route) *string { return route.GatewayId }),
+			"route.instance-id":                     eachRoute(func(route *ec2.Route) *string { return route.InstanceId }),
+			"route.nat-gateway-id":                  eachRoute(func(route *ec2.Route) *string { return route.NatGatewayId }),
+			"route.network-interface-id":            eachRoute(func(route *ec2.Route) *string { return route.NetworkInterfaceId }),
+			"route.transit-gateway-id":              eachRoute(func(route *ec2.Route) *string { return route.TransitGatewayId }),
+			"route.vpc-peering-connection-id":       eachRoute(func(route *ec2.Route) *string { return route.VpcPeeringConnectionId }),
+			"route.origin":                          eachRoute(func(route *ec2.Route) *string { return route.Origin }),
+			"route.state":                           eachRoute(func(route *ec2.Route) *string { return route.State }),
+		},
+		tags: func(r interface{}) []*ec2.Tag { return routeTable(r).Tags },
+	}
+}()
+
+var addressFilter = &resourceFilter{
+	fields: filterFields{
+		"allocation-id":              func(r interface{}) []string { return strs(r.(*ec2.Address).AllocationId) },
+		"association-id":             func(r interface{}) []string { return strs(r.(*ec2.Address).AssociationId) },
+		"domain":                     func(r interface{}) []string { return strs(r.(*ec2.Address).Domain) },
+		"instance-id":                func(r interface{}) []string { return strs(r.(*ec2.Address).InstanceId) },
+		"network-interface-id":       func(r interface{}) []string { return strs(r.(*ec2.Address).NetworkInterfaceId) },
+		"network-interface-owner-id": func(r interface{}) []string { return strs(r.(*ec2.Address).NetworkInterfaceOwnerId) },
+		"private-ip-address":         func(r interface{}) []string { return strs(r.(*ec2.Address).PrivateIpAddress) },
+		"public-ip":                  func(r interface{}) []string { return strs(r.(*ec2.Address).PublicIp) },
+	},
+	tags: func(r interface{}) []*ec2.Tag { return r.(*ec2.Address).Tags },
+}
+
+var availabilityZoneFilter = &resourceFilter{
+	fields: filterFields{
+		"zone-name":   func(r interface{}) []string { return strs(r.(*ec2.AvailabilityZone).ZoneName) },
+		"zone-id":     func(r interface{}) []string { return strs(r.(*ec2.AvailabilityZone).ZoneId) },
+		"region-name": func(r interface{}) []string { return strs(r.(*ec2.AvailabilityZone).RegionName) },
+		"state":       func(r interface{}) []string { return strs(r.(*ec2.AvailabilityZone).State) },
+		"message": func(r interface{}) []string {
+			values := []string{}
+			for _, message := range r.(*ec2.AvailabilityZone).Messages {
+				values = append(values, strs(message.Message)...)
+			}
+			return values
+		},
+	},
+}
+
+var volumeFilter = func() *resourceFilter {
+	volume := func(r interface{}) *ec2.Volume { return r.(*ec2.Volume) }
+	eachAttachment := func(extract func(attachment *ec2.VolumeAttachment) []string) func(r interface{}) []string {
+		return func(r interface{}) []string {
+			values := []string{}
+			for _, attachment := range volume(r).Attachments {
+				values = append(values, extract(attachment)...)
+			}
+			return values
+		}
+	}
+	return &resourceFilter{
+		fields: filterFields{
+			"volume-id":         func(r interface{}) []string { return strs(volume(r).VolumeId) },
+			"availability-zone": func(r interface{}) []string { return strs(volume(r).AvailabilityZone) },
+			"create-time": func(r interface{}) []string {
+				if volume(r).CreateTime == nil {
+					return []string{}
+				}
+				return []string{volume(r).CreateTime.UTC().Format(time.RFC3339)}
+			},
+			"encrypted":   func(r interface{}) []string { return boolStrs(volume(r).Encrypted) },
+			"size":        func(r interface{}) []string { return int64Strs(volume(r).Size) },
+			"snapshot-id": func(r interface{}) []string { return strs(volume(r).SnapshotId) },
+			"status":      func(r interface{}) []string { return strs(volume(r).State) },
+			"volume-type": func(r interface{}) []string { return strs(volume(r).VolumeType) },
+			"attachment.attach-time": eachAttachment(func(a *ec2.VolumeAttachment) []string {
+				if a.AttachTime == nil {
+					return []string{}
+				}
+				return []string{a.AttachTime.UTC().Format(time.RFC3339)}
+			}),
+			"attachment.delete-on-termination": eachAttachment(func(a *ec2.VolumeAttachment) []string {
+				return boolStrs(a.DeleteOnTermination)
+			}),
+			"attachment.device": eachAttachment(func(a *ec2.VolumeAttachment) []string {
+				return strs(a.Device)
+			}),
+			"attachment.instance-id": eachAttachment(func(a *ec2.VolumeAttachment) []string {
+				return strs(a.InstanceId)
+			}),
+			"attachment.status": eachAttachment(func(a *ec2.VolumeAttachment) []string {
+				return strs(a.State)
+			}),
+		},
+		tags: func(r interface{}) []*ec2.Tag { return volume(r).Tags },
+	}
+}()
+
+var networkAclFilter = func() *resourceFilter {
+	networkAcl := func(r interface{}) *ec2.NetworkAcl { return r.(*ec2.NetworkAcl) }
+	eachEntry := func(extract func(entry *ec2.NetworkAclEntry) []string) func(r interface{}) []string {
+		return func(r interface{}) []string {
+			values := []string{}
+			for _, entry := range networkAcl(r).Entries {
+				values = append(values, extract(entry)...)
+			}
+			return values
+		}
+	}
+	eachAssociation := func(extract func(association *ec2.NetworkAclAssociation) *string) func(r interface{}) []string {
+		return func(r interface{}) []string {
+			values := []string{}
+			for _, association := range networkAcl(r).Associations {
+				values = append(values, strs(extract(association))...)
+			}
+			return values
+		}
+	}
+	return &resourceFilter{
+		fields: filterFields{
+			"network-acl-id": func(r interface{}) []string { return strs(networkAcl(r).NetworkAclId) },
+			"vpc-id":         func(r interface{}) []string { return strs(networkAcl(r).VpcId) },
+			"default":        func(r interface{}) []string { return boolStrs(networkAcl(r).IsDefault) },
+			"owner-id":       func(r interface{}) []string { return strs(networkAcl(r).OwnerId) },
+			"association.association-id": eachAssociation(func(a *ec2.NetworkAclAssociation) *string {
+				return a.NetworkAclAssociationId
+			}),
+			"association.network-acl-id": eachAssociation(func(a *ec2.NetworkAclAssociation) *string { return a.NetworkAclId }),
+			"association.subnet-id":      eachAssociation(func(a *ec2.NetworkAclAssociation) *string { return a.SubnetId }),
+			"entry.cidr":                 eachEntry(func(e *ec2.NetworkAclEntry) []string { return strs(e.CidrBlock) }),
+			"entry.ipv6-cidr":            eachEntry(func(e *ec2.NetworkAclEntry) []string { return strs(e.Ipv6CidrBlock) }),
+			"entry.egress":               eachEntry(func(e *ec2.NetworkAclEntry) []string { return boolStrs(e.Egress) }),
+			"entry.protocol":             eachEntry(func(e *ec2.NetworkAclEntry) []string { return strs(e.Protocol) }),
+			"entry.rule-action":          eachEntry(func(e *ec2.NetworkAclEntry) []string { return strs(e.RuleAction) }),
+			"entry.rule-number":          eachEntry(func(e *ec2.NetworkAclEntry) []string { return int64Strs(e.RuleNumber) }),
+			"entry.port-range.from": eachEntry(func(e *ec2.NetworkAclEntry) []string {
+				if e.PortRange == nil {
+					return []string{}
+				}
+				return int64Strs(e.PortRange.From)
+			}),
+			"entry.port-range.to": eachEntry(func(e *ec2.NetworkAclEntry) []string {
+				if e.PortRange == nil {
+					return []string{}
+				}
+				return int64Strs(e.PortRange.To)
+			}),
+			"entry.icmp.code": eachEntry(func(e *ec2.NetworkAclEntry) []string {
+				if e.IcmpTypeCode == nil {
+					return []string{}
+				}
+				return int64Strs(e.IcmpTypeCode.Code)
+			}),
+			"entry.icmp.type": eachEntry(func(e *ec2.NetworkAclEntry) []string {
+				if e.IcmpTypeCode == nil {
+					return []string{}
+				}
+				return int64Strs(e.IcmpTypeCode.Type)
+			}),
+		},
+		tags: func(r interface{}) []*ec2.Tag { return networkAcl(r).Tags },
+	}
+}()
+
+var internetGatewayFilter = func() *resourceFilter {
+	internetGateway := func(r interface{}) *ec2.InternetGateway { return r.(*ec2.InternetGateway) }
+	eachAttachment := func(extract func(attachment *ec2.InternetGatewayAttachment) *string) func(r interface{}) []string {
+		return func(r interface{}) []string {
+			values := []string{}
+			for _, attachment := range internetGateway(r).Attachments {
+				values = append(values, strs(extract(attachment))...)
+			}
+			return values
+		}
+	}
+	return &resourceFilter{
+		fields: filterFields{
+			"internet-gateway-id": func(r interface{}) []string { return strs(internetGateway(r).InternetGatewayId) },
+			"owner-id":            func(r interface{}) []string { return strs(internetGateway(r).OwnerId) },
+			"attachment.state":    eachAttachment(func(a *ec2.InternetGatewayAttachment) *string { return a.State }),
+			"attachment.vpc-id":   eachAttachment(func(a *ec2.InternetGatewayAttachment) *string { return a.VpcId }),
+		},
+		tags: func(r interface{}) []*ec2.Tag { return internetGateway(r).Tags },
+	}
+}()
+
+var natGatewayFilter = func() *resourceFilter {
+	natGateway := func(r interface{}) *ec2.NatGateway { return r.(*ec2.NatGateway) }
+	return &resourceFilter{
+		fields: filterFields{
+			"nat-gateway-id": func(r interface{}) []string { return strs(natGateway(r).NatGatewayId) },
+			"state":          func(r interface{}) []string { return strs(natGateway(r).State) },
+			"subnet-id":      func(r interface{}) []string { return strs(natGateway(r).SubnetId) },
+			"vpc-id":         func(r interface{}) []string { return strs(natGateway(r).VpcId) },
+		},
+		tags: func(r interface{}) []*ec2.Tag { return natGateway(r).Tags },
+	}
+}()
@@ -0,0 +1,139 @@
+/*
+Copyright 2018 The Avi Networks.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package ec2
+
+import (
+	"testing"
+
+	aws "github.com/aws/aws-sdk-go/aws"
+	ec2 "github.com/aws/aws-sdk-go/service/ec2"
+)
+
+func filter(name string, values ...string) *ec2.Filter {
+	return &ec2.Filter{Name: aws.String(name), Values: aws.StringSlice(values)}
+}
+
+func TestMatchWildcard(t *testing.T) {
+	for _, test := range []struct {
+		pattern, value string
+		match          bool
+	}{
+		{"web", "web", true},
+		{"web", "web-1", false},
+		{"web*", "web-1", true},
+		{"*-1", "web-1", true},
+		{"web-?", "web-1", true},
+		{"web-?", "web-10", false},
+		{`web\*`, "web*", true},
+		{`web\*`, "web-1", false},
+		{"10.0.*", "10.0.1.0/24", true},
+		{"10.0.*", "1000.1", false},
+	} {
+		if got := matchWildcard(test.pattern, test.value); got != test.match {
+			t.Errorf("matchWildcard(%q, %q) = %v, want %v", test.pattern, test.value, got, test.match)
+		}
+	}
+}
+
+func TestFiltersRejectUnknownNames(t *testing.T) {
+	m := newSeededMock(t)
+	_, err := m.DescribeSubnets(&ec2.DescribeSubnetsInput{Filters: []*ec2.Filter{filter("no-such-filter", "x")}})
+	expectErrorCode(t, err, "InvalidParameterValue")
+	_, err = m.DescribeInstances(&ec2.DescribeInstancesInput{Filters: []*ec2.Filter{filter("no-such-filter", "x")}})
+	expectErrorCode(t, err, "InvalidParameterValue")
+	_, err = m.DescribeAvailabilityZones(&ec2.DescribeAvailabilityZonesInput{Filters: []*ec2.Filter{filter("tag:Name", "x")}})
+	expectErrorCode(t, err, "InvalidParameterValue")
+}
+
+func TestFiltersCombineValuesWithOrAndFiltersWithAnd(t *testing.T) {
+	m := newSeededMock(t)
+	first := createTestSubnet(t, m, "10.0.10.0/24")
+	second := createTestSubnet(t, m, "10.0.11.0/24")
+	third := createTestSubnet(t, m, "10.0.12.0/24")
+
+	for _, test := range []struct {
+		filters []*ec2.Filter
+		want    []string
+	}{
+		{[]*ec2.Filter{filter("cidr-block", "10.0.10.0/24", "10.0.11.0/24")}, []string{*first.SubnetId, *second.SubnetId}},
+		{[]*ec2.Filter{filter("cidr-block", "10.0.1?.0/24")}, []string{*first.SubnetId, *second.SubnetId, *third.SubnetId}},
+		{[]*ec2.Filter{filter("cidr-block", "10.0.1*"), filter("subnet-id", *second.SubnetId)}, []string{*second.SubnetId}},
+		{[]*ec2.Filter{filter("vpc-id", "vpc-none")}, []string{}},
+	} {
+		output, err := m.DescribeSubnets(&ec2.DescribeSubnetsInput{Filters: test.filters})
+		if err != nil {
+			t.Fatalf("DescribeSubnets(%v): %v", test.filters, err)
+		}
+		if len(output.Subnets) != len(test.want) {
+			t.Fatalf("DescribeSubnets(%v) gave %d subnets, want %d", test.filters, len(output.Subnets), len(test.want))
+		}
+		for _, want := range test.want {
+			found := false
+			for _, subnet := range output.Subnets {
+				found = found || *subnet.SubnetId == want
+			}
+			if !found {
+				t.Errorf("DescribeSubnets(%v) misses %s", test.filters, want)
+			}
+		}
+	}
+}
+
+func TestFiltersMatchTags(t *testing.T) {
+	m := newSeededMock(t)
+	tagged, err := m.RunInstances(&ec2.RunInstancesInput{
+		ImageId:  aws.String("ami-test"),
+		MinCount: aws.Int64(1),
+		MaxCount: aws.Int64(1),
+		TagSpecifications: []*ec2.TagSpecification{{
+			ResourceType: aws.String("instance"),
+			Tags:         []*ec2.Tag{{Key: aws.String("Name"), Value: aws.String("web-1")}},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("RunInstances: %v", err)
+	}
+	runTestInstances(t, m, aws.String(m.GetDefaultSubnetID()), 1)
+
+	for _, test := range []struct {
+		filter *ec2.Filter
+		want   int
+	}{
+		{filter("tag:Name", "web-1"), 1},
+		{filter("tag:Name", "web-*"), 1},
+		{filter("tag:Name", "db-*"), 0},
+		{filter("tag-key", "Name"), 1},
+		{filter("tag-value", "web-1"), 1},
+		{filter("tag:Other", "web-1"), 0},
+	} {
+		output, err := m.DescribeInstances(&ec2.DescribeInstancesInput{Filters: []*ec2.Filter{
+			test.filter,
+			filter("image-id", "ami-test"),
+		}})
+		if err != nil {
+			t.Fatalf("DescribeInstances(%v): %v", test.filter, err)
+		}
+		got := 0
+		for _, reservation := range output.Reservations {
+			for _, instance := range reservation.Instances {
+				if *instance.InstanceId != *tagged.Instances[0].InstanceId {
+					t.Errorf("DescribeInstances(%v) matched untagged instance %s", test.filter, *instance.InstanceId)
+				}
+				got++
+			}
+		}
+		if got != test.want {
+			t.Errorf("DescribeInstances(%v) matched %d instances, want %d", test.filter, got, test.want)
+		}
+	}
+}
@@ -111,3 +111,15 @@ func GiveRandomId(prefix string) string {
 	}
 	return rsp
 }
+
+// cidrContains tells whether inner lies entirely within outer.
+func cidrContains(outer, inner *net.IPNet) bool {
+	outerOnes, outerBits := outer.Mask.Size()
+	innerOnes, innerBits := inner.Mask.Size()
+	return outerBits == innerBits && outerOnes <= innerOnes && outer.Contains(inner.IP)
+}
+
+// cidrsOverlap tells whether the two blocks share any address.
+func cidrsOverlap(a, b *net.IPNet) bool {
+	return cidrContains(a, b) || cidrContains(b, a)
+}
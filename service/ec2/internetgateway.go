@@ -0,0 +1,76 @@
+/*
+Copyright 2018 The Avi Networks.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package ec2
+
+import (
+	aws "github.com/aws/aws-sdk-go/aws"
+	ec2 "github.com/aws/aws-sdk-go/service/ec2"
+)
+
+// the state ec2 reports for an internet gateway attached to a vpc, egress
+// only internet gateways report attached
+const internetGatewayAttached = "available"
+
+// internetGatewayOf looks an internet gateway up by id.
+func (_m *EC2API) internetGatewayOf(internetGatewayId *string) (*ec2.InternetGateway, error) {
+	internetGateway, ok := _m.internetGateways[aws.StringValue(internetGatewayId)]
+	if !ok {
+		return nil, newAwsError("InvalidInternetGatewayID.NotFound", "The internetGateway ID '"+aws.StringValue(internetGatewayId)+"' does not exist")
+	}
+	return internetGateway, nil
+}
+
+// egressOnlyInternetGatewayOf looks an egress only internet gateway up by id.
+func (_m *EC2API) egressOnlyInternetGatewayOf(egressOnlyInternetGatewayId *string) (*ec2.EgressOnlyInternetGateway, error) {
+	egressOnlyInternetGateway, ok := _m.egressOnlyGateways[aws.StringValue(egressOnlyInternetGatewayId)]
+	if !ok {
+		return nil, newAwsError("InvalidGatewayID.NotFound", "The eigw ID '"+aws.StringValue(egressOnlyInternetGatewayId)+"' does not exist")
+	}
+	return egressOnlyInternetGateway, nil
+}
+
+// attachedToVpc tells whether one of the attachments is to the vpc.
+func attachedToVpc(attachments []*ec2.InternetGatewayAttachment, vpcId string) bool {
+	for _, attachment := range attachments {
+		if aws.StringValue(attachment.VpcId) == vpcId {
+			return true
+		}
+	}
+	return false
+}
+
+// vpcInternetGateway returns the internet gateway attached to the vpc, if any.
+func (_m *EC2API) vpcInternetGateway(vpcId string) *ec2.InternetGateway {
+	for _, internetGateway := range _m.internetGateways {
+		if attachedToVpc(internetGateway.Attachments, vpcId) {
+			return internetGateway
+		}
+	}
+	return nil
+}
+
+// vpcHasPublicIps tells whether an interface in the vpc has a public ip,
+// elastic or auto assigned, mapped to it.
+func (_m *EC2API) vpcHasPublicIps(vpcId string) bool {
+	for _, ntwInterface := range _m.networkinterfaces {
+		if aws.StringValue(ntwInterface.VpcId) != vpcId {
+			continue
+		}
+		for _, privateIp := range ntwInterface.PrivateIpAddresses {
+			if privateIp.Association != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
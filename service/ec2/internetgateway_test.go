@@ -0,0 +1,137 @@
+/*
+Copyright 2018 The Avi Networks.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package ec2
+
+import (
+	"testing"
+
+	aws "github.com/aws/aws-sdk-go/aws"
+	ec2 "github.com/aws/aws-sdk-go/service/ec2"
+)
+
+func createTestInternetGateway(t *testing.T, m *EC2API) *string {
+	t.Helper()
+	output, err := m.CreateInternetGateway(&ec2.CreateInternetGatewayInput{})
+	if err != nil {
+		t.Fatalf("CreateInternetGateway: %v", err)
+	}
+	return output.InternetGateway.InternetGatewayId
+}
+
+// mainTestRouteTable gives the id of the main route table of the vpc.
+func mainTestRouteTable(t *testing.T, m *EC2API, vpcId *string) *string {
+	t.Helper()
+	routeTables := describeTestRouteTables(t, m, filter("vpc-id", *vpcId), filter("association.main", "true"))
+	if len(routeTables) != 1 {
+		t.Fatalf("vpc %s has %d main route tables", *vpcId, len(routeTables))
+	}
+	return routeTables[0].RouteTableId
+}
+
+func TestInternetGatewayAttachment(t *testing.T) {
+	m := newSeededMock(t)
+	vpc, subnet := createTestVpc(t, m, "10.1.0.0/16", "10.1.1.0/24")
+	routeTableId := mainTestRouteTable(t, m, vpc.VpcId)
+	gatewayId := createTestInternetGateway(t, m)
+	otherId := createTestInternetGateway(t, m)
+
+	_, err := m.CreateRoute(&ec2.CreateRouteInput{RouteTableId: routeTableId, DestinationCidrBlock: aws.String("0.0.0.0/0"), GatewayId: gatewayId})
+	expectErrorCode(t, err, "InvalidParameterValue")
+	if _, err := m.AttachInternetGateway(&ec2.AttachInternetGatewayInput{InternetGatewayId: gatewayId, VpcId: vpc.VpcId}); err != nil {
+		t.Fatalf("AttachInternetGateway: %v", err)
+	}
+	_, err = m.AttachInternetGateway(&ec2.AttachInternetGatewayInput{InternetGatewayId: gatewayId, VpcId: aws.String(m.GetDefaultVPCID())})
+	expectErrorCode(t, err, "Resource.AlreadyAssociated")
+	_, err = m.AttachInternetGateway(&ec2.AttachInternetGatewayInput{InternetGatewayId: otherId, VpcId: vpc.VpcId})
+	expectErrorCode(t, err, "Resource.AlreadyAssociated")
+	_, err = m.DeleteInternetGateway(&ec2.DeleteInternetGatewayInput{InternetGatewayId: gatewayId})
+	expectErrorCode(t, err, "DependencyViolation")
+	if _, err := m.CreateRoute(&ec2.CreateRouteInput{RouteTableId: routeTableId, DestinationCidrBlock: aws.String("0.0.0.0/0"), GatewayId: gatewayId}); err != nil {
+		t.Fatalf("CreateRoute: %v", err)
+	}
+
+	// a mapped public address keeps the gateway attached
+	ntwInterface := createTestInterface(t, m, subnet.SubnetId)
+	allocationId := allocateTestAddress(t, m)
+	associated, err := m.AssociateAddress(&ec2.AssociateAddressInput{AllocationId: allocationId, NetworkInterfaceId: ntwInterface.NetworkInterfaceId})
+	if err != nil {
+		t.Fatalf("AssociateAddress: %v", err)
+	}
+	_, err = m.DetachInternetGateway(&ec2.DetachInternetGatewayInput{InternetGatewayId: gatewayId, VpcId: vpc.VpcId})
+	expectErrorCode(t, err, "DependencyViolation")
+	if _, err := m.DisassociateAddress(&ec2.DisassociateAddressInput{AssociationId: associated.AssociationId}); err != nil {
+		t.Fatalf("DisassociateAddress: %v", err)
+	}
+	if _, err := m.DetachInternetGateway(&ec2.DetachInternetGatewayInput{InternetGatewayId: gatewayId, VpcId: vpc.VpcId}); err != nil {
+		t.Fatalf("DetachInternetGateway: %v", err)
+	}
+	routeState := func() string {
+		t.Helper()
+		routeTable := describeTestRouteTables(t, m, filter("route-table-id", *routeTableId))[0]
+		return aws.StringValue(testRoute(routeTable, "0.0.0.0/0").State)
+	}
+	if state := routeState(); state != ec2.RouteStateBlackhole {
+		t.Fatalf("route through a detached gateway is %s", state)
+	}
+	_, err = m.DetachInternetGateway(&ec2.DetachInternetGatewayInput{InternetGatewayId: gatewayId, VpcId: vpc.VpcId})
+	expectErrorCode(t, err, "Gateway.NotAttached")
+
+	if _, err := m.AttachInternetGateway(&ec2.AttachInternetGatewayInput{InternetGatewayId: gatewayId, VpcId: vpc.VpcId}); err != nil {
+		t.Fatalf("AttachInternetGateway: %v", err)
+	}
+	if state := routeState(); state != ec2.RouteStateActive {
+		t.Fatalf("route through a reattached gateway is %s", state)
+	}
+	if _, err := m.DetachInternetGateway(&ec2.DetachInternetGatewayInput{InternetGatewayId: gatewayId, VpcId: vpc.VpcId}); err != nil {
+		t.Fatalf("DetachInternetGateway: %v", err)
+	}
+	if _, err := m.DeleteInternetGateway(&ec2.DeleteInternetGatewayInput{InternetGatewayId: gatewayId}); err != nil {
+		t.Fatalf("DeleteInternetGateway: %v", err)
+	}
+	_, err = m.DescribeInternetGateways(&ec2.DescribeInternetGatewaysInput{InternetGatewayIds: []*string{gatewayId}})
+	expectErrorCode(t, err, "InvalidInternetGatewayID.NotFound")
+}
+
+func TestEgressOnlyInternetGatewayRoutesIpv6(t *testing.T) {
+	m := newSeededMock(t)
+	vpc, _ := createTestVpc(t, m, "10.1.0.0/16", "10.1.1.0/24")
+	routeTableId := mainTestRouteTable(t, m, vpc.VpcId)
+	created, err := m.CreateEgressOnlyInternetGateway(&ec2.CreateEgressOnlyInternetGatewayInput{VpcId: vpc.VpcId})
+	if err != nil {
+		t.Fatalf("CreateEgressOnlyInternetGateway: %v", err)
+	}
+	gatewayId := created.EgressOnlyInternetGateway.EgressOnlyInternetGatewayId
+	if attachments := created.EgressOnlyInternetGateway.Attachments; len(attachments) != 1 || aws.StringValue(attachments[0].VpcId) != *vpc.VpcId {
+		t.Fatalf("egress only internet gateway has attachments %v", attachments)
+	}
+
+	_, err = m.CreateRoute(&ec2.CreateRouteInput{RouteTableId: routeTableId, DestinationCidrBlock: aws.String("0.0.0.0/0"), EgressOnlyInternetGatewayId: gatewayId})
+	expectErrorCode(t, err, "InvalidParameterValue")
+	otherVpc, _ := createTestVpc(t, m, "10.2.0.0/16", "10.2.1.0/24")
+	_, err = m.CreateRoute(&ec2.CreateRouteInput{RouteTableId: mainTestRouteTable(t, m, otherVpc.VpcId), DestinationIpv6CidrBlock: aws.String("::/0"), EgressOnlyInternetGatewayId: gatewayId})
+	expectErrorCode(t, err, "InvalidParameterValue")
+	if _, err := m.CreateRoute(&ec2.CreateRouteInput{RouteTableId: routeTableId, DestinationIpv6CidrBlock: aws.String("::/0"), EgressOnlyInternetGatewayId: gatewayId}); err != nil {
+		t.Fatalf("CreateRoute: %v", err)
+	}
+
+	if _, err := m.DeleteEgressOnlyInternetGateway(&ec2.DeleteEgressOnlyInternetGatewayInput{EgressOnlyInternetGatewayId: gatewayId}); err != nil {
+		t.Fatalf("DeleteEgressOnlyInternetGateway: %v", err)
+	}
+	for _, route := range describeTestRouteTables(t, m, filter("route-table-id", *routeTableId))[0].Routes {
+		if aws.StringValue(route.DestinationIpv6CidrBlock) == "::/0" && aws.StringValue(route.State) != ec2.RouteStateBlackhole {
+			t.Fatalf("route through a deleted egress only internet gateway is %s", aws.StringValue(route.State))
+		}
+	}
+	_, err = m.DeleteEgressOnlyInternetGateway(&ec2.DeleteEgressOnlyInternetGatewayInput{EgressOnlyInternetGatewayId: gatewayId})
+	expectErrorCode(t, err, "InvalidGatewayID.NotFound")
+}
@@ -0,0 +1,275 @@
+/*
+Copyright 2018 The Avi Networks.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package ec2
+
+import (
+	"encoding/binary"
+	"math/rand"
+	"net"
+
+	aws "github.com/aws/aws-sdk-go/aws"
+	ec2 "github.com/aws/aws-sdk-go/service/ec2"
+)
+
+// IpAllocation picks how the mock chooses the private ips it hands out.
+type IpAllocation int
+
+const (
+	// SequentialIpAllocation hands out the lowest free address of the
+	// subnet, the default.
+	SequentialIpAllocation IpAllocation = iota
+	// RandomIpAllocation hands out free addresses in an order drawn from
+	// the seed given to SetIpAllocation, so a run can be replayed.
+	RandomIpAllocation
+)
+
+// SetIpAllocation switches how the following private ips are chosen, seed
+// is only used by RandomIpAllocation.
+func (_m *EC2API) SetIpAllocation(allocation IpAllocation, seed int64) {
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	_m.ipRandom = nil
+	if allocation == RandomIpAllocation {
+		_m.ipRandom = rand.New(rand.NewSource(seed))
+	}
+}
+
+// ipAllocator books the ipv4 addresses of one subnet in a bitmap of offsets
+// from the network address. The first four offsets and the last one are
+// reserved by ec2 and never handed out.
+type ipAllocator struct {
+	base uint32
+	size uint32
+	used []uint64
+	free int64
+}
+
+func newIpAllocator(cidr *net.IPNet) *ipAllocator {
+	ones, bits := cidr.Mask.Size()
+	size := uint32(1) << uint(bits-ones)
+	allocator := &ipAllocator{
+		base: binary.BigEndian.Uint32(cidr.IP.To4()),
+		size: size,
+		used: make([]uint64, (size+63)/64),
+		free: int64(size) - reservedAddressesPerSubnet,
+	}
+	for offset := uint32(0); offset < reservedAddressesPerSubnet-1; offset++ {
+		allocator.mark(offset)
+	}
+	allocator.mark(size - 1)
+	return allocator
+}
+
+func (a *ipAllocator) mark(offset uint32) {
+	a.used[offset/64] |= 1 << (offset % 64)
+}
+
+func (a *ipAllocator) isUsed(offset uint32) bool {
+	return a.used[offset/64]&(1<<(offset%64)) != 0
+}
+
+func (a *ipAllocator) ip(offset uint32) string {
+	ip := make(net.IP, 4)
+	binary.BigEndian.PutUint32(ip, a.base+offset)
+	return ip.String()
+}
+
+// offset gives the offset of ip in the block, false if it lies outside.
+func (a *ipAllocator) offset(ip string) (uint32, bool) {
+	parsed := net.ParseIP(ip).To4()
+	if parsed == nil {
+		return 0, false
+	}
+	offset := binary.BigEndian.Uint32(parsed) - a.base
+	return offset, offset < a.size
+}
+
+// allocate books a free address, the lowest one or, given random, the first
+// free one from a random offset on.
+func (a *ipAllocator) allocate(random *rand.Rand) (string, bool) {
+	if a.free <= 0 {
+		return "", false
+	}
+	start := uint32(0)
+	if random != nil {
+		start = uint32(random.Int63n(int64(a.size)))
+	}
+	for i := uint32(0); i < a.size; i++ {
+		offset := (start + i) % a.size
+		if offset%64 == 0 && a.used[offset/64] == ^uint64(0) && offset+64 <= a.size {
+			// skip a full word at once
+			i += 63
+			continue
+		}
+		if !a.isUsed(offset) {
+			a.mark(offset)
+			a.free--
+			return a.ip(offset), true
+		}
+	}
+	return "", false
+}
+
+// release hands a booked address back, reserved ones stay booked.
+func (a *ipAllocator) release(ip string) {
+	offset, ok := a.offset(ip)
+	if !ok || offset < reservedAddressesPerSubnet-1 || offset == a.size-1 || !a.isUsed(offset) {
+		return
+	}
+	a.used[offset/64] &^= 1 << (offset % 64)
+	a.free++
+}
+
+// ipAllocatorOf gives the allocator of the subnet, made on first use.
+func (_m *EC2API) ipAllocatorOf(subnet *ec2.Subnet) (*ipAllocator, error) {
+	if allocator, ok := _m.assignedIpOnSubnet[*subnet.SubnetId]; ok {
+		return allocator, nil
+	}
+	_, cidr, err := net.ParseCIDR(aws.StringValue(subnet.CidrBlock))
+	if err != nil || cidr.IP.To4() == nil {
+		return nil, newAwsError("InvalidParameterValue", "The subnet '"+aws.StringValue(subnet.SubnetId)+"' has no valid CIDR block")
+	}
+	allocator := newIpAllocator(cidr)
+	_m.assignedIpOnSubnet[*subnet.SubnetId] = allocator
+	return allocator, nil
+}
+
+// pickUnassignedIp books a free address of the subnet.
+func (_m *EC2API) pickUnassignedIp(subnet *ec2.Subnet) (string, error) {
+	allocator, err := _m.ipAllocatorOf(subnet)
+	if err != nil {
+		return "", err
+	}
+	ip, ok := allocator.allocate(_m.ipRandom)
+	if !ok {
+		return "", newAwsError("InsufficientFreeAddressesInSubnet", "There are not enough free addresses in subnet '"+*subnet.SubnetId+"' to satisfy the requested number of instances.")
+	}
+	return ip, nil
+}
+
+// assignIpOnSubnet books an explicitly requested ip of the subnet.
+func (_m *EC2API) assignIpOnSubnet(subnet *ec2.Subnet, ip string) error {
+	allocator, err := _m.ipAllocatorOf(subnet)
+	if err != nil {
+		return err
+	}
+	offset, ok := allocator.offset(ip)
+	if !ok {
+		return newAwsError("InvalidParameterValue", "Address "+ip+" does not fall within the subnet's address range")
+	}
+	if offset < reservedAddressesPerSubnet-1 || offset == allocator.size-1 {
+		return newAwsError("InvalidParameterValue", "Address "+ip+" is in subnet's reserved address range")
+	}
+	if allocator.isUsed(offset) {
+		return newAwsError("InvalidIPAddress.InUse", "Address "+ip+" is in use.")
+	}
+	allocator.mark(offset)
+	allocator.free--
+	return nil
+}
+
+// releaseIpOnSubnet hands the ip back to the subnet.
+func (_m *EC2API) releaseIpOnSubnet(subnetId, ip string) {
+	if allocator, ok := _m.assignedIpOnSubnet[subnetId]; ok {
+		allocator.release(ip)
+	}
+}
+
+// firstIpv6Offset is the lowest interface id handed out sequentially, the
+// ones below it are kept back like the reserved ipv4 addresses.
+const firstIpv6Offset = 4
+
+// subnetIpv6Block gives the associated ipv6 block of the subnet.
+func subnetIpv6Block(subnet *ec2.Subnet) (*net.IPNet, error) {
+	for _, association := range subnet.Ipv6CidrBlockAssociationSet {
+		if aws.StringValue(association.Ipv6CidrBlockState.State) != ec2.SubnetCidrBlockStateCodeAssociated {
+			continue
+		}
+		if _, cidr, err := net.ParseCIDR(aws.StringValue(association.Ipv6CidrBlock)); err == nil {
+			return cidr, nil
+		}
+	}
+	return nil, newAwsError("InvalidParameterValue", "The subnet '"+aws.StringValue(subnet.SubnetId)+"' does not have an IPv6 CIDR block.")
+}
+
+// ipv6At gives the address of the block with the given interface id.
+func ipv6At(cidr *net.IPNet, interfaceId uint64) string {
+	ip := make(net.IP, net.IPv6len)
+	copy(ip, cidr.IP.To16())
+	binary.BigEndian.PutUint64(ip[8:], interfaceId)
+	return ip.String()
+}
+
+// pickUnassignedIpv6 books a free ipv6 address of the subnet, the lowest one
+// or, with a random allocation, one with a random interface id. In order a
+// free id turns up within one more try than there are booked addresses, the
+// random order gets as many tries.
+func (_m *EC2API) pickUnassignedIpv6(subnet *ec2.Subnet) (string, error) {
+	cidr, err := subnetIpv6Block(subnet)
+	if err != nil {
+		return "", err
+	}
+	booked, ok := _m.assignedIpv6OnSubnet[*subnet.SubnetId]
+	if !ok {
+		booked = map[string]bool{}
+		_m.assignedIpv6OnSubnet[*subnet.SubnetId] = booked
+	}
+	for try := 0; try <= len(booked); try++ {
+		interfaceId := uint64(firstIpv6Offset + try)
+		if _m.ipRandom != nil {
+			interfaceId = _m.ipRandom.Uint64()
+			if interfaceId < firstIpv6Offset {
+				continue
+			}
+		}
+		ip := ipv6At(cidr, interfaceId)
+		if !booked[ip] {
+			booked[ip] = true
+			return ip, nil
+		}
+	}
+	return "", newAwsError("InsufficientFreeAddressesInSubnet", "There are not enough free IPv6 addresses in subnet '"+*subnet.SubnetId+"' to satisfy the request.")
+}
+
+// assignIpv6OnSubnet books an explicitly requested ipv6 address of the
+// subnet, it gives the address in its canonical form.
+func (_m *EC2API) assignIpv6OnSubnet(subnet *ec2.Subnet, ip string) (string, error) {
+	cidr, err := subnetIpv6Block(subnet)
+	if err != nil {
+		return "", err
+	}
+	parsed := net.ParseIP(ip)
+	if parsed == nil || parsed.To4() != nil || !cidr.Contains(parsed) {
+		return "", newAwsError("InvalidParameterValue", "Address "+ip+" does not fall within the subnet's address range")
+	}
+	if binary.BigEndian.Uint64(parsed[8:]) < firstIpv6Offset {
+		return "", newAwsError("InvalidParameterValue", "Address "+ip+" is in subnet's reserved address range")
+	}
+	booked, ok := _m.assignedIpv6OnSubnet[*subnet.SubnetId]
+	if !ok {
+		booked = map[string]bool{}
+		_m.assignedIpv6OnSubnet[*subnet.SubnetId] = booked
+	}
+	if booked[parsed.String()] {
+		return "", newAwsError("InvalidIPAddress.InUse", "Address "+ip+" is in use.")
+	}
+	booked[parsed.String()] = true
+	return parsed.String(), nil
+}
+
+// releaseIpv6OnSubnet hands the ipv6 address back to the subnet.
+func (_m *EC2API) releaseIpv6OnSubnet(subnetId, ip string) {
+	if booked, ok := _m.assignedIpv6OnSubnet[subnetId]; ok {
+		delete(booked, ip)
+	}
+}
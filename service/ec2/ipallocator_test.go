@@ -0,0 +1,127 @@
+/*
+Copyright 2018 The Avi Networks.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package ec2
+
+import (
+	"fmt"
+	"math/rand"
+	"net"
+	"testing"
+)
+
+func testIpAllocator(t *testing.T, cidrBlock string) *ipAllocator {
+	t.Helper()
+	_, cidr, err := net.ParseCIDR(cidrBlock)
+	if err != nil {
+		t.Fatalf("ParseCIDR(%s): %v", cidrBlock, err)
+	}
+	return newIpAllocator(cidr)
+}
+
+func TestIpAllocatorHandsOutTheLowestFreeAddress(t *testing.T) {
+	allocator := testIpAllocator(t, "10.0.1.0/28")
+	got := []string{}
+	for {
+		ip, ok := allocator.allocate(nil)
+		if !ok {
+			break
+		}
+		got = append(got, ip)
+	}
+	// .0 to .3 and .15 are reserved
+	if len(got) != 11 || got[0] != "10.0.1.4" || got[10] != "10.0.1.14" {
+		t.Fatalf("allocated %v, want 10.0.1.4 to 10.0.1.14", got)
+	}
+
+	allocator.release("10.0.1.9")
+	allocator.release("10.0.1.0")
+	allocator.release("10.0.1.15")
+	allocator.release("10.0.2.9")
+	if ip, ok := allocator.allocate(nil); !ok || ip != "10.0.1.9" {
+		t.Fatalf("allocate after a release gave %s, %v, want 10.0.1.9", ip, ok)
+	}
+	if _, ok := allocator.allocate(nil); ok {
+		t.Fatal("releasing reserved or foreign addresses freed room")
+	}
+}
+
+func TestIpAllocatorSkipsFullWords(t *testing.T) {
+	allocator := testIpAllocator(t, "10.0.0.0/22")
+	for i := 0; i < 200; i++ {
+		want := fmt.Sprintf("10.0.%d.%d", (i+4)/256, (i+4)%256)
+		if ip, ok := allocator.allocate(nil); !ok || ip != want {
+			t.Fatalf("allocation %d gave %s, %v, want %s", i, ip, ok, want)
+		}
+	}
+	if allocator.free != 1024-5-200 {
+		t.Fatalf("%d addresses free, want %d", allocator.free, 1024-5-200)
+	}
+}
+
+func TestIpAllocatorRandomOrderFollowsTheSeed(t *testing.T) {
+	allocate := func(seed int64) []string {
+		allocator := testIpAllocator(t, "10.0.1.0/24")
+		random := rand.New(rand.NewSource(seed))
+		got := []string{}
+		for i := 0; i < 20; i++ {
+			ip, ok := allocator.allocate(random)
+			if !ok {
+				t.Fatalf("allocation %d failed", i)
+			}
+			got = append(got, ip)
+		}
+		return got
+	}
+	first, again, other := allocate(1), allocate(1), allocate(2)
+	if fmt.Sprint(first) != fmt.Sprint(again) {
+		t.Fatalf("seed 1 gave %v, then %v", first, again)
+	}
+	if fmt.Sprint(first) == fmt.Sprint(other) {
+		t.Fatalf("seeds 1 and 2 both gave %v", first)
+	}
+	seen := map[string]bool{}
+	for _, ip := range first {
+		if seen[ip] {
+			t.Fatalf("%s handed out twice", ip)
+		}
+		seen[ip] = true
+	}
+}
+
+func TestAssignIpOnSubnetChecksTheAddress(t *testing.T) {
+	m := newSeededMock(t)
+	subnet := createTestSubnet(t, m, "10.0.1.0/24")
+	if err := m.assignIpOnSubnet(subnet, "10.0.1.10"); err != nil {
+		t.Fatalf("assignIpOnSubnet: %v", err)
+	}
+	for _, test := range []struct {
+		ip, code string
+	}{
+		{"10.0.1.10", "InvalidIPAddress.InUse"},
+		{"10.0.1.1", "InvalidParameterValue"},
+		{"10.0.1.255", "InvalidParameterValue"},
+		{"10.0.2.10", "InvalidParameterValue"},
+	} {
+		expectErrorCode(t, m.assignIpOnSubnet(subnet, test.ip), test.code)
+	}
+
+	m.SetIpAllocation(RandomIpAllocation, 7)
+	ip, err := m.pickUnassignedIp(subnet)
+	if err != nil {
+		t.Fatalf("pickUnassignedIp: %v", err)
+	}
+	if ip == "10.0.1.10" {
+		t.Fatalf("pickUnassignedIp gave the address in use %s", ip)
+	}
+	expectErrorCode(t, m.assignIpOnSubnet(subnet, ip), "InvalidIPAddress.InUse")
+}
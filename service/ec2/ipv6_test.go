@@ -0,0 +1,180 @@
+/*
+Copyright 2018 The Avi Networks.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package ec2
+
+import (
+	"math/rand"
+	"net"
+	"testing"
+
+	aws "github.com/aws/aws-sdk-go/aws"
+	ec2 "github.com/aws/aws-sdk-go/service/ec2"
+)
+
+// createTestIpv6Subnet creates a dual stack vpc with a subnet taking the
+// first /64 of its block.
+func createTestIpv6Subnet(t *testing.T, m *EC2API) (*ec2.Subnet, *net.IPNet) {
+	t.Helper()
+	vpc, err := m.CreateVpc(&ec2.CreateVpcInput{CidrBlock: aws.String("10.1.0.0/16"), AmazonProvidedIpv6CidrBlock: aws.Bool(true)})
+	if err != nil {
+		t.Fatalf("CreateVpc: %v", err)
+	}
+	m.CompleteTransitions()
+	ipv6CidrBlock := testIpv6Subnet(t, m, vpc.Vpc.VpcId, 0)
+	subnet, err := m.CreateSubnet(&ec2.CreateSubnetInput{
+		VpcId:         vpc.Vpc.VpcId,
+		CidrBlock:     aws.String("10.1.1.0/24"),
+		Ipv6CidrBlock: aws.String(ipv6CidrBlock),
+	})
+	if err != nil {
+		t.Fatalf("CreateSubnet: %v", err)
+	}
+	_, block, _ := net.ParseCIDR(ipv6CidrBlock)
+	return subnet.Subnet, block
+}
+
+func interfaceIpv6Addresses(t *testing.T, m *EC2API, ntwInterfaceId *string) []string {
+	t.Helper()
+	ips := []string{}
+	for _, ip := range describeTestInterface(t, m, ntwInterfaceId).Ipv6Addresses {
+		ips = append(ips, aws.StringValue(ip.Ipv6Address))
+	}
+	return ips
+}
+
+func TestAssignIpv6AddressesFromTheSubnetBlock(t *testing.T) {
+	m := newSeededMock(t)
+	subnet, block := createTestIpv6Subnet(t, m)
+	ntwInterface := createTestInterface(t, m, subnet.SubnetId)
+
+	output, err := m.AssignIpv6Addresses(&ec2.AssignIpv6AddressesInput{
+		NetworkInterfaceId: ntwInterface.NetworkInterfaceId,
+		Ipv6AddressCount:   aws.Int64(2),
+	})
+	if err != nil {
+		t.Fatalf("AssignIpv6Addresses: %v", err)
+	}
+	// interface ids below 4 are reserved
+	first, second := ipv6At(block, 4), ipv6At(block, 5)
+	if got := aws.StringValueSlice(output.AssignedIpv6Addresses); len(got) != 2 || got[0] != first || got[1] != second {
+		t.Fatalf("assigned %v, want %s and %s", got, first, second)
+	}
+
+	free := ipv6At(block, 0x20)
+	for _, test := range []struct {
+		name  string
+		input *ec2.AssignIpv6AddressesInput
+		code  string
+	}{
+		{"reserved address", &ec2.AssignIpv6AddressesInput{Ipv6Addresses: []*string{aws.String(ipv6At(block, 1))}}, "InvalidParameterValue"},
+		{"outside the block", &ec2.AssignIpv6AddressesInput{Ipv6Addresses: []*string{aws.String("2600:1f18::10")}}, "InvalidParameterValue"},
+		{"address in use", &ec2.AssignIpv6AddressesInput{Ipv6Addresses: []*string{aws.String(free), aws.String(first)}}, "InvalidIPAddress.InUse"},
+		{"count and addresses", &ec2.AssignIpv6AddressesInput{Ipv6AddressCount: aws.Int64(1), Ipv6Addresses: []*string{aws.String(free)}}, "InvalidParameterCombination"},
+	} {
+		test.input.NetworkInterfaceId = ntwInterface.NetworkInterfaceId
+		_, err := m.AssignIpv6Addresses(test.input)
+		if errorCode(err) != test.code {
+			t.Errorf("%s: got error %v, want code %s", test.name, err, test.code)
+		}
+	}
+	if ips := interfaceIpv6Addresses(t, m, ntwInterface.NetworkInterfaceId); len(ips) != 2 {
+		t.Fatalf("rejected requests changed the addresses to %v", ips)
+	}
+
+	// the address booked before the in use one was handed back
+	if _, err := m.AssignIpv6Addresses(&ec2.AssignIpv6AddressesInput{
+		NetworkInterfaceId: ntwInterface.NetworkInterfaceId,
+		Ipv6Addresses:      []*string{aws.String(free)},
+	}); err != nil {
+		t.Fatalf("AssignIpv6Addresses(%s): %v", free, err)
+	}
+	described, err := m.DescribeNetworkInterfaces(&ec2.DescribeNetworkInterfacesInput{Filters: []*ec2.Filter{filter("ipv6-addresses.ipv6-address", free)}})
+	if err != nil {
+		t.Fatalf("DescribeNetworkInterfaces: %v", err)
+	}
+	if len(described.NetworkInterfaces) != 1 || *described.NetworkInterfaces[0].NetworkInterfaceId != *ntwInterface.NetworkInterfaceId {
+		t.Fatalf("filtering on %s gave %v", free, described.NetworkInterfaces)
+	}
+}
+
+func TestIpv6AddressesAreReleased(t *testing.T) {
+	m := newSeededMock(t)
+	subnet, block := createTestIpv6Subnet(t, m)
+	associationId := subnet.Ipv6CidrBlockAssociationSet[0].AssociationId
+	created, err := m.CreateNetworkInterface(&ec2.CreateNetworkInterfaceInput{
+		SubnetId:         subnet.SubnetId,
+		Ipv6AddressCount: aws.Int64(2),
+	})
+	if err != nil {
+		t.Fatalf("CreateNetworkInterface: %v", err)
+	}
+	ntwInterfaceId := created.NetworkInterface.NetworkInterfaceId
+	first := ipv6At(block, 4)
+
+	_, err = m.UnassignIpv6Addresses(&ec2.UnassignIpv6AddressesInput{
+		NetworkInterfaceId: ntwInterfaceId,
+		Ipv6Addresses:      []*string{aws.String(ipv6At(block, 9))},
+	})
+	expectErrorCode(t, err, "InvalidParameterValue")
+	output, err := m.UnassignIpv6Addresses(&ec2.UnassignIpv6AddressesInput{
+		NetworkInterfaceId: ntwInterfaceId,
+		Ipv6Addresses:      []*string{aws.String(first)},
+	})
+	if err != nil {
+		t.Fatalf("UnassignIpv6Addresses: %v", err)
+	}
+	if got := aws.StringValueSlice(output.UnassignedIpv6Addresses); len(got) != 1 || got[0] != first {
+		t.Fatalf("unassigned %v, want %s", got, first)
+	}
+	if ips := interfaceIpv6Addresses(t, m, ntwInterfaceId); len(ips) != 1 || ips[0] != ipv6At(block, 5) {
+		t.Fatalf("interface keeps %v", ips)
+	}
+	// the lowest free address is the released one
+	assigned, err := m.AssignIpv6Addresses(&ec2.AssignIpv6AddressesInput{NetworkInterfaceId: ntwInterfaceId, Ipv6AddressCount: aws.Int64(1)})
+	if err != nil {
+		t.Fatalf("AssignIpv6Addresses: %v", err)
+	}
+	if got := aws.StringValue(assigned.AssignedIpv6Addresses[0]); got != first {
+		t.Fatalf("assigned %s, want the released %s", got, first)
+	}
+
+	_, err = m.DisassociateSubnetCidrBlock(&ec2.DisassociateSubnetCidrBlockInput{AssociationId: associationId})
+	expectErrorCode(t, err, "DependencyViolation")
+	if _, err := m.DeleteNetworkInterface(&ec2.DeleteNetworkInterfaceInput{NetworkInterfaceId: ntwInterfaceId}); err != nil {
+		t.Fatalf("DeleteNetworkInterface: %v", err)
+	}
+	if _, err := m.DisassociateSubnetCidrBlock(&ec2.DisassociateSubnetCidrBlockInput{AssociationId: associationId}); err != nil {
+		t.Fatalf("DisassociateSubnetCidrBlock after the addresses were released: %v", err)
+	}
+}
+
+// reservedSource draws nothing but the reserved interface id 0.
+type reservedSource struct{}
+
+func (reservedSource) Int63() int64 { return 0 }
+func (reservedSource) Seed(int64)   {}
+
+func TestPickUnassignedIpv6GivesUp(t *testing.T) {
+	m := newSeededMock(t)
+	subnet, _ := createTestIpv6Subnet(t, m)
+	ntwInterface := createTestInterface(t, m, subnet.SubnetId)
+	m.ipRandom = rand.New(reservedSource{})
+	_, err := m.AssignIpv6Addresses(&ec2.AssignIpv6AddressesInput{
+		NetworkInterfaceId: ntwInterface.NetworkInterfaceId,
+		Ipv6AddressCount:   aws.Int64(1),
+	})
+	expectErrorCode(t, err, "InsufficientFreeAddressesInSubnet")
+	if ips := interfaceIpv6Addresses(t, m, ntwInterface.NetworkInterfaceId); len(ips) != 0 {
+		t.Fatalf("interface got %v", ips)
+	}
+}
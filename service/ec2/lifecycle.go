@@ -0,0 +1,194 @@
+/*
+Copyright 2018 The Avi Networks.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package ec2
+
+import (
+	"sort"
+	"time"
+
+	aws "github.com/aws/aws-sdk-go/aws"
+	ec2 "github.com/aws/aws-sdk-go/service/ec2"
+)
+
+const PENDING int64 = 0
+const SHUTTING_DOWN int64 = 32
+const STOPPING int64 = 64
+
+var instanceStateNames = map[int64]string{
+	PENDING:       "pending",
+	RUNNING:       "running",
+	SHUTTING_DOWN: "shutting-down",
+	TERMINATED:    "terminated",
+	STOPPING:      "stopping",
+	STOP:          "stopped",
+}
+
+// Transition names a transitional state whose duration can be configured
+// with SetTransitionDelay.
+type Transition string
+
+const (
+	// InstancePending is the time an instance spends pending before running.
+	InstancePending Transition = "instance:pending"
+	// InstanceStopping is the time an instance spends stopping before stopped.
+	InstanceStopping Transition = "instance:stopping"
+	// InstanceShuttingDown is the time an instance spends shutting-down before terminated.
+	InstanceShuttingDown Transition = "instance:shutting-down"
+	// VolumeCreating is the time a volume spends creating before available.
+	VolumeCreating Transition = "volume:creating"
+	// VolumeDeleting is the time a volume spends deleting before it is gone.
+	VolumeDeleting Transition = "volume:deleting"
+	// VpcPending is the time a vpc spends pending before available.
+	VpcPending Transition = "vpc:pending"
+	// CidrAssociating is the time a vpc or subnet cidr block spends associating before associated.
+	CidrAssociating Transition = "cidr:associating"
+	// CidrDisassociating is the time a vpc or subnet cidr block spends disassociating before it is gone.
+	CidrDisassociating Transition = "cidr:disassociating"
+	// NetworkInterfaceAttaching is the time a network interface attachment spends attaching before attached.
+	NetworkInterfaceAttaching Transition = "eni:attaching"
+	// NetworkInterfaceDetaching is the time a network interface attachment spends detaching before it is gone.
+	NetworkInterfaceDetaching Transition = "eni:detaching"
+	// NatGatewayPending is the time a nat gateway spends pending before available or failed.
+	NatGatewayPending Transition = "natgw:pending"
+	// NatGatewayDeleting is the time a nat gateway spends deleting before deleted.
+	NatGatewayDeleting Transition = "natgw:deleting"
+)
+
+// stateTransition is an outstanding move of a resource out of a transitional
+// state. Transitions are settled lazily whenever the mock is called, so no
+// goroutine ever touches the state on its own.
+type stateTransition struct {
+	resourceId string
+	due        time.Time
+	complete   func()
+}
+
+// SetTransitionDelay sets how long resources stay in the given transitional
+// state, measured on the mock's Clock. Zero, the default, completes the
+// transition on the next api call.
+func (_m *EC2API) SetTransitionDelay(transition Transition, delay time.Duration) {
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	_m.transitionDelays[transition] = delay
+}
+
+// SetManualTransitions stops transitions from completing on their own, they
+// only complete when CompleteTransitions is called.
+func (_m *EC2API) SetManualTransitions(manual bool) {
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	_m.manualTransitions = manual
+}
+
+// CompleteTransitions completes every outstanding transition regardless of
+// its delay.
+func (_m *EC2API) CompleteTransitions() {
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	for len(_m.transitions) != 0 {
+		transitions := _m.transitions
+		_m.transitions = []*stateTransition{}
+		for _, transition := range transitions {
+			transition.complete()
+		}
+	}
+}
+
+func (_m *EC2API) now() time.Time {
+	return _m.clock.Now()
+}
+
+// scheduleTransition replaces any outstanding transition of the resource.
+func (_m *EC2API) scheduleTransition(resourceId string, transition Transition, complete func()) {
+	_m.cancelTransition(resourceId)
+	_m.transitions = append(_m.transitions, &stateTransition{
+		resourceId: resourceId,
+		due:        _m.now().Add(_m.transitionDelays[transition]),
+		complete:   complete,
+	})
+}
+
+func (_m *EC2API) cancelTransition(resourceId string) {
+	for i, transition := range _m.transitions {
+		if transition.resourceId == resourceId {
+			_m.transitions = append(_m.transitions[:i], _m.transitions[i+1:]...)
+			return
+		}
+	}
+}
+
+// settleTransitions completes, in due order, every transition whose delay
+// has elapsed.
+func (_m *EC2API) settleTransitions() {
+	if _m.manualTransitions {
+		return
+	}
+	now := _m.now()
+	sort.SliceStable(_m.transitions, func(i, j int) bool {
+		return _m.transitions[i].due.Before(_m.transitions[j].due)
+	})
+	for len(_m.transitions) != 0 && !_m.transitions[0].due.After(now) {
+		transition := _m.transitions[0]
+		_m.transitions = _m.transitions[1:]
+		transition.complete()
+	}
+}
+
+func instanceState(code int64) *ec2.InstanceState {
+	return &ec2.InstanceState{
+		Code: aws.Int64(code),
+		Name: aws.String(instanceStateNames[code]),
+	}
+}
+
+// moveInstance puts the instance in a transitional state and schedules the
+// final state. It returns the state change as reported by the api.
+func (_m *EC2API) moveInstance(instance *ec2.Instance, transitional, final int64, transition Transition) *ec2.InstanceStateChange {
+	previousState := instanceState(aws.Int64Value(instance.State.Code))
+	instance.State = instanceState(transitional)
+	if transitional != PENDING {
+		instance.StateTransitionReason = aws.String("User initiated (" + _m.now().UTC().Format("2006-01-02 15:04:05 MST") + ")")
+		instance.StateReason = &ec2.StateReason{
+			Code:    aws.String("Client.UserInitiatedShutdown"),
+			Message: aws.String("Client.UserInitiatedShutdown: User initiated shutdown"),
+		}
+	} else {
+		instance.StateTransitionReason = aws.String("")
+		instance.StateReason = nil
+	}
+	_m.scheduleTransition(*instance.InstanceId, transition, func() {
+		instance.State = instanceState(final)
+		switch final {
+		case TERMINATED:
+			_m.releaseInstanceNetworkInterfaces(instance)
+		case STOP:
+			_m.releaseInstancePublicIps(instance)
+		case RUNNING:
+			_m.assignInstancePublicIp(instance)
+		}
+	})
+	return &ec2.InstanceStateChange{
+		InstanceId:    instance.InstanceId,
+		PreviousState: previousState,
+		CurrentState:  instanceState(transitional),
+	}
+}
+
+// unchangedInstance reports a state change request that leaves the instance as it is.
+func unchangedInstance(instance *ec2.Instance) *ec2.InstanceStateChange {
+	return &ec2.InstanceStateChange{
+		InstanceId:    instance.InstanceId,
+		PreviousState: instanceState(aws.Int64Value(instance.State.Code)),
+		CurrentState:  instanceState(aws.Int64Value(instance.State.Code)),
+	}
+}
@@ -0,0 +1,322 @@
+/*
+Copyright 2018 The Avi Networks.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package ec2
+
+import (
+	"testing"
+	"time"
+
+	aws "github.com/aws/aws-sdk-go/aws"
+	ec2 "github.com/aws/aws-sdk-go/service/ec2"
+)
+
+// newSeededMock gives a seeded mock running on a fake clock.
+func newSeededMock(t *testing.T) *EC2API {
+	t.Helper()
+	m := New()
+	m.InitialSeeding()
+	m.SetClock(NewFakeClock(time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)))
+	return m
+}
+
+// createTestSubnet creates a subnet of the default vpc.
+func createTestSubnet(t *testing.T, m *EC2API, cidrBlock string) *ec2.Subnet {
+	t.Helper()
+	output, err := m.CreateSubnet(&ec2.CreateSubnetInput{
+		VpcId:     aws.String(m.GetDefaultVPCID()),
+		CidrBlock: aws.String(cidrBlock),
+	})
+	if err != nil {
+		t.Fatalf("CreateSubnet(%s): %v", cidrBlock, err)
+	}
+	return output.Subnet
+}
+
+// runTestInstances launches count instances in the subnet.
+func runTestInstances(t *testing.T, m *EC2API, subnetId *string, count int64) []*ec2.Instance {
+	t.Helper()
+	output, err := m.RunInstances(&ec2.RunInstancesInput{
+		ImageId:  aws.String("ami-test"),
+		MinCount: aws.Int64(count),
+		MaxCount: aws.Int64(count),
+		SubnetId: subnetId,
+	})
+	if err != nil {
+		t.Fatalf("RunInstances: %v", err)
+	}
+	return output.Instances
+}
+
+// expectErrorCode fails the test unless err carries the ec2 error code.
+func expectErrorCode(t *testing.T, err error, code string) {
+	t.Helper()
+	if errorCode(err) != code {
+		t.Fatalf("got error %v, want code %s", err, code)
+	}
+}
+
+func describeTestInstance(t *testing.T, m *EC2API, instanceId *string) *ec2.Instance {
+	t.Helper()
+	output, err := m.DescribeInstances(&ec2.DescribeInstancesInput{InstanceIds: []*string{instanceId}})
+	if err != nil {
+		t.Fatalf("DescribeInstances(%s): %v", aws.StringValue(instanceId), err)
+	}
+	if len(output.Reservations) != 1 || len(output.Reservations[0].Instances) != 1 {
+		t.Fatalf("DescribeInstances(%s) = %v, want one instance", aws.StringValue(instanceId), output)
+	}
+	return output.Reservations[0].Instances[0]
+}
+
+func TestRunInstancesLaunchesAndTerminates(t *testing.T) {
+	m := newSeededMock(t)
+	subnet := createTestSubnet(t, m, "10.0.1.0/24")
+	instances := runTestInstances(t, m, subnet.SubnetId, 2)
+	if len(instances) != 2 {
+		t.Fatalf("launched %d instances, want 2", len(instances))
+	}
+	for index, instance := range instances {
+		if aws.Int64Value(instance.AmiLaunchIndex) != int64(index) {
+			t.Errorf("instance %d has launch index %d", index, aws.Int64Value(instance.AmiLaunchIndex))
+		}
+		if aws.StringValue(instance.SubnetId) != *subnet.SubnetId || len(instance.NetworkInterfaces) != 1 {
+			t.Errorf("instance %s is not in subnet %s with one interface", *instance.InstanceId, *subnet.SubnetId)
+		}
+	}
+	eniId := instances[0].NetworkInterfaces[0].NetworkInterfaceId
+	if _, err := m.TerminateInstances(&ec2.TerminateInstancesInput{InstanceIds: []*string{instances[0].InstanceId}}); err != nil {
+		t.Fatalf("TerminateInstances: %v", err)
+	}
+	m.CompleteTransitions()
+	if state := describeTestInstance(t, m, instances[0].InstanceId).State; aws.StringValue(state.Name) != "terminated" {
+		t.Errorf("terminated instance is %s", aws.StringValue(state.Name))
+	}
+	_, err := m.DescribeNetworkInterfaces(&ec2.DescribeNetworkInterfacesInput{NetworkInterfaceIds: []*string{eniId}})
+	expectErrorCode(t, err, "InvalidNetworkInterfaceID.NotFound")
+}
+
+func TestRunInstancesValidatesCounts(t *testing.T) {
+	m := newSeededMock(t)
+	_, err := m.RunInstances(&ec2.RunInstancesInput{ImageId: aws.String("ami-test"), MaxCount: aws.Int64(1)})
+	expectErrorCode(t, err, "MissingParameter")
+	_, err = m.RunInstances(&ec2.RunInstancesInput{ImageId: aws.String("ami-test"), MinCount: aws.Int64(2), MaxCount: aws.Int64(1)})
+	expectErrorCode(t, err, "InvalidParameterValue")
+}
+
+func TestRunInstancesLaunchesWhatFitsAboveMinCount(t *testing.T) {
+	m := newSeededMock(t)
+	subnet := createTestSubnet(t, m, "10.0.2.0/28")
+	free := aws.Int64Value(subnet.AvailableIpAddressCount)
+
+	_, err := m.RunInstances(&ec2.RunInstancesInput{
+		ImageId:  aws.String("ami-test"),
+		MinCount: aws.Int64(free + 1),
+		MaxCount: aws.Int64(free + 5),
+		SubnetId: subnet.SubnetId,
+	})
+	expectErrorCode(t, err, "InsufficientFreeAddressesInSubnet")
+	interfaces, err := m.DescribeNetworkInterfaces(&ec2.DescribeNetworkInterfacesInput{Filters: []*ec2.Filter{
+		{Name: aws.String("subnet-id"), Values: []*string{subnet.SubnetId}},
+	}})
+	if err != nil {
+		t.Fatalf("DescribeNetworkInterfaces: %v", err)
+	}
+	if len(interfaces.NetworkInterfaces) != 0 {
+		t.Fatalf("a failed launch left %d interfaces behind", len(interfaces.NetworkInterfaces))
+	}
+
+	output, err := m.RunInstances(&ec2.RunInstancesInput{
+		ImageId:  aws.String("ami-test"),
+		MinCount: aws.Int64(1),
+		MaxCount: aws.Int64(free + 5),
+		SubnetId: subnet.SubnetId,
+	})
+	if err != nil {
+		t.Fatalf("RunInstances: %v", err)
+	}
+	if int64(len(output.Instances)) != free {
+		t.Fatalf("launched %d instances, want the %d that fit", len(output.Instances), free)
+	}
+}
+
+func TestRunInstancesFailureReleasesExistingInterfaces(t *testing.T) {
+	m := newSeededMock(t)
+	subnet := createTestSubnet(t, m, "10.0.3.0/24")
+	existing, err := m.CreateNetworkInterface(&ec2.CreateNetworkInterfaceInput{SubnetId: subnet.SubnetId})
+	if err != nil {
+		t.Fatalf("CreateNetworkInterface: %v", err)
+	}
+	taken, err := m.CreateNetworkInterface(&ec2.CreateNetworkInterfaceInput{SubnetId: subnet.SubnetId})
+	if err != nil {
+		t.Fatalf("CreateNetworkInterface: %v", err)
+	}
+	_, err = m.RunInstances(&ec2.RunInstancesInput{
+		ImageId:  aws.String("ami-test"),
+		MinCount: aws.Int64(1),
+		MaxCount: aws.Int64(1),
+		UserData: aws.String("dXNlcmRhdGE="),
+		NetworkInterfaces: []*ec2.InstanceNetworkInterfaceSpecification{
+			{DeviceIndex: aws.Int64(0), NetworkInterfaceId: existing.NetworkInterface.NetworkInterfaceId},
+			{DeviceIndex: aws.Int64(1), SubnetId: subnet.SubnetId, PrivateIpAddress: taken.NetworkInterface.PrivateIpAddress},
+		},
+	})
+	if err == nil {
+		t.Fatal("RunInstances with a private ip in use succeeded")
+	}
+	described, err := m.DescribeNetworkInterfaces(&ec2.DescribeNetworkInterfacesInput{
+		NetworkInterfaceIds: []*string{existing.NetworkInterface.NetworkInterfaceId},
+	})
+	if err != nil {
+		t.Fatalf("DescribeNetworkInterfaces: %v", err)
+	}
+	if ntwInterface := described.NetworkInterfaces[0]; ntwInterface.Attachment != nil || aws.StringValue(ntwInterface.Status) != "available" {
+		t.Fatalf("existing interface left attached: %v", ntwInterface)
+	}
+	if _, err := m.DeleteNetworkInterface(&ec2.DeleteNetworkInterfaceInput{NetworkInterfaceId: existing.NetworkInterface.NetworkInterfaceId}); err != nil {
+		t.Fatalf("DeleteNetworkInterface: %v", err)
+	}
+	if len(m.instanceUserData) != 0 {
+		t.Fatalf("user data kept for %d instances that were not launched", len(m.instanceUserData))
+	}
+}
+
+func TestInstanceStateMachine(t *testing.T) {
+	m := newSeededMock(t)
+	m.SetTransitionDelay(InstancePending, time.Minute)
+	instance := runTestInstances(t, m, aws.String(m.GetDefaultSubnetID()), 1)[0]
+	ids := []*string{instance.InstanceId}
+	expectState := func(want string) {
+		t.Helper()
+		if state := describeTestInstance(t, m, instance.InstanceId).State; aws.StringValue(state.Name) != want {
+			t.Fatalf("instance is %s, want %s", aws.StringValue(state.Name), want)
+		}
+	}
+
+	expectState("pending")
+	_, err := m.StopInstances(&ec2.StopInstancesInput{InstanceIds: ids})
+	expectErrorCode(t, err, "IncorrectInstanceState")
+	_, err = m.RebootInstances(&ec2.RebootInstancesInput{InstanceIds: ids})
+	expectErrorCode(t, err, "IncorrectInstanceState")
+	m.CompleteTransitions()
+	expectState("running")
+	if _, err := m.RebootInstances(&ec2.RebootInstancesInput{InstanceIds: ids}); err != nil {
+		t.Fatalf("RebootInstances of a running instance: %v", err)
+	}
+
+	m.SetManualTransitions(true)
+	stopped, err := m.StopInstances(&ec2.StopInstancesInput{InstanceIds: ids})
+	if err != nil {
+		t.Fatalf("StopInstances: %v", err)
+	}
+	if change := stopped.StoppingInstances[0]; aws.StringValue(change.PreviousState.Name) != "running" || aws.StringValue(change.CurrentState.Name) != "stopping" {
+		t.Fatalf("StopInstances reported %v", change)
+	}
+	_, err = m.RebootInstances(&ec2.RebootInstancesInput{InstanceIds: ids})
+	expectErrorCode(t, err, "IncorrectInstanceState")
+	_, err = m.StartInstances(&ec2.StartInstancesInput{InstanceIds: ids})
+	expectErrorCode(t, err, "IncorrectInstanceState")
+	m.CompleteTransitions()
+	expectState("stopped")
+	_, err = m.RebootInstances(&ec2.RebootInstancesInput{InstanceIds: ids})
+	expectErrorCode(t, err, "IncorrectInstanceState")
+
+	m.SetManualTransitions(false)
+	if _, err := m.StartInstances(&ec2.StartInstancesInput{InstanceIds: ids}); err != nil {
+		t.Fatalf("StartInstances: %v", err)
+	}
+	expectState("pending")
+	m.clock.(*FakeClock).Advance(time.Minute)
+	expectState("running")
+
+	terminated, err := m.TerminateInstances(&ec2.TerminateInstancesInput{InstanceIds: ids})
+	if err != nil {
+		t.Fatalf("TerminateInstances: %v", err)
+	}
+	if change := terminated.TerminatingInstances[0]; aws.StringValue(change.CurrentState.Name) != "shutting-down" {
+		t.Fatalf("TerminateInstances reported %v", change)
+	}
+	expectState("terminated")
+	_, err = m.StartInstances(&ec2.StartInstancesInput{InstanceIds: ids})
+	expectErrorCode(t, err, "IncorrectInstanceState")
+}
+
+func TestRunInstancesKeepsInterfacesInThePrimaryVpcAndZone(t *testing.T) {
+	m := newSeededMock(t)
+	createSubnet := func(vpcId, cidrBlock, zone string) *string {
+		t.Helper()
+		output, err := m.CreateSubnet(&ec2.CreateSubnetInput{
+			VpcId:            aws.String(vpcId),
+			CidrBlock:        aws.String(cidrBlock),
+			AvailabilityZone: aws.String(zone),
+		})
+		if err != nil {
+			t.Fatalf("CreateSubnet(%s): %v", cidrBlock, err)
+		}
+		return output.Subnet.SubnetId
+	}
+	zone := m.GetDefaultAvailabiltyZone()
+	primary := createSubnet(m.GetDefaultVPCID(), "10.0.4.0/24", zone)
+	otherZone := createSubnet(m.GetDefaultVPCID(), "10.0.5.0/24", zone+"b")
+	_, otherVpc := createTestVpc(t, m, "10.1.0.0/16", "10.1.1.0/24")
+	existing := createTestInterface(t, m, otherVpc.SubnetId)
+
+	for _, test := range []struct {
+		name string
+		spec *ec2.InstanceNetworkInterfaceSpecification
+	}{
+		{"subnet in another zone", &ec2.InstanceNetworkInterfaceSpecification{DeviceIndex: aws.Int64(1), SubnetId: otherZone}},
+		{"subnet in another vpc", &ec2.InstanceNetworkInterfaceSpecification{DeviceIndex: aws.Int64(1), SubnetId: otherVpc.SubnetId}},
+		{"interface in another vpc", &ec2.InstanceNetworkInterfaceSpecification{DeviceIndex: aws.Int64(1), NetworkInterfaceId: existing.NetworkInterfaceId}},
+	} {
+		output, err := m.RunInstances(&ec2.RunInstancesInput{
+			ImageId:  aws.String("ami-test"),
+			MinCount: aws.Int64(1),
+			MaxCount: aws.Int64(1),
+			NetworkInterfaces: []*ec2.InstanceNetworkInterfaceSpecification{
+				{DeviceIndex: aws.Int64(0), SubnetId: primary},
+				test.spec,
+			},
+		})
+		if errorCode(err) != "InvalidParameterValue" {
+			t.Errorf("%s: got error %v, want code InvalidParameterValue", test.name, err)
+		}
+		if output == nil || len(output.Instances) != 0 {
+			t.Errorf("%s: got output %v, want an empty reservation", test.name, output)
+		}
+	}
+	described, err := m.DescribeNetworkInterfaces(&ec2.DescribeNetworkInterfacesInput{
+		NetworkInterfaceIds: []*string{existing.NetworkInterfaceId},
+	})
+	if err != nil {
+		t.Fatalf("DescribeNetworkInterfaces: %v", err)
+	}
+	if described.NetworkInterfaces[0].Attachment != nil {
+		t.Fatalf("rejected launch attached %s", *existing.NetworkInterfaceId)
+	}
+
+	sameZone := createSubnet(m.GetDefaultVPCID(), "10.0.6.0/24", zone)
+	output, err := m.RunInstances(&ec2.RunInstancesInput{
+		ImageId:  aws.String("ami-test"),
+		MinCount: aws.Int64(1),
+		MaxCount: aws.Int64(1),
+		NetworkInterfaces: []*ec2.InstanceNetworkInterfaceSpecification{
+			{DeviceIndex: aws.Int64(0), SubnetId: primary},
+			{DeviceIndex: aws.Int64(1), SubnetId: sameZone},
+		},
+	})
+	if err != nil {
+		t.Fatalf("RunInstances: %v", err)
+	}
+	if len(output.Instances[0].NetworkInterfaces) != 2 {
+		t.Fatalf("instance has %d interfaces, want 2", len(output.Instances[0].NetworkInterfaces))
+	}
+}
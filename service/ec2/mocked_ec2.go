@@ -13,16 +13,26 @@ limitations under the License.
 package ec2
 
 import (
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 
-	randomdata "github.com/Pallinder/go-randomdata"
 	aws "github.com/aws/aws-sdk-go/aws"
 	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/awsutil"
+	"github.com/aws/aws-sdk-go/aws/request"
 	ec2 "github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 
-	"errors"
 	"net"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	mock "github.com/stretchr/testify/mock"
@@ -31,40 +41,121 @@ import (
 )
 
 const RUNNING int64 = 16
+const TERMINATED int64 = 48
 const STOP int64 = 80
 
 // EC2API is an autogenerated mock type for the EC2API type
 type EC2API struct {
 	mock.Mock
+	// mutex guards all of the state below, every api call holds it from the
+	// moment its recorder checks are done until its output is copied.
+	mutex                    sync.Mutex
 	vpcs                     map[string]*ec2.Vpc
 	vpcassocaiatedsubnet     map[string][]*ec2.Subnet         // vpc name will be key
 	networkinterfaces        map[string]*ec2.NetworkInterface // interfaceid will be the name
-	assignedIpOnSubnet       map[string][]string              // key subnet id
+	assignedIpOnSubnet       map[string]*ipAllocator          // key subnet id
+	assignedIpv6OnSubnet     map[string]map[string]bool       // key subnet id
 	subnets                  map[string]*ec2.Subnet           // key subnet id
 	assignedMacAddress       []string                         //assigned mac address
-	assignedelasticIps       map[string]string                // key is allocation id
+	assignedelasticIps       map[string]*ec2.Address          // key is allocation id
+	assignedPublicIps        map[string]bool                  // auto assigned public ips
+	launchPublicIps          map[string]bool                  // key instance id, instances launched with a public ip
 	assignedsecurityGroups   map[string]*ec2.SecurityGroup    // key is security group id
 	createdEc2instances      []*ec2.Instance
+	instanceUserData         map[string]string // key instance id
+	instanceReservations     map[string]string // key instance id, value the reservation it was launched in
 	defaultSecurityGroupID   string
 	defaultSubnetId          string
 	routeTable               map[string]*ec2.RouteTable
+	volumes                  map[string]*ec2.Volume                    // key volume id
+	vpcAttributes            map[string]*vpcAttributes                 // key vpc id
+	networkAcls              map[string]*ec2.NetworkAcl                // key network acl id
+	internetGateways         map[string]*ec2.InternetGateway           // key internet gateway id
+	egressOnlyGateways       map[string]*ec2.EgressOnlyInternetGateway // key egress only internet gateway id
+	natGateways              map[string]*ec2.NatGateway                // key nat gateway id
+	natGatewayFailure        *natGatewayFailure                        // set with SetNatGatewayFailure
 	recorder                 *Recorder
+	clock                    Clock
 	defaultSecurityGroupName string
+	transitions              []*stateTransition
+	transitionDelays         map[Transition]time.Duration
+	manualTransitions        bool
+	defaultPageSize          int64
+	waiterDelay              *time.Duration
+	ipRandom                 *rand.Rand // nil allocates ips sequentially
+	permissionCheck          PermissionCheck
+	region                   string
 }
 
 var AVI_STANDARD_ELASTIC_ALLOCATION_DOMAIN string = "aws"
 
 var _ ec2iface.EC2API = &EC2API{}
 
-//supported filters
-var supportedsubnetfilter []string = []string{"vpc-id", "availabilityZone", "cidrBlock"}
-
 var defaultServiceEngineInstanceName = "service-engine"
 var defaultAvailabilityZone = "us-east-1"
+var defaultRegion = "us-east-1"
 var defaultVpcID = "avi-seeding-vpc"
+var defaultInternetGatewayID = "igw-avi-internet-gateway"
 var defaultCidrBlock = "10.0.0.0/16"
 var defaultVpcState = "available"
 var defaultSubnetCidr = "10.0.0.0/24"
+var defaultOwnerId = "123456789012"
+var defaultInstanceType = "m1.small"
+var defaultVolumeType = "gp2"
+var defaultVpcSecurityGroupName = "default"
+
+// volumeSizes holds the smallest and largest size in GiB of each volume type.
+var volumeSizes = map[string][2]int64{
+	"gp2":      {1, 16384},
+	"io1":      {4, 16384},
+	"st1":      {500, 16384},
+	"sc1":      {500, 16384},
+	"standard": {1, 1024},
+}
+
+// maxNetworkInterfaces holds how many network interfaces an instance of
+// each type takes, types not listed take defaultMaxNetworkInterfaces.
+var maxNetworkInterfaces = map[string]int{
+	"m1.small":   2,
+	"m1.medium":  2,
+	"m1.large":   3,
+	"m1.xlarge":  4,
+	"t2.nano":    2,
+	"t2.micro":   2,
+	"t2.small":   2,
+	"t2.medium":  3,
+	"t2.large":   3,
+	"t2.xlarge":  3,
+	"t3.micro":   2,
+	"t3.small":   3,
+	"t3.medium":  3,
+	"t3.large":   3,
+	"m4.large":   2,
+	"m4.xlarge":  4,
+	"m4.2xlarge": 4,
+	"m5.large":   3,
+	"m5.xlarge":  4,
+	"m5.2xlarge": 4,
+	"c4.large":   3,
+	"c4.xlarge":  4,
+	"c5.large":   3,
+	"c5.xlarge":  4,
+	"c5.2xlarge": 4,
+	"r5.large":   3,
+	"r5.xlarge":  4,
+}
+
+const defaultMaxNetworkInterfaces = 3
+
+// the smallest and the largest vpc ec2 creates, as prefix lengths
+const minVpcPrefixLength = 16
+const maxVpcPrefixLength = 28
+
+// vpcAttributes holds the dns attributes of a vpc, they aren't part of ec2.Vpc.
+type vpcAttributes struct {
+	enableDnsSupport   bool
+	enableDnsHostnames bool
+}
 
 func New() *EC2API {
 	// aws allocate default security group to every instances
@@ -73,10 +164,12 @@ func New() *EC2API {
 	securityGroupIdStr := "sg-" + securityGroupId.String()
 	securityGroupName := "sg-default"
 	defaultSecurityGroup := &ec2.SecurityGroup{
-		GroupId:     &securityGroupIdStr,
-		GroupName:   &securityGroupName,
-		VpcId:       &defaultVpcID,
-		Description: proto.String("default security group"),
+		GroupId:             &securityGroupIdStr,
+		GroupName:           &securityGroupName,
+		VpcId:               &defaultVpcID,
+		Description:         proto.String("default security group"),
+		IpPermissions:       []*ec2.IpPermission{},
+		IpPermissionsEgress: allowAllEgress(),
 	}
 	defaultSecurityGroups := map[string]*ec2.SecurityGroup{}
 	defaultSecurityGroups[securityGroupIdStr] = defaultSecurityGroup
@@ -86,16 +179,31 @@ func New() *EC2API {
 		vpcs:                     make(map[string]*ec2.Vpc, 0),
 		vpcassocaiatedsubnet:     make(map[string][]*ec2.Subnet, 0),
 		networkinterfaces:        make(map[string]*ec2.NetworkInterface, 0),
-		assignedIpOnSubnet:       make(map[string][]string, 0),
+		assignedIpOnSubnet:       make(map[string]*ipAllocator, 0),
+		assignedIpv6OnSubnet:     make(map[string]map[string]bool, 0),
 		subnets:                  make(map[string]*ec2.Subnet, 0),
 		assignedMacAddress:       make([]string, 0),
-		assignedelasticIps:       make(map[string]string, 0),
+		assignedelasticIps:       make(map[string]*ec2.Address, 0),
+		assignedPublicIps:        make(map[string]bool, 0),
+		launchPublicIps:          make(map[string]bool, 0),
 		assignedsecurityGroups:   defaultSecurityGroups,
 		createdEc2instances:      make([]*ec2.Instance, 0),
+		instanceUserData:         make(map[string]string, 0),
+		instanceReservations:     make(map[string]string, 0),
 		defaultSecurityGroupID:   securityGroupIdStr,
 		recorder:                 recorder,
+		clock:                    recorder.clock,
 		routeTable:               make(map[string]*ec2.RouteTable, 0),
+		volumes:                  make(map[string]*ec2.Volume, 0),
+		vpcAttributes:            make(map[string]*vpcAttributes, 0),
+		networkAcls:              make(map[string]*ec2.NetworkAcl, 0),
+		internetGateways:         make(map[string]*ec2.InternetGateway, 0),
+		egressOnlyGateways:       make(map[string]*ec2.EgressOnlyInternetGateway, 0),
+		natGateways:              make(map[string]*ec2.NatGateway, 0),
 		defaultSecurityGroupName: securityGroupName,
+		transitions:              make([]*stateTransition, 0),
+		transitionDelays:         make(map[Transition]time.Duration, 0),
+		region:                   defaultRegion,
 	}
 }
 
@@ -103,19 +211,128 @@ func (_m *EC2API) EXPECT() *Recorder {
 	return _m.recorder
 }
 
+// SetClock makes the recorder delays, the timed errors and the state
+// transitions follow the given clock, typically a FakeClock.
+func (_m *EC2API) SetClock(clock Clock) {
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	_m.clock = clock
+	_m.recorder.Lock()
+	defer _m.recorder.Unlock()
+	_m.recorder.clock = clock
+}
+
+// SetRegion sets the region the mock answers for, it shows in the dns names
+// and arns it hands out. The default is us-east-1.
+func (_m *EC2API) SetRegion(region string) {
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	_m.region = region
+}
+
+// PermissionCheck tells whether the caller may make the api call with the
+// given input, a denied call fails with UnauthorizedOperation.
+type PermissionCheck func(apiName string, input interface{}) bool
+
+// SetPermissionCheck installs the check the mutating apis consult before
+// touching any state, nil, the default, allows everything.
+func (_m *EC2API) SetPermissionCheck(check PermissionCheck) {
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	_m.permissionCheck = check
+}
+
+// checkPermission answers a mutating call the way ec2 does before it changes
+// anything: UnauthorizedOperation when the permission check denies it, and
+// DryRunOperation when it is allowed but only a dry run. The apis check it
+// before settling the due transitions, so a refused call changes no state.
+func (_m *EC2API) checkPermission(apiName string, input interface{}, dryRun *bool) error {
+	if _m.permissionCheck != nil && !_m.permissionCheck(apiName, input) {
+		return newAwsError("UnauthorizedOperation", "You are not authorized to perform this operation.")
+	}
+	if aws.BoolValue(dryRun) {
+		return newAwsError("DryRunOperation", "Request would have succeeded, but DryRun flag is set.")
+	}
+	return nil
+}
+
+// copyOutput deep copies an api output, so callers never share the pointers
+// of the mocked state with the calls running after them.
+func copyOutput(output interface{}) interface{} {
+	if reflect.ValueOf(output).IsNil() {
+		return output
+	}
+	return awsutil.CopyOf(output)
+}
+
+// applyRequestOptions runs the request options of a WithContext call against
+// a request standing in for the call, so options reading the response, such
+// as request.WithGetResponseHeader, find one. An error set by the handlers
+// fails the call.
+func applyRequestOptions(ctx aws.Context, operation string, params, output interface{}, err error, opts []request.Option) error {
+	if len(opts) == 0 {
+		return err
+	}
+	requestId := uuid.New().String()
+	statusCode := http.StatusOK
+	if failure, ok := err.(awserr.RequestFailure); ok {
+		requestId = failure.RequestID()
+		statusCode = failure.StatusCode()
+	}
+	httpRequest, _ := http.NewRequest("POST", "https://ec2.amazonaws.com/", nil)
+	r := &request.Request{
+		Operation: &request.Operation{
+			Name:       operation,
+			HTTPMethod: "POST",
+			HTTPPath:   "/",
+		},
+		HTTPRequest: httpRequest,
+		HTTPResponse: &http.Response{
+			StatusCode: statusCode,
+			Header:     http.Header{"X-Amzn-Requestid": []string{requestId}},
+			Body:       ioutil.NopCloser(strings.NewReader("")),
+		},
+		Params:    params,
+		Data:      output,
+		Error:     err,
+		RequestID: requestId,
+	}
+	r.SetContext(ctx)
+	r.ApplyOptions(opts...)
+	r.Handlers.Complete.Run(r)
+	return r.Error
+}
+
+// AppendInstance adds the instance as it is, instances without a state are
+// added running.
 func (_m *EC2API) AppendInstance(instance *ec2.Instance) {
-	instance.State = &ec2.InstanceState{
-		Code: proto.Int64(RUNNING),
-		Name: proto.String("running"),
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	_m.appendInstance(instance, "")
+}
+
+// appendInstance adds the instance to the reservation, an empty reservation
+// id gives the instance a reservation of its own.
+func (_m *EC2API) appendInstance(instance *ec2.Instance, reservationId string) {
+	if instance.State == nil {
+		instance.State = instanceState(RUNNING)
+	}
+	if reservationId == "" {
+		reservationId = GiveRandomId("r-")
 	}
 	_m.createdEc2instances = append(_m.createdEc2instances, instance)
+	_m.instanceReservations[*instance.InstanceId] = reservationId
 }
 
 func (_m *EC2API) AppendVpcs(vpc *ec2.Vpc) {
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
 	_m.vpcs[*vpc.VpcId] = vpc
 }
 
 func (_m *EC2API) GetDefaultSecurityGroupID() string {
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
 	return _m.defaultSecurityGroupID
 }
 
@@ -127,6 +344,9 @@ func (_m *EC2API) InitialSeeding() {
 		CidrBlock: &defaultCidrBlock,
 		State:     &defaultVpcState,
 	})
+	_m.mutex.Lock()
+	_m.appendDefaultNetworkAcl(_m.vpcs[defaultVpcID])
+	_m.mutex.Unlock()
 
 	// default subnet in vpc
 	createSubnetOutput, _ := _m.CreateSubnet(
@@ -136,12 +356,29 @@ func (_m *EC2API) InitialSeeding() {
 			AvailabilityZone: &defaultAvailabilityZone,
 		},
 	)
+	_m.mutex.Lock()
 	_m.defaultSubnetId = *createSubnetOutput.Subnet.SubnetId
+	_m.mutex.Unlock()
 	networkInterface, _ := _m.CreateNetworkInterface(&ec2.CreateNetworkInterfaceInput{
 		Description: awssdk.String("se nic"),
 		SubnetId:    awssdk.String(_m.GetDefaultSubnetID()),
 	})
 
+	_m.mutex.Lock()
+
+	// the internet gateway the public route goes through
+	_m.internetGateways[defaultInternetGatewayID] = &ec2.InternetGateway{
+		InternetGatewayId: &defaultInternetGatewayID,
+		OwnerId:           aws.String(defaultOwnerId),
+		Attachments: []*ec2.InternetGatewayAttachment{
+			&ec2.InternetGatewayAttachment{
+				State: aws.String(internetGatewayAttached),
+				VpcId: &defaultVpcID,
+			},
+		},
+		Tags: []*ec2.Tag{},
+	}
+
 	//populating route table, we'll associate one public route as well for
 	// avi networks internal testing
 	routeTableID := "avi-route-table-" + uuid.New().String()
@@ -181,18 +418,19 @@ func (_m *EC2API) InitialSeeding() {
 		},
 		PropagatingVgws: []*ec2.PropagatingVgw{
 			&ec2.PropagatingVgw{
-				GatewayId: proto.String("igw-avi-internet-gateway"),
+				GatewayId: &defaultInternetGatewayID,
 			},
 		},
 		Routes: []*ec2.Route{
 			&ec2.Route{
-				GatewayId:            proto.String("igw-avi-internet-gateway"),
+				GatewayId:            &defaultInternetGatewayID,
 				DestinationCidrBlock: proto.String("0.0.0.0/0"),
 				State:                proto.String("active"),
 				Origin:               proto.String("Create Route Table"),
 			},
 		},
 	}
+	_m.mutex.Unlock()
 
 	// service engine
 	_m.AppendInstance(&ec2.Instance{
@@ -226,10 +464,17 @@ func (_m *EC2API) InitialSeeding() {
 }
 
 func (_m *EC2API) GetDefaultSubnetID() string {
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
 	return _m.defaultSubnetId
 }
 
+// GetDefaultServiceEngine gives the seeded instance itself rather than a
+// copy, changes made to it show in the api.
 func (_m *EC2API) GetDefaultServiceEngine() *ec2.Instance {
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	_m.settleTransitions()
 	for _, instance := range _m.createdEc2instances {
 		if *instance.InstanceId == defaultServiceEngineInstanceName {
 			return instance
@@ -261,19 +506,33 @@ func (_m *EC2API) CreateInstace(name, subnetId, vpcId string) *ec2.Instance {
 		SubnetId:    awssdk.String(subnetId),
 	})
 	instanceid := name + "-" + uuid.New().String()
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	ntwInterface := _m.networkinterfaces[*networkInterface.NetworkInterface.NetworkInterfaceId]
+	attachTime := _m.now()
+	ntwInterface.Attachment = &ec2.NetworkInterfaceAttachment{
+		AttachmentId:        aws.String(GiveRandomId("eni-attach-")),
+		AttachTime:          &attachTime,
+		DeleteOnTermination: aws.Bool(true),
+		DeviceIndex:         aws.Int64(0),
+		InstanceId:          &instanceid,
+		InstanceOwnerId:     &defaultOwnerId,
+		Status:              aws.String("attached"),
+	}
+	ntwInterface.Status = aws.String("in-use")
+	launchPublicIp := aws.BoolValue(_m.subnets[subnetId].MapPublicIpOnLaunch)
+	if launchPublicIp {
+		_m.assignPublicIp(ntwInterface)
+	}
+	instanceInterface := instanceNetworkInterface(ntwInterface)
+	instanceInterface.VpcId = &vpcId
 	instance := &ec2.Instance{
-		InstanceId: &instanceid,
-		VpcId:      &vpcId,
-		NetworkInterfaces: []*ec2.InstanceNetworkInterface{
-			&ec2.InstanceNetworkInterface{
-				VpcId:              &vpcId,
-				NetworkInterfaceId: networkInterface.NetworkInterface.NetworkInterfaceId,
-				MacAddress:         networkInterface.NetworkInterface.MacAddress,
-				PrivateIpAddress:   networkInterface.NetworkInterface.PrivateIpAddress,
-				SubnetId:           networkInterface.NetworkInterface.SubnetId,
-				Attachment:         &ec2.InstanceNetworkInterfaceAttachment{DeviceIndex: aws.Int64(0), DeleteOnTermination: aws.Bool(true), AttachmentId: aws.String(GiveRandomId("eni-attach-"))},
-			},
-		},
+		InstanceId:        &instanceid,
+		VpcId:             &vpcId,
+		SubnetId:          ntwInterface.SubnetId,
+		PrivateIpAddress:  ntwInterface.PrivateIpAddress,
+		PrivateDnsName:    ntwInterface.PrivateDnsName,
+		NetworkInterfaces: []*ec2.InstanceNetworkInterface{instanceInterface},
 		Placement: &ec2.Placement{
 			AvailabilityZone: &defaultAvailabilityZone,
 		},
@@ -290,28 +549,205 @@ func (_m *EC2API) CreateInstace(name, subnetId, vpcId string) *ec2.Instance {
 			},
 		},
 	}
-	_m.AppendInstance(instance)
+	if ntwInterface.Association != nil {
+		instance.PublicIpAddress = ntwInterface.Association.PublicIp
+		instance.PublicDnsName = ntwInterface.Association.PublicDnsName
+	}
+	_m.appendInstance(instance, "")
+	if launchPublicIp {
+		_m.launchPublicIps[instanceid] = true
+	}
 	return instance
 }
 
-func (_m *EC2API) AttachNetworkInterface(req *ec2.AttachNetworkInterfaceInput) (*ec2.AttachNetworkInterfaceOutput, error) {
-	output := new(ec2.AttachNetworkInterfaceOutput)
-	output.AttachmentId = aws.String(GiveRandomId("eni-attach-"))
-	return output, nil
+// AttachNetworkInterface provides a mock function with given fields: _a0
+func (_m *EC2API) AttachNetworkInterface(_a0 *ec2.AttachNetworkInterfaceInput) (*ec2.AttachNetworkInterfaceOutput, error) {
+	return _m.AttachNetworkInterfaceWithContext(aws.BackgroundContext(), _a0)
 }
 
-func (m *EC2API) ModifyNetworkInterfaceAttribute(req *ec2.ModifyNetworkInterfaceAttributeInput) (*ec2.ModifyNetworkInterfaceAttributeOutput, error) {
-	output := new(ec2.ModifyNetworkInterfaceAttributeOutput)
+// AttachNetworkInterfaceWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) AttachNetworkInterfaceWithContext(ctx aws.Context, _a0 *ec2.AttachNetworkInterfaceInput, opts ...request.Option) (output *ec2.AttachNetworkInterfaceOutput, err error) {
+	output = &ec2.AttachNetworkInterfaceOutput{}
+	if err := _m.recorder.CheckErrorWithContext(ctx, "AttachNetworkInterface"); err != nil {
+		return output, err
+	}
+	defer func() { err = applyRequestOptions(ctx, "AttachNetworkInterface", _a0, output, err, opts) }()
+	_m.recorder.Record("AttachNetworkInterface")
+	returns, exist := _m.recorder.giveRecordedOutput("AttachNetworkInterface", _a0)
+	if exist {
+		assertedErr, _ := returns[1].(error)
+		return returns[0].(*ec2.AttachNetworkInterfaceOutput), assertedErr
+	}
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.AttachNetworkInterfaceOutput) }()
+	if err = _m.checkPermission("AttachNetworkInterface", _a0, _a0.DryRun); err != nil {
+		return output, err
+	}
+	_m.settleTransitions()
+	if _a0.DeviceIndex == nil {
+		err = newAwsError("MissingParameter", "The request must contain the parameter deviceIndex")
+		return
+	}
+	ntwInterface, ok := _m.networkinterfaces[aws.StringValue(_a0.NetworkInterfaceId)]
+	if !ok {
+		err = newAwsError("InvalidNetworkInterfaceID.NotFound", "The networkInterface ID '"+aws.StringValue(_a0.NetworkInterfaceId)+"' does not exist")
+		return
+	}
+	instance, ok := _m.getInstance(aws.StringValue(_a0.InstanceId))
+	if !ok {
+		err = newAwsError("InvalidInstanceID.NotFound", "The instance ID '"+aws.StringValue(_a0.InstanceId)+"' does not exist")
+		return
+	}
+	if ntwInterface.Attachment != nil || aws.StringValue(ntwInterface.Status) == "in-use" {
+		err = newAwsError("InvalidNetworkInterface.InUse", "Interface: ["+aws.StringValue(_a0.NetworkInterfaceId)+"] in use.")
+		return
+	}
+	if state := aws.Int64Value(instance.State.Code); state != RUNNING && state != STOP {
+		err = newAwsError("IncorrectInstanceState", "The instance '"+aws.StringValue(_a0.InstanceId)+"' is not in a valid state for this operation.")
+		return
+	}
+	if instance.Placement != nil && aws.StringValue(instance.Placement.AvailabilityZone) != aws.StringValue(ntwInterface.AvailabilityZone) {
+		err = newAwsError("InvalidParameterCombination", "You may not attach a network interface to an instance if they are not in the same availability zone")
+		return
+	}
+	if instance.VpcId != nil && aws.StringValue(instance.VpcId) != aws.StringValue(ntwInterface.VpcId) {
+		err = newAwsError("InvalidParameterCombination", "You may not attach a network interface to an instance if they are not in the same VPC")
+		return
+	}
+	for index, instanceInterface := range instance.NetworkInterfaces {
+		deviceIndex := int64(-1)
+		if instanceInterface.Attachment != nil {
+			deviceIndex = aws.Int64Value(instanceInterface.Attachment.DeviceIndex)
+		} else if index == 0 {
+			// interfaces seeded without an attachment are the primary one
+			deviceIndex = 0
+		}
+		if deviceIndex == *_a0.DeviceIndex {
+			err = newAwsError("InvalidParameterValue", "Instance '"+aws.StringValue(_a0.InstanceId)+"' already has an interface attached at device index '"+strconv.FormatInt(*_a0.DeviceIndex, 10)+"'.")
+			return
+		}
+	}
+	limit, ok := maxNetworkInterfaces[aws.StringValue(instance.InstanceType)]
+	if !ok {
+		limit = defaultMaxNetworkInterfaces
+	}
+	if len(instance.NetworkInterfaces) >= limit {
+		err = newAwsError("AttachmentLimitExceeded", "Interface count "+strconv.Itoa(len(instance.NetworkInterfaces)+1)+" exceeds the limit for "+aws.StringValue(instance.InstanceType))
+		return
+	}
+	attachTime := _m.now()
+	attachment := &ec2.NetworkInterfaceAttachment{
+		AttachmentId:        aws.String(GiveRandomId("eni-attach-")),
+		AttachTime:          &attachTime,
+		DeleteOnTermination: aws.Bool(false),
+		DeviceIndex:         aws.Int64(*_a0.DeviceIndex),
+		InstanceId:          instance.InstanceId,
+		InstanceOwnerId:     &defaultOwnerId,
+		Status:              aws.String("attaching"),
+	}
+	ntwInterface.Attachment = attachment
+	ntwInterface.Status = aws.String("in-use")
+	instance.NetworkInterfaces = append(instance.NetworkInterfaces, instanceNetworkInterface(ntwInterface))
+	_m.scheduleTransition(*ntwInterface.NetworkInterfaceId, NetworkInterfaceAttaching, func() {
+		if ntwInterface.Attachment != attachment {
+			return
+		}
+		attachment.Status = aws.String("attached")
+		_m.refreshInstanceNetworkInterface(ntwInterface)
+	})
+	output.AttachmentId = attachment.AttachmentId
 	return output, nil
 }
 
+// ModifyNetworkInterfaceAttribute provides a mock function with given fields: _a0
+func (_m *EC2API) ModifyNetworkInterfaceAttribute(_a0 *ec2.ModifyNetworkInterfaceAttributeInput) (*ec2.ModifyNetworkInterfaceAttributeOutput, error) {
+	return _m.ModifyNetworkInterfaceAttributeWithContext(aws.BackgroundContext(), _a0)
+}
+
+// ModifyNetworkInterfaceAttributeWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) ModifyNetworkInterfaceAttributeWithContext(ctx aws.Context, _a0 *ec2.ModifyNetworkInterfaceAttributeInput, opts ...request.Option) (output *ec2.ModifyNetworkInterfaceAttributeOutput, err error) {
+	output = &ec2.ModifyNetworkInterfaceAttributeOutput{}
+	if err := _m.recorder.CheckErrorWithContext(ctx, "ModifyNetworkInterfaceAttribute"); err != nil {
+		return output, err
+	}
+	defer func() { err = applyRequestOptions(ctx, "ModifyNetworkInterfaceAttribute", _a0, output, err, opts) }()
+	_m.recorder.Record("ModifyNetworkInterfaceAttribute")
+	returns, exist := _m.recorder.giveRecordedOutput("ModifyNetworkInterfaceAttribute", _a0)
+	if exist {
+		assertedErr, _ := returns[1].(error)
+		return returns[0].(*ec2.ModifyNetworkInterfaceAttributeOutput), assertedErr
+	}
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.ModifyNetworkInterfaceAttributeOutput) }()
+	if err = _m.checkPermission("ModifyNetworkInterfaceAttribute", _a0, _a0.DryRun); err != nil {
+		return output, err
+	}
+	_m.settleTransitions()
+	ntwInterface, ok := _m.networkinterfaces[aws.StringValue(_a0.NetworkInterfaceId)]
+	if !ok {
+		return output, newAwsError("InvalidNetworkInterfaceID.NotFound", "The networkInterface ID '"+aws.StringValue(_a0.NetworkInterfaceId)+"' does not exist")
+	}
+	// ec2 takes exactly one attribute per request
+	attributes := []string{}
+	if _a0.Description != nil {
+		attributes = append(attributes, ec2.NetworkInterfaceAttributeDescription)
+	}
+	if len(_a0.Groups) != 0 {
+		attributes = append(attributes, ec2.NetworkInterfaceAttributeGroupSet)
+	}
+	if _a0.SourceDestCheck != nil {
+		attributes = append(attributes, ec2.NetworkInterfaceAttributeSourceDestCheck)
+	}
+	if _a0.Attachment != nil {
+		attributes = append(attributes, ec2.NetworkInterfaceAttributeAttachment)
+	}
+	switch {
+	case len(attributes) > 1:
+		return output, newAwsError("InvalidParameterCombination", "Fields for multiple attribute types specified: "+strings.Join(attributes, ", "))
+	case len(attributes) == 0:
+		return output, newAwsError("InvalidParameterCombination", "No attributes specified.")
+	}
+	switch attributes[0] {
+	case ec2.NetworkInterfaceAttributeDescription:
+		ntwInterface.Description = aws.String(aws.StringValue(_a0.Description.Value))
+	case ec2.NetworkInterfaceAttributeGroupSet:
+		var groups []*ec2.GroupIdentifier
+		if groups, err = _m.groupIdentifiers(*ntwInterface.VpcId, _a0.Groups); err != nil {
+			return
+		}
+		for _, group := range groups {
+			if aws.StringValue(_m.assignedsecurityGroups[*group.GroupId].VpcId) != aws.StringValue(ntwInterface.VpcId) {
+				return output, newAwsError("InvalidParameter", "Security group "+*group.GroupId+" and interface "+*ntwInterface.NetworkInterfaceId+" belong to different networks.")
+			}
+		}
+		ntwInterface.Groups = groups
+	case ec2.NetworkInterfaceAttributeSourceDestCheck:
+		ntwInterface.SourceDestCheck = aws.Bool(aws.BoolValue(_a0.SourceDestCheck.Value))
+	case ec2.NetworkInterfaceAttributeAttachment:
+		if ntwInterface.Attachment == nil || aws.StringValue(ntwInterface.Attachment.AttachmentId) != aws.StringValue(_a0.Attachment.AttachmentId) {
+			return output, newAwsError("InvalidAttachmentID.NotFound", "The attachment ID '"+aws.StringValue(_a0.Attachment.AttachmentId)+"' does not exist")
+		}
+		ntwInterface.Attachment.DeleteOnTermination = aws.Bool(aws.BoolValue(_a0.Attachment.DeleteOnTermination))
+	}
+	_m.refreshInstanceNetworkInterface(ntwInterface)
+	return
+}
+
 // DescribeVpcs provides a mock function with given fields: _a0
 func (_m *EC2API) DescribeVpcs(req *ec2.DescribeVpcsInput) (*ec2.DescribeVpcsOutput, error) {
-	output := &ec2.DescribeVpcsOutput{}
-	if err := _m.recorder.CheckError("DescribeVpcs"); err != nil {
+	return _m.DescribeVpcsWithContext(aws.BackgroundContext(), req)
+}
+
+// DescribeVpcsWithContext provides a mock function with given fields: ctx, req, opts
+func (_m *EC2API) DescribeVpcsWithContext(ctx aws.Context, req *ec2.DescribeVpcsInput, opts ...request.Option) (output *ec2.DescribeVpcsOutput, err error) {
+	output = &ec2.DescribeVpcsOutput{}
+	if err := _m.recorder.CheckErrorWithContext(ctx, "DescribeVpcs"); err != nil {
 
 		return output, err
 	}
+	defer func() { err = applyRequestOptions(ctx, "DescribeVpcs", req, output, err, opts) }()
 	_m.recorder.Record("DescribeVpcs")
 	returns, exist := _m.recorder.giveRecordedOutput("DescribeVpcs", req)
 	if exist {
@@ -319,22 +755,54 @@ func (_m *EC2API) DescribeVpcs(req *ec2.DescribeVpcsInput) (*ec2.DescribeVpcsOut
 		assertedErr, _ := returns[1].(error)
 		return returns[0].(*ec2.DescribeVpcsOutput), assertedErr
 	}
-	for _, reqvpcid := range req.VpcIds {
-		for _, vpc := range _m.vpcs {
-			if reqvpcid == vpc.VpcId {
-				output.Vpcs = append(output.Vpcs, vpc)
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.DescribeVpcsOutput) }()
+	_m.settleTransitions()
+	if err := vpcFilter.validate(req.Filters); err != nil {
+		return output, err
+	}
+	for _, id := range req.VpcIds {
+		if _, ok := _m.vpcs[aws.StringValue(id)]; !ok {
+			return output, newAwsError("InvalidVpcID.NotFound", "The vpc ID '"+aws.StringValue(id)+"' does not exist")
+		}
+	}
+	for _, vpc := range _m.vpcs {
+		if len(req.VpcIds) != 0 {
+			if exist, _ := in_array(*vpc.VpcId, aws.StringValueSlice(req.VpcIds)); !exist {
+				continue
 			}
 		}
+		if vpcFilter.match(vpc, req.Filters) {
+			output.Vpcs = append(output.Vpcs, vpc)
+		}
 	}
+	sort.Slice(output.Vpcs, func(i, j int) bool {
+		return *output.Vpcs[i].VpcId < *output.Vpcs[j].VpcId
+	})
+	start, end, nextToken, err := _m.page(len(output.Vpcs), func(i int) string {
+		return *output.Vpcs[i].VpcId
+	}, req.MaxResults, req.NextToken)
+	if err != nil {
+		return output, err
+	}
+	output.Vpcs = output.Vpcs[start:end]
+	output.NextToken = nextToken
 	return output, nil
 }
 
 // CreateSubnet provides a mock function with given fields: _a0
-func (_m *EC2API) CreateSubnet(_a0 *ec2.CreateSubnetInput) (output *ec2.CreateSubnetOutput, err error) {
+func (_m *EC2API) CreateSubnet(_a0 *ec2.CreateSubnetInput) (*ec2.CreateSubnetOutput, error) {
+	return _m.CreateSubnetWithContext(aws.BackgroundContext(), _a0)
+}
+
+// CreateSubnetWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) CreateSubnetWithContext(ctx aws.Context, _a0 *ec2.CreateSubnetInput, opts ...request.Option) (output *ec2.CreateSubnetOutput, err error) {
 	output = &ec2.CreateSubnetOutput{}
-	if err := _m.recorder.CheckError("CreateSubnet"); err != nil {
+	if err := _m.recorder.CheckErrorWithContext(ctx, "CreateSubnet"); err != nil {
 		return output, err
 	}
+	defer func() { err = applyRequestOptions(ctx, "CreateSubnet", _a0, output, err, opts) }()
 	_m.recorder.Record("CreateSubnet")
 	returns, exist := _m.recorder.giveRecordedOutput("CreateSubnet", _a0)
 	if exist {
@@ -342,32 +810,57 @@ func (_m *EC2API) CreateSubnet(_a0 *ec2.CreateSubnetInput) (output *ec2.CreateSu
 		assertedErr, _ := returns[1].(error)
 		return returns[0].(*ec2.CreateSubnetOutput), assertedErr
 	}
-	_, _, err = net.ParseCIDR(*_a0.CidrBlock)
-	if err != nil {
-		return nil, err
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.CreateSubnetOutput) }()
+	if err = _m.checkPermission("CreateSubnet", _a0, _a0.DryRun); err != nil {
+		return output, err
 	}
-	_, ok := _m.vpcs[*_a0.VpcId]
+	_m.settleTransitions()
+	ip, subnetCidr, parseErr := net.ParseCIDR(aws.StringValue(_a0.CidrBlock))
+	if parseErr != nil || ip.To4() == nil {
+		return output, newAwsError("InvalidParameterValue", "Value ("+aws.StringValue(_a0.CidrBlock)+") for parameter cidrBlock is invalid. This is not a valid CIDR block.")
+	}
+	vpc, ok := _m.vpcs[aws.StringValue(_a0.VpcId)]
 	if !ok {
-		return nil, errors.New("vpc not exist")
+		return output, newAwsError("InvalidVpcID.NotFound", "The vpc ID '"+aws.StringValue(_a0.VpcId)+"' does not exist")
+	}
+	// the subnet may lie in any of the vpc's associated blocks
+	ones, _ := subnetCidr.Mask.Size()
+	if ones < minVpcPrefixLength || ones > maxVpcPrefixLength || !anyCidrContains(vpcCidrBlocks(vpc), subnetCidr) {
+		return output, newAwsError("InvalidSubnet.Range", "The CIDR '"+aws.StringValue(_a0.CidrBlock)+"' is invalid.")
 	}
-	subnetId := "subnet-" + rangeIn(1000, 9999)
+	if _m.cidrBlockInUse(*vpc.VpcId, subnetCidr.String(), func(subnet *ec2.Subnet) []*string {
+		return []*string{subnet.CidrBlock}
+	}) {
+		return output, newAwsError("InvalidSubnet.Conflict", "The CIDR '"+aws.StringValue(_a0.CidrBlock)+"' conflicts with another subnet")
+	}
+	subnetId := GiveRandomId("subnet-")
 	ipv6block := []*ec2.SubnetIpv6CidrBlockAssociation{}
 	if _a0.Ipv6CidrBlock != nil {
-		ipv6associationid := "subnet-cidr-assoc-" + rangeIn(1000, 9999)
-		defaultState := "ASSOCIATED"
+		ipv6Cidr, err := _m.subnetIpv6CidrBlock(vpc, *_a0.Ipv6CidrBlock)
+		if err != nil {
+			return output, err
+		}
 		ipv6block = append(ipv6block, &ec2.SubnetIpv6CidrBlockAssociation{
-			Ipv6CidrBlock: _a0.Ipv6CidrBlock,
-			AssociationId: &ipv6associationid,
+			Ipv6CidrBlock: aws.String(ipv6Cidr.String()),
+			AssociationId: aws.String(GiveRandomId("subnet-cidr-assoc-")),
 			Ipv6CidrBlockState: &ec2.SubnetCidrBlockState{
-				State: &defaultState,
+				State: aws.String(ec2.SubnetCidrBlockStateCodeAssociated),
 			},
 		})
 	}
 	subnet := &ec2.Subnet{
-		CidrBlock:                   _a0.CidrBlock,
+		CidrBlock:                   aws.String(subnetCidr.String()),
 		AvailabilityZone:            _a0.AvailabilityZone,
 		VpcId:                       _a0.VpcId,
 		SubnetId:                    &subnetId,
+		SubnetArn:                   aws.String("arn:aws:ec2:" + _m.region + ":" + defaultOwnerId + ":subnet/" + subnetId),
+		OwnerId:                     aws.String(defaultOwnerId),
+		State:                       aws.String(ec2.SubnetStateAvailable),
+		DefaultForAz:                aws.Bool(false),
+		MapPublicIpOnLaunch:         aws.Bool(false),
+		AssignIpv6AddressOnCreation: aws.Bool(false),
 		Ipv6CidrBlockAssociationSet: ipv6block,
 		Tags: []*ec2.Tag{
 			&ec2.Tag{
@@ -379,6 +872,14 @@ func (_m *EC2API) CreateSubnet(_a0 *ec2.CreateSubnetInput) (output *ec2.CreateSu
 
 	_m.vpcassocaiatedsubnet[*_a0.VpcId] = append(_m.vpcassocaiatedsubnet[*_a0.VpcId], subnet)
 	_m.subnets[subnetId] = subnet
+	_m.refreshAvailableIpAddressCount(subnet)
+	if networkAcl := _m.defaultNetworkAcl(*_a0.VpcId); networkAcl != nil {
+		networkAcl.Associations = append(networkAcl.Associations, &ec2.NetworkAclAssociation{
+			NetworkAclAssociationId: aws.String(GiveRandomId("aclassoc-")),
+			NetworkAclId:            networkAcl.NetworkAclId,
+			SubnetId:                &subnetId,
+		})
+	}
 	output = &ec2.CreateSubnetOutput{
 		Subnet: subnet,
 	}
@@ -386,11 +887,17 @@ func (_m *EC2API) CreateSubnet(_a0 *ec2.CreateSubnetInput) (output *ec2.CreateSu
 }
 
 // DescribeSubnets provides a mock function with given fields: _a0
-func (_m *EC2API) DescribeSubnets(_a0 *ec2.DescribeSubnetsInput) (output *ec2.DescribeSubnetsOutput, err error) {
+func (_m *EC2API) DescribeSubnets(_a0 *ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error) {
+	return _m.DescribeSubnetsWithContext(aws.BackgroundContext(), _a0)
+}
+
+// DescribeSubnetsWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) DescribeSubnetsWithContext(ctx aws.Context, _a0 *ec2.DescribeSubnetsInput, opts ...request.Option) (output *ec2.DescribeSubnetsOutput, err error) {
 	output = &ec2.DescribeSubnetsOutput{}
-	if err := _m.recorder.CheckError("DescribeSubnets"); err != nil {
+	if err := _m.recorder.CheckErrorWithContext(ctx, "DescribeSubnets"); err != nil {
 		return output, err
 	}
+	defer func() { err = applyRequestOptions(ctx, "DescribeSubnets", _a0, output, err, opts) }()
 	_m.recorder.Record("DescribeSubnets")
 	returns, exist := _m.recorder.giveRecordedOutput("DescribeSubnets", _a0)
 	if exist {
@@ -398,87 +905,56 @@ func (_m *EC2API) DescribeSubnets(_a0 *ec2.DescribeSubnetsInput) (output *ec2.De
 		assertedErr, _ := returns[1].(error)
 		return returns[0].(*ec2.DescribeSubnetsOutput), assertedErr
 	}
-	filteredSubnets := []*ec2.Subnet{}
-	for _, val := range _a0.SubnetIds {
-		for _, associatedsubnet := range _m.vpcassocaiatedsubnet {
-			for _, subnet := range associatedsubnet {
-				if *val == *subnet.SubnetId {
-					filteredSubnets = append(filteredSubnets, subnet)
-				}
-			}
-		}
-	}
-
-	if len(filteredSubnets) == 0 {
-		for _, associatedsubnet := range _m.vpcassocaiatedsubnet {
-			for _, subnet := range associatedsubnet {
-				filteredSubnets = append(filteredSubnets, subnet)
-			}
-		}
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.DescribeSubnetsOutput) }()
+	_m.settleTransitions()
+	if err = subnetFilter.validate(_a0.Filters); err != nil {
+		return
 	}
-	furtherFilteredSubnet := []*ec2.Subnet{}
-	for _, val := range filteredSubnets {
-		for _, filter := range _a0.Filters {
-			if *filter.Name == "vpc-id" {
-				for _, vpcid := range filter.Values {
-					if *val.VpcId == *vpcid {
-						furtherFilteredSubnet = append(furtherFilteredSubnet, val)
-					}
-				}
-			}
+	for _, id := range _a0.SubnetIds {
+		if _, ok := _m.subnets[aws.StringValue(id)]; !ok {
+			err = newAwsError("InvalidSubnetID.NotFound", "The subnet ID '"+aws.StringValue(id)+"' does not exist")
+			return
 		}
 	}
-
-	if len(furtherFilteredSubnet) != 0 {
-		filteredSubnets = furtherFilteredSubnet
-		furtherFilteredSubnet = []*ec2.Subnet{}
-	} else {
-		furtherFilteredSubnet = filteredSubnets
-	}
-	for _, val := range filteredSubnets {
-		for _, filter := range _a0.Filters {
-			if *filter.Name == "availabilityZone" {
-				for _, availabilityZone := range filter.Values {
-					if *val.AvailabilityZone == *availabilityZone {
-						furtherFilteredSubnet = append(furtherFilteredSubnet, val)
-					}
-				}
+	for _, subnet := range _m.subnets {
+		if len(_a0.SubnetIds) != 0 {
+			if exist, _ := in_array(*subnet.SubnetId, aws.StringValueSlice(_a0.SubnetIds)); !exist {
+				continue
 			}
 		}
-	}
-
-	if len(furtherFilteredSubnet) != 0 {
-		filteredSubnets = furtherFilteredSubnet
-		furtherFilteredSubnet = []*ec2.Subnet{}
-	} else {
-		furtherFilteredSubnet = filteredSubnets
-	}
-
-	for _, val := range filteredSubnets {
-		for _, filter := range _a0.Filters {
-			if *filter.Name == "cidrBlock" {
-				for _, cidrBlock := range filter.Values {
-					if *val.CidrBlock == *cidrBlock {
-						furtherFilteredSubnet = append(furtherFilteredSubnet, val)
-					}
-				}
-			}
+		_m.refreshAvailableIpAddressCount(subnet)
+		if subnetFilter.match(subnet, _a0.Filters) {
+			output.Subnets = append(output.Subnets, subnet)
 		}
 	}
-	if len(furtherFilteredSubnet) == 0 {
-		furtherFilteredSubnet = filteredSubnets
+	sort.Slice(output.Subnets, func(i, j int) bool {
+		return *output.Subnets[i].SubnetId < *output.Subnets[j].SubnetId
+	})
+	start, end, nextToken, err := _m.page(len(output.Subnets), func(i int) string {
+		return *output.Subnets[i].SubnetId
+	}, _a0.MaxResults, _a0.NextToken)
+	if err != nil {
+		return
 	}
-
-	output.Subnets = furtherFilteredSubnet
+	output.Subnets = output.Subnets[start:end]
+	output.NextToken = nextToken
 	return
 }
 
 // CreateNetworkInterface provides a mock function with given fields: _a0
-func (_m *EC2API) CreateNetworkInterface(_a0 *ec2.CreateNetworkInterfaceInput) (output *ec2.CreateNetworkInterfaceOutput, err error) {
+func (_m *EC2API) CreateNetworkInterface(_a0 *ec2.CreateNetworkInterfaceInput) (*ec2.CreateNetworkInterfaceOutput, error) {
+	return _m.CreateNetworkInterfaceWithContext(aws.BackgroundContext(), _a0)
+}
+
+// CreateNetworkInterfaceWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) CreateNetworkInterfaceWithContext(ctx aws.Context, _a0 *ec2.CreateNetworkInterfaceInput, opts ...request.Option) (output *ec2.CreateNetworkInterfaceOutput, err error) {
 	output = &ec2.CreateNetworkInterfaceOutput{}
-	if err := _m.recorder.CheckError("CreateNetworkInterface"); err != nil {
+	if err := _m.recorder.CheckErrorWithContext(ctx, "CreateNetworkInterface"); err != nil {
 		return output, err
 	}
+	defer func() { err = applyRequestOptions(ctx, "CreateNetworkInterface", _a0, output, err, opts) }()
 	_m.recorder.Record("CreateNetworkInterface")
 	returns, exist := _m.recorder.giveRecordedOutput("CreateNetworkInterface", _a0)
 	if exist {
@@ -486,68 +962,119 @@ func (_m *EC2API) CreateNetworkInterface(_a0 *ec2.CreateNetworkInterfaceInput) (
 		assertedErr, _ := returns[1].(error)
 		return returns[0].(*ec2.CreateNetworkInterfaceOutput), assertedErr
 	}
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.CreateNetworkInterfaceOutput) }()
+	if err = _m.checkPermission("CreateNetworkInterface", _a0, _a0.DryRun); err != nil {
+		return output, err
+	}
+	_m.settleTransitions()
+	ntwInterface, err := _m.createNetworkInterface(_a0)
+	if err != nil {
+		return
+	}
+	output.NetworkInterface = ntwInterface
+	return
+}
+
+// createNetworkInterface allocates the addresses, mac and id of a new network
+// interface and stores it. It is shared by CreateNetworkInterface,
+// RunInstances and CreateNatGateway.
+func (_m *EC2API) createNetworkInterface(_a0 *ec2.CreateNetworkInterfaceInput) (ntwInterface *ec2.NetworkInterface, err error) {
+	subnet, ok := _m.subnets[aws.StringValue(_a0.SubnetId)]
+	if !ok {
+		return nil, newAwsError("InvalidSubnetID.NotFound", "The subnet ID '"+aws.StringValue(_a0.SubnetId)+"' does not exist")
+	}
+	groups, err := _m.groupIdentifiers(*subnet.VpcId, _a0.Groups)
+	if err != nil {
+		return
+	}
 	var zero_val int64
 	zero_val = 0
 	if _a0.SecondaryPrivateIpAddressCount == nil {
 		_a0.SecondaryPrivateIpAddressCount = &zero_val
 	}
-	subnet := _m.subnets[*_a0.SubnetId]
-	cidr := subnet.CidrBlock
-	var hostForCidr string
-	var secPrivateDnsNames []string
-	var secPrivateDnsName string
-	var secIps []string
-	var secIp string
-	// TODO: @sch00lb0y retry block is repeated many time, can be moved to a function
-	// retry until you find the unassigned ip
-	for {
-
-		hostForCidr, err = PickRandomHostFromCIDR(*cidr)
+	if _a0.Ipv6AddressCount != nil && len(_a0.Ipv6Addresses) != 0 {
+		return nil, newAwsError("InvalidParameterCombination", "The parameter ipv6AddressCount cannot be used with the parameter ipv6Addresses")
+	}
+	// on failure hand back every address booked so far
+	bookedIps := []string{}
+	bookedIpv6s := []string{}
+	defer func() {
 		if err != nil {
+			for _, ip := range bookedIps {
+				_m.releaseIpOnSubnet(*subnet.SubnetId, ip)
+			}
+			for _, ip := range bookedIpv6s {
+				_m.releaseIpv6OnSubnet(*subnet.SubnetId, ip)
+			}
+		}
+	}()
+	var hostForCidr string
+	requestedIps := []string{}
+	if _a0.PrivateIpAddress != nil {
+		hostForCidr = *_a0.PrivateIpAddress
+	}
+	for _, spec := range _a0.PrivateIpAddresses {
+		if aws.BoolValue(spec.Primary) && hostForCidr == "" {
+			hostForCidr = aws.StringValue(spec.PrivateIpAddress)
+			continue
+		}
+		if aws.StringValue(spec.PrivateIpAddress) != hostForCidr {
+			requestedIps = append(requestedIps, aws.StringValue(spec.PrivateIpAddress))
+		}
+	}
+	if hostForCidr != "" {
+		if err = _m.assignIpOnSubnet(subnet, hostForCidr); err != nil {
 			return
 		}
-		assignedIps, ok := _m.assignedIpOnSubnet[*_a0.SubnetId]
-		if !ok {
-			_m.assignedIpOnSubnet[*_a0.SubnetId] = append(_m.assignedIpOnSubnet[*_a0.SubnetId], hostForCidr)
-			break
+	} else {
+		hostForCidr, err = _m.pickUnassignedIp(subnet)
+		if err != nil {
+			return
 		}
-		// check the random ip is alredy assiggned or not, if assingned, redo the step
-		exist, _ := in_array(hostForCidr, assignedIps)
-		if !exist {
-			break
+	}
+	bookedIps = append(bookedIps, hostForCidr)
+	secIps := []string{}
+	for _, secIp := range requestedIps {
+		if err = _m.assignIpOnSubnet(subnet, secIp); err != nil {
+			return
 		}
+		bookedIps = append(bookedIps, secIp)
+		secIps = append(secIps, secIp)
 	}
 	for i := int64(0); i < *(_a0.SecondaryPrivateIpAddressCount); i++ {
-		for {
-
-			secIp, err = PickRandomHostFromCIDR(*cidr)
-			if err != nil {
-				return
-			}
-			assignedIps, ok := _m.assignedIpOnSubnet[*_a0.SubnetId]
-			if !ok {
-				_m.assignedIpOnSubnet[*_a0.SubnetId] = append(_m.assignedIpOnSubnet[*_a0.SubnetId], secIp)
-				break
-			}
-			// check the random ip is alredy assiggned or not, if assingned, redo the step
-			exist, _ := in_array(secIp, assignedIps)
-			if !exist {
-				secIps = append(secIps, secIp)
-				secPrivateDnsName = "ip-" + strings.Replace(secIp, ".", "-", -1) + ".ap-southeast-1.compute.internal"
-				secPrivateDnsNames = append(secPrivateDnsNames, secPrivateDnsName)
-				break
-			}
+		var secIp string
+		secIp, err = _m.pickUnassignedIp(subnet)
+		if err != nil {
+			return
 		}
+		bookedIps = append(bookedIps, secIp)
+		secIps = append(secIps, secIp)
 	}
-	var ntwInterfaceId string
-	// retry until you find the unassinged interface id
-	for {
-		ntwInterfaceId = "eni-" + rangeIn(1000, 9999)
-		_, exist := _m.networkinterfaces[ntwInterfaceId]
-		if !exist {
-			break
+	ipv6Count := aws.Int64Value(_a0.Ipv6AddressCount)
+	if _a0.Ipv6AddressCount == nil && len(_a0.Ipv6Addresses) == 0 && aws.BoolValue(subnet.AssignIpv6AddressOnCreation) {
+		ipv6Count = 1
+	}
+	for _, spec := range _a0.Ipv6Addresses {
+		var ipv6Ip string
+		if ipv6Ip, err = _m.assignIpv6OnSubnet(subnet, aws.StringValue(spec.Ipv6Address)); err != nil {
+			return
 		}
+		bookedIpv6s = append(bookedIpv6s, ipv6Ip)
 	}
+	for i := int64(0); i < ipv6Count; i++ {
+		var ipv6Ip string
+		if ipv6Ip, err = _m.pickUnassignedIpv6(subnet); err != nil {
+			return
+		}
+		bookedIpv6s = append(bookedIpv6s, ipv6Ip)
+	}
+	ipv6Adds := []*ec2.NetworkInterfaceIpv6Address{}
+	for _, ipv6Ip := range bookedIpv6s {
+		ipv6Adds = append(ipv6Adds, &ec2.NetworkInterfaceIpv6Address{Ipv6Address: aws.String(ipv6Ip)})
+	}
+	ntwInterfaceId := GiveRandomId("eni-")
 
 	// retry until you find the unassinged mac address
 	var randomMac string
@@ -562,45 +1089,147 @@ func (_m *EC2API) CreateNetworkInterface(_a0 *ec2.CreateNetworkInterfaceInput) (
 			break
 		}
 	}
-	primary := true
-	secondary := false
-	privateDnsName := "ip-" + strings.Replace(hostForCidr, ".", "-", -1) + ".ap-southeast-1.compute.internal"
 	privateIpAdds := []*ec2.NetworkInterfacePrivateIpAddress{}
 	privateIpAdds = append(privateIpAdds, &ec2.NetworkInterfacePrivateIpAddress{
-		PrivateIpAddress: &hostForCidr,
-		PrivateDnsName:   &privateDnsName,
-		Primary:          &primary,
+		PrivateIpAddress: aws.String(hostForCidr),
+		PrivateDnsName:   aws.String(_m.privateDnsNameForIp(hostForCidr)),
+		Primary:          aws.Bool(true),
 	},
 	)
-	for i := int64(0); i < *(_a0.SecondaryPrivateIpAddressCount); i++ {
+	for _, secIp := range secIps {
 		privateIpAdds = append(privateIpAdds, &ec2.NetworkInterfacePrivateIpAddress{
-			PrivateIpAddress: &secIps[i],
-			PrivateDnsName:   &secPrivateDnsNames[i],
-			Primary:          &secondary,
+			PrivateIpAddress: aws.String(secIp),
+			PrivateDnsName:   aws.String(_m.privateDnsNameForIp(secIp)),
+			Primary:          aws.Bool(false),
 		},
 		)
 	}
-	ntwInterface := &ec2.NetworkInterface{
+	interfaceType := _a0.InterfaceType
+	if interfaceType == nil {
+		interfaceType = aws.String("interface")
+	}
+	ntwInterface = &ec2.NetworkInterface{
 		Description:        _a0.Description,
-		SubnetId:           _a0.SubnetId,
+		SubnetId:           subnet.SubnetId,
 		VpcId:              subnet.VpcId,
+		AvailabilityZone:   subnet.AvailabilityZone,
 		NetworkInterfaceId: &ntwInterfaceId,
 		MacAddress:         &randomMac,
-		PrivateIpAddress:   &hostForCidr,
-		PrivateDnsName:     &privateDnsName,
+		PrivateIpAddress:   aws.String(hostForCidr),
+		PrivateDnsName:     aws.String(_m.privateDnsNameForIp(hostForCidr)),
 		PrivateIpAddresses: privateIpAdds,
+		Ipv6Addresses:      ipv6Adds,
+		Groups:             groups,
+		InterfaceType:      interfaceType,
+		OwnerId:            &defaultOwnerId,
+		SourceDestCheck:    aws.Bool(true),
+		Status:             aws.String("available"),
 	}
 	_m.networkinterfaces[ntwInterfaceId] = ntwInterface
-	output.NetworkInterface = ntwInterface
 	return
 }
 
+// releaseNetworkInterface removes the network interface and hands its
+// addresses and mac back to the pools.
+func (_m *EC2API) releaseNetworkInterface(ntwInterface *ec2.NetworkInterface) {
+	_m.cancelTransition(*ntwInterface.NetworkInterfaceId)
+	for _, privateIp := range ntwInterface.PrivateIpAddresses {
+		if address := _m.addressOnPrivateIp(*ntwInterface.NetworkInterfaceId, *privateIp.PrivateIpAddress); address != nil {
+			_m.disassociateAddress(address)
+		}
+		_m.releaseIpOnSubnet(*ntwInterface.SubnetId, *privateIp.PrivateIpAddress)
+	}
+	_m.releasePublicIp(ntwInterface)
+	_m.blackholeRoutes(func(route *ec2.Route) bool {
+		return aws.StringValue(route.NetworkInterfaceId) == *ntwInterface.NetworkInterfaceId
+	})
+	for _, ipv6Ip := range ntwInterface.Ipv6Addresses {
+		_m.releaseIpv6OnSubnet(*ntwInterface.SubnetId, *ipv6Ip.Ipv6Address)
+	}
+	exist, index := in_array(aws.StringValue(ntwInterface.MacAddress), _m.assignedMacAddress)
+	if exist {
+		_m.assignedMacAddress = append(_m.assignedMacAddress[:index], _m.assignedMacAddress[index+1:]...)
+	}
+	delete(_m.networkinterfaces, *ntwInterface.NetworkInterfaceId)
+}
+
+// groupIdentifiers resolves the security group ids, falling back to the
+// default security group of the vpc.
+func (_m *EC2API) groupIdentifiers(vpcId string, groupIds []*string) ([]*ec2.GroupIdentifier, error) {
+	groups := []*ec2.GroupIdentifier{}
+	for _, groupId := range groupIds {
+		securityGroup, ok := _m.assignedsecurityGroups[aws.StringValue(groupId)]
+		if !ok {
+			return nil, newAwsError("InvalidGroup.NotFound", "The security group '"+aws.StringValue(groupId)+"' does not exist")
+		}
+		groups = append(groups, &ec2.GroupIdentifier{
+			GroupId:   securityGroup.GroupId,
+			GroupName: securityGroup.GroupName,
+		})
+	}
+	if len(groups) == 0 {
+		defaultSecurityGroup := _m.defaultSecurityGroup(vpcId)
+		if defaultSecurityGroup != nil {
+			groups = append(groups, &ec2.GroupIdentifier{
+				GroupId:   defaultSecurityGroup.GroupId,
+				GroupName: defaultSecurityGroup.GroupName,
+			})
+		}
+	}
+	return groups, nil
+}
+
+// defaultSecurityGroup gives the security group aws attaches when none is
+// requested, nil if the vpc has none.
+func (_m *EC2API) defaultSecurityGroup(vpcId string) *ec2.SecurityGroup {
+	securityGroup, ok := _m.assignedsecurityGroups[_m.defaultSecurityGroupID]
+	if ok && aws.StringValue(securityGroup.VpcId) == vpcId {
+		return securityGroup
+	}
+	for _, securityGroup := range _m.assignedsecurityGroups {
+		if aws.StringValue(securityGroup.VpcId) == vpcId && aws.StringValue(securityGroup.GroupName) == "default" {
+			return securityGroup
+		}
+	}
+	return nil
+}
+
+// privateDnsNameForIp gives the name the amazon provided dns resolves to a
+// private ip, us-east-1 has a domain of its own.
+func (_m *EC2API) privateDnsNameForIp(ip string) string {
+	domain := _m.region + ".compute.internal"
+	if _m.region == "us-east-1" {
+		domain = "ec2.internal"
+	}
+	return "ip-" + strings.Replace(ip, ".", "-", -1) + "." + domain
+}
+
+// publicDnsNameForIp gives the name the amazon provided dns resolves to a
+// public ip, nil when the vpc doesn't hand out dns hostnames.
+func (_m *EC2API) publicDnsNameForIp(vpcId string, ip string) *string {
+	vpc, ok := _m.vpcs[vpcId]
+	if !ok || !_m.vpcAttributesOf(vpc).enableDnsHostnames {
+		return nil
+	}
+	domain := _m.region + ".compute.amazonaws.com"
+	if _m.region == "us-east-1" {
+		domain = "compute-1.amazonaws.com"
+	}
+	return aws.String("ec2-" + strings.Replace(ip, ".", "-", -1) + "." + domain)
+}
+
 // DeleteNetworkInterface provides a mock function with given fields: _a0
-func (_m *EC2API) DeleteNetworkInterface(_a0 *ec2.DeleteNetworkInterfaceInput) (output *ec2.DeleteNetworkInterfaceOutput, err error) {
+func (_m *EC2API) DeleteNetworkInterface(_a0 *ec2.DeleteNetworkInterfaceInput) (*ec2.DeleteNetworkInterfaceOutput, error) {
+	return _m.DeleteNetworkInterfaceWithContext(aws.BackgroundContext(), _a0)
+}
+
+// DeleteNetworkInterfaceWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) DeleteNetworkInterfaceWithContext(ctx aws.Context, _a0 *ec2.DeleteNetworkInterfaceInput, opts ...request.Option) (output *ec2.DeleteNetworkInterfaceOutput, err error) {
 	output = &ec2.DeleteNetworkInterfaceOutput{}
-	if err := _m.recorder.CheckError("DeleteNetworkInterface"); err != nil {
+	if err := _m.recorder.CheckErrorWithContext(ctx, "DeleteNetworkInterface"); err != nil {
 		return output, err
 	}
+	defer func() { err = applyRequestOptions(ctx, "DeleteNetworkInterface", _a0, output, err, opts) }()
 	_m.recorder.Record("DeleteNetworkInterface")
 	returns, exist := _m.recorder.giveRecordedOutput("DeleteNetworkInterface", _a0)
 	if exist {
@@ -608,814 +1237,4369 @@ func (_m *EC2API) DeleteNetworkInterface(_a0 *ec2.DeleteNetworkInterfaceInput) (
 		assertedErr, _ := returns[1].(error)
 		return returns[0].(*ec2.DeleteNetworkInterfaceOutput), assertedErr
 	}
-	_, ok := _m.networkinterfaces[*_a0.NetworkInterfaceId]
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.DeleteNetworkInterfaceOutput) }()
+	if err = _m.checkPermission("DeleteNetworkInterface", _a0, _a0.DryRun); err != nil {
+		return output, err
+	}
+	_m.settleTransitions()
+	ntwInterface, ok := _m.networkinterfaces[*_a0.NetworkInterfaceId]
 	if !ok {
-		return nil, errors.New("networks interface not found")
+		err = newAwsError("InvalidNetworkInterfaceID.NotFound", "The networkInterface ID '"+aws.StringValue(_a0.NetworkInterfaceId)+"' does not exist")
+		return
+	}
+	if ntwInterface.Attachment != nil || aws.StringValue(ntwInterface.Status) == "in-use" {
+		err = newAwsError("InvalidNetworkInterface.InUse", "The interface "+aws.StringValue(_a0.NetworkInterfaceId)+" is currently in use.")
+		return
 	}
-	delete(_m.networkinterfaces, *_a0.NetworkInterfaceId)
+	_m.releaseNetworkInterface(ntwInterface)
 	return
 }
 
 // AllocateAddress provides a mock function with given fields: _a0
-func (_m *EC2API) AllocateAddress(_a0 *ec2.AllocateAddressInput) (output *ec2.AllocateAddressOutput, err error) {
+func (_m *EC2API) AllocateAddress(_a0 *ec2.AllocateAddressInput) (*ec2.AllocateAddressOutput, error) {
+	return _m.AllocateAddressWithContext(aws.BackgroundContext(), _a0)
+}
+
+// AllocateAddressWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) AllocateAddressWithContext(ctx aws.Context, _a0 *ec2.AllocateAddressInput, opts ...request.Option) (output *ec2.AllocateAddressOutput, err error) {
 	output = &ec2.AllocateAddressOutput{}
-	if err := _m.recorder.CheckError("AllocateAddress"); err != nil {
+	if err := _m.recorder.CheckErrorWithContext(ctx, "AllocateAddress"); err != nil {
 		return output, err
 	}
+	defer func() { err = applyRequestOptions(ctx, "AllocateAddress", _a0, output, err, opts) }()
 	_m.recorder.Record("AllocateAddress")
 	returns, exist := _m.recorder.giveRecordedOutput("AllocateAddress", _a0)
 	if exist {
 		assertedErr, _ := returns[1].(error)
 		return returns[0].(*ec2.AllocateAddressOutput), assertedErr
 	}
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.AllocateAddressOutput) }()
+	if err = _m.checkPermission("AllocateAddress", _a0, _a0.DryRun); err != nil {
+		return output, err
+	}
+	_m.settleTransitions()
 	allocationId := uuid.New()
 	allocationIdStr := "eipalloc-" + allocationId.String()
 
 	// no need to retry since uuid is unique
-	var allocatedElasticIps []string
-	for _, allocatedIp := range _m.assignedelasticIps {
-		allocatedElasticIps = append(allocatedElasticIps, allocatedIp)
-	}
-	var randomElasticIp string
-	// retry to find a unassinged ip
-	for {
-		randomElasticIp = randomdata.IpV4Address()
-		exist, _ := in_array(randomElasticIp, allocatedElasticIps)
-		if !exist {
-			break
-		}
-	}
-	_m.assignedelasticIps[allocationIdStr] = randomElasticIp
-	output.PublicIp = &randomElasticIp
+	randomElasticIp := _m.pickPublicIp()
 	// default vpc is used, cuz in avi only vpc is used
 	// TODO: add support for standard, if needed in future
-	output.Domain = &AVI_STANDARD_ELASTIC_ALLOCATION_DOMAIN
-	output.AllocationId = &allocationIdStr
+	address := &ec2.Address{
+		AllocationId:   aws.String(allocationIdStr),
+		Domain:         aws.String(AVI_STANDARD_ELASTIC_ALLOCATION_DOMAIN),
+		PublicIp:       aws.String(randomElasticIp),
+		PublicIpv4Pool: aws.String("amazon"),
+		Tags:           []*ec2.Tag{},
+	}
+	_m.assignedelasticIps[allocationIdStr] = address
+	output.PublicIp = address.PublicIp
+	output.Domain = address.Domain
+	output.PublicIpv4Pool = address.PublicIpv4Pool
+	output.AllocationId = address.AllocationId
 	return
 }
 
 // ReleaseAddress provides a mock function with given fields: _a0
-func (_m *EC2API) ReleaseAddress(_a0 *ec2.ReleaseAddressInput) (output *ec2.ReleaseAddressOutput, err error) {
+func (_m *EC2API) ReleaseAddress(_a0 *ec2.ReleaseAddressInput) (*ec2.ReleaseAddressOutput, error) {
+	return _m.ReleaseAddressWithContext(aws.BackgroundContext(), _a0)
+}
+
+// ReleaseAddressWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) ReleaseAddressWithContext(ctx aws.Context, _a0 *ec2.ReleaseAddressInput, opts ...request.Option) (output *ec2.ReleaseAddressOutput, err error) {
 	output = &ec2.ReleaseAddressOutput{}
-	if err := _m.recorder.CheckError("ReleaseAddress"); err != nil {
+	if err := _m.recorder.CheckErrorWithContext(ctx, "ReleaseAddress"); err != nil {
 		return output, err
 	}
+	defer func() { err = applyRequestOptions(ctx, "ReleaseAddress", _a0, output, err, opts) }()
 	_m.recorder.Record("ReleaseAddress")
 	returns, exist := _m.recorder.giveRecordedOutput("ReleaseAddress", _a0)
 	if exist {
 		assertedErr, _ := returns[1].(error)
 		return returns[0].(*ec2.ReleaseAddressOutput), assertedErr
 	}
-	_, ok := _m.assignedelasticIps[*_a0.AllocationId]
-	if !ok {
-		err = errors.New("allocation id not exist")
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.ReleaseAddressOutput) }()
+	if err = _m.checkPermission("ReleaseAddress", _a0, _a0.DryRun); err != nil {
+		return output, err
+	}
+	_m.settleTransitions()
+	address, err := _m.addressOf(_a0.AllocationId, _a0.PublicIp)
+	if err != nil {
 		return
 	}
-	delete(_m.assignedelasticIps, *_a0.AllocationId)
-	return
+	if err = _m.checkAddressNotManaged(address); err != nil {
+		return
+	}
+	if address.AssociationId != nil {
+		err = newAwsError("InvalidIPAddress.InUse", "Address "+*address.PublicIp+" is in use.")
+		return
+	}
+	delete(_m.assignedelasticIps, *address.AllocationId)
+	return
 }
 
 // CreateSecurityGroup provides a mock function with given fields: _a0
-func (_m *EC2API) CreateSecurityGroup(_a0 *ec2.CreateSecurityGroupInput) (output *ec2.CreateSecurityGroupOutput, err error) {
+func (_m *EC2API) CreateSecurityGroup(_a0 *ec2.CreateSecurityGroupInput) (*ec2.CreateSecurityGroupOutput, error) {
+	return _m.CreateSecurityGroupWithContext(aws.BackgroundContext(), _a0)
+}
+
+// CreateSecurityGroupWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) CreateSecurityGroupWithContext(ctx aws.Context, _a0 *ec2.CreateSecurityGroupInput, opts ...request.Option) (output *ec2.CreateSecurityGroupOutput, err error) {
 	output = &ec2.CreateSecurityGroupOutput{}
-	if err := _m.recorder.CheckError("CreateSecurityGroup"); err != nil {
+	if err := _m.recorder.CheckErrorWithContext(ctx, "CreateSecurityGroup"); err != nil {
 		return output, err
 	}
+	defer func() { err = applyRequestOptions(ctx, "CreateSecurityGroup", _a0, output, err, opts) }()
 	_m.recorder.Record("CreateSecurityGroup")
 	returns, exist := _m.recorder.giveRecordedOutput("CreateSecurityGroup", _a0)
 	if exist {
 		assertedErr, _ := returns[1].(error)
 		return returns[0].(*ec2.CreateSecurityGroupOutput), assertedErr
 	}
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.CreateSecurityGroupOutput) }()
+	if err = _m.checkPermission("CreateSecurityGroup", _a0, _a0.DryRun); err != nil {
+		return output, err
+	}
+	_m.settleTransitions()
 	securityGroupId := uuid.New()
 	securityGroupIdStr := "sg-" + securityGroupId.String()
 
 	_m.assignedsecurityGroups[securityGroupIdStr] = &ec2.SecurityGroup{
-		GroupId:     &securityGroupIdStr,
-		VpcId:       _a0.VpcId,
-		GroupName:   _a0.GroupName,
-		Description: _a0.Description,
+		GroupId:             &securityGroupIdStr,
+		VpcId:               _a0.VpcId,
+		GroupName:           _a0.GroupName,
+		Description:         _a0.Description,
+		OwnerId:             aws.String(defaultOwnerId),
+		IpPermissions:       []*ec2.IpPermission{},
+		IpPermissionsEgress: allowAllEgress(),
 	}
 	output.GroupId = &securityGroupIdStr
 	return
 }
 
 // DeleteSecurityGroup provides a mock function with given fields: _a0
-func (_m *EC2API) DeleteSecurityGroup(_a0 *ec2.DeleteSecurityGroupInput) (output *ec2.DeleteSecurityGroupOutput, err error) {
+func (_m *EC2API) DeleteSecurityGroup(_a0 *ec2.DeleteSecurityGroupInput) (*ec2.DeleteSecurityGroupOutput, error) {
+	return _m.DeleteSecurityGroupWithContext(aws.BackgroundContext(), _a0)
+}
+
+// DeleteSecurityGroupWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) DeleteSecurityGroupWithContext(ctx aws.Context, _a0 *ec2.DeleteSecurityGroupInput, opts ...request.Option) (output *ec2.DeleteSecurityGroupOutput, err error) {
 	output = &ec2.DeleteSecurityGroupOutput{}
-	if err := _m.recorder.CheckError("DeleteSecurityGroup"); err != nil {
+	if err := _m.recorder.CheckErrorWithContext(ctx, "DeleteSecurityGroup"); err != nil {
 		return output, err
 	}
+	defer func() { err = applyRequestOptions(ctx, "DeleteSecurityGroup", _a0, output, err, opts) }()
 	_m.recorder.Record("DeleteSecurityGroup")
 	returns, exist := _m.recorder.giveRecordedOutput("DeleteSecurityGroup", _a0)
 	if exist {
 		assertedErr, _ := returns[1].(error)
 		return returns[0].(*ec2.DeleteSecurityGroupOutput), assertedErr
 	}
-	_, exist = _m.assignedsecurityGroups[*_a0.GroupId]
-	if !exist {
-		err = errors.New("group id not exist")
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.DeleteSecurityGroupOutput) }()
+	if err = _m.checkPermission("DeleteSecurityGroup", _a0, _a0.DryRun); err != nil {
+		return output, err
+	}
+	_m.settleTransitions()
+	group, err := _m.securityGroupOf(_a0.GroupId, _a0.GroupName)
+	if err != nil {
+		return
+	}
+	if aws.StringValue(group.GroupName) == defaultVpcSecurityGroupName {
+		err = newAwsError("CannotDelete", "the specified group: \""+*group.GroupId+"\" name: \"default\" cannot be deleted by a user")
+		return
+	}
+	if _m.securityGroupInUse(*group.GroupId) {
+		err = newAwsError("DependencyViolation", "resource "+*group.GroupId+" has a dependent object")
 		return
 	}
-	delete(_m.assignedsecurityGroups, *_a0.GroupId)
+	delete(_m.assignedsecurityGroups, *group.GroupId)
 	return
 }
 
 // DescribeSecurityGroups provides a mock function with given fields: _a0
-func (_m *EC2API) DescribeSecurityGroups(_a0 *ec2.DescribeSecurityGroupsInput) (output *ec2.DescribeSecurityGroupsOutput, err error) {
-	// TODO @sch00lb0y check what all the filters are being used in avi and implement according to that
-	// for referenece check DescribeSubnets mock
+func (_m *EC2API) DescribeSecurityGroups(_a0 *ec2.DescribeSecurityGroupsInput) (*ec2.DescribeSecurityGroupsOutput, error) {
+	return _m.DescribeSecurityGroupsWithContext(aws.BackgroundContext(), _a0)
+}
+
+// DescribeSecurityGroupsWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) DescribeSecurityGroupsWithContext(ctx aws.Context, _a0 *ec2.DescribeSecurityGroupsInput, opts ...request.Option) (output *ec2.DescribeSecurityGroupsOutput, err error) {
 	output = &ec2.DescribeSecurityGroupsOutput{}
-	if err := _m.recorder.CheckError("DescribeSecurityGroups"); err != nil {
+	if err := _m.recorder.CheckErrorWithContext(ctx, "DescribeSecurityGroups"); err != nil {
 		return output, err
 	}
+	defer func() { err = applyRequestOptions(ctx, "DescribeSecurityGroups", _a0, output, err, opts) }()
 	_m.recorder.Record("DescribeSecurityGroups")
 	returns, exist := _m.recorder.giveRecordedOutput("DescribeSecurityGroups", _a0)
 	if exist {
 		assertedErr, _ := returns[1].(error)
 		return returns[0].(*ec2.DescribeSecurityGroupsOutput), assertedErr
 	}
-
-	filteredSecuirytGroup := []*ec2.SecurityGroup{}
-	for _, groupId := range _a0.GroupIds {
-		sg, ok := _m.assignedsecurityGroups[*groupId]
-		if ok {
-			filteredSecuirytGroup = append(filteredSecuirytGroup, sg)
-		}
-	}
-	if len(_a0.Filters) == 0 {
-		output.SecurityGroups = filteredSecuirytGroup
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.DescribeSecurityGroupsOutput) }()
+	_m.settleTransitions()
+	if err = securityGroupFilter.validate(_a0.Filters); err != nil {
 		return
 	}
-
-	if len(filteredSecuirytGroup) == 0 {
-		for _, val := range _m.assignedsecurityGroups {
-			filteredSecuirytGroup = append(filteredSecuirytGroup, val)
+	for _, id := range _a0.GroupIds {
+		if _, ok := _m.assignedsecurityGroups[aws.StringValue(id)]; !ok {
+			err = newAwsError("InvalidGroup.NotFound", "The security group '"+aws.StringValue(id)+"' does not exist")
+			return
 		}
 	}
-	furtherFilteredSecurityGroup := []*ec2.SecurityGroup{}
-	for _, val := range filteredSecuirytGroup {
-		for _, filter := range _a0.Filters {
-			if *filter.Name == "vpc-id" {
-				for _, vpcId := range filter.Values {
-					if *val.VpcId == *vpcId {
-						furtherFilteredSecurityGroup = append(furtherFilteredSecurityGroup, val)
-					}
-				}
+	for _, securityGroup := range _m.assignedsecurityGroups {
+		if len(_a0.GroupIds) != 0 {
+			if exist, _ := in_array(*securityGroup.GroupId, aws.StringValueSlice(_a0.GroupIds)); !exist {
+				continue
 			}
 		}
+		if len(_a0.GroupNames) != 0 {
+			if exist, _ := in_array(aws.StringValue(securityGroup.GroupName), aws.StringValueSlice(_a0.GroupNames)); !exist {
+				continue
+			}
+		}
+		if securityGroupFilter.match(securityGroup, _a0.Filters) {
+			output.SecurityGroups = append(output.SecurityGroups, securityGroup)
+		}
 	}
-
-	output.SecurityGroups = furtherFilteredSecurityGroup
+	sort.Slice(output.SecurityGroups, func(i, j int) bool {
+		return *output.SecurityGroups[i].GroupId < *output.SecurityGroups[j].GroupId
+	})
+	start, end, nextToken, err := _m.page(len(output.SecurityGroups), func(i int) string {
+		return *output.SecurityGroups[i].GroupId
+	}, _a0.MaxResults, _a0.NextToken)
+	if err != nil {
+		return
+	}
+	output.SecurityGroups = output.SecurityGroups[start:end]
+	output.NextToken = nextToken
 	return
 }
 
 // AuthorizeSecurityGroupIngress provides a mock function with given fields: _a0
-func (_m *EC2API) AuthorizeSecurityGroupIngress(_a0 *ec2.AuthorizeSecurityGroupIngressInput) (output *ec2.AuthorizeSecurityGroupIngressOutput, err error) {
+func (_m *EC2API) AuthorizeSecurityGroupIngress(_a0 *ec2.AuthorizeSecurityGroupIngressInput) (*ec2.AuthorizeSecurityGroupIngressOutput, error) {
+	return _m.AuthorizeSecurityGroupIngressWithContext(aws.BackgroundContext(), _a0)
+}
+
+// AuthorizeSecurityGroupIngressWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) AuthorizeSecurityGroupIngressWithContext(ctx aws.Context, _a0 *ec2.AuthorizeSecurityGroupIngressInput, opts ...request.Option) (output *ec2.AuthorizeSecurityGroupIngressOutput, err error) {
 	output = &ec2.AuthorizeSecurityGroupIngressOutput{}
-	if err := _m.recorder.CheckError("AuthorizeSecurityGroupIngress"); err != nil {
+	if err := _m.recorder.CheckErrorWithContext(ctx, "AuthorizeSecurityGroupIngress"); err != nil {
 		return output, err
 	}
+	defer func() { err = applyRequestOptions(ctx, "AuthorizeSecurityGroupIngress", _a0, output, err, opts) }()
 	_m.recorder.Record("AuthorizeSecurityGroupIngress")
 	returns, exist := _m.recorder.giveRecordedOutput("AuthorizeSecurityGroupIngress", _a0)
 	if exist {
 		assertedErr, _ := returns[1].(error)
 		return returns[0].(*ec2.AuthorizeSecurityGroupIngressOutput), assertedErr
 	}
-	securityGroup, exist := _m.assignedsecurityGroups[*_a0.GroupId]
-	if !exist {
-		err = errors.New("Group ID not exist")
-	}
-
-	ingressRule := &ec2.IpPermission{
-		IpProtocol: _a0.IpProtocol,
-		FromPort:   _a0.FromPort,
-		ToPort:     _a0.ToPort,
-		IpRanges: []*ec2.IpRange{
-			&ec2.IpRange{
-				CidrIp: _a0.CidrIp,
-			},
-		},
-	}
-	if len(securityGroup.IpPermissions) == 0 {
-		securityGroup.IpPermissions = make([]*ec2.IpPermission, 0)
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.AuthorizeSecurityGroupIngressOutput) }()
+	if err = _m.checkPermission("AuthorizeSecurityGroupIngress", _a0, _a0.DryRun); err != nil {
+		return output, err
 	}
-	securityGroup.IpPermissions = append(securityGroup.IpPermissions, ingressRule)
-	_m.assignedsecurityGroups[*_a0.GroupId] = securityGroup
+	_m.settleTransitions()
+	err = _m.changeSecurityGroupRules(_a0.GroupId, _a0.GroupName, false, _a0.IpPermissions, _a0.IpProtocol, _a0.FromPort, _a0.ToPort, _a0.CidrIp, _a0.SourceSecurityGroupName, _a0.SourceSecurityGroupOwnerId, authorizePermissions)
 	return
 }
 
 // RevokeSecurityGroupIngress provides a mock function with given fields: _a0
-func (_m *EC2API) RevokeSecurityGroupIngress(_a0 *ec2.RevokeSecurityGroupIngressInput) (output *ec2.RevokeSecurityGroupIngressOutput, err error) {
+func (_m *EC2API) RevokeSecurityGroupIngress(_a0 *ec2.RevokeSecurityGroupIngressInput) (*ec2.RevokeSecurityGroupIngressOutput, error) {
+	return _m.RevokeSecurityGroupIngressWithContext(aws.BackgroundContext(), _a0)
+}
+
+// RevokeSecurityGroupIngressWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) RevokeSecurityGroupIngressWithContext(ctx aws.Context, _a0 *ec2.RevokeSecurityGroupIngressInput, opts ...request.Option) (output *ec2.RevokeSecurityGroupIngressOutput, err error) {
 	output = &ec2.RevokeSecurityGroupIngressOutput{}
-	if err := _m.recorder.CheckError("RevokeSecurityGroupIngress"); err != nil {
+	if err := _m.recorder.CheckErrorWithContext(ctx, "RevokeSecurityGroupIngress"); err != nil {
 		return output, err
 	}
+	defer func() { err = applyRequestOptions(ctx, "RevokeSecurityGroupIngress", _a0, output, err, opts) }()
 	_m.recorder.Record("RevokeSecurityGroupIngress")
 	returns, exist := _m.recorder.giveRecordedOutput("RevokeSecurityGroupIngress", _a0)
 	if exist {
 		assertedErr, _ := returns[1].(error)
 		return returns[0].(*ec2.RevokeSecurityGroupIngressOutput), assertedErr
 	}
-	securityGroup, exist := _m.assignedsecurityGroups[*_a0.GroupId]
-	if !exist {
-		err = errors.New("Group ID not exist")
-	}
-	var matchingForAll bool
-	var foundIndex int
-	for index, ingressRule := range securityGroup.IpPermissions {
-		matchingForAll = false
-		if ingressRule.IpProtocol != _a0.IpProtocol || ingressRule.FromPort != _a0.FromPort || ingressRule.ToPort != _a0.ToPort {
-			matchingForAll = true
-			foundIndex = index
-			break
-		}
-	}
-	if matchingForAll {
-		securityGroup.IpPermissions = append(securityGroup.IpPermissions[:foundIndex], securityGroup.IpPermissions[foundIndex+1:]...)
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.RevokeSecurityGroupIngressOutput) }()
+	if err = _m.checkPermission("RevokeSecurityGroupIngress", _a0, _a0.DryRun); err != nil {
+		return output, err
 	}
-	_m.assignedsecurityGroups[*_a0.GroupId] = securityGroup
+	_m.settleTransitions()
+	err = _m.changeSecurityGroupRules(_a0.GroupId, _a0.GroupName, false, _a0.IpPermissions, _a0.IpProtocol, _a0.FromPort, _a0.ToPort, _a0.CidrIp, _a0.SourceSecurityGroupName, _a0.SourceSecurityGroupOwnerId, revokePermissions)
 	return
 }
 
+// changeSecurityGroupRules applies a rule request, given either as ip
+// permissions or through the legacy top level fields, to the ingress or the
+// egress rules of the group.
+func (_m *EC2API) changeSecurityGroupRules(groupId, groupName *string, egress bool, permissions []*ec2.IpPermission, protocol *string, fromPort, toPort *int64, cidrIp, sourceGroupName, sourceGroupOwnerId *string, change func(rules, singles []*ec2.IpPermission) ([]*ec2.IpPermission, error)) error {
+	securityGroup, err := _m.securityGroupOf(groupId, groupName)
+	if err != nil {
+		return err
+	}
+	permissions, err = legacyPermissions(permissions, protocol, fromPort, toPort, cidrIp, sourceGroupName, sourceGroupOwnerId)
+	if err != nil {
+		return err
+	}
+	singles, err := _m.normalizePermissions(securityGroup, permissions)
+	if err != nil {
+		return err
+	}
+	rules := securityGroupRules(securityGroup, egress)
+	changed, err := change(*rules, singles)
+	if err != nil {
+		return err
+	}
+	*rules = changed
+	return nil
+}
+
 // AssignPrivateIpAddresses provides a mock function with given fields: _a0
-func (_m *EC2API) AssignPrivateIpAddresses(_a0 *ec2.AssignPrivateIpAddressesInput) (output *ec2.AssignPrivateIpAddressesOutput, err error) {
+func (_m *EC2API) AssignPrivateIpAddresses(_a0 *ec2.AssignPrivateIpAddressesInput) (*ec2.AssignPrivateIpAddressesOutput, error) {
+	return _m.AssignPrivateIpAddressesWithContext(aws.BackgroundContext(), _a0)
+}
+
+// AssignPrivateIpAddressesWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) AssignPrivateIpAddressesWithContext(ctx aws.Context, _a0 *ec2.AssignPrivateIpAddressesInput, opts ...request.Option) (output *ec2.AssignPrivateIpAddressesOutput, err error) {
 	output = &ec2.AssignPrivateIpAddressesOutput{}
-	if err := _m.recorder.CheckError("AssignPrivateIpAddresses"); err != nil {
+	if err := _m.recorder.CheckErrorWithContext(ctx, "AssignPrivateIpAddresses"); err != nil {
 		return output, err
 	}
+	defer func() { err = applyRequestOptions(ctx, "AssignPrivateIpAddresses", _a0, output, err, opts) }()
 	_m.recorder.Record("AssignPrivateIpAddresses")
 	returns, exist := _m.recorder.giveRecordedOutput("AssignPrivateIpAddresses", _a0)
 	if exist {
 		assertedErr, _ := returns[1].(error)
 		return returns[0].(*ec2.AssignPrivateIpAddressesOutput), assertedErr
 	}
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.AssignPrivateIpAddressesOutput) }()
+	if err = _m.checkPermission("AssignPrivateIpAddresses", _a0, nil); err != nil {
+		return output, err
+	}
+	_m.settleTransitions()
 	if _m.recorder.GetAssignIpFailNetworkInterfaceId() == *_a0.NetworkInterfaceId {
-		err = errors.New("avi assign Ip failure")
+		err = newAwsError("InternalError", "avi assign Ip failure")
+		return
 	}
 	networkInterface, exist := _m.networkinterfaces[*_a0.NetworkInterfaceId]
 	if !exist {
-		err = errors.New("interface id not found")
+		err = newAwsError("InvalidNetworkInterfaceID.NotFound", "The networkInterface ID '"+aws.StringValue(_a0.NetworkInterfaceId)+"' does not exist")
 		return
 	}
 	primary := false
-	if _a0.SecondaryPrivateIpAddressCount == nil {
-		//handle for attaching ip
-		for _, ip := range _a0.PrivateIpAddresses {
-			if networkInterface.PrivateIpAddresses == nil {
-				networkInterface.PrivateIpAddresses = make([]*ec2.NetworkInterfacePrivateIpAddress, 0)
+	subnet := _m.subnets[*networkInterface.SubnetId]
+	// on failure hand back every address booked so far
+	secondaryIps := []string{}
+	defer func() {
+		if err != nil {
+			for _, ip := range secondaryIps {
+				_m.releaseIpOnSubnet(*subnet.SubnetId, ip)
 			}
-			privateDnsName := "ip-" + strings.Replace(*ip, ".", "-", -1) + ".ap-southeast-1.compute.internal"
-			networkInterface.PrivateIpAddresses = append(networkInterface.PrivateIpAddresses, &ec2.NetworkInterfacePrivateIpAddress{
-				PrivateIpAddress: ip,
-				PrivateDnsName:   &privateDnsName,
-				Primary:          &primary,
-			})
 		}
-
-		_m.networkinterfaces[*_a0.NetworkInterfaceId] = networkInterface
-		return
+	}()
+	for _, ip := range _a0.PrivateIpAddresses {
+		if err = _m.assignIpOnSubnet(subnet, aws.StringValue(ip)); err != nil {
+			return
+		}
+		secondaryIps = append(secondaryIps, aws.StringValue(ip))
 	}
-	count := *_a0.SecondaryPrivateIpAddressCount
-
-	subnet := _m.subnets[*networkInterface.SubnetId]
-	cidr := subnet.CidrBlock
-	randomSecondaryIps := []string{}
-	var i int64
-	for i = 0; i < count; i++ {
-		var hostForCidr string
-
-		for {
-			hostForCidr, err = PickRandomHostFromCIDR(*cidr)
-			if err != nil {
-				return
-			}
-			assignedIps, ok := _m.assignedIpOnSubnet[*subnet.SubnetId]
-			if !ok {
-				_m.assignedIpOnSubnet[*subnet.SubnetId] = append(_m.assignedIpOnSubnet[*subnet.SubnetId], hostForCidr)
-				break
-			}
-			// check the random ip is alredy assiggned or not, if assingned, redo the step
-			exist, _ := in_array(hostForCidr, assignedIps)
-			if !exist {
-				randomSecondaryIps = append(randomSecondaryIps, hostForCidr)
-				_m.assignedIpOnSubnet[*subnet.SubnetId] = append(_m.assignedIpOnSubnet[*subnet.SubnetId], hostForCidr)
-				break
-			}
-			//time.Sleep(10 * time.Nanosecond)
+	for i := int64(0); i < aws.Int64Value(_a0.SecondaryPrivateIpAddressCount); i++ {
+		var ip string
+		if ip, err = _m.pickUnassignedIp(subnet); err != nil {
+			return
 		}
+		secondaryIps = append(secondaryIps, ip)
 	}
-	for _, secondaryip := range randomSecondaryIps {
+	for _, secondaryip := range secondaryIps {
 		copyedIp := secondaryip
-		privateDnsName := "ip-" + strings.Replace(secondaryip, ".", "-", -1) + ".ap-southeast-1.compute.internal"
+		privateDnsName := _m.privateDnsNameForIp(secondaryip)
 		privateIP := &ec2.NetworkInterfacePrivateIpAddress{
 			PrivateIpAddress: &copyedIp,
 			PrivateDnsName:   &privateDnsName,
 			Primary:          &primary,
 		}
 		networkInterface.PrivateIpAddresses = append(networkInterface.PrivateIpAddresses, privateIP)
+		output.AssignedPrivateIpAddresses = append(output.AssignedPrivateIpAddresses, &ec2.AssignedPrivateIpAddress{
+			PrivateIpAddress: &copyedIp,
+		})
 	}
+	output.NetworkInterfaceId = networkInterface.NetworkInterfaceId
 	_m.networkinterfaces[*_a0.NetworkInterfaceId] = networkInterface
+	_m.refreshInstanceNetworkInterface(networkInterface)
 	return
 }
 
 // UnassignPrivateIpAddresses provides a mock function with given fields: _a0
-func (_m *EC2API) UnassignPrivateIpAddresses(_a0 *ec2.UnassignPrivateIpAddressesInput) (output *ec2.UnassignPrivateIpAddressesOutput, err error) {
+func (_m *EC2API) UnassignPrivateIpAddresses(_a0 *ec2.UnassignPrivateIpAddressesInput) (*ec2.UnassignPrivateIpAddressesOutput, error) {
+	return _m.UnassignPrivateIpAddressesWithContext(aws.BackgroundContext(), _a0)
+}
+
+// UnassignPrivateIpAddressesWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) UnassignPrivateIpAddressesWithContext(ctx aws.Context, _a0 *ec2.UnassignPrivateIpAddressesInput, opts ...request.Option) (output *ec2.UnassignPrivateIpAddressesOutput, err error) {
 	output = &ec2.UnassignPrivateIpAddressesOutput{}
-	if err := _m.recorder.CheckError("UnassignPrivateIpAddresses"); err != nil {
+	if err := _m.recorder.CheckErrorWithContext(ctx, "UnassignPrivateIpAddresses"); err != nil {
 		return output, err
 	}
+	defer func() { err = applyRequestOptions(ctx, "UnassignPrivateIpAddresses", _a0, output, err, opts) }()
 	_m.recorder.Record("UnassignPrivateIpAddresses")
 	returns, exist := _m.recorder.giveRecordedOutput("UnassignPrivateIpAddresses", _a0)
 	if exist {
 		assertedErr, _ := returns[1].(error)
 		return returns[0].(*ec2.UnassignPrivateIpAddressesOutput), assertedErr
 	}
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.UnassignPrivateIpAddressesOutput) }()
+	if err = _m.checkPermission("UnassignPrivateIpAddresses", _a0, nil); err != nil {
+		return output, err
+	}
+	_m.settleTransitions()
 	networkInterface, exist := _m.networkinterfaces[*_a0.NetworkInterfaceId]
 	if !exist {
-		err = errors.New("interface id not found")
+		err = newAwsError("InvalidNetworkInterfaceID.NotFound", "The networkInterface ID '"+aws.StringValue(_a0.NetworkInterfaceId)+"' does not exist")
+		return
 	}
-	var foundIndex int
-	var found bool
+	// check every address before touching the interface so a bad request
+	// leaves it as it was
+	unassigned := map[string]bool{}
 	for _, incommingPrivateIp := range _a0.PrivateIpAddresses {
-		for index, privateIp := range networkInterface.PrivateIpAddresses {
-			found = false
-			if privateIp.PrivateIpAddress == incommingPrivateIp {
-				foundIndex = index
-				found = true
+		var found *ec2.NetworkInterfacePrivateIpAddress
+		for _, privateIp := range networkInterface.PrivateIpAddresses {
+			if aws.StringValue(privateIp.PrivateIpAddress) == aws.StringValue(incommingPrivateIp) {
+				found = privateIp
 				break
 			}
-			if found {
-				networkInterface.PrivateIpAddresses = append(networkInterface.PrivateIpAddresses[:foundIndex], networkInterface.PrivateIpAddresses[foundIndex+1:]...)
+		}
+		if found == nil {
+			err = newAwsError("InvalidParameterValue", "Some of the specified addresses are not assigned to interface "+aws.StringValue(_a0.NetworkInterfaceId))
+			return
+		}
+		if aws.BoolValue(found.Primary) {
+			err = newAwsError("InvalidParameterValue", "The primary IP address "+aws.StringValue(incommingPrivateIp)+" of interface "+aws.StringValue(_a0.NetworkInterfaceId)+" cannot be unassigned")
+			return
+		}
+		unassigned[aws.StringValue(incommingPrivateIp)] = true
+	}
+	privateIps := []*ec2.NetworkInterfacePrivateIpAddress{}
+	for _, privateIp := range networkInterface.PrivateIpAddresses {
+		if unassigned[aws.StringValue(privateIp.PrivateIpAddress)] {
+			if address := _m.addressOnPrivateIp(*networkInterface.NetworkInterfaceId, *privateIp.PrivateIpAddress); address != nil {
+				_m.disassociateAddress(address)
 			}
+			_m.releaseIpOnSubnet(*networkInterface.SubnetId, *privateIp.PrivateIpAddress)
+			continue
 		}
+		privateIps = append(privateIps, privateIp)
 	}
+	networkInterface.PrivateIpAddresses = privateIps
+	_m.refreshInstanceNetworkInterface(networkInterface)
 	return
 }
 
 // DescribeInstances provides a mock function with given fields: _a0
-func (_m *EC2API) DescribeInstances(_a0 *ec2.DescribeInstancesInput) (output *ec2.DescribeInstancesOutput, err error) {
+func (_m *EC2API) DescribeInstances(_a0 *ec2.DescribeInstancesInput) (*ec2.DescribeInstancesOutput, error) {
+	return _m.DescribeInstancesWithContext(aws.BackgroundContext(), _a0)
+}
+
+// DescribeInstancesWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) DescribeInstancesWithContext(ctx aws.Context, _a0 *ec2.DescribeInstancesInput, opts ...request.Option) (output *ec2.DescribeInstancesOutput, err error) {
 	output = &ec2.DescribeInstancesOutput{}
-	if err := _m.recorder.CheckError("DescribeInstances"); err != nil {
+	if err := _m.recorder.CheckErrorWithContext(ctx, "DescribeInstances"); err != nil {
 		return output, err
 	}
+	defer func() { err = applyRequestOptions(ctx, "DescribeInstances", _a0, output, err, opts) }()
 	_m.recorder.Record("DescribeInstances")
 	returns, exist := _m.recorder.giveRecordedOutput("DescribeInstances", _a0)
 	if exist {
 		assertedErr, _ := returns[1].(error)
 		return returns[0].(*ec2.DescribeInstancesOutput), assertedErr
 	}
-	filteredInstances := []*ec2.Instance{}
-	for _, instanceId := range _a0.InstanceIds {
-		for _, instance := range _m.createdEc2instances {
-			if *instance.InstanceId == *instanceId {
-				filteredInstances = append(filteredInstances, instance)
-			}
-		}
-	}
-	if len(_a0.Filters) == 0 {
-		reservations := []*ec2.Reservation{
-			&ec2.Reservation{
-				Instances: filteredInstances,
-			},
-		}
-		output.Reservations = reservations
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.DescribeInstancesOutput) }()
+	_m.settleTransitions()
+	if err = instanceFilter.validate(_a0.Filters); err != nil {
 		return
 	}
-	if len(filteredInstances) == 0 {
-		for _, val := range _m.createdEc2instances {
-			filteredInstances = append(filteredInstances, val)
-		}
+	if _, err = _m.getInstances(_a0.InstanceIds); err != nil {
+		return
 	}
-	furtherFilteredInstances := []*ec2.Instance{}
-	for _, val := range filteredInstances {
-		for _, filter := range _a0.Filters {
-			if *filter.Name == "vpc-id" {
-				for _, vpcId := range filter.Values {
-					if *val.VpcId == *vpcId {
-						furtherFilteredInstances = append(furtherFilteredInstances, val)
-					}
-				}
+	filteredInstances := []*ec2.Instance{}
+	for _, instance := range _m.createdEc2instances {
+		if len(_a0.InstanceIds) != 0 {
+			if exist, _ := in_array(*instance.InstanceId, aws.StringValueSlice(_a0.InstanceIds)); !exist {
+				continue
 			}
 		}
+		if instanceFilter.match(instance, _a0.Filters) {
+			filteredInstances = append(filteredInstances, instance)
+		}
 	}
-	if len(furtherFilteredInstances) != 0 {
-		filteredInstances = furtherFilteredInstances
-		furtherFilteredInstances = []*ec2.Instance{}
-	} else {
-		furtherFilteredInstances = filteredInstances
+	sort.Slice(filteredInstances, func(i, j int) bool {
+		return *filteredInstances[i].InstanceId < *filteredInstances[j].InstanceId
+	})
+	start, end, nextToken, err := _m.page(len(filteredInstances), func(i int) string {
+		return *filteredInstances[i].InstanceId
+	}, _a0.MaxResults, _a0.NextToken)
+	if err != nil {
+		return
 	}
-
-	for _, val := range filteredInstances {
-		for _, filter := range _a0.Filters {
-			if *filter.Name == "network-interface.subnet-id" {
-				for _, subnetId := range filter.Values {
-					if *val.NetworkInterfaces[0].SubnetId == *subnetId {
-						furtherFilteredInstances = append(furtherFilteredInstances, val)
-					}
-				}
+	output.NextToken = nextToken
+	// the instances of the page are grouped by the reservation they were
+	// launched in, an empty page has no reservation at all
+	output.Reservations = []*ec2.Reservation{}
+	reservations := map[string]*ec2.Reservation{}
+	for _, instance := range filteredInstances[start:end] {
+		reservationId := _m.instanceReservations[*instance.InstanceId]
+		reservation, ok := reservations[reservationId]
+		if !ok {
+			reservation = &ec2.Reservation{
+				ReservationId: aws.String(reservationId),
+				OwnerId:       aws.String(defaultOwnerId),
+				Groups:        []*ec2.GroupIdentifier{},
 			}
+			reservations[reservationId] = reservation
+			output.Reservations = append(output.Reservations, reservation)
 		}
+		reservation.Instances = append(reservation.Instances, instance)
 	}
-	if len(furtherFilteredInstances) == 0 {
-		furtherFilteredInstances = filteredInstances
-	}
-	reservations := []*ec2.Reservation{
-		&ec2.Reservation{
-			Instances: furtherFilteredInstances,
-		},
-	}
-	output.Reservations = reservations
 	return
 }
 
 // DescribeInstanceAttribute provides a mock function with given fields: _a0
-func (_m *EC2API) DescribeInstanceAttribute(_a0 *ec2.DescribeInstanceAttributeInput) (output *ec2.DescribeInstanceAttributeOutput, err error) {
+func (_m *EC2API) DescribeInstanceAttribute(_a0 *ec2.DescribeInstanceAttributeInput) (*ec2.DescribeInstanceAttributeOutput, error) {
+	return _m.DescribeInstanceAttributeWithContext(aws.BackgroundContext(), _a0)
+}
+
+// DescribeInstanceAttributeWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) DescribeInstanceAttributeWithContext(ctx aws.Context, _a0 *ec2.DescribeInstanceAttributeInput, opts ...request.Option) (output *ec2.DescribeInstanceAttributeOutput, err error) {
 	output = &ec2.DescribeInstanceAttributeOutput{}
-	if err := _m.recorder.CheckError("DescribeInstanceAttribute"); err != nil {
+	if err := _m.recorder.CheckErrorWithContext(ctx, "DescribeInstanceAttribute"); err != nil {
 		return output, err
 	}
+	defer func() { err = applyRequestOptions(ctx, "DescribeInstanceAttribute", _a0, output, err, opts) }()
 	_m.recorder.Record("DescribeInstanceAttribute")
 	returns, exist := _m.recorder.giveRecordedOutput("DescribeInstanceAttribute", _a0)
 	if exist {
 		assertedErr, _ := returns[1].(error)
 		return returns[0].(*ec2.DescribeInstanceAttributeOutput), assertedErr
 	}
-	//NOTE: support for GroupSet only added, add remaining attribute if needed.
-	var groupSet string = "groupSet"
-	if _a0.Attribute == &groupSet {
-		for _, instance := range _m.createdEc2instances {
-			if instance.InstanceId == _a0.InstanceId {
-				output.Groups = instance.SecurityGroups
-			}
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.DescribeInstanceAttributeOutput) }()
+	_m.settleTransitions()
+	//NOTE: support for GroupSet and UserData only added, add remaining attribute if needed.
+	instance, ok := _m.getInstance(aws.StringValue(_a0.InstanceId))
+	if !ok {
+		return output, newAwsError("InvalidInstanceID.NotFound", "The instance ID '"+aws.StringValue(_a0.InstanceId)+"' does not exist")
+	}
+	output.InstanceId = instance.InstanceId
+	switch aws.StringValue(_a0.Attribute) {
+	case ec2.InstanceAttributeNameGroupSet:
+		output.Groups = instance.SecurityGroups
+	case ec2.InstanceAttributeNameUserData:
+		output.UserData = &ec2.AttributeValue{}
+		if userData, ok := _m.instanceUserData[*instance.InstanceId]; ok {
+			output.UserData.Value = aws.String(userData)
 		}
 	}
 	return
 }
 
-func (_m *EC2API) CreateTags(_a0 *ec2.CreateTagsInput) (output *ec2.CreateTagsOutput, err error) {
+func (_m *EC2API) CreateTags(_a0 *ec2.CreateTagsInput) (*ec2.CreateTagsOutput, error) {
+	return _m.CreateTagsWithContext(aws.BackgroundContext(), _a0)
+}
+
+// CreateTagsWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) CreateTagsWithContext(ctx aws.Context, _a0 *ec2.CreateTagsInput, opts ...request.Option) (output *ec2.CreateTagsOutput, err error) {
 	output = &ec2.CreateTagsOutput{}
-	if err := _m.recorder.CheckError("CreateTags"); err != nil {
+	if err := _m.recorder.CheckErrorWithContext(ctx, "CreateTags"); err != nil {
 		return output, err
 	}
+	defer func() { err = applyRequestOptions(ctx, "CreateTags", _a0, output, err, opts) }()
 	_m.recorder.Record("CreateTags")
 	returns, exist := _m.recorder.giveRecordedOutput("CreateTags", _a0)
 	if exist {
 		assertedErr, _ := returns[1].(error)
 		return returns[0].(*ec2.CreateTagsOutput), assertedErr
 	}
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.CreateTagsOutput) }()
+	if err = _m.checkPermission("CreateTags", _a0, _a0.DryRun); err != nil {
+		return output, err
+	}
+	_m.settleTransitions()
 	//if prefix is eni then update network interface tag
 	for _, resourceId := range _a0.Resources {
 		if strings.HasPrefix(*resourceId, "eni") {
 			// update tag for network interface
 			networkInterfaceCard, ok := _m.networkinterfaces[*resourceId]
 			if !ok {
-				return output, errors.New("network interface not found")
+				return output, newAwsError("InvalidNetworkInterfaceID.NotFound", "The networkInterface ID '"+*resourceId+"' does not exist")
 			}
 			networkInterfaceCard.TagSet = _a0.Tags
 			_m.networkinterfaces[*resourceId] = networkInterfaceCard
 		}
+		if strings.HasPrefix(*resourceId, "vpc-") {
+			vpc, ok := _m.vpcs[*resourceId]
+			if !ok {
+				return output, newAwsError("InvalidVpcID.NotFound", "The vpc ID '"+*resourceId+"' does not exist")
+			}
+			vpc.Tags = _a0.Tags
+		}
+		if strings.HasPrefix(*resourceId, "igw-") {
+			internetGateway, ok := _m.internetGateways[*resourceId]
+			if !ok {
+				return output, newAwsError("InvalidInternetGatewayID.NotFound", "The internetGateway ID '"+*resourceId+"' does not exist")
+			}
+			internetGateway.Tags = _a0.Tags
+		}
+		if strings.HasPrefix(*resourceId, "nat-") {
+			natGateway, ok := _m.natGateways[*resourceId]
+			if !ok {
+				return output, newAwsError("NatGatewayNotFound", "The Nat Gateway "+*resourceId+" was not found")
+			}
+			natGateway.Tags = _a0.Tags
+		}
+		if strings.HasPrefix(*resourceId, "rtb-") {
+			routeTable, ok := _m.routeTable[*resourceId]
+			if !ok {
+				return output, newAwsError("InvalidRouteTableID.NotFound", "The routeTable ID '"+*resourceId+"' does not exist")
+			}
+			routeTable.Tags = _a0.Tags
+		}
+		if strings.HasPrefix(*resourceId, "eipalloc-") {
+			address, ok := _m.assignedelasticIps[*resourceId]
+			if !ok {
+				return output, newAwsError("InvalidAllocationID.NotFound", "The allocation ID '"+*resourceId+"' does not exist")
+			}
+			address.Tags = _a0.Tags
+		}
 	}
 	return
 }
 
 // DescribeNetworkInterfaces provides a mock function with given fields: _a0
-func (_m *EC2API) DescribeNetworkInterfaces(_a0 *ec2.DescribeNetworkInterfacesInput) (output *ec2.DescribeNetworkInterfacesOutput, err error) {
+func (_m *EC2API) DescribeNetworkInterfaces(_a0 *ec2.DescribeNetworkInterfacesInput) (*ec2.DescribeNetworkInterfacesOutput, error) {
+	return _m.DescribeNetworkInterfacesWithContext(aws.BackgroundContext(), _a0)
+}
+
+// DescribeNetworkInterfacesWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) DescribeNetworkInterfacesWithContext(ctx aws.Context, _a0 *ec2.DescribeNetworkInterfacesInput, opts ...request.Option) (output *ec2.DescribeNetworkInterfacesOutput, err error) {
 	output = &ec2.DescribeNetworkInterfacesOutput{}
-	if err := _m.recorder.CheckError("DescribeNetworkInterfaces"); err != nil {
+	if err := _m.recorder.CheckErrorWithContext(ctx, "DescribeNetworkInterfaces"); err != nil {
 		return output, err
 	}
+	defer func() { err = applyRequestOptions(ctx, "DescribeNetworkInterfaces", _a0, output, err, opts) }()
 	_m.recorder.Record("DescribeNetworkInterfaces")
 	returns, exist := _m.recorder.giveRecordedOutput("DescribeNetworkInterfaces", _a0)
 	if exist {
 		assertedErr, _ := returns[1].(error)
 		return returns[0].(*ec2.DescribeNetworkInterfacesOutput), assertedErr
 	}
-
-	filteredNetworkInterfaces := []*ec2.NetworkInterface{}
-	for _, id := range _a0.NetworkInterfaceIds {
-		val, ok := _m.networkinterfaces[*id]
-		if ok {
-			filteredNetworkInterfaces = append(filteredNetworkInterfaces, val)
-		}
-	}
-	if len(_a0.Filters) == 0 {
-		output.NetworkInterfaces = filteredNetworkInterfaces
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.DescribeNetworkInterfacesOutput) }()
+	_m.settleTransitions()
+	if err = networkInterfaceFilter.validate(_a0.Filters); err != nil {
 		return
 	}
-
-	if len(filteredNetworkInterfaces) == 0 {
-		for _, val := range _m.networkinterfaces {
-			filteredNetworkInterfaces = append(filteredNetworkInterfaces, val)
+	for _, id := range _a0.NetworkInterfaceIds {
+		if _, ok := _m.networkinterfaces[aws.StringValue(id)]; !ok {
+			err = newAwsError("InvalidNetworkInterfaceID.NotFound", "The networkInterface ID '"+aws.StringValue(id)+"' does not exist")
+			return
 		}
 	}
-
-	set := make(map[*ec2.NetworkInterface]bool)
-
-	for _, val := range filteredNetworkInterfaces {
-		for _, filter := range _a0.Filters {
-			if *filter.Name == "vpc-id" {
-				for _, vpcid := range filter.Values {
-					if *val.VpcId == *vpcid {
-						if filtered, exists := set[val]; (exists && filtered != false) || !exists {
-							set[val] = true
-						}
-					} else {
-						set[val] = false
-					}
-				}
-			} else if *filter.Name == "subnet-id" {
-				for _, subnetid := range filter.Values {
-					if *val.SubnetId == *subnetid {
-						if filtered, exists := set[val]; (exists && filtered != false) || !exists {
-							set[val] = true
-						}
-					} else {
-						set[val] = false
-					}
-				}
-			} else if *filter.Name == "availabilityZone" {
-				for _, availabilityZone := range filter.Values {
-					if *val.AvailabilityZone == *availabilityZone {
-						if filtered, exists := set[val]; (exists && filtered != false) || !exists {
-							set[val] = true
-						}
-					} else {
-						set[val] = false
-					}
-				}
-			} else if *filter.Name == "addresses.private-ip-address" {
-				for _, privateIP := range filter.Values {
-					onceFlag := false
-					for _, ip := range val.PrivateIpAddresses {
-						if *ip.PrivateIpAddress == *privateIP {
-							if filtered, exists := set[val]; (exists && filtered != false) || !exists {
-								set[val] = true
-								onceFlag = true
-							}
-						}
-					}
-					if onceFlag == false {
-						set[val] = false
-					}
-				}
-			} else if *filter.Name == "mac-address" {
-				for _, macAddress := range filter.Values {
-					if *val.MacAddress == *macAddress {
-						if filtered, exists := set[val]; (exists && filtered != false) || !exists {
-							set[val] = true
-						}
-					} else {
-						set[val] = false
-					}
-				}
-			} else if strings.HasPrefix("tag", *filter.Name) {
-				// get the tag name
-				tagName := strings.Split(*filter.Name, ":")[1]
-				tagValue := *filter.Values[0]
-				for _, tag := range val.TagSet {
-					if *tag.Key == tagName {
-						if *tag.Value == tagValue {
-							if filtered, exists := set[val]; (exists && filtered != false) || !exists {
-								set[val] = true
-							}
-						} else {
-							set[val] = false
-						}
-					}
-				}
+	for _, ntwInterface := range _m.networkinterfaces {
+		if len(_a0.NetworkInterfaceIds) != 0 {
+			if exist, _ := in_array(*ntwInterface.NetworkInterfaceId, aws.StringValueSlice(_a0.NetworkInterfaceIds)); !exist {
+				continue
 			}
 		}
-	}
-	if _a0.Filters != nil {
-		filteredNetworkInterfaces = []*ec2.NetworkInterface{}
-		for k, v := range set {
-			if v == true {
-				filteredNetworkInterfaces = append(filteredNetworkInterfaces, k)
-			}
+		if networkInterfaceFilter.match(ntwInterface, _a0.Filters) {
+			output.NetworkInterfaces = append(output.NetworkInterfaces, ntwInterface)
 		}
 	}
-	output.NetworkInterfaces = filteredNetworkInterfaces
+	sort.Slice(output.NetworkInterfaces, func(i, j int) bool {
+		return *output.NetworkInterfaces[i].NetworkInterfaceId < *output.NetworkInterfaces[j].NetworkInterfaceId
+	})
+	start, end, nextToken, err := _m.page(len(output.NetworkInterfaces), func(i int) string {
+		return *output.NetworkInterfaces[i].NetworkInterfaceId
+	}, _a0.MaxResults, _a0.NextToken)
+	if err != nil {
+		return
+	}
+	output.NetworkInterfaces = output.NetworkInterfaces[start:end]
+	output.NextToken = nextToken
 	return
 }
 
 // AssociateAddress provides a mock function with given fields: _a0
-func (_m *EC2API) AssociateAddress(_a0 *ec2.AssociateAddressInput) (output *ec2.AssociateAddressOutput, err error) {
+func (_m *EC2API) AssociateAddress(_a0 *ec2.AssociateAddressInput) (*ec2.AssociateAddressOutput, error) {
+	return _m.AssociateAddressWithContext(aws.BackgroundContext(), _a0)
+}
+
+// AssociateAddressWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) AssociateAddressWithContext(ctx aws.Context, _a0 *ec2.AssociateAddressInput, opts ...request.Option) (output *ec2.AssociateAddressOutput, err error) {
 	output = &ec2.AssociateAddressOutput{}
-	if err := _m.recorder.CheckError("AssociateAddress"); err != nil {
+	if err := _m.recorder.CheckErrorWithContext(ctx, "AssociateAddress"); err != nil {
 		return output, err
 	}
+	defer func() { err = applyRequestOptions(ctx, "AssociateAddress", _a0, output, err, opts) }()
 	_m.recorder.Record("AssociateAddress")
 	returns, exist := _m.recorder.giveRecordedOutput("AssociateAddress", _a0)
 	if exist {
 		assertedErr, _ := returns[1].(error)
 		return returns[0].(*ec2.AssociateAddressOutput), assertedErr
 	}
-	networkInterfaceCard, ok := _m.networkinterfaces[*_a0.NetworkInterfaceId]
-	if !ok {
-		return output, errors.New("network interface not found")
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.AssociateAddressOutput) }()
+	if err = _m.checkPermission("AssociateAddress", _a0, _a0.DryRun); err != nil {
+		return output, err
 	}
-	for i, privateIP := range networkInterfaceCard.PrivateIpAddresses {
-		if *privateIP.PrivateIpAddress == *_a0.PrivateIpAddress {
-			association := &ec2.NetworkInterfaceAssociation{}
-			association.AllocationId = _a0.AllocationId
-			association.PublicIp = _a0.PublicIp
-			associationId := uuid.New()
-
-			associationIdStr := "fip-alloc-" + associationId.String()
-			association.AssociationId = &associationIdStr
-			privateIP.Association = association
-			networkInterfaceCard.PrivateIpAddresses[i] = privateIP
+	_m.settleTransitions()
+	address, err := _m.addressOf(_a0.AllocationId, _a0.PublicIp)
+	if err != nil {
+		return
+	}
+	var networkInterfaceCard *ec2.NetworkInterface
+	if _a0.NetworkInterfaceId != nil {
+		var ok bool
+		networkInterfaceCard, ok = _m.networkinterfaces[*_a0.NetworkInterfaceId]
+		if !ok {
+			return output, newAwsError("InvalidNetworkInterfaceID.NotFound", "The networkInterface ID '"+aws.StringValue(_a0.NetworkInterfaceId)+"' does not exist")
+		}
+	} else if _a0.InstanceId != nil {
+		instance, ok := _m.getInstance(*_a0.InstanceId)
+		if !ok || aws.StringValue(instance.State.Name) == ec2.InstanceStateNameTerminated {
+			return output, newAwsError("InvalidInstanceID.NotFound", "The instance ID '"+*_a0.InstanceId+"' does not exist")
+		}
+		// an instance id is only enough when it leaves no doubt about the
+		// interface to use
+		if len(instance.NetworkInterfaces) == 0 {
+			return output, newAwsError("InvalidInstanceID", "The instance '"+*_a0.InstanceId+"' does not have any network interface to associate the address with.")
+		}
+		if len(instance.NetworkInterfaces) > 1 {
+			return output, newAwsError("InvalidInstanceID", "There are multiple interfaces attached to instance '"+*_a0.InstanceId+"'. Please specify an interface ID for the operation instead.")
+		}
+		networkInterfaceCard, ok = _m.networkinterfaces[aws.StringValue(instance.NetworkInterfaces[0].NetworkInterfaceId)]
+		if !ok {
+			return output, newAwsError("InvalidInstanceID", "The network interface '"+aws.StringValue(instance.NetworkInterfaces[0].NetworkInterfaceId)+"' of instance '"+*_a0.InstanceId+"' does not exist.")
 		}
+	} else {
+		return output, newAwsError("MissingParameter", "Either an instance ID or a network interface ID must be specified")
+	}
+	// neither the elastic ip of a nat gateway nor its interface can be
+	// taken over
+	if err = _m.checkAddressNotManaged(address); err != nil {
+		return
+	}
+	if aws.BoolValue(networkInterfaceCard.RequesterManaged) {
+		return output, newAwsError("AuthFailure", "You do not have permission to access the specified resource.")
+	}
+	privateIpAddress := networkInterfaceCard.PrivateIpAddress
+	if _a0.PrivateIpAddress != nil {
+		privateIpAddress = _a0.PrivateIpAddress
+	}
+	var privateIP *ec2.NetworkInterfacePrivateIpAddress
+	for _, ip := range networkInterfaceCard.PrivateIpAddresses {
+		if aws.StringValue(ip.PrivateIpAddress) == aws.StringValue(privateIpAddress) {
+			privateIP = ip
+		}
+	}
+	if privateIP == nil {
+		return output, newAwsError("InvalidParameterValue", "The specified private IP address "+aws.StringValue(privateIpAddress)+" is not assigned to interface "+*networkInterfaceCard.NetworkInterfaceId)
 	}
+	if address.AssociationId != nil {
+		if aws.StringValue(address.NetworkInterfaceId) == *networkInterfaceCard.NetworkInterfaceId && aws.StringValue(address.PrivateIpAddress) == *privateIP.PrivateIpAddress {
+			output.AssociationId = address.AssociationId
+			return
+		}
+		if !aws.BoolValue(_a0.AllowReassociation) {
+			return output, newAwsError("Resource.AlreadyAssociated", "resource "+*address.AllocationId+" is already associated with associate-id "+*address.AssociationId)
+		}
+		_m.disassociateAddress(address)
+	}
+	// the private ip gives up whatever elastic ip it had before
+	if previous := _m.addressOnPrivateIp(*networkInterfaceCard.NetworkInterfaceId, *privateIP.PrivateIpAddress); previous != nil {
+		_m.disassociateAddress(previous)
+	}
+	_m.associateAddress(address, networkInterfaceCard, privateIP)
+	output.AssociationId = address.AssociationId
 	return
 }
 
 // DescribeAddresses provides a mock function with given fields: _a0
-func (_m *EC2API) DescribeAddresses(_a0 *ec2.DescribeAddressesInput) (output *ec2.DescribeAddressesOutput, err error) {
+func (_m *EC2API) DescribeAddresses(_a0 *ec2.DescribeAddressesInput) (*ec2.DescribeAddressesOutput, error) {
+	return _m.DescribeAddressesWithContext(aws.BackgroundContext(), _a0)
+}
+
+// DescribeAddressesWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) DescribeAddressesWithContext(ctx aws.Context, _a0 *ec2.DescribeAddressesInput, opts ...request.Option) (output *ec2.DescribeAddressesOutput, err error) {
 	output = &ec2.DescribeAddressesOutput{}
 	output.Addresses = make([]*ec2.Address, 0)
-	if err := _m.recorder.CheckError("DescribeAddresses"); err != nil {
+	if err := _m.recorder.CheckErrorWithContext(ctx, "DescribeAddresses"); err != nil {
 		return output, err
 	}
+	defer func() { err = applyRequestOptions(ctx, "DescribeAddresses", _a0, output, err, opts) }()
 	_m.recorder.Record("DescribeAddresses")
 	returns, exist := _m.recorder.giveRecordedOutput("DescribeAddresses", _a0)
 	if exist {
 		assertedErr, _ := returns[1].(error)
 		return returns[0].(*ec2.DescribeAddressesOutput), assertedErr
 	}
-	for _, inputIP := range _a0.PublicIps {
-		for allocationId, elasticIP := range _m.assignedelasticIps {
-			if elasticIP == *inputIP {
-				output.Addresses = append(output.Addresses, &ec2.Address{
-					AllocationId: &allocationId,
-					PublicIp:     inputIP,
-				})
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.DescribeAddressesOutput) }()
+	_m.settleTransitions()
+	if err = addressFilter.validate(_a0.Filters); err != nil {
+		return
+	}
+	for _, allocationId := range _a0.AllocationIds {
+		if _, err = _m.addressOf(allocationId, nil); err != nil {
+			return
+		}
+	}
+	for _, publicIp := range _a0.PublicIps {
+		if _, err = _m.addressOf(nil, publicIp); err != nil {
+			return
+		}
+	}
+	for allocationId, elasticIP := range _m.assignedelasticIps {
+		if len(_a0.PublicIps) != 0 {
+			if exist, _ := in_array(*elasticIP.PublicIp, aws.StringValueSlice(_a0.PublicIps)); !exist {
+				continue
+			}
+		}
+		if len(_a0.AllocationIds) != 0 {
+			if exist, _ := in_array(allocationId, aws.StringValueSlice(_a0.AllocationIds)); !exist {
+				continue
 			}
 		}
+		address := _m.describeAddress(elasticIP)
+		if addressFilter.match(address, _a0.Filters) {
+			output.Addresses = append(output.Addresses, address)
+		}
 	}
+	sort.Slice(output.Addresses, func(i, j int) bool {
+		return *output.Addresses[i].AllocationId < *output.Addresses[j].AllocationId
+	})
 	return
 }
 
 // DisassociateAddress provides a mock function with given fields: _a0
-func (_m *EC2API) DisassociateAddress(_a0 *ec2.DisassociateAddressInput) (output *ec2.DisassociateAddressOutput, err error) {
+func (_m *EC2API) DisassociateAddress(_a0 *ec2.DisassociateAddressInput) (*ec2.DisassociateAddressOutput, error) {
+	return _m.DisassociateAddressWithContext(aws.BackgroundContext(), _a0)
+}
+
+// DisassociateAddressWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) DisassociateAddressWithContext(ctx aws.Context, _a0 *ec2.DisassociateAddressInput, opts ...request.Option) (output *ec2.DisassociateAddressOutput, err error) {
 	output = &ec2.DisassociateAddressOutput{}
-	if err := _m.recorder.CheckError("AssociateAddress"); err != nil {
+	if err := _m.recorder.CheckErrorWithContext(ctx, "DisassociateAddress"); err != nil {
 		return output, err
 	}
-	_m.recorder.Record("AssociateAddress")
-	returns, exist := _m.recorder.giveRecordedOutput("AssociateAddress", _a0)
+	defer func() { err = applyRequestOptions(ctx, "DisassociateAddress", _a0, output, err, opts) }()
+	_m.recorder.Record("DisassociateAddress")
+	returns, exist := _m.recorder.giveRecordedOutput("DisassociateAddress", _a0)
 	if exist {
 		assertedErr, _ := returns[1].(error)
 		return returns[0].(*ec2.DisassociateAddressOutput), assertedErr
 	}
-	for networkInterfaceIndex, networkInterfaceCard := range _m.networkinterfaces {
-		for i, privateIP := range networkInterfaceCard.PrivateIpAddresses {
-			if *privateIP.Association.AllocationId == *_a0.AssociationId {
-				networkInterfaceCard.PrivateIpAddresses[i].Association = &ec2.NetworkInterfaceAssociation{}
-				_m.networkinterfaces[networkInterfaceIndex] = networkInterfaceCard
-			}
-		}
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.DisassociateAddressOutput) }()
+	if err = _m.checkPermission("DisassociateAddress", _a0, _a0.DryRun); err != nil {
+		return output, err
+	}
+	_m.settleTransitions()
+	address, err := _m.addressOfAssociation(_a0.AssociationId, _a0.PublicIp)
+	if err != nil {
+		return
+	}
+	if err = _m.checkAddressNotManaged(address); err != nil {
+		return
 	}
+	_m.disassociateAddress(address)
 	return
 }
 
 // DescribeRouteTables provides a mock function with given fields: _a0
-func (_m *EC2API) DescribeRouteTables(_a0 *ec2.DescribeRouteTablesInput) (output *ec2.DescribeRouteTablesOutput, err error) {
+func (_m *EC2API) DescribeRouteTables(_a0 *ec2.DescribeRouteTablesInput) (*ec2.DescribeRouteTablesOutput, error) {
+	return _m.DescribeRouteTablesWithContext(aws.BackgroundContext(), _a0)
+}
+
+// DescribeRouteTablesWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) DescribeRouteTablesWithContext(ctx aws.Context, _a0 *ec2.DescribeRouteTablesInput, opts ...request.Option) (output *ec2.DescribeRouteTablesOutput, err error) {
 	output = &ec2.DescribeRouteTablesOutput{}
-	if err := _m.recorder.CheckError("DescribeRouteTables"); err != nil {
+	if err := _m.recorder.CheckErrorWithContext(ctx, "DescribeRouteTables"); err != nil {
 		return output, err
 	}
+	defer func() { err = applyRequestOptions(ctx, "DescribeRouteTables", _a0, output, err, opts) }()
 	_m.recorder.Record("DescribeRouteTables")
 	returns, exist := _m.recorder.giveRecordedOutput("DescribeRouteTables", _a0)
 	if exist {
 		assertedErr, _ := returns[1].(error)
 		return returns[0].(*ec2.DescribeRouteTablesOutput), assertedErr
 	}
-
-	filteredRouteTables := []*ec2.RouteTable{}
-	for _, filterId := range _a0.RouteTableIds {
-		for routeTableID, routeTable := range _m.routeTable {
-			if routeTableID == *filterId {
-				filteredRouteTables = append(filteredRouteTables, routeTable)
-			}
-		}
-	}
-	if len(_a0.Filters) == 0 {
-		output.RouteTables = filteredRouteTables
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.DescribeRouteTablesOutput) }()
+	_m.settleTransitions()
+	if err = routeTableFilter.validate(_a0.Filters); err != nil {
 		return
 	}
-
-	if len(filteredRouteTables) == 0 {
-		for _, val := range _m.routeTable {
-			filteredRouteTables = append(filteredRouteTables, val)
+	for _, id := range _a0.RouteTableIds {
+		if _, ok := _m.routeTable[aws.StringValue(id)]; !ok {
+			err = newAwsError("InvalidRouteTableID.NotFound", "The routeTable ID '"+aws.StringValue(id)+"' does not exist")
+			return
 		}
 	}
-
-	furtherFilteredRouteTables := []*ec2.RouteTable{}
-
-	for _, val := range filteredRouteTables {
-		for _, filter := range _a0.Filters {
-			if *filter.Name == "vpc-id" {
-				for _, vpcId := range filter.Values {
-					if *val.VpcId == *vpcId {
-						furtherFilteredRouteTables = append(furtherFilteredRouteTables, val)
-					}
-				}
+	for _, routeTable := range _m.routeTable {
+		if len(_a0.RouteTableIds) != 0 {
+			if exist, _ := in_array(*routeTable.RouteTableId, aws.StringValueSlice(_a0.RouteTableIds)); !exist {
+				continue
 			}
 		}
+		if routeTableFilter.match(routeTable, _a0.Filters) {
+			output.RouteTables = append(output.RouteTables, routeTable)
+		}
+	}
+	sort.Slice(output.RouteTables, func(i, j int) bool {
+		return *output.RouteTables[i].RouteTableId < *output.RouteTables[j].RouteTableId
+	})
+	start, end, nextToken, err := _m.page(len(output.RouteTables), func(i int) string {
+		return *output.RouteTables[i].RouteTableId
+	}, _a0.MaxResults, _a0.NextToken)
+	if err != nil {
+		return
 	}
-	output.RouteTables = furtherFilteredRouteTables
+	output.RouteTables = output.RouteTables[start:end]
+	output.NextToken = nextToken
 	return
 }
 
 // DescribeAvailabilityZones provides a mock function with given fields: _a0
-func (_m *EC2API) DescribeAvailabilityZones(_a0 *ec2.DescribeAvailabilityZonesInput) (output *ec2.DescribeAvailabilityZonesOutput, err error) {
+func (_m *EC2API) DescribeAvailabilityZones(_a0 *ec2.DescribeAvailabilityZonesInput) (*ec2.DescribeAvailabilityZonesOutput, error) {
+	return _m.DescribeAvailabilityZonesWithContext(aws.BackgroundContext(), _a0)
+}
+
+// DescribeAvailabilityZonesWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) DescribeAvailabilityZonesWithContext(ctx aws.Context, _a0 *ec2.DescribeAvailabilityZonesInput, opts ...request.Option) (output *ec2.DescribeAvailabilityZonesOutput, err error) {
 	output = &ec2.DescribeAvailabilityZonesOutput{}
-	if err := _m.recorder.CheckError("DescribeAvailabilityZones"); err != nil {
+	if err := _m.recorder.CheckErrorWithContext(ctx, "DescribeAvailabilityZones"); err != nil {
 		return output, err
 	}
+	defer func() { err = applyRequestOptions(ctx, "DescribeAvailabilityZones", _a0, output, err, opts) }()
 	_m.recorder.Record("DescribeAvailabilityZones")
 	returns, exist := _m.recorder.giveRecordedOutput("DescribeAvailabilityZones", _a0)
 	if exist {
 		assertedErr, _ := returns[1].(error)
 		return returns[0].(*ec2.DescribeAvailabilityZonesOutput), assertedErr
 	}
-	output.AvailabilityZones = []*ec2.AvailabilityZone{
-		&ec2.AvailabilityZone{
-			RegionName: &defaultAvailabilityZone,
-			State:      proto.String("available"),
-			ZoneName:   &defaultAvailabilityZone,
-		},
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.DescribeAvailabilityZonesOutput) }()
+	_m.settleTransitions()
+	if err = availabilityZoneFilter.validate(_a0.Filters); err != nil {
+		return
 	}
-	return
-}
-
-// StopInstances provides a mock function with given fields: _a0
-func (_m *EC2API) StopInstances(_a0 *ec2.StopInstancesInput) (output *ec2.StopInstancesOutput, err error) {
+	availabilityZone := &ec2.AvailabilityZone{
+		RegionName: aws.String(_m.region),
+		State:      proto.String("available"),
+		ZoneName:   &defaultAvailabilityZone,
+	}
+	if len(_a0.ZoneNames) != 0 {
+		if exist, _ := in_array(defaultAvailabilityZone, aws.StringValueSlice(_a0.ZoneNames)); !exist {
+			return
+		}
+	}
+	if availabilityZoneFilter.match(availabilityZone, _a0.Filters) {
+		output.AvailabilityZones = []*ec2.AvailabilityZone{availabilityZone}
+	}
+	return
+}
+
+// StopInstances provides a mock function with given fields: _a0
+func (_m *EC2API) StopInstances(_a0 *ec2.StopInstancesInput) (*ec2.StopInstancesOutput, error) {
+	return _m.StopInstancesWithContext(aws.BackgroundContext(), _a0)
+}
+
+// StopInstancesWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) StopInstancesWithContext(ctx aws.Context, _a0 *ec2.StopInstancesInput, opts ...request.Option) (output *ec2.StopInstancesOutput, err error) {
 	output = &ec2.StopInstancesOutput{}
-	if err := _m.recorder.CheckError("StopInstances"); err != nil {
+	if err := _m.recorder.CheckErrorWithContext(ctx, "StopInstances"); err != nil {
 		return output, err
 	}
+	defer func() { err = applyRequestOptions(ctx, "StopInstances", _a0, output, err, opts) }()
 	_m.recorder.Record("StopInstances")
 	returns, exist := _m.recorder.giveRecordedOutput("StopInstances", _a0)
 	if exist {
 		assertedErr, _ := returns[1].(error)
 		return returns[0].(*ec2.StopInstancesOutput), assertedErr
 	}
-	for _, instanceId := range _a0.InstanceIds {
-		for _, instance := range _m.createdEc2instances {
-			if *instance.InstanceId == *instanceId {
-				instance.State.Code = proto.Int64(80)
-				instance.State.Name = proto.String("stopped")
-			}
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.StopInstancesOutput) }()
+	if err = _m.checkPermission("StopInstances", _a0, _a0.DryRun); err != nil {
+		return output, err
+	}
+	_m.settleTransitions()
+	instances, err := _m.getInstances(_a0.InstanceIds)
+	if err != nil {
+		return
+	}
+	for _, instance := range instances {
+		switch aws.Int64Value(instance.State.Code) {
+		case PENDING, SHUTTING_DOWN, TERMINATED:
+			return output, newAwsError("IncorrectInstanceState", "The instance '"+*instance.InstanceId+"' is not in a state from which it can be stopped.")
+		}
+	}
+	for _, instance := range instances {
+		switch aws.Int64Value(instance.State.Code) {
+		case STOPPING, STOP:
+			output.StoppingInstances = append(output.StoppingInstances, unchangedInstance(instance))
+		default:
+			output.StoppingInstances = append(output.StoppingInstances, _m.moveInstance(instance, STOPPING, STOP, InstanceStopping))
 		}
 	}
 	return
 }
 
 // StartInstances provides a mock function with given fields: _a0
-func (_m *EC2API) StartInstances(_a0 *ec2.StartInstancesInput) (output *ec2.StartInstancesOutput, err error) {
+func (_m *EC2API) StartInstances(_a0 *ec2.StartInstancesInput) (*ec2.StartInstancesOutput, error) {
+	return _m.StartInstancesWithContext(aws.BackgroundContext(), _a0)
+}
+
+// StartInstancesWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) StartInstancesWithContext(ctx aws.Context, _a0 *ec2.StartInstancesInput, opts ...request.Option) (output *ec2.StartInstancesOutput, err error) {
 	output = &ec2.StartInstancesOutput{}
-	if err := _m.recorder.CheckError("StartInstances"); err != nil {
+	if err := _m.recorder.CheckErrorWithContext(ctx, "StartInstances"); err != nil {
 		return output, err
 	}
+	defer func() { err = applyRequestOptions(ctx, "StartInstances", _a0, output, err, opts) }()
 	_m.recorder.Record("StartInstances")
 	returns, exist := _m.recorder.giveRecordedOutput("StartInstances", _a0)
 	if exist {
 		assertedErr, _ := returns[1].(error)
 		return returns[0].(*ec2.StartInstancesOutput), assertedErr
 	}
-	for _, instanceId := range _a0.InstanceIds {
-		for _, instance := range _m.createdEc2instances {
-			if *instance.InstanceId == *instanceId {
-				instance.State.Code = proto.Int64(80)
-				instance.State.Name = proto.String("stopped")
-			}
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.StartInstancesOutput) }()
+	if err = _m.checkPermission("StartInstances", _a0, _a0.DryRun); err != nil {
+		return output, err
+	}
+	_m.settleTransitions()
+	instances, err := _m.getInstances(_a0.InstanceIds)
+	if err != nil {
+		return
+	}
+	for _, instance := range instances {
+		switch aws.Int64Value(instance.State.Code) {
+		case STOPPING, SHUTTING_DOWN, TERMINATED:
+			return output, newAwsError("IncorrectInstanceState", "The instance '"+*instance.InstanceId+"' is not in a state from which it can be started.")
+		}
+	}
+	for _, instance := range instances {
+		switch aws.Int64Value(instance.State.Code) {
+		case PENDING, RUNNING:
+			output.StartingInstances = append(output.StartingInstances, unchangedInstance(instance))
+		default:
+			output.StartingInstances = append(output.StartingInstances, _m.moveInstance(instance, PENDING, RUNNING, InstancePending))
 		}
 	}
 	return
 }
 
 // RebootInstances provides a mock function with given fields: _a0
-func (_m *EC2API) RebootInstances(_a0 *ec2.RebootInstancesInput) (output *ec2.RebootInstancesOutput, err error) {
+func (_m *EC2API) RebootInstances(_a0 *ec2.RebootInstancesInput) (*ec2.RebootInstancesOutput, error) {
+	return _m.RebootInstancesWithContext(aws.BackgroundContext(), _a0)
+}
+
+// RebootInstancesWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) RebootInstancesWithContext(ctx aws.Context, _a0 *ec2.RebootInstancesInput, opts ...request.Option) (output *ec2.RebootInstancesOutput, err error) {
 	output = &ec2.RebootInstancesOutput{}
-	if err := _m.recorder.CheckError("RebootInstances"); err != nil {
+	if err := _m.recorder.CheckErrorWithContext(ctx, "RebootInstances"); err != nil {
 		return output, err
 	}
+	defer func() { err = applyRequestOptions(ctx, "RebootInstances", _a0, output, err, opts) }()
 	_m.recorder.Record("RebootInstances")
 	returns, exist := _m.recorder.giveRecordedOutput("RebootInstances", _a0)
 	if exist {
 		assertedErr, _ := returns[1].(error)
 		return returns[0].(*ec2.RebootInstancesOutput), assertedErr
 	}
-	for _, instanceId := range _a0.InstanceIds {
-		for _, instance := range _m.createdEc2instances {
-			if *instance.InstanceId == *instanceId {
-				instance.State.Code = proto.Int64(80)
-				instance.State.Name = proto.String("stopped")
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.RebootInstancesOutput) }()
+	if err = _m.checkPermission("RebootInstances", _a0, _a0.DryRun); err != nil {
+		return output, err
+	}
+	_m.settleTransitions()
+	instances, err := _m.getInstances(_a0.InstanceIds)
+	if err != nil {
+		return
+	}
+	// a reboot keeps the instance running, so only a running instance can be rebooted
+	for _, instance := range instances {
+		switch aws.Int64Value(instance.State.Code) {
+		case PENDING, STOPPING, STOP, SHUTTING_DOWN, TERMINATED:
+			return output, newAwsError("IncorrectInstanceState", "The instance '"+*instance.InstanceId+"' is not in a state from which it can be rebooted.")
+		}
+	}
+	return
+}
+
+// RunInstances provides a mock function with given fields: _a0
+func (_m *EC2API) RunInstances(_a0 *ec2.RunInstancesInput) (*ec2.Reservation, error) {
+	return _m.RunInstancesWithContext(aws.BackgroundContext(), _a0)
+}
+
+// RunInstancesWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) RunInstancesWithContext(ctx aws.Context, _a0 *ec2.RunInstancesInput, opts ...request.Option) (output *ec2.Reservation, err error) {
+	output = &ec2.Reservation{}
+	if err := _m.recorder.CheckErrorWithContext(ctx, "RunInstances"); err != nil {
+		return output, err
+	}
+	defer func() { err = applyRequestOptions(ctx, "RunInstances", _a0, output, err, opts) }()
+	_m.recorder.Record("RunInstances")
+	returns, exist := _m.recorder.giveRecordedOutput("RunInstances", _a0)
+	if exist {
+		assertedErr, _ := returns[1].(error)
+		return returns[0].(*ec2.Reservation), assertedErr
+	}
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.Reservation) }()
+	if err = _m.checkPermission("RunInstances", _a0, _a0.DryRun); err != nil {
+		return output, err
+	}
+	_m.settleTransitions()
+	if _a0.MinCount == nil || _a0.MaxCount == nil {
+		return output, newAwsError("MissingParameter", "The request must contain the parameters MinCount and MaxCount")
+	}
+	if *_a0.MinCount < 1 || *_a0.MinCount > *_a0.MaxCount {
+		return output, newAwsError("InvalidParameterValue", "Value for parameter MinCount must be between 1 and MaxCount")
+	}
+	if _a0.ImageId == nil {
+		return output, newAwsError("MissingParameter", "The request must contain the parameter ImageId")
+	}
+	// without network interface specs the top level fields describe the primary one
+	interfaceSpecs := _a0.NetworkInterfaces
+	if len(interfaceSpecs) == 0 {
+		subnetId := _a0.SubnetId
+		if subnetId == nil {
+			subnetId = aws.String(_m.defaultSubnetId)
+		}
+		interfaceSpecs = []*ec2.InstanceNetworkInterfaceSpecification{
+			&ec2.InstanceNetworkInterfaceSpecification{
+				DeviceIndex:         aws.Int64(0),
+				SubnetId:            subnetId,
+				Groups:              _a0.SecurityGroupIds,
+				PrivateIpAddress:    _a0.PrivateIpAddress,
+				Ipv6AddressCount:    _a0.Ipv6AddressCount,
+				Ipv6Addresses:       _a0.Ipv6Addresses,
+				DeleteOnTermination: aws.Bool(true),
+			},
+		}
+	} else if _a0.SubnetId != nil || len(_a0.SecurityGroupIds) != 0 || _a0.PrivateIpAddress != nil || _a0.Ipv6AddressCount != nil || len(_a0.Ipv6Addresses) != 0 {
+		return output, newAwsError("InvalidParameterCombination", "Network interfaces and an instance-level subnet ID, security groups, private IP address or IPv6 addresses may not be specified on the same request")
+	}
+	if *_a0.MaxCount > 1 {
+		for _, spec := range interfaceSpecs {
+			if spec.NetworkInterfaceId != nil || spec.PrivateIpAddress != nil || len(spec.PrivateIpAddresses) != 0 || len(spec.Ipv6Addresses) != 0 {
+				return output, newAwsError("InvalidParameterCombination", "Existing network interfaces and private IP addresses can only be used to launch a single instance")
+			}
+		}
+	}
+	// validate every spec before touching any state
+	var primarySubnet *ec2.Subnet
+	specSubnets := make([]*ec2.Subnet, len(interfaceSpecs))
+	deviceIndexes := map[int64]bool{}
+	for index, spec := range interfaceSpecs {
+		deviceIndex := aws.Int64Value(spec.DeviceIndex)
+		if aws.BoolValue(spec.AssociatePublicIpAddress) {
+			switch {
+			case len(interfaceSpecs) > 1:
+				return output, newAwsError("InvalidParameterCombination", "The associatePublicIPAddress parameter cannot be specified when launching with multiple network interfaces.")
+			case spec.NetworkInterfaceId != nil || deviceIndex != 0:
+				return output, newAwsError("InvalidParameterCombination", "The associatePublicIPAddress parameter can only be specified for the network interface with DeviceIndex 0 that is created at launch.")
+			}
+		}
+		if deviceIndexes[deviceIndex] {
+			return output, newAwsError("InvalidParameterValue", "Each network interface requires a unique device index.")
+		}
+		deviceIndexes[deviceIndex] = true
+		var subnet *ec2.Subnet
+		if spec.NetworkInterfaceId != nil {
+			ntwInterface, ok := _m.networkinterfaces[*spec.NetworkInterfaceId]
+			if !ok {
+				return output, newAwsError("InvalidNetworkInterfaceID.NotFound", "The networkInterface ID '"+*spec.NetworkInterfaceId+"' does not exist")
+			}
+			if ntwInterface.Attachment != nil {
+				return output, newAwsError("InvalidNetworkInterface.InUse", "Interface: ["+*spec.NetworkInterfaceId+"] in use.")
+			}
+			subnet = _m.subnets[*ntwInterface.SubnetId]
+		} else {
+			var ok bool
+			subnet, ok = _m.subnets[aws.StringValue(spec.SubnetId)]
+			if !ok {
+				return output, newAwsError("InvalidSubnetID.NotFound", "The subnet ID '"+aws.StringValue(spec.SubnetId)+"' does not exist")
+			}
+			if _, err = _m.groupIdentifiers(*subnet.VpcId, spec.Groups); err != nil {
+				return output, err
+			}
+		}
+		specSubnets[index] = subnet
+		if deviceIndex == 0 {
+			primarySubnet = subnet
+		}
+	}
+	if primarySubnet == nil {
+		return output, newAwsError("InvalidParameterValue", "A network interface with device index 0 is required")
+	}
+	// every interface of an instance lives in the vpc and availability zone
+	// of the primary one
+	for index, spec := range interfaceSpecs {
+		subnet := specSubnets[index]
+		if *subnet.VpcId == *primarySubnet.VpcId && aws.StringValue(subnet.AvailabilityZone) == aws.StringValue(primarySubnet.AvailabilityZone) {
+			continue
+		}
+		if spec.NetworkInterfaceId != nil {
+			return output, newAwsError("InvalidParameterValue", "The network interface '"+*spec.NetworkInterfaceId+"' is not in the same VPC and availability zone as the network interface with device index 0")
+		}
+		return output, newAwsError("InvalidParameterValue", "The subnet '"+*subnet.SubnetId+"' is not in the same VPC and availability zone as the network interface with device index 0")
+	}
+	// the subnet setting only applies to a single new interface, the launch
+	// spec overrides it
+	launchPublicIp := len(interfaceSpecs) == 1 && interfaceSpecs[0].NetworkInterfaceId == nil && aws.BoolValue(primarySubnet.MapPublicIpOnLaunch)
+	if len(interfaceSpecs) == 1 && interfaceSpecs[0].AssociatePublicIpAddress != nil {
+		launchPublicIp = *interfaceSpecs[0].AssociatePublicIpAddress
+	}
+	instanceType := _a0.InstanceType
+	if instanceType == nil {
+		instanceType = &defaultInstanceType
+	}
+	instanceTags := []*ec2.Tag{}
+	interfaceTags := []*ec2.Tag{}
+	for _, tagSpecification := range _a0.TagSpecifications {
+		switch aws.StringValue(tagSpecification.ResourceType) {
+		case "instance":
+			instanceTags = append(instanceTags, tagSpecification.Tags...)
+		case "network-interface":
+			interfaceTags = append(interfaceTags, tagSpecification.Tags...)
+		}
+	}
+
+	launchTime := _m.now()
+	instances := []*ec2.Instance{}
+	for launchIndex := int64(0); launchIndex < *_a0.MaxCount; launchIndex++ {
+		instanceId := GiveRandomId("i-")
+		instance := &ec2.Instance{
+			InstanceId:         &instanceId,
+			ImageId:            _a0.ImageId,
+			InstanceType:       instanceType,
+			KeyName:            _a0.KeyName,
+			AmiLaunchIndex:     aws.Int64(launchIndex),
+			LaunchTime:         &launchTime,
+			SubnetId:           primarySubnet.SubnetId,
+			VpcId:              primarySubnet.VpcId,
+			Architecture:       aws.String("x86_64"),
+			Hypervisor:         aws.String("xen"),
+			RootDeviceType:     aws.String("ebs"),
+			RootDeviceName:     aws.String("/dev/xvda"),
+			VirtualizationType: aws.String("hvm"),
+			SourceDestCheck:    aws.Bool(true),
+			ClientToken:        _a0.ClientToken,
+			Placement: &ec2.Placement{
+				AvailabilityZone: primarySubnet.AvailabilityZone,
+				Tenancy:          aws.String("default"),
+			},
+			Monitoring: &ec2.Monitoring{
+				State: aws.String("disabled"),
+			},
+			State: instanceState(PENDING),
+			Tags:  instanceTags,
+		}
+		if err = _m.attachLaunchInterfaces(instance, interfaceSpecs, interfaceTags, launchPublicIp); err != nil {
+			// ec2 launches as many instances as there is room for, as long as
+			// that is at least MinCount
+			if errorCode(err) == "InsufficientFreeAddressesInSubnet" && int64(len(instances)) >= *_a0.MinCount {
+				err = nil
+				break
+			}
+			for _, launched := range instances {
+				_m.releaseLaunchInterfaces(launched, interfaceSpecs)
+			}
+			return output, err
+		}
+		instances = append(instances, instance)
+	}
+	reservationId := GiveRandomId("r-")
+	for _, instance := range instances {
+		_m.appendInstance(instance, reservationId)
+		if _a0.UserData != nil {
+			_m.instanceUserData[*instance.InstanceId] = *_a0.UserData
+		}
+		if launchPublicIp {
+			_m.launchPublicIps[*instance.InstanceId] = true
+		}
+		launched := instance
+		_m.scheduleTransition(*instance.InstanceId, InstancePending, func() {
+			launched.State = instanceState(RUNNING)
+		})
+	}
+	output.ReservationId = aws.String(reservationId)
+	output.OwnerId = &defaultOwnerId
+	output.Groups = []*ec2.GroupIdentifier{}
+	output.Instances = instances
+	return
+}
+
+// attachLaunchInterfaces creates or takes the interfaces the specs describe
+// and attaches them to the instance being launched. On failure it undoes what
+// it did for the instance.
+func (_m *EC2API) attachLaunchInterfaces(instance *ec2.Instance, interfaceSpecs []*ec2.InstanceNetworkInterfaceSpecification, interfaceTags []*ec2.Tag, launchPublicIp bool) error {
+	for index, spec := range interfaceSpecs {
+		var ntwInterface *ec2.NetworkInterface
+		if spec.NetworkInterfaceId != nil {
+			ntwInterface = _m.networkinterfaces[*spec.NetworkInterfaceId]
+		} else {
+			var err error
+			ntwInterface, err = _m.createNetworkInterface(&ec2.CreateNetworkInterfaceInput{
+				Description:                    spec.Description,
+				SubnetId:                       spec.SubnetId,
+				Groups:                         spec.Groups,
+				PrivateIpAddress:               spec.PrivateIpAddress,
+				PrivateIpAddresses:             spec.PrivateIpAddresses,
+				SecondaryPrivateIpAddressCount: spec.SecondaryPrivateIpAddressCount,
+				Ipv6AddressCount:               spec.Ipv6AddressCount,
+				Ipv6Addresses:                  spec.Ipv6Addresses,
+				InterfaceType:                  spec.InterfaceType,
+			})
+			if err != nil {
+				_m.releaseLaunchInterfaces(instance, interfaceSpecs[:index])
+				return err
+			}
+			if ntwInterface.Description == nil {
+				ntwInterface.Description = aws.String("")
+			}
+			ntwInterface.TagSet = append(ntwInterface.TagSet, interfaceTags...)
+		}
+		ntwInterface.Attachment = &ec2.NetworkInterfaceAttachment{
+			AttachmentId:        aws.String(GiveRandomId("eni-attach-")),
+			AttachTime:          instance.LaunchTime,
+			DeleteOnTermination: aws.Bool(spec.NetworkInterfaceId == nil && aws.BoolValue(spec.DeleteOnTermination)),
+			DeviceIndex:         aws.Int64(aws.Int64Value(spec.DeviceIndex)),
+			InstanceId:          instance.InstanceId,
+			InstanceOwnerId:     &defaultOwnerId,
+			Status:              aws.String("attached"),
+		}
+		ntwInterface.Status = aws.String("in-use")
+		if launchPublicIp {
+			_m.assignPublicIp(ntwInterface)
+		}
+		instance.NetworkInterfaces = append(instance.NetworkInterfaces, instanceNetworkInterface(ntwInterface))
+		if aws.Int64Value(spec.DeviceIndex) == 0 {
+			instance.PrivateIpAddress = ntwInterface.PrivateIpAddress
+			instance.PrivateDnsName = ntwInterface.PrivateDnsName
+			instance.SecurityGroups = ntwInterface.Groups
+			if ntwInterface.Association != nil {
+				instance.PublicIpAddress = ntwInterface.Association.PublicIp
+				instance.PublicDnsName = ntwInterface.Association.PublicDnsName
+			}
+		}
+	}
+	return nil
+}
+
+// releaseLaunchInterfaces undoes attachLaunchInterfaces for an instance that
+// is not launched after all: the interfaces created for it are released, the
+// existing ones it took are available again.
+func (_m *EC2API) releaseLaunchInterfaces(instance *ec2.Instance, interfaceSpecs []*ec2.InstanceNetworkInterfaceSpecification) {
+	for index, spec := range interfaceSpecs {
+		ntwInterface, ok := _m.networkinterfaces[aws.StringValue(instance.NetworkInterfaces[index].NetworkInterfaceId)]
+		if !ok {
+			continue
+		}
+		if spec.NetworkInterfaceId == nil {
+			_m.releaseNetworkInterface(ntwInterface)
+			continue
+		}
+		ntwInterface.Attachment = nil
+		ntwInterface.Status = aws.String("available")
+	}
+	instance.NetworkInterfaces = nil
+}
+
+// TerminateInstances provides a mock function with given fields: _a0
+func (_m *EC2API) TerminateInstances(_a0 *ec2.TerminateInstancesInput) (*ec2.TerminateInstancesOutput, error) {
+	return _m.TerminateInstancesWithContext(aws.BackgroundContext(), _a0)
+}
+
+// TerminateInstancesWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) TerminateInstancesWithContext(ctx aws.Context, _a0 *ec2.TerminateInstancesInput, opts ...request.Option) (output *ec2.TerminateInstancesOutput, err error) {
+	output = &ec2.TerminateInstancesOutput{}
+	if err := _m.recorder.CheckErrorWithContext(ctx, "TerminateInstances"); err != nil {
+		return output, err
+	}
+	defer func() { err = applyRequestOptions(ctx, "TerminateInstances", _a0, output, err, opts) }()
+	_m.recorder.Record("TerminateInstances")
+	returns, exist := _m.recorder.giveRecordedOutput("TerminateInstances", _a0)
+	if exist {
+		assertedErr, _ := returns[1].(error)
+		return returns[0].(*ec2.TerminateInstancesOutput), assertedErr
+	}
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.TerminateInstancesOutput) }()
+	if err = _m.checkPermission("TerminateInstances", _a0, _a0.DryRun); err != nil {
+		return output, err
+	}
+	_m.settleTransitions()
+	instances, err := _m.getInstances(_a0.InstanceIds)
+	if err != nil {
+		return
+	}
+	for _, instance := range instances {
+		switch aws.Int64Value(instance.State.Code) {
+		case SHUTTING_DOWN, TERMINATED:
+			output.TerminatingInstances = append(output.TerminatingInstances, unchangedInstance(instance))
+		default:
+			output.TerminatingInstances = append(output.TerminatingInstances, _m.moveInstance(instance, SHUTTING_DOWN, TERMINATED, InstanceShuttingDown))
+		}
+	}
+	return
+}
+
+func (_m *EC2API) getInstance(instanceId string) (*ec2.Instance, bool) {
+	for _, instance := range _m.createdEc2instances {
+		if *instance.InstanceId == instanceId {
+			return instance, true
+		}
+	}
+	return nil, false
+}
+
+// getInstances resolves every id, failing the whole request if one is unknown.
+func (_m *EC2API) getInstances(instanceIds []*string) ([]*ec2.Instance, error) {
+	instances := []*ec2.Instance{}
+	for _, instanceId := range instanceIds {
+		instance, ok := _m.getInstance(aws.StringValue(instanceId))
+		if !ok {
+			return nil, newAwsError("InvalidInstanceID.NotFound", "The instance ID '"+aws.StringValue(instanceId)+"' does not exist")
+		}
+		instances = append(instances, instance)
+	}
+	return instances, nil
+}
+
+// releaseInstanceNetworkInterfaces deletes the interfaces of a terminated
+// instance flagged with DeleteOnTermination and detaches the rest.
+func (_m *EC2API) releaseInstanceNetworkInterfaces(instance *ec2.Instance) {
+	for _, instanceInterface := range instance.NetworkInterfaces {
+		ntwInterface, ok := _m.networkinterfaces[aws.StringValue(instanceInterface.NetworkInterfaceId)]
+		if !ok {
+			continue
+		}
+		deleteOnTermination := instanceInterface.Attachment != nil && aws.BoolValue(instanceInterface.Attachment.DeleteOnTermination)
+		if ntwInterface.Attachment != nil {
+			deleteOnTermination = aws.BoolValue(ntwInterface.Attachment.DeleteOnTermination)
+		}
+		if deleteOnTermination {
+			_m.releaseNetworkInterface(ntwInterface)
+			continue
+		}
+		_m.cancelTransition(*ntwInterface.NetworkInterfaceId)
+		_m.releasePublicIp(ntwInterface)
+		ntwInterface.Attachment = nil
+		ntwInterface.Status = aws.String("available")
+	}
+	delete(_m.launchPublicIps, *instance.InstanceId)
+	_m.blackholeRoutes(func(route *ec2.Route) bool {
+		return aws.StringValue(route.InstanceId) == *instance.InstanceId
+	})
+	instance.NetworkInterfaces = []*ec2.InstanceNetworkInterface{}
+	instance.PrivateIpAddress = nil
+	instance.PrivateDnsName = nil
+	instance.PublicIpAddress = nil
+	instance.PublicDnsName = nil
+}
+
+// releaseInstancePublicIps hands back the auto assigned public ips of a
+// stopped instance, its elastic ips stay associated.
+func (_m *EC2API) releaseInstancePublicIps(instance *ec2.Instance) {
+	for _, instanceInterface := range instance.NetworkInterfaces {
+		if ntwInterface, ok := _m.networkinterfaces[aws.StringValue(instanceInterface.NetworkInterfaceId)]; ok {
+			_m.releasePublicIp(ntwInterface)
+		}
+	}
+}
+
+// assignInstancePublicIp gives an instance launched with a public ip a new one
+// when it starts again, as long as it has a single network interface.
+func (_m *EC2API) assignInstancePublicIp(instance *ec2.Instance) {
+	if !_m.launchPublicIps[*instance.InstanceId] || len(instance.NetworkInterfaces) != 1 {
+		return
+	}
+	if ntwInterface, ok := _m.networkinterfaces[aws.StringValue(instance.NetworkInterfaces[0].NetworkInterfaceId)]; ok {
+		_m.assignPublicIp(ntwInterface)
+	}
+}
+
+// refreshInstanceNetworkInterface updates the view the attached instance
+// keeps of the network interface after it changed.
+func (_m *EC2API) refreshInstanceNetworkInterface(ntwInterface *ec2.NetworkInterface) {
+	if ntwInterface.Attachment == nil {
+		return
+	}
+	instance, ok := _m.getInstance(aws.StringValue(ntwInterface.Attachment.InstanceId))
+	if !ok {
+		return
+	}
+	for index, instanceInterface := range instance.NetworkInterfaces {
+		if aws.StringValue(instanceInterface.NetworkInterfaceId) == aws.StringValue(ntwInterface.NetworkInterfaceId) {
+			instance.NetworkInterfaces[index] = instanceNetworkInterface(ntwInterface)
+		}
+	}
+	// the instance level attributes mirror the primary interface
+	if aws.Int64Value(ntwInterface.Attachment.DeviceIndex) == 0 {
+		instance.SecurityGroups = ntwInterface.Groups
+		instance.SourceDestCheck = ntwInterface.SourceDestCheck
+		instance.PublicIpAddress = nil
+		instance.PublicDnsName = nil
+		if ntwInterface.Association != nil {
+			instance.PublicIpAddress = ntwInterface.Association.PublicIp
+			instance.PublicDnsName = ntwInterface.Association.PublicDnsName
+		}
+	}
+}
+
+// instanceNetworkInterface gives the instance's view of an attached network interface.
+func instanceNetworkInterface(ntwInterface *ec2.NetworkInterface) *ec2.InstanceNetworkInterface {
+	instanceInterface := &ec2.InstanceNetworkInterface{
+		Association:        instanceNetworkInterfaceAssociation(ntwInterface.Association),
+		Description:        ntwInterface.Description,
+		Groups:             ntwInterface.Groups,
+		InterfaceType:      ntwInterface.InterfaceType,
+		MacAddress:         ntwInterface.MacAddress,
+		NetworkInterfaceId: ntwInterface.NetworkInterfaceId,
+		OwnerId:            ntwInterface.OwnerId,
+		PrivateDnsName:     ntwInterface.PrivateDnsName,
+		PrivateIpAddress:   ntwInterface.PrivateIpAddress,
+		SourceDestCheck:    ntwInterface.SourceDestCheck,
+		Status:             ntwInterface.Status,
+		SubnetId:           ntwInterface.SubnetId,
+		VpcId:              ntwInterface.VpcId,
+	}
+	for _, privateIp := range ntwInterface.PrivateIpAddresses {
+		instanceInterface.PrivateIpAddresses = append(instanceInterface.PrivateIpAddresses, &ec2.InstancePrivateIpAddress{
+			Association:      instanceNetworkInterfaceAssociation(privateIp.Association),
+			Primary:          privateIp.Primary,
+			PrivateDnsName:   privateIp.PrivateDnsName,
+			PrivateIpAddress: privateIp.PrivateIpAddress,
+		})
+	}
+	for _, ipv6Ip := range ntwInterface.Ipv6Addresses {
+		instanceInterface.Ipv6Addresses = append(instanceInterface.Ipv6Addresses, &ec2.InstanceIpv6Address{
+			Ipv6Address: ipv6Ip.Ipv6Address,
+		})
+	}
+	if ntwInterface.Attachment != nil {
+		instanceInterface.Attachment = &ec2.InstanceNetworkInterfaceAttachment{
+			AttachmentId:        ntwInterface.Attachment.AttachmentId,
+			AttachTime:          ntwInterface.Attachment.AttachTime,
+			DeleteOnTermination: ntwInterface.Attachment.DeleteOnTermination,
+			DeviceIndex:         ntwInterface.Attachment.DeviceIndex,
+			Status:              ntwInterface.Attachment.Status,
+		}
+	}
+	return instanceInterface
+}
+func instanceNetworkInterfaceAssociation(association *ec2.NetworkInterfaceAssociation) *ec2.InstanceNetworkInterfaceAssociation {
+	if association == nil {
+		return nil
+	}
+	return &ec2.InstanceNetworkInterfaceAssociation{
+		IpOwnerId:     association.IpOwnerId,
+		PublicDnsName: association.PublicDnsName,
+		PublicIp:      association.PublicIp,
+	}
+}
+
+// CreateVolume provides a mock function with given fields: _a0
+func (_m *EC2API) CreateVolume(_a0 *ec2.CreateVolumeInput) (*ec2.Volume, error) {
+	return _m.CreateVolumeWithContext(aws.BackgroundContext(), _a0)
+}
+
+// CreateVolumeWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) CreateVolumeWithContext(ctx aws.Context, _a0 *ec2.CreateVolumeInput, opts ...request.Option) (output *ec2.Volume, err error) {
+	output = &ec2.Volume{}
+	if err := _m.recorder.CheckErrorWithContext(ctx, "CreateVolume"); err != nil {
+		return output, err
+	}
+	defer func() { err = applyRequestOptions(ctx, "CreateVolume", _a0, output, err, opts) }()
+	_m.recorder.Record("CreateVolume")
+	returns, exist := _m.recorder.giveRecordedOutput("CreateVolume", _a0)
+	if exist {
+		assertedErr, _ := returns[1].(error)
+		return returns[0].(*ec2.Volume), assertedErr
+	}
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.Volume) }()
+	if err = _m.checkPermission("CreateVolume", _a0, _a0.DryRun); err != nil {
+		return output, err
+	}
+	_m.settleTransitions()
+	if _a0.AvailabilityZone == nil {
+		return nil, newAwsError("MissingParameter", "The request must contain the parameter AvailabilityZone")
+	}
+	if _a0.Size == nil && _a0.SnapshotId == nil {
+		return nil, newAwsError("MissingParameter", "The request must contain the parameter size or snapshotId")
+	}
+	volumeType := aws.StringValue(_a0.VolumeType)
+	if volumeType == "" {
+		volumeType = defaultVolumeType
+	}
+	sizeRange, ok := volumeSizes[volumeType]
+	if !ok {
+		return nil, newAwsError("InvalidParameterValue", "Value ("+volumeType+") for parameter volumeType is invalid. Valid values: gp2, io1, st1, sc1, standard")
+	}
+	size := aws.Int64Value(_a0.Size)
+	if _a0.Size == nil {
+		// the snapshots aren't modelled, a volume from one gets the smallest size
+		size = sizeRange[0]
+	}
+	if size < sizeRange[0] || size > sizeRange[1] {
+		return nil, newAwsError("InvalidParameterValue", "Volume of "+strconv.FormatInt(size, 10)+"GiB is outside the allowed range for "+volumeType)
+	}
+	tags := []*ec2.Tag{}
+	for _, tagSpecification := range _a0.TagSpecifications {
+		if aws.StringValue(tagSpecification.ResourceType) == "volume" {
+			tags = append(tags, tagSpecification.Tags...)
+		}
+	}
+	volume := &ec2.Volume{
+		VolumeId:         aws.String(GiveRandomId("vol-")),
+		AvailabilityZone: _a0.AvailabilityZone,
+		CreateTime:       aws.Time(_m.now()),
+		Encrypted:        aws.Bool(aws.BoolValue(_a0.Encrypted)),
+		Iops:             _a0.Iops,
+		KmsKeyId:         _a0.KmsKeyId,
+		Size:             aws.Int64(size),
+		SnapshotId:       aws.String(aws.StringValue(_a0.SnapshotId)),
+		State:            aws.String("creating"),
+		VolumeType:       aws.String(volumeType),
+		Attachments:      []*ec2.VolumeAttachment{},
+		Tags:             tags,
+	}
+	_m.volumes[*volume.VolumeId] = volume
+	_m.scheduleTransition(*volume.VolumeId, VolumeCreating, func() {
+		volume.State = aws.String("available")
+	})
+	output = volume
+	return
+}
+
+// DescribeVolumes provides a mock function with given fields: _a0
+func (_m *EC2API) DescribeVolumes(_a0 *ec2.DescribeVolumesInput) (*ec2.DescribeVolumesOutput, error) {
+	return _m.DescribeVolumesWithContext(aws.BackgroundContext(), _a0)
+}
+
+// DescribeVolumesWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) DescribeVolumesWithContext(ctx aws.Context, _a0 *ec2.DescribeVolumesInput, opts ...request.Option) (output *ec2.DescribeVolumesOutput, err error) {
+	output = &ec2.DescribeVolumesOutput{}
+	if err := _m.recorder.CheckErrorWithContext(ctx, "DescribeVolumes"); err != nil {
+		return output, err
+	}
+	defer func() { err = applyRequestOptions(ctx, "DescribeVolumes", _a0, output, err, opts) }()
+	_m.recorder.Record("DescribeVolumes")
+	returns, exist := _m.recorder.giveRecordedOutput("DescribeVolumes", _a0)
+	if exist {
+		assertedErr, _ := returns[1].(error)
+		return returns[0].(*ec2.DescribeVolumesOutput), assertedErr
+	}
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.DescribeVolumesOutput) }()
+	_m.settleTransitions()
+	if err = volumeFilter.validate(_a0.Filters); err != nil {
+		return
+	}
+	for _, id := range _a0.VolumeIds {
+		if _, ok := _m.volumes[aws.StringValue(id)]; !ok {
+			err = newAwsError("InvalidVolume.NotFound", "The volume '"+aws.StringValue(id)+"' does not exist.")
+			return
+		}
+	}
+	for _, volume := range _m.volumes {
+		if len(_a0.VolumeIds) != 0 {
+			if exist, _ := in_array(*volume.VolumeId, aws.StringValueSlice(_a0.VolumeIds)); !exist {
+				continue
 			}
 		}
+		if volumeFilter.match(volume, _a0.Filters) {
+			output.Volumes = append(output.Volumes, volume)
+		}
+	}
+	sort.Slice(output.Volumes, func(i, j int) bool {
+		return *output.Volumes[i].VolumeId < *output.Volumes[j].VolumeId
+	})
+	start, end, nextToken, err := _m.page(len(output.Volumes), func(i int) string {
+		return *output.Volumes[i].VolumeId
+	}, _a0.MaxResults, _a0.NextToken)
+	if err != nil {
+		return
+	}
+	output.Volumes = output.Volumes[start:end]
+	output.NextToken = nextToken
+	return
+}
+
+// DeleteVolume provides a mock function with given fields: _a0
+func (_m *EC2API) DeleteVolume(_a0 *ec2.DeleteVolumeInput) (*ec2.DeleteVolumeOutput, error) {
+	return _m.DeleteVolumeWithContext(aws.BackgroundContext(), _a0)
+}
+
+// DeleteVolumeWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) DeleteVolumeWithContext(ctx aws.Context, _a0 *ec2.DeleteVolumeInput, opts ...request.Option) (output *ec2.DeleteVolumeOutput, err error) {
+	output = &ec2.DeleteVolumeOutput{}
+	if err := _m.recorder.CheckErrorWithContext(ctx, "DeleteVolume"); err != nil {
+		return output, err
+	}
+	defer func() { err = applyRequestOptions(ctx, "DeleteVolume", _a0, output, err, opts) }()
+	_m.recorder.Record("DeleteVolume")
+	returns, exist := _m.recorder.giveRecordedOutput("DeleteVolume", _a0)
+	if exist {
+		assertedErr, _ := returns[1].(error)
+		return returns[0].(*ec2.DeleteVolumeOutput), assertedErr
+	}
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.DeleteVolumeOutput) }()
+	if err = _m.checkPermission("DeleteVolume", _a0, _a0.DryRun); err != nil {
+		return output, err
+	}
+	_m.settleTransitions()
+	volume, ok := _m.volumes[aws.StringValue(_a0.VolumeId)]
+	if !ok {
+		return output, newAwsError("InvalidVolume.NotFound", "The volume '"+aws.StringValue(_a0.VolumeId)+"' does not exist.")
+	}
+	switch aws.StringValue(volume.State) {
+	case "available", "error":
+	default:
+		return output, newAwsError("IncorrectState", "The volume '"+*volume.VolumeId+"' is '"+aws.StringValue(volume.State)+"'")
+	}
+	volume.State = aws.String("deleting")
+	_m.scheduleTransition(*volume.VolumeId, VolumeDeleting, func() {
+		delete(_m.volumes, *volume.VolumeId)
+	})
+	return
+}
+
+// CreateVpc provides a mock function with given fields: _a0
+func (_m *EC2API) CreateVpc(_a0 *ec2.CreateVpcInput) (*ec2.CreateVpcOutput, error) {
+	return _m.CreateVpcWithContext(aws.BackgroundContext(), _a0)
+}
+
+// CreateVpcWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) CreateVpcWithContext(ctx aws.Context, _a0 *ec2.CreateVpcInput, opts ...request.Option) (output *ec2.CreateVpcOutput, err error) {
+	output = &ec2.CreateVpcOutput{}
+	if err := _m.recorder.CheckErrorWithContext(ctx, "CreateVpc"); err != nil {
+		return output, err
+	}
+	defer func() { err = applyRequestOptions(ctx, "CreateVpc", _a0, output, err, opts) }()
+	_m.recorder.Record("CreateVpc")
+	returns, exist := _m.recorder.giveRecordedOutput("CreateVpc", _a0)
+	if exist {
+		assertedErr, _ := returns[1].(error)
+		return returns[0].(*ec2.CreateVpcOutput), assertedErr
+	}
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.CreateVpcOutput) }()
+	if err = _m.checkPermission("CreateVpc", _a0, _a0.DryRun); err != nil {
+		return output, err
+	}
+	_m.settleTransitions()
+	if _a0.CidrBlock == nil {
+		return output, newAwsError("MissingParameter", "The request must contain the parameter cidrBlock")
+	}
+	ip, cidr, parseErr := net.ParseCIDR(*_a0.CidrBlock)
+	if parseErr != nil || ip.To4() == nil {
+		return output, newAwsError("InvalidParameterValue", "Value ("+*_a0.CidrBlock+") for parameter cidrBlock is invalid. This is not a valid CIDR block.")
+	}
+	if ones, _ := cidr.Mask.Size(); ones < minVpcPrefixLength || ones > maxVpcPrefixLength {
+		return output, newAwsError("InvalidVpc.Range", "The CIDR '"+*_a0.CidrBlock+"' is invalid.")
+	}
+	tenancy := aws.StringValue(_a0.InstanceTenancy)
+	switch tenancy {
+	case "":
+		tenancy = ec2.TenancyDefault
+	case ec2.TenancyDefault, ec2.TenancyDedicated:
+	default:
+		return output, newAwsError("InvalidParameterValue", "Value ("+tenancy+") for parameter instanceTenancy is invalid. Valid values: default, dedicated")
+	}
+	vpc := &ec2.Vpc{
+		VpcId:           aws.String(GiveRandomId("vpc-")),
+		CidrBlock:       aws.String(cidr.String()),
+		DhcpOptionsId:   aws.String("default"),
+		InstanceTenancy: aws.String(tenancy),
+		IsDefault:       aws.Bool(false),
+		OwnerId:         aws.String(defaultOwnerId),
+		State:           aws.String(ec2.VpcStatePending),
+		CidrBlockAssociationSet: []*ec2.VpcCidrBlockAssociation{
+			&ec2.VpcCidrBlockAssociation{
+				AssociationId:  aws.String(GiveRandomId("vpc-cidr-assoc-")),
+				CidrBlock:      aws.String(cidr.String()),
+				CidrBlockState: &ec2.VpcCidrBlockState{State: aws.String(ec2.VpcCidrBlockStateCodeAssociated)},
+			},
+		},
+		Ipv6CidrBlockAssociationSet: []*ec2.VpcIpv6CidrBlockAssociation{},
+		Tags:                        []*ec2.Tag{},
+	}
+	if aws.BoolValue(_a0.AmazonProvidedIpv6CidrBlock) {
+		vpc.Ipv6CidrBlockAssociationSet = append(vpc.Ipv6CidrBlockAssociationSet, &ec2.VpcIpv6CidrBlockAssociation{
+			AssociationId:      aws.String(GiveRandomId("vpc-cidr-assoc-")),
+			Ipv6CidrBlock:      aws.String(fmt.Sprintf("2600:1f18:%x:%x00::/56", rangeInInt(0x1000, 0xffff), rangeInInt(0x10, 0xff))),
+			Ipv6CidrBlockState: &ec2.VpcCidrBlockState{State: aws.String(ec2.VpcCidrBlockStateCodeAssociating)},
+		})
+	}
+	_m.vpcs[*vpc.VpcId] = vpc
+	_m.vpcAttributes[*vpc.VpcId] = &vpcAttributes{enableDnsSupport: true}
+	_m.createVpcDefaults(vpc)
+	_m.scheduleTransition(*vpc.VpcId, VpcPending, func() {
+		vpc.State = aws.String(ec2.VpcStateAvailable)
+		for _, association := range vpc.Ipv6CidrBlockAssociationSet {
+			association.Ipv6CidrBlockState.State = aws.String(ec2.VpcCidrBlockStateCodeAssociated)
+		}
+	})
+	output.Vpc = vpc
+	return
+}
+
+// createVpcDefaults gives a new vpc the main route table, the default
+// security group and the default network acl ec2 creates along with it.
+func (_m *EC2API) createVpcDefaults(vpc *ec2.Vpc) {
+	routeTableID := GiveRandomId("rtb-")
+	routes := []*ec2.Route{
+		&ec2.Route{
+			GatewayId:            aws.String("local"),
+			DestinationCidrBlock: vpc.CidrBlock,
+			State:                aws.String(ec2.RouteStateActive),
+			Origin:               aws.String(ec2.RouteOriginCreateRouteTable),
+		},
+	}
+	for _, association := range vpc.Ipv6CidrBlockAssociationSet {
+		routes = append(routes, &ec2.Route{
+			GatewayId:                aws.String("local"),
+			DestinationIpv6CidrBlock: association.Ipv6CidrBlock,
+			State:                    aws.String(ec2.RouteStateActive),
+			Origin:                   aws.String(ec2.RouteOriginCreateRouteTable),
+		})
+	}
+	_m.routeTable[routeTableID] = &ec2.RouteTable{
+		VpcId:        vpc.VpcId,
+		RouteTableId: &routeTableID,
+		OwnerId:      aws.String(defaultOwnerId),
+		Associations: []*ec2.RouteTableAssociation{
+			&ec2.RouteTableAssociation{
+				RouteTableAssociationId: aws.String(GiveRandomId("rtbassoc-")),
+				Main:                    aws.Bool(true),
+				RouteTableId:            &routeTableID,
+			},
+		},
+		Routes: routes,
+		Tags:   []*ec2.Tag{},
+	}
+
+	securityGroupID := GiveRandomId("sg-")
+	_m.assignedsecurityGroups[securityGroupID] = &ec2.SecurityGroup{
+		GroupId:     &securityGroupID,
+		GroupName:   aws.String(defaultVpcSecurityGroupName),
+		Description: aws.String("default VPC security group"),
+		VpcId:       vpc.VpcId,
+		OwnerId:     aws.String(defaultOwnerId),
+		IpPermissions: []*ec2.IpPermission{
+			&ec2.IpPermission{
+				IpProtocol: aws.String("-1"),
+				UserIdGroupPairs: []*ec2.UserIdGroupPair{
+					&ec2.UserIdGroupPair{
+						GroupId: &securityGroupID,
+						UserId:  aws.String(defaultOwnerId),
+					},
+				},
+			},
+		},
+		IpPermissionsEgress: []*ec2.IpPermission{
+			&ec2.IpPermission{
+				IpProtocol: aws.String("-1"),
+				IpRanges:   []*ec2.IpRange{&ec2.IpRange{CidrIp: aws.String("0.0.0.0/0")}},
+			},
+		},
+		Tags: []*ec2.Tag{},
+	}
+
+	_m.appendDefaultNetworkAcl(vpc)
+}
+
+// appendDefaultNetworkAcl adds the network acl that allows all traffic in
+// and out of the vpc's subnets.
+func (_m *EC2API) appendDefaultNetworkAcl(vpc *ec2.Vpc) {
+	entries := []*ec2.NetworkAclEntry{}
+	for _, egress := range []bool{false, true} {
+		entries = append(entries,
+			&ec2.NetworkAclEntry{
+				RuleNumber: aws.Int64(100),
+				Protocol:   aws.String("-1"),
+				RuleAction: aws.String(ec2.RuleActionAllow),
+				Egress:     aws.Bool(egress),
+				CidrBlock:  aws.String("0.0.0.0/0"),
+			},
+			&ec2.NetworkAclEntry{
+				RuleNumber: aws.Int64(32767),
+				Protocol:   aws.String("-1"),
+				RuleAction: aws.String(ec2.RuleActionDeny),
+				Egress:     aws.Bool(egress),
+				CidrBlock:  aws.String("0.0.0.0/0"),
+			},
+		)
+		if len(vpc.Ipv6CidrBlockAssociationSet) != 0 {
+			entries = append(entries,
+				&ec2.NetworkAclEntry{
+					RuleNumber:    aws.Int64(101),
+					Protocol:      aws.String("-1"),
+					RuleAction:    aws.String(ec2.RuleActionAllow),
+					Egress:        aws.Bool(egress),
+					Ipv6CidrBlock: aws.String("::/0"),
+				},
+				&ec2.NetworkAclEntry{
+					RuleNumber:    aws.Int64(32768),
+					Protocol:      aws.String("-1"),
+					RuleAction:    aws.String(ec2.RuleActionDeny),
+					Egress:        aws.Bool(egress),
+					Ipv6CidrBlock: aws.String("::/0"),
+				},
+			)
+		}
+	}
+	networkAclID := GiveRandomId("acl-")
+	_m.networkAcls[networkAclID] = &ec2.NetworkAcl{
+		NetworkAclId: &networkAclID,
+		VpcId:        vpc.VpcId,
+		IsDefault:    aws.Bool(true),
+		OwnerId:      aws.String(defaultOwnerId),
+		Entries:      entries,
+		Associations: []*ec2.NetworkAclAssociation{},
+		Tags:         []*ec2.Tag{},
+	}
+}
+
+// defaultNetworkAcl gives the vpc's default network acl, nil for a vpc
+// appended without one.
+func (_m *EC2API) defaultNetworkAcl(vpcID string) *ec2.NetworkAcl {
+	for _, networkAcl := range _m.networkAcls {
+		if aws.StringValue(networkAcl.VpcId) == vpcID && aws.BoolValue(networkAcl.IsDefault) {
+			return networkAcl
+		}
+	}
+	return nil
+}
+
+// isDefaultSecurityGroup tells the groups ec2 creates with a vpc, and the
+// seeded default group, apart from the ones created through the api.
+func (_m *EC2API) isDefaultSecurityGroup(group *ec2.SecurityGroup) bool {
+	return aws.StringValue(group.GroupName) == defaultVpcSecurityGroupName || aws.StringValue(group.GroupId) == _m.defaultSecurityGroupID
+}
+
+// DeleteVpc provides a mock function with given fields: _a0
+func (_m *EC2API) DeleteVpc(_a0 *ec2.DeleteVpcInput) (*ec2.DeleteVpcOutput, error) {
+	return _m.DeleteVpcWithContext(aws.BackgroundContext(), _a0)
+}
+
+// DeleteVpcWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) DeleteVpcWithContext(ctx aws.Context, _a0 *ec2.DeleteVpcInput, opts ...request.Option) (output *ec2.DeleteVpcOutput, err error) {
+	output = &ec2.DeleteVpcOutput{}
+	if err := _m.recorder.CheckErrorWithContext(ctx, "DeleteVpc"); err != nil {
+		return output, err
+	}
+	defer func() { err = applyRequestOptions(ctx, "DeleteVpc", _a0, output, err, opts) }()
+	_m.recorder.Record("DeleteVpc")
+	returns, exist := _m.recorder.giveRecordedOutput("DeleteVpc", _a0)
+	if exist {
+		assertedErr, _ := returns[1].(error)
+		return returns[0].(*ec2.DeleteVpcOutput), assertedErr
+	}
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.DeleteVpcOutput) }()
+	if err = _m.checkPermission("DeleteVpc", _a0, _a0.DryRun); err != nil {
+		return output, err
+	}
+	_m.settleTransitions()
+	vpcID := aws.StringValue(_a0.VpcId)
+	if _, ok := _m.vpcs[vpcID]; !ok {
+		return output, newAwsError("InvalidVpcID.NotFound", "The vpc ID '"+vpcID+"' does not exist")
+	}
+	if _m.vpcHasDependencies(vpcID) {
+		return output, newAwsError("DependencyViolation", "The vpc '"+vpcID+"' has dependencies and cannot be deleted.")
+	}
+	for id, routeTable := range _m.routeTable {
+		if aws.StringValue(routeTable.VpcId) == vpcID {
+			delete(_m.routeTable, id)
+		}
+	}
+	for id, group := range _m.assignedsecurityGroups {
+		if aws.StringValue(group.VpcId) == vpcID {
+			delete(_m.assignedsecurityGroups, id)
+		}
+	}
+	for id, networkAcl := range _m.networkAcls {
+		if aws.StringValue(networkAcl.VpcId) == vpcID {
+			delete(_m.networkAcls, id)
+		}
+	}
+	_m.cancelTransition(vpcID)
+	delete(_m.vpcAttributes, vpcID)
+	delete(_m.vpcassocaiatedsubnet, vpcID)
+	delete(_m.vpcs, vpcID)
+	return
+}
+
+// vpcHasDependencies tells whether anything but the resources ec2 created
+// along with the vpc is left in it.
+func (_m *EC2API) vpcHasDependencies(vpcID string) bool {
+	for _, subnet := range _m.subnets {
+		if aws.StringValue(subnet.VpcId) == vpcID {
+			return true
+		}
+	}
+	for _, networkInterface := range _m.networkinterfaces {
+		if aws.StringValue(networkInterface.VpcId) == vpcID {
+			return true
+		}
+	}
+	for _, group := range _m.assignedsecurityGroups {
+		if aws.StringValue(group.VpcId) == vpcID && !_m.isDefaultSecurityGroup(group) {
+			return true
+		}
+	}
+	for _, routeTable := range _m.routeTable {
+		if aws.StringValue(routeTable.VpcId) != vpcID {
+			continue
+		}
+		main := false
+		for _, association := range routeTable.Associations {
+			main = main || aws.BoolValue(association.Main)
+		}
+		if !main {
+			return true
+		}
+	}
+	for _, networkAcl := range _m.networkAcls {
+		if aws.StringValue(networkAcl.VpcId) == vpcID && !aws.BoolValue(networkAcl.IsDefault) {
+			return true
+		}
+	}
+	if _m.vpcInternetGateway(vpcID) != nil {
+		return true
+	}
+	for _, egressOnlyInternetGateway := range _m.egressOnlyGateways {
+		if attachedToVpc(egressOnlyInternetGateway.Attachments, vpcID) {
+			return true
+		}
+	}
+	return false
+}
+
+// vpcAttributesOf gives the dns attributes of the vpc, a vpc appended
+// without them gets the ones ec2 gives a vpc of its kind.
+func (_m *EC2API) vpcAttributesOf(vpc *ec2.Vpc) *vpcAttributes {
+	attributes, ok := _m.vpcAttributes[*vpc.VpcId]
+	if !ok {
+		attributes = &vpcAttributes{
+			enableDnsSupport:   true,
+			enableDnsHostnames: aws.BoolValue(vpc.IsDefault),
+		}
+		_m.vpcAttributes[*vpc.VpcId] = attributes
+	}
+	return attributes
+}
+
+// ModifyVpcAttribute provides a mock function with given fields: _a0
+func (_m *EC2API) ModifyVpcAttribute(_a0 *ec2.ModifyVpcAttributeInput) (*ec2.ModifyVpcAttributeOutput, error) {
+	return _m.ModifyVpcAttributeWithContext(aws.BackgroundContext(), _a0)
+}
+
+// ModifyVpcAttributeWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) ModifyVpcAttributeWithContext(ctx aws.Context, _a0 *ec2.ModifyVpcAttributeInput, opts ...request.Option) (output *ec2.ModifyVpcAttributeOutput, err error) {
+	output = &ec2.ModifyVpcAttributeOutput{}
+	if err := _m.recorder.CheckErrorWithContext(ctx, "ModifyVpcAttribute"); err != nil {
+		return output, err
+	}
+	defer func() { err = applyRequestOptions(ctx, "ModifyVpcAttribute", _a0, output, err, opts) }()
+	_m.recorder.Record("ModifyVpcAttribute")
+	returns, exist := _m.recorder.giveRecordedOutput("ModifyVpcAttribute", _a0)
+	if exist {
+		assertedErr, _ := returns[1].(error)
+		return returns[0].(*ec2.ModifyVpcAttributeOutput), assertedErr
+	}
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.ModifyVpcAttributeOutput) }()
+	if err = _m.checkPermission("ModifyVpcAttribute", _a0, nil); err != nil {
+		return output, err
+	}
+	_m.settleTransitions()
+	vpc, ok := _m.vpcs[aws.StringValue(_a0.VpcId)]
+	if !ok {
+		return output, newAwsError("InvalidVpcID.NotFound", "The vpc ID '"+aws.StringValue(_a0.VpcId)+"' does not exist")
+	}
+	// ec2 takes exactly one attribute per request
+	switch {
+	case _a0.EnableDnsSupport != nil && _a0.EnableDnsHostnames != nil:
+		return output, newAwsError("InvalidParameterCombination", "Fields for multiple attribute types specified: enableDnsSupport, enableDnsHostnames")
+	case _a0.EnableDnsSupport == nil && _a0.EnableDnsHostnames == nil:
+		return output, newAwsError("InvalidParameterCombination", "No attributes specified.")
+	}
+	attributes := _m.vpcAttributesOf(vpc)
+	if _a0.EnableDnsSupport != nil {
+		attributes.enableDnsSupport = aws.BoolValue(_a0.EnableDnsSupport.Value)
+	}
+	if _a0.EnableDnsHostnames != nil {
+		attributes.enableDnsHostnames = aws.BoolValue(_a0.EnableDnsHostnames.Value)
+	}
+	return
+}
+
+// DescribeVpcAttribute provides a mock function with given fields: _a0
+func (_m *EC2API) DescribeVpcAttribute(_a0 *ec2.DescribeVpcAttributeInput) (*ec2.DescribeVpcAttributeOutput, error) {
+	return _m.DescribeVpcAttributeWithContext(aws.BackgroundContext(), _a0)
+}
+
+// DescribeVpcAttributeWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) DescribeVpcAttributeWithContext(ctx aws.Context, _a0 *ec2.DescribeVpcAttributeInput, opts ...request.Option) (output *ec2.DescribeVpcAttributeOutput, err error) {
+	output = &ec2.DescribeVpcAttributeOutput{}
+	if err := _m.recorder.CheckErrorWithContext(ctx, "DescribeVpcAttribute"); err != nil {
+		return output, err
+	}
+	defer func() { err = applyRequestOptions(ctx, "DescribeVpcAttribute", _a0, output, err, opts) }()
+	_m.recorder.Record("DescribeVpcAttribute")
+	returns, exist := _m.recorder.giveRecordedOutput("DescribeVpcAttribute", _a0)
+	if exist {
+		assertedErr, _ := returns[1].(error)
+		return returns[0].(*ec2.DescribeVpcAttributeOutput), assertedErr
+	}
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.DescribeVpcAttributeOutput) }()
+	_m.settleTransitions()
+	vpc, ok := _m.vpcs[aws.StringValue(_a0.VpcId)]
+	if !ok {
+		return output, newAwsError("InvalidVpcID.NotFound", "The vpc ID '"+aws.StringValue(_a0.VpcId)+"' does not exist")
+	}
+	attributes := _m.vpcAttributesOf(vpc)
+	switch aws.StringValue(_a0.Attribute) {
+	case ec2.VpcAttributeNameEnableDnsSupport:
+		output.EnableDnsSupport = &ec2.AttributeBooleanValue{Value: aws.Bool(attributes.enableDnsSupport)}
+	case ec2.VpcAttributeNameEnableDnsHostnames:
+		output.EnableDnsHostnames = &ec2.AttributeBooleanValue{Value: aws.Bool(attributes.enableDnsHostnames)}
+	default:
+		return output, newAwsError("InvalidParameterValue", "Value ("+aws.StringValue(_a0.Attribute)+") for parameter attribute is invalid. Unknown attribute.")
+	}
+	output.VpcId = vpc.VpcId
+	return
+}
+
+// DescribeNetworkAcls provides a mock function with given fields: _a0
+func (_m *EC2API) DescribeNetworkAcls(_a0 *ec2.DescribeNetworkAclsInput) (*ec2.DescribeNetworkAclsOutput, error) {
+	return _m.DescribeNetworkAclsWithContext(aws.BackgroundContext(), _a0)
+}
+
+// DescribeNetworkAclsWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) DescribeNetworkAclsWithContext(ctx aws.Context, _a0 *ec2.DescribeNetworkAclsInput, opts ...request.Option) (output *ec2.DescribeNetworkAclsOutput, err error) {
+	output = &ec2.DescribeNetworkAclsOutput{}
+	if err := _m.recorder.CheckErrorWithContext(ctx, "DescribeNetworkAcls"); err != nil {
+		return output, err
+	}
+	defer func() { err = applyRequestOptions(ctx, "DescribeNetworkAcls", _a0, output, err, opts) }()
+	_m.recorder.Record("DescribeNetworkAcls")
+	returns, exist := _m.recorder.giveRecordedOutput("DescribeNetworkAcls", _a0)
+	if exist {
+		assertedErr, _ := returns[1].(error)
+		return returns[0].(*ec2.DescribeNetworkAclsOutput), assertedErr
+	}
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.DescribeNetworkAclsOutput) }()
+	_m.settleTransitions()
+	if err = networkAclFilter.validate(_a0.Filters); err != nil {
+		return
+	}
+	for _, id := range _a0.NetworkAclIds {
+		if _, ok := _m.networkAcls[aws.StringValue(id)]; !ok {
+			err = newAwsError("InvalidNetworkAclID.NotFound", "The network ACL '"+aws.StringValue(id)+"' does not exist")
+			return
+		}
+	}
+	for _, networkAcl := range _m.networkAcls {
+		if len(_a0.NetworkAclIds) != 0 {
+			if exist, _ := in_array(*networkAcl.NetworkAclId, aws.StringValueSlice(_a0.NetworkAclIds)); !exist {
+				continue
+			}
+		}
+		if networkAclFilter.match(networkAcl, _a0.Filters) {
+			output.NetworkAcls = append(output.NetworkAcls, networkAcl)
+		}
+	}
+	sort.Slice(output.NetworkAcls, func(i, j int) bool {
+		return *output.NetworkAcls[i].NetworkAclId < *output.NetworkAcls[j].NetworkAclId
+	})
+	start, end, nextToken, err := _m.page(len(output.NetworkAcls), func(i int) string {
+		return *output.NetworkAcls[i].NetworkAclId
+	}, _a0.MaxResults, _a0.NextToken)
+	if err != nil {
+		return
+	}
+	output.NetworkAcls = output.NetworkAcls[start:end]
+	output.NextToken = nextToken
+	return
+}
+
+// maxVpcCidrBlocks is how many ipv4 blocks a vpc may have, the primary included.
+const maxVpcCidrBlocks = 5
+
+// privateRanges are the rfc1918 ranges, a vpc takes secondary blocks from the
+// range of its primary block only.
+var privateRanges = func() []*net.IPNet {
+	ranges := []*net.IPNet{}
+	for _, cidr := range []string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16"} {
+		_, privateRange, _ := net.ParseCIDR(cidr)
+		ranges = append(ranges, privateRange)
+	}
+	return ranges
+}()
+
+func privateRangeOf(cidr *net.IPNet) *net.IPNet {
+	for _, privateRange := range privateRanges {
+		if cidrContains(privateRange, cidr) {
+			return privateRange
+		}
+	}
+	return nil
+}
+
+// vpcCidrBlocks gives the ipv4 blocks subnets of the vpc can be created in,
+// a vpc appended without associations has its primary block only.
+func vpcCidrBlocks(vpc *ec2.Vpc) []*net.IPNet {
+	blocks := []*net.IPNet{}
+	if len(vpc.CidrBlockAssociationSet) == 0 {
+		if _, block, err := net.ParseCIDR(aws.StringValue(vpc.CidrBlock)); err == nil {
+			blocks = append(blocks, block)
+		}
+		return blocks
+	}
+	for _, association := range vpc.CidrBlockAssociationSet {
+		if aws.StringValue(association.CidrBlockState.State) != ec2.VpcCidrBlockStateCodeAssociated {
+			continue
+		}
+		if _, block, err := net.ParseCIDR(aws.StringValue(association.CidrBlock)); err == nil {
+			blocks = append(blocks, block)
+		}
+	}
+	return blocks
+}
+
+// vpcIpv6CidrBlocks gives the associated ipv6 blocks of the vpc.
+func vpcIpv6CidrBlocks(vpc *ec2.Vpc) []*net.IPNet {
+	blocks := []*net.IPNet{}
+	for _, association := range vpc.Ipv6CidrBlockAssociationSet {
+		if aws.StringValue(association.Ipv6CidrBlockState.State) != ec2.VpcCidrBlockStateCodeAssociated {
+			continue
+		}
+		if _, block, err := net.ParseCIDR(aws.StringValue(association.Ipv6CidrBlock)); err == nil {
+			blocks = append(blocks, block)
+		}
+	}
+	return blocks
+}
+
+func anyCidrContains(blocks []*net.IPNet, cidr *net.IPNet) bool {
+	for _, block := range blocks {
+		if cidrContains(block, cidr) {
+			return true
+		}
+	}
+	return false
+}
+
+// addLocalRoutes routes the new block of the vpc locally in each of its
+// route tables, as ec2 does on association.
+func (_m *EC2API) addLocalRoutes(vpcID string, route *ec2.Route) {
+	for _, routeTable := range _m.routeTable {
+		if aws.StringValue(routeTable.VpcId) != vpcID {
+			continue
+		}
+		localRoute := *route
+		routeTable.Routes = append(routeTable.Routes, &localRoute)
+	}
+}
+
+// removeLocalRoutes drops the local routes of a disassociated block.
+func (_m *EC2API) removeLocalRoutes(vpcID string, cidrBlock string) {
+	for _, routeTable := range _m.routeTable {
+		if aws.StringValue(routeTable.VpcId) != vpcID {
+			continue
+		}
+		routes := []*ec2.Route{}
+		for _, route := range routeTable.Routes {
+			local := aws.StringValue(route.GatewayId) == "local"
+			if local && (aws.StringValue(route.DestinationCidrBlock) == cidrBlock || aws.StringValue(route.DestinationIpv6CidrBlock) == cidrBlock) {
+				continue
+			}
+			routes = append(routes, route)
+		}
+		routeTable.Routes = routes
+	}
+}
+
+// AssociateVpcCidrBlock provides a mock function with given fields: _a0
+func (_m *EC2API) AssociateVpcCidrBlock(_a0 *ec2.AssociateVpcCidrBlockInput) (*ec2.AssociateVpcCidrBlockOutput, error) {
+	return _m.AssociateVpcCidrBlockWithContext(aws.BackgroundContext(), _a0)
+}
+
+// AssociateVpcCidrBlockWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) AssociateVpcCidrBlockWithContext(ctx aws.Context, _a0 *ec2.AssociateVpcCidrBlockInput, opts ...request.Option) (output *ec2.AssociateVpcCidrBlockOutput, err error) {
+	output = &ec2.AssociateVpcCidrBlockOutput{}
+	if err := _m.recorder.CheckErrorWithContext(ctx, "AssociateVpcCidrBlock"); err != nil {
+		return output, err
+	}
+	defer func() { err = applyRequestOptions(ctx, "AssociateVpcCidrBlock", _a0, output, err, opts) }()
+	_m.recorder.Record("AssociateVpcCidrBlock")
+	returns, exist := _m.recorder.giveRecordedOutput("AssociateVpcCidrBlock", _a0)
+	if exist {
+		assertedErr, _ := returns[1].(error)
+		return returns[0].(*ec2.AssociateVpcCidrBlockOutput), assertedErr
+	}
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.AssociateVpcCidrBlockOutput) }()
+	if err = _m.checkPermission("AssociateVpcCidrBlock", _a0, nil); err != nil {
+		return output, err
+	}
+	_m.settleTransitions()
+	vpc, ok := _m.vpcs[aws.StringValue(_a0.VpcId)]
+	if !ok {
+		return output, newAwsError("InvalidVpcID.NotFound", "The vpc ID '"+aws.StringValue(_a0.VpcId)+"' does not exist")
+	}
+	switch {
+	case _a0.CidrBlock != nil && aws.BoolValue(_a0.AmazonProvidedIpv6CidrBlock):
+		return output, newAwsError("InvalidParameterCombination", "The parameter cidrBlock cannot be used with the parameter amazonProvidedIpv6CidrBlock")
+	case _a0.CidrBlock == nil && !aws.BoolValue(_a0.AmazonProvidedIpv6CidrBlock):
+		return output, newAwsError("MissingParameter", "Either cidrBlock or amazonProvidedIpv6CidrBlock must be specified")
+	}
+	output.VpcId = vpc.VpcId
+
+	if aws.BoolValue(_a0.AmazonProvidedIpv6CidrBlock) {
+		for _, association := range vpc.Ipv6CidrBlockAssociationSet {
+			if aws.StringValue(association.Ipv6CidrBlockState.State) != ec2.VpcCidrBlockStateCodeDisassociating {
+				return output, newAwsError("CidrLimitExceeded", "This network '"+*vpc.VpcId+"' has met its maximum number of allowed CIDRs: 1")
+			}
+		}
+		association := &ec2.VpcIpv6CidrBlockAssociation{
+			AssociationId:      aws.String(GiveRandomId("vpc-cidr-assoc-")),
+			Ipv6CidrBlock:      aws.String(fmt.Sprintf("2600:1f18:%x:%x00::/56", rangeInInt(0x1000, 0xffff), rangeInInt(0x10, 0xff))),
+			Ipv6CidrBlockState: &ec2.VpcCidrBlockState{State: aws.String(ec2.VpcCidrBlockStateCodeAssociating)},
+		}
+		vpc.Ipv6CidrBlockAssociationSet = append(vpc.Ipv6CidrBlockAssociationSet, association)
+		_m.addLocalRoutes(*vpc.VpcId, &ec2.Route{
+			GatewayId:                aws.String("local"),
+			DestinationIpv6CidrBlock: association.Ipv6CidrBlock,
+			State:                    aws.String(ec2.RouteStateActive),
+			Origin:                   aws.String(ec2.RouteOriginCreateRouteTable),
+		})
+		_m.scheduleTransition(*association.AssociationId, CidrAssociating, func() {
+			association.Ipv6CidrBlockState.State = aws.String(ec2.VpcCidrBlockStateCodeAssociated)
+		})
+		output.Ipv6CidrBlockAssociation = association
+		return
+	}
+
+	ip, cidr, parseErr := net.ParseCIDR(*_a0.CidrBlock)
+	if parseErr != nil || ip.To4() == nil {
+		return output, newAwsError("InvalidParameterValue", "Value ("+*_a0.CidrBlock+") for parameter cidrBlock is invalid. This is not a valid CIDR block.")
+	}
+	if ones, _ := cidr.Mask.Size(); ones < minVpcPrefixLength || ones > maxVpcPrefixLength {
+		return output, newAwsError("InvalidVpc.Range", "The CIDR '"+*_a0.CidrBlock+"' is invalid.")
+	}
+	blocks := []*net.IPNet{}
+	for _, association := range vpc.CidrBlockAssociationSet {
+		if aws.StringValue(association.CidrBlockState.State) == ec2.VpcCidrBlockStateCodeDisassociating {
+			continue
+		}
+		if _, block, err := net.ParseCIDR(aws.StringValue(association.CidrBlock)); err == nil {
+			blocks = append(blocks, block)
+		}
+	}
+	if len(vpc.CidrBlockAssociationSet) == 0 {
+		blocks = vpcCidrBlocks(vpc)
+	}
+	if len(blocks) >= maxVpcCidrBlocks {
+		return output, newAwsError("CidrLimitExceeded", "This network '"+*vpc.VpcId+"' has met its maximum number of allowed CIDRs: "+strconv.Itoa(maxVpcCidrBlocks))
+	}
+	for _, block := range blocks {
+		if cidrsOverlap(block, cidr) {
+			return output, newAwsError("CidrConflict", "The CIDR '"+cidr.String()+"' conflicts with another CIDR block of the VPC '"+*vpc.VpcId+"'")
+		}
+	}
+	if _, primary, err := net.ParseCIDR(aws.StringValue(vpc.CidrBlock)); err == nil {
+		if privateRange := privateRangeOf(cidr); privateRange != nil && privateRange != privateRangeOf(primary) {
+			return output, newAwsError("InvalidVpc.Range", "The CIDR '"+cidr.String()+"' is restricted. Use a CIDR from the same private address range as the current VPC CIDR, or use a publicly-routable CIDR.")
+		}
+	}
+	association := &ec2.VpcCidrBlockAssociation{
+		AssociationId:  aws.String(GiveRandomId("vpc-cidr-assoc-")),
+		CidrBlock:      aws.String(cidr.String()),
+		CidrBlockState: &ec2.VpcCidrBlockState{State: aws.String(ec2.VpcCidrBlockStateCodeAssociating)},
+	}
+	if len(vpc.CidrBlockAssociationSet) == 0 && vpc.CidrBlock != nil {
+		// the appended vpc didn't list its primary block
+		vpc.CidrBlockAssociationSet = append(vpc.CidrBlockAssociationSet, &ec2.VpcCidrBlockAssociation{
+			AssociationId:  aws.String(GiveRandomId("vpc-cidr-assoc-")),
+			CidrBlock:      vpc.CidrBlock,
+			CidrBlockState: &ec2.VpcCidrBlockState{State: aws.String(ec2.VpcCidrBlockStateCodeAssociated)},
+		})
+	}
+	vpc.CidrBlockAssociationSet = append(vpc.CidrBlockAssociationSet, association)
+	_m.addLocalRoutes(*vpc.VpcId, &ec2.Route{
+		GatewayId:            aws.String("local"),
+		DestinationCidrBlock: association.CidrBlock,
+		State:                aws.String(ec2.RouteStateActive),
+		Origin:               aws.String(ec2.RouteOriginCreateRouteTable),
+	})
+	_m.scheduleTransition(*association.AssociationId, CidrAssociating, func() {
+		association.CidrBlockState.State = aws.String(ec2.VpcCidrBlockStateCodeAssociated)
+	})
+	output.CidrBlockAssociation = association
+	return
+}
+
+// DisassociateVpcCidrBlock provides a mock function with given fields: _a0
+func (_m *EC2API) DisassociateVpcCidrBlock(_a0 *ec2.DisassociateVpcCidrBlockInput) (*ec2.DisassociateVpcCidrBlockOutput, error) {
+	return _m.DisassociateVpcCidrBlockWithContext(aws.BackgroundContext(), _a0)
+}
+
+// DisassociateVpcCidrBlockWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) DisassociateVpcCidrBlockWithContext(ctx aws.Context, _a0 *ec2.DisassociateVpcCidrBlockInput, opts ...request.Option) (output *ec2.DisassociateVpcCidrBlockOutput, err error) {
+	output = &ec2.DisassociateVpcCidrBlockOutput{}
+	if err := _m.recorder.CheckErrorWithContext(ctx, "DisassociateVpcCidrBlock"); err != nil {
+		return output, err
+	}
+	defer func() { err = applyRequestOptions(ctx, "DisassociateVpcCidrBlock", _a0, output, err, opts) }()
+	_m.recorder.Record("DisassociateVpcCidrBlock")
+	returns, exist := _m.recorder.giveRecordedOutput("DisassociateVpcCidrBlock", _a0)
+	if exist {
+		assertedErr, _ := returns[1].(error)
+		return returns[0].(*ec2.DisassociateVpcCidrBlockOutput), assertedErr
+	}
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.DisassociateVpcCidrBlockOutput) }()
+	if err = _m.checkPermission("DisassociateVpcCidrBlock", _a0, nil); err != nil {
+		return output, err
+	}
+	_m.settleTransitions()
+	associationID := aws.StringValue(_a0.AssociationId)
+	for _, vpc := range _m.vpcs {
+		for _, association := range vpc.CidrBlockAssociationSet {
+			if aws.StringValue(association.AssociationId) != associationID {
+				continue
+			}
+			if aws.StringValue(association.CidrBlock) == aws.StringValue(vpc.CidrBlock) {
+				return output, newAwsError("OperationNotPermitted", "The vpc CIDR block with association ID "+associationID+" may not be disassociated. It is the primary IPv4 CIDR block of the VPC")
+			}
+			if aws.StringValue(association.CidrBlockState.State) != ec2.VpcCidrBlockStateCodeAssociated {
+				return output, newAwsError("IncorrectState", "The vpc CIDR block with association ID "+associationID+" is "+aws.StringValue(association.CidrBlockState.State))
+			}
+			if _m.cidrBlockInUse(*vpc.VpcId, *association.CidrBlock, func(subnet *ec2.Subnet) []*string {
+				return []*string{subnet.CidrBlock}
+			}) {
+				return output, newAwsError("DependencyViolation", "The vpc CIDR block with association ID "+associationID+" has dependencies and cannot be disassociated.")
+			}
+			vpc := vpc
+			association := association
+			association.CidrBlockState.State = aws.String(ec2.VpcCidrBlockStateCodeDisassociating)
+			_m.scheduleTransition(associationID, CidrDisassociating, func() {
+				_m.removeLocalRoutes(*vpc.VpcId, *association.CidrBlock)
+				vpc.CidrBlockAssociationSet = removeVpcCidrBlockAssociation(vpc.CidrBlockAssociationSet, association)
+			})
+			output.VpcId = vpc.VpcId
+			output.CidrBlockAssociation = association
+			return
+		}
+		for _, association := range vpc.Ipv6CidrBlockAssociationSet {
+			if aws.StringValue(association.AssociationId) != associationID {
+				continue
+			}
+			if aws.StringValue(association.Ipv6CidrBlockState.State) != ec2.VpcCidrBlockStateCodeAssociated {
+				return output, newAwsError("IncorrectState", "The vpc CIDR block with association ID "+associationID+" is "+aws.StringValue(association.Ipv6CidrBlockState.State))
+			}
+			if _m.cidrBlockInUse(*vpc.VpcId, *association.Ipv6CidrBlock, func(subnet *ec2.Subnet) []*string {
+				blocks := []*string{}
+				for _, subnetAssociation := range subnet.Ipv6CidrBlockAssociationSet {
+					blocks = append(blocks, subnetAssociation.Ipv6CidrBlock)
+				}
+				return blocks
+			}) {
+				return output, newAwsError("DependencyViolation", "The vpc CIDR block with association ID "+associationID+" has dependencies and cannot be disassociated.")
+			}
+			vpc := vpc
+			association := association
+			association.Ipv6CidrBlockState.State = aws.String(ec2.VpcCidrBlockStateCodeDisassociating)
+			_m.scheduleTransition(associationID, CidrDisassociating, func() {
+				_m.removeLocalRoutes(*vpc.VpcId, *association.Ipv6CidrBlock)
+				blocks := []*ec2.VpcIpv6CidrBlockAssociation{}
+				for _, other := range vpc.Ipv6CidrBlockAssociationSet {
+					if other != association {
+						blocks = append(blocks, other)
+					}
+				}
+				vpc.Ipv6CidrBlockAssociationSet = blocks
+			})
+			output.VpcId = vpc.VpcId
+			output.Ipv6CidrBlockAssociation = association
+			return
+		}
+	}
+	return output, newAwsError("InvalidVpcCidrBlockAssociationID.NotFound", "The vpc CIDR block association ID '"+associationID+"' does not exist")
+}
+
+func removeVpcCidrBlockAssociation(associations []*ec2.VpcCidrBlockAssociation, association *ec2.VpcCidrBlockAssociation) []*ec2.VpcCidrBlockAssociation {
+	kept := []*ec2.VpcCidrBlockAssociation{}
+	for _, other := range associations {
+		if other != association {
+			kept = append(kept, other)
+		}
+	}
+	return kept
+}
+
+// cidrBlockInUse tells whether a subnet of the vpc has a block, as given by
+// blocks, within cidrBlock.
+func (_m *EC2API) cidrBlockInUse(vpcID string, cidrBlock string, blocks func(subnet *ec2.Subnet) []*string) bool {
+	_, block, err := net.ParseCIDR(cidrBlock)
+	if err != nil {
+		return false
+	}
+	for _, subnet := range _m.subnets {
+		if aws.StringValue(subnet.VpcId) != vpcID {
+			continue
+		}
+		for _, subnetBlock := range blocks(subnet) {
+			if _, subnetCidr, err := net.ParseCIDR(aws.StringValue(subnetBlock)); err == nil && cidrsOverlap(block, subnetCidr) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// AssociateSubnetCidrBlock provides a mock function with given fields: _a0
+func (_m *EC2API) AssociateSubnetCidrBlock(_a0 *ec2.AssociateSubnetCidrBlockInput) (*ec2.AssociateSubnetCidrBlockOutput, error) {
+	return _m.AssociateSubnetCidrBlockWithContext(aws.BackgroundContext(), _a0)
+}
+
+// AssociateSubnetCidrBlockWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) AssociateSubnetCidrBlockWithContext(ctx aws.Context, _a0 *ec2.AssociateSubnetCidrBlockInput, opts ...request.Option) (output *ec2.AssociateSubnetCidrBlockOutput, err error) {
+	output = &ec2.AssociateSubnetCidrBlockOutput{}
+	if err := _m.recorder.CheckErrorWithContext(ctx, "AssociateSubnetCidrBlock"); err != nil {
+		return output, err
+	}
+	defer func() { err = applyRequestOptions(ctx, "AssociateSubnetCidrBlock", _a0, output, err, opts) }()
+	_m.recorder.Record("AssociateSubnetCidrBlock")
+	returns, exist := _m.recorder.giveRecordedOutput("AssociateSubnetCidrBlock", _a0)
+	if exist {
+		assertedErr, _ := returns[1].(error)
+		return returns[0].(*ec2.AssociateSubnetCidrBlockOutput), assertedErr
+	}
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.AssociateSubnetCidrBlockOutput) }()
+	if err = _m.checkPermission("AssociateSubnetCidrBlock", _a0, nil); err != nil {
+		return output, err
+	}
+	_m.settleTransitions()
+	subnet, ok := _m.subnets[aws.StringValue(_a0.SubnetId)]
+	if !ok {
+		return output, newAwsError("InvalidSubnetID.NotFound", "The subnet ID '"+aws.StringValue(_a0.SubnetId)+"' does not exist")
+	}
+	for _, association := range subnet.Ipv6CidrBlockAssociationSet {
+		if aws.StringValue(association.Ipv6CidrBlockState.State) != ec2.SubnetCidrBlockStateCodeDisassociating {
+			return output, newAwsError("CidrLimitExceeded", "The subnet '"+*subnet.SubnetId+"' already has an IPv6 CIDR block")
+		}
+	}
+	cidr, err := _m.subnetIpv6CidrBlock(_m.vpcs[aws.StringValue(subnet.VpcId)], aws.StringValue(_a0.Ipv6CidrBlock))
+	if err != nil {
+		return output, err
+	}
+	association := &ec2.SubnetIpv6CidrBlockAssociation{
+		AssociationId:      aws.String(GiveRandomId("subnet-cidr-assoc-")),
+		Ipv6CidrBlock:      aws.String(cidr.String()),
+		Ipv6CidrBlockState: &ec2.SubnetCidrBlockState{State: aws.String(ec2.SubnetCidrBlockStateCodeAssociating)},
+	}
+	subnet.Ipv6CidrBlockAssociationSet = append(subnet.Ipv6CidrBlockAssociationSet, association)
+	_m.scheduleTransition(*association.AssociationId, CidrAssociating, func() {
+		association.Ipv6CidrBlockState.State = aws.String(ec2.SubnetCidrBlockStateCodeAssociated)
+	})
+	output.SubnetId = subnet.SubnetId
+	output.Ipv6CidrBlockAssociation = association
+	return
+}
+
+// subnetIpv6CidrBlock validates the ipv6 block asked for a subnet of the vpc,
+// a /64 of one of the vpc's blocks no other subnet has.
+func (_m *EC2API) subnetIpv6CidrBlock(vpc *ec2.Vpc, cidrBlock string) (*net.IPNet, error) {
+	ip, cidr, err := net.ParseCIDR(cidrBlock)
+	if err != nil || ip.To4() != nil {
+		return nil, newAwsError("InvalidParameterValue", "Value ("+cidrBlock+") for parameter ipv6CidrBlock is invalid. This is not a valid IPv6 CIDR block.")
+	}
+	if ones, _ := cidr.Mask.Size(); ones != 64 {
+		return nil, newAwsError("InvalidSubnet.Range", "The IPv6 CIDR '"+cidrBlock+"' is invalid, a subnet takes a /64.")
+	}
+	if !anyCidrContains(vpcIpv6CidrBlocks(vpc), cidr) {
+		return nil, newAwsError("InvalidSubnet.Range", "The IPv6 CIDR '"+cidr.String()+"' is not within the IPv6 CIDR blocks of the VPC.")
+	}
+	if _m.cidrBlockInUse(*vpc.VpcId, cidr.String(), func(subnet *ec2.Subnet) []*string {
+		blocks := []*string{}
+		for _, association := range subnet.Ipv6CidrBlockAssociationSet {
+			blocks = append(blocks, association.Ipv6CidrBlock)
+		}
+		return blocks
+	}) {
+		return nil, newAwsError("InvalidSubnet.Conflict", "The CIDR '"+cidr.String()+"' conflicts with another subnet")
+	}
+	return cidr, nil
+}
+
+// DisassociateSubnetCidrBlock provides a mock function with given fields: _a0
+func (_m *EC2API) DisassociateSubnetCidrBlock(_a0 *ec2.DisassociateSubnetCidrBlockInput) (*ec2.DisassociateSubnetCidrBlockOutput, error) {
+	return _m.DisassociateSubnetCidrBlockWithContext(aws.BackgroundContext(), _a0)
+}
+
+// DisassociateSubnetCidrBlockWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) DisassociateSubnetCidrBlockWithContext(ctx aws.Context, _a0 *ec2.DisassociateSubnetCidrBlockInput, opts ...request.Option) (output *ec2.DisassociateSubnetCidrBlockOutput, err error) {
+	output = &ec2.DisassociateSubnetCidrBlockOutput{}
+	if err := _m.recorder.CheckErrorWithContext(ctx, "DisassociateSubnetCidrBlock"); err != nil {
+		return output, err
+	}
+	defer func() { err = applyRequestOptions(ctx, "DisassociateSubnetCidrBlock", _a0, output, err, opts) }()
+	_m.recorder.Record("DisassociateSubnetCidrBlock")
+	returns, exist := _m.recorder.giveRecordedOutput("DisassociateSubnetCidrBlock", _a0)
+	if exist {
+		assertedErr, _ := returns[1].(error)
+		return returns[0].(*ec2.DisassociateSubnetCidrBlockOutput), assertedErr
+	}
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.DisassociateSubnetCidrBlockOutput) }()
+	if err = _m.checkPermission("DisassociateSubnetCidrBlock", _a0, nil); err != nil {
+		return output, err
+	}
+	_m.settleTransitions()
+	associationID := aws.StringValue(_a0.AssociationId)
+	for _, subnet := range _m.subnets {
+		for _, association := range subnet.Ipv6CidrBlockAssociationSet {
+			if aws.StringValue(association.AssociationId) != associationID {
+				continue
+			}
+			if aws.StringValue(association.Ipv6CidrBlockState.State) != ec2.SubnetCidrBlockStateCodeAssociated {
+				return output, newAwsError("IncorrectState", "The subnet CIDR block with association ID "+associationID+" is "+aws.StringValue(association.Ipv6CidrBlockState.State))
+			}
+			if len(_m.assignedIpv6OnSubnet[*subnet.SubnetId]) != 0 {
+				return output, newAwsError("DependencyViolation", "The subnet CIDR block with association ID "+associationID+" has addresses in use")
+			}
+			subnet := subnet
+			association := association
+			association.Ipv6CidrBlockState.State = aws.String(ec2.SubnetCidrBlockStateCodeDisassociating)
+			_m.scheduleTransition(associationID, CidrDisassociating, func() {
+				blocks := []*ec2.SubnetIpv6CidrBlockAssociation{}
+				for _, other := range subnet.Ipv6CidrBlockAssociationSet {
+					if other != association {
+						blocks = append(blocks, other)
+					}
+				}
+				subnet.Ipv6CidrBlockAssociationSet = blocks
+			})
+			output.SubnetId = subnet.SubnetId
+			output.Ipv6CidrBlockAssociation = association
+			return
+		}
+	}
+	return output, newAwsError("InvalidSubnetCidrBlockAssociationID.NotFound", "The subnet CIDR block association ID '"+associationID+"' does not exist")
+}
+
+// reservedAddressesPerSubnet are the addresses ec2 keeps in every subnet: the
+// network address, the router, dns, one for future use and the broadcast.
+const reservedAddressesPerSubnet = 5
+
+// refreshAvailableIpAddressCount brings the count of the subnet in line
+// with the addresses taken from it.
+func (_m *EC2API) refreshAvailableIpAddressCount(subnet *ec2.Subnet) {
+	allocator, err := _m.ipAllocatorOf(subnet)
+	if err != nil {
+		return
+	}
+	subnet.AvailableIpAddressCount = aws.Int64(allocator.free)
+}
+
+// DeleteSubnet provides a mock function with given fields: _a0
+func (_m *EC2API) DeleteSubnet(_a0 *ec2.DeleteSubnetInput) (*ec2.DeleteSubnetOutput, error) {
+	return _m.DeleteSubnetWithContext(aws.BackgroundContext(), _a0)
+}
+
+// DeleteSubnetWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) DeleteSubnetWithContext(ctx aws.Context, _a0 *ec2.DeleteSubnetInput, opts ...request.Option) (output *ec2.DeleteSubnetOutput, err error) {
+	output = &ec2.DeleteSubnetOutput{}
+	if err := _m.recorder.CheckErrorWithContext(ctx, "DeleteSubnet"); err != nil {
+		return output, err
+	}
+	defer func() { err = applyRequestOptions(ctx, "DeleteSubnet", _a0, output, err, opts) }()
+	_m.recorder.Record("DeleteSubnet")
+	returns, exist := _m.recorder.giveRecordedOutput("DeleteSubnet", _a0)
+	if exist {
+		assertedErr, _ := returns[1].(error)
+		return returns[0].(*ec2.DeleteSubnetOutput), assertedErr
+	}
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.DeleteSubnetOutput) }()
+	if err = _m.checkPermission("DeleteSubnet", _a0, _a0.DryRun); err != nil {
+		return output, err
+	}
+	_m.settleTransitions()
+	subnetID := aws.StringValue(_a0.SubnetId)
+	subnet, ok := _m.subnets[subnetID]
+	if !ok {
+		return output, newAwsError("InvalidSubnetID.NotFound", "The subnet ID '"+subnetID+"' does not exist")
+	}
+	if _m.subnetHasDependencies(subnetID) {
+		return output, newAwsError("DependencyViolation", "The subnet '"+subnetID+"' has dependencies and cannot be deleted.")
+	}
+	vpcSubnets := []*ec2.Subnet{}
+	for _, other := range _m.vpcassocaiatedsubnet[*subnet.VpcId] {
+		if other != subnet {
+			vpcSubnets = append(vpcSubnets, other)
+		}
+	}
+	_m.vpcassocaiatedsubnet[*subnet.VpcId] = vpcSubnets
+	// the subnet's associations go with it
+	for _, networkAcl := range _m.networkAcls {
+		associations := []*ec2.NetworkAclAssociation{}
+		for _, association := range networkAcl.Associations {
+			if aws.StringValue(association.SubnetId) != subnetID {
+				associations = append(associations, association)
+			}
+		}
+		networkAcl.Associations = associations
+	}
+	for _, routeTable := range _m.routeTable {
+		associations := []*ec2.RouteTableAssociation{}
+		for _, association := range routeTable.Associations {
+			if aws.StringValue(association.SubnetId) != subnetID {
+				associations = append(associations, association)
+			}
+		}
+		routeTable.Associations = associations
+	}
+	delete(_m.assignedIpOnSubnet, subnetID)
+	delete(_m.assignedIpv6OnSubnet, subnetID)
+	delete(_m.subnets, subnetID)
+	return
+}
+
+// subnetHasDependencies tells whether network interfaces or instances are
+// left in the subnet.
+func (_m *EC2API) subnetHasDependencies(subnetID string) bool {
+	for _, networkInterface := range _m.networkinterfaces {
+		if aws.StringValue(networkInterface.SubnetId) == subnetID {
+			return true
+		}
+	}
+	for _, instance := range _m.createdEc2instances {
+		if aws.StringValue(instance.SubnetId) == subnetID && (instance.State == nil || aws.Int64Value(instance.State.Code) != TERMINATED) {
+			return true
+		}
+	}
+	return false
+}
+
+// ModifySubnetAttribute provides a mock function with given fields: _a0
+func (_m *EC2API) ModifySubnetAttribute(_a0 *ec2.ModifySubnetAttributeInput) (*ec2.ModifySubnetAttributeOutput, error) {
+	return _m.ModifySubnetAttributeWithContext(aws.BackgroundContext(), _a0)
+}
+
+// ModifySubnetAttributeWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) ModifySubnetAttributeWithContext(ctx aws.Context, _a0 *ec2.ModifySubnetAttributeInput, opts ...request.Option) (output *ec2.ModifySubnetAttributeOutput, err error) {
+	output = &ec2.ModifySubnetAttributeOutput{}
+	if err := _m.recorder.CheckErrorWithContext(ctx, "ModifySubnetAttribute"); err != nil {
+		return output, err
+	}
+	defer func() { err = applyRequestOptions(ctx, "ModifySubnetAttribute", _a0, output, err, opts) }()
+	_m.recorder.Record("ModifySubnetAttribute")
+	returns, exist := _m.recorder.giveRecordedOutput("ModifySubnetAttribute", _a0)
+	if exist {
+		assertedErr, _ := returns[1].(error)
+		return returns[0].(*ec2.ModifySubnetAttributeOutput), assertedErr
+	}
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.ModifySubnetAttributeOutput) }()
+	if err = _m.checkPermission("ModifySubnetAttribute", _a0, nil); err != nil {
+		return output, err
+	}
+	_m.settleTransitions()
+	subnet, ok := _m.subnets[aws.StringValue(_a0.SubnetId)]
+	if !ok {
+		return output, newAwsError("InvalidSubnetID.NotFound", "The subnet ID '"+aws.StringValue(_a0.SubnetId)+"' does not exist")
+	}
+	// ec2 takes exactly one attribute per request
+	switch {
+	case _a0.MapPublicIpOnLaunch != nil && _a0.AssignIpv6AddressOnCreation != nil:
+		return output, newAwsError("InvalidParameterCombination", "Fields for multiple attribute types specified: mapPublicIpOnLaunch, assignIpv6AddressOnCreation")
+	case _a0.MapPublicIpOnLaunch == nil && _a0.AssignIpv6AddressOnCreation == nil:
+		return output, newAwsError("InvalidParameterCombination", "No attributes specified.")
+	}
+	if _a0.MapPublicIpOnLaunch != nil {
+		subnet.MapPublicIpOnLaunch = aws.Bool(aws.BoolValue(_a0.MapPublicIpOnLaunch.Value))
+	}
+	if _a0.AssignIpv6AddressOnCreation != nil {
+		assign := aws.BoolValue(_a0.AssignIpv6AddressOnCreation.Value)
+		if assign && len(subnet.Ipv6CidrBlockAssociationSet) == 0 {
+			return output, newAwsError("InvalidParameterValue", "The subnet '"+*subnet.SubnetId+"' does not have an IPv6 CIDR block.")
+		}
+		subnet.AssignIpv6AddressOnCreation = aws.Bool(assign)
+	}
+	return
+}
+
+// DetachNetworkInterface provides a mock function with given fields: _a0
+func (_m *EC2API) DetachNetworkInterface(_a0 *ec2.DetachNetworkInterfaceInput) (*ec2.DetachNetworkInterfaceOutput, error) {
+	return _m.DetachNetworkInterfaceWithContext(aws.BackgroundContext(), _a0)
+}
+
+// DetachNetworkInterfaceWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) DetachNetworkInterfaceWithContext(ctx aws.Context, _a0 *ec2.DetachNetworkInterfaceInput, opts ...request.Option) (output *ec2.DetachNetworkInterfaceOutput, err error) {
+	output = &ec2.DetachNetworkInterfaceOutput{}
+	if err := _m.recorder.CheckErrorWithContext(ctx, "DetachNetworkInterface"); err != nil {
+		return output, err
+	}
+	defer func() { err = applyRequestOptions(ctx, "DetachNetworkInterface", _a0, output, err, opts) }()
+	_m.recorder.Record("DetachNetworkInterface")
+	returns, exist := _m.recorder.giveRecordedOutput("DetachNetworkInterface", _a0)
+	if exist {
+		assertedErr, _ := returns[1].(error)
+		return returns[0].(*ec2.DetachNetworkInterfaceOutput), assertedErr
+	}
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.DetachNetworkInterfaceOutput) }()
+	if err = _m.checkPermission("DetachNetworkInterface", _a0, _a0.DryRun); err != nil {
+		return output, err
+	}
+	_m.settleTransitions()
+	var ntwInterface *ec2.NetworkInterface
+	for _, candidate := range _m.networkinterfaces {
+		if candidate.Attachment != nil && aws.StringValue(candidate.Attachment.AttachmentId) == aws.StringValue(_a0.AttachmentId) {
+			ntwInterface = candidate
+			break
+		}
+	}
+	if ntwInterface == nil {
+		err = newAwsError("InvalidAttachmentID.NotFound", "The attachment ID '"+aws.StringValue(_a0.AttachmentId)+"' does not exist")
+		return
+	}
+	attachment := ntwInterface.Attachment
+	if aws.Int64Value(attachment.DeviceIndex) == 0 {
+		err = newAwsError("OperationNotPermitted", "The network interface at device index 0 cannot be detached.")
+		return
+	}
+	force := aws.BoolValue(_a0.Force)
+	if aws.StringValue(attachment.Status) == "detaching" && !force {
+		err = newAwsError("IncorrectState", "The attachment '"+aws.StringValue(_a0.AttachmentId)+"' is already detaching.")
+		return
+	}
+	if force {
+		_m.cancelTransition(*ntwInterface.NetworkInterfaceId)
+		_m.detachNetworkInterface(ntwInterface)
+		return
+	}
+	attachment.Status = aws.String("detaching")
+	_m.refreshInstanceNetworkInterface(ntwInterface)
+	_m.scheduleTransition(*ntwInterface.NetworkInterfaceId, NetworkInterfaceDetaching, func() {
+		if ntwInterface.Attachment != attachment {
+			return
+		}
+		_m.detachNetworkInterface(ntwInterface)
+	})
+	return
+}
+
+// detachNetworkInterface drops the interface from its instance and makes it
+// available again.
+func (_m *EC2API) detachNetworkInterface(ntwInterface *ec2.NetworkInterface) {
+	if instance, ok := _m.getInstance(aws.StringValue(ntwInterface.Attachment.InstanceId)); ok {
+		instanceInterfaces := []*ec2.InstanceNetworkInterface{}
+		for _, instanceInterface := range instance.NetworkInterfaces {
+			if aws.StringValue(instanceInterface.NetworkInterfaceId) != aws.StringValue(ntwInterface.NetworkInterfaceId) {
+				instanceInterfaces = append(instanceInterfaces, instanceInterface)
+			}
+		}
+		instance.NetworkInterfaces = instanceInterfaces
+	}
+	ntwInterface.Attachment = nil
+	ntwInterface.Status = aws.String("available")
+}
+
+// DescribeNetworkInterfaceAttribute provides a mock function with given fields: _a0
+func (_m *EC2API) DescribeNetworkInterfaceAttribute(_a0 *ec2.DescribeNetworkInterfaceAttributeInput) (*ec2.DescribeNetworkInterfaceAttributeOutput, error) {
+	return _m.DescribeNetworkInterfaceAttributeWithContext(aws.BackgroundContext(), _a0)
+}
+
+// DescribeNetworkInterfaceAttributeWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) DescribeNetworkInterfaceAttributeWithContext(ctx aws.Context, _a0 *ec2.DescribeNetworkInterfaceAttributeInput, opts ...request.Option) (output *ec2.DescribeNetworkInterfaceAttributeOutput, err error) {
+	output = &ec2.DescribeNetworkInterfaceAttributeOutput{}
+	if err := _m.recorder.CheckErrorWithContext(ctx, "DescribeNetworkInterfaceAttribute"); err != nil {
+		return output, err
+	}
+	defer func() { err = applyRequestOptions(ctx, "DescribeNetworkInterfaceAttribute", _a0, output, err, opts) }()
+	_m.recorder.Record("DescribeNetworkInterfaceAttribute")
+	returns, exist := _m.recorder.giveRecordedOutput("DescribeNetworkInterfaceAttribute", _a0)
+	if exist {
+		assertedErr, _ := returns[1].(error)
+		return returns[0].(*ec2.DescribeNetworkInterfaceAttributeOutput), assertedErr
+	}
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.DescribeNetworkInterfaceAttributeOutput) }()
+	_m.settleTransitions()
+	ntwInterface, ok := _m.networkinterfaces[aws.StringValue(_a0.NetworkInterfaceId)]
+	if !ok {
+		return output, newAwsError("InvalidNetworkInterfaceID.NotFound", "The networkInterface ID '"+aws.StringValue(_a0.NetworkInterfaceId)+"' does not exist")
+	}
+	switch aws.StringValue(_a0.Attribute) {
+	case ec2.NetworkInterfaceAttributeDescription:
+		output.Description = &ec2.AttributeValue{Value: ntwInterface.Description}
+	case ec2.NetworkInterfaceAttributeGroupSet:
+		output.Groups = ntwInterface.Groups
+	case ec2.NetworkInterfaceAttributeSourceDestCheck:
+		output.SourceDestCheck = &ec2.AttributeBooleanValue{Value: ntwInterface.SourceDestCheck}
+	case ec2.NetworkInterfaceAttributeAttachment:
+		output.Attachment = ntwInterface.Attachment
+	default:
+		return output, newAwsError("InvalidParameterValue", "Value ("+aws.StringValue(_a0.Attribute)+") for parameter attribute is invalid. Unknown attribute.")
+	}
+	output.NetworkInterfaceId = ntwInterface.NetworkInterfaceId
+	return
+}
+
+// ResetNetworkInterfaceAttribute provides a mock function with given fields: _a0
+func (_m *EC2API) ResetNetworkInterfaceAttribute(_a0 *ec2.ResetNetworkInterfaceAttributeInput) (*ec2.ResetNetworkInterfaceAttributeOutput, error) {
+	return _m.ResetNetworkInterfaceAttributeWithContext(aws.BackgroundContext(), _a0)
+}
+
+// ResetNetworkInterfaceAttributeWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) ResetNetworkInterfaceAttributeWithContext(ctx aws.Context, _a0 *ec2.ResetNetworkInterfaceAttributeInput, opts ...request.Option) (output *ec2.ResetNetworkInterfaceAttributeOutput, err error) {
+	output = &ec2.ResetNetworkInterfaceAttributeOutput{}
+	if err := _m.recorder.CheckErrorWithContext(ctx, "ResetNetworkInterfaceAttribute"); err != nil {
+		return output, err
+	}
+	defer func() { err = applyRequestOptions(ctx, "ResetNetworkInterfaceAttribute", _a0, output, err, opts) }()
+	_m.recorder.Record("ResetNetworkInterfaceAttribute")
+	returns, exist := _m.recorder.giveRecordedOutput("ResetNetworkInterfaceAttribute", _a0)
+	if exist {
+		assertedErr, _ := returns[1].(error)
+		return returns[0].(*ec2.ResetNetworkInterfaceAttributeOutput), assertedErr
+	}
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.ResetNetworkInterfaceAttributeOutput) }()
+	if err = _m.checkPermission("ResetNetworkInterfaceAttribute", _a0, _a0.DryRun); err != nil {
+		return output, err
+	}
+	_m.settleTransitions()
+	ntwInterface, ok := _m.networkinterfaces[aws.StringValue(_a0.NetworkInterfaceId)]
+	if !ok {
+		return output, newAwsError("InvalidNetworkInterfaceID.NotFound", "The networkInterface ID '"+aws.StringValue(_a0.NetworkInterfaceId)+"' does not exist")
+	}
+	// sourceDestCheck is the only attribute ec2 resets
+	ntwInterface.SourceDestCheck = aws.Bool(true)
+	_m.refreshInstanceNetworkInterface(ntwInterface)
+	return
+}
+
+// AssignIpv6Addresses provides a mock function with given fields: _a0
+func (_m *EC2API) AssignIpv6Addresses(_a0 *ec2.AssignIpv6AddressesInput) (*ec2.AssignIpv6AddressesOutput, error) {
+	return _m.AssignIpv6AddressesWithContext(aws.BackgroundContext(), _a0)
+}
+
+// AssignIpv6AddressesWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) AssignIpv6AddressesWithContext(ctx aws.Context, _a0 *ec2.AssignIpv6AddressesInput, opts ...request.Option) (output *ec2.AssignIpv6AddressesOutput, err error) {
+	output = &ec2.AssignIpv6AddressesOutput{}
+	if err := _m.recorder.CheckErrorWithContext(ctx, "AssignIpv6Addresses"); err != nil {
+		return output, err
+	}
+	defer func() { err = applyRequestOptions(ctx, "AssignIpv6Addresses", _a0, output, err, opts) }()
+	_m.recorder.Record("AssignIpv6Addresses")
+	returns, exist := _m.recorder.giveRecordedOutput("AssignIpv6Addresses", _a0)
+	if exist {
+		assertedErr, _ := returns[1].(error)
+		return returns[0].(*ec2.AssignIpv6AddressesOutput), assertedErr
+	}
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.AssignIpv6AddressesOutput) }()
+	if err = _m.checkPermission("AssignIpv6Addresses", _a0, nil); err != nil {
+		return output, err
+	}
+	_m.settleTransitions()
+	networkInterface, exist := _m.networkinterfaces[aws.StringValue(_a0.NetworkInterfaceId)]
+	if !exist {
+		err = newAwsError("InvalidNetworkInterfaceID.NotFound", "The networkInterface ID '"+aws.StringValue(_a0.NetworkInterfaceId)+"' does not exist")
+		return
+	}
+	if _a0.Ipv6AddressCount != nil && len(_a0.Ipv6Addresses) != 0 {
+		err = newAwsError("InvalidParameterCombination", "The parameter ipv6AddressCount cannot be used with the parameter ipv6Addresses")
+		return
+	}
+	subnet := _m.subnets[*networkInterface.SubnetId]
+	// on failure hand back every address booked so far
+	ipv6Ips := []string{}
+	defer func() {
+		if err != nil {
+			for _, ip := range ipv6Ips {
+				_m.releaseIpv6OnSubnet(*subnet.SubnetId, ip)
+			}
+		}
+	}()
+	for _, ip := range _a0.Ipv6Addresses {
+		var ipv6Ip string
+		if ipv6Ip, err = _m.assignIpv6OnSubnet(subnet, aws.StringValue(ip)); err != nil {
+			return
+		}
+		ipv6Ips = append(ipv6Ips, ipv6Ip)
+	}
+	for i := int64(0); i < aws.Int64Value(_a0.Ipv6AddressCount); i++ {
+		var ipv6Ip string
+		if ipv6Ip, err = _m.pickUnassignedIpv6(subnet); err != nil {
+			return
+		}
+		ipv6Ips = append(ipv6Ips, ipv6Ip)
+	}
+	for _, ipv6Ip := range ipv6Ips {
+		networkInterface.Ipv6Addresses = append(networkInterface.Ipv6Addresses, &ec2.NetworkInterfaceIpv6Address{
+			Ipv6Address: aws.String(ipv6Ip),
+		})
+		output.AssignedIpv6Addresses = append(output.AssignedIpv6Addresses, aws.String(ipv6Ip))
+	}
+	output.NetworkInterfaceId = networkInterface.NetworkInterfaceId
+	_m.refreshInstanceNetworkInterface(networkInterface)
+	return
+}
+
+// UnassignIpv6Addresses provides a mock function with given fields: _a0
+func (_m *EC2API) UnassignIpv6Addresses(_a0 *ec2.UnassignIpv6AddressesInput) (*ec2.UnassignIpv6AddressesOutput, error) {
+	return _m.UnassignIpv6AddressesWithContext(aws.BackgroundContext(), _a0)
+}
+
+// UnassignIpv6AddressesWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) UnassignIpv6AddressesWithContext(ctx aws.Context, _a0 *ec2.UnassignIpv6AddressesInput, opts ...request.Option) (output *ec2.UnassignIpv6AddressesOutput, err error) {
+	output = &ec2.UnassignIpv6AddressesOutput{}
+	if err := _m.recorder.CheckErrorWithContext(ctx, "UnassignIpv6Addresses"); err != nil {
+		return output, err
+	}
+	defer func() { err = applyRequestOptions(ctx, "UnassignIpv6Addresses", _a0, output, err, opts) }()
+	_m.recorder.Record("UnassignIpv6Addresses")
+	returns, exist := _m.recorder.giveRecordedOutput("UnassignIpv6Addresses", _a0)
+	if exist {
+		assertedErr, _ := returns[1].(error)
+		return returns[0].(*ec2.UnassignIpv6AddressesOutput), assertedErr
+	}
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.UnassignIpv6AddressesOutput) }()
+	if err = _m.checkPermission("UnassignIpv6Addresses", _a0, nil); err != nil {
+		return output, err
+	}
+	_m.settleTransitions()
+	networkInterface, exist := _m.networkinterfaces[aws.StringValue(_a0.NetworkInterfaceId)]
+	if !exist {
+		err = newAwsError("InvalidNetworkInterfaceID.NotFound", "The networkInterface ID '"+aws.StringValue(_a0.NetworkInterfaceId)+"' does not exist")
+		return
+	}
+	// check every address before touching the interface so a bad request
+	// leaves it as it was
+	unassigned := map[string]bool{}
+	for _, ip := range _a0.Ipv6Addresses {
+		parsed := net.ParseIP(aws.StringValue(ip))
+		found := false
+		for _, ipv6Ip := range networkInterface.Ipv6Addresses {
+			if parsed != nil && parsed.String() == aws.StringValue(ipv6Ip.Ipv6Address) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			err = newAwsError("InvalidParameterValue", "Some of the specified addresses are not assigned to interface "+aws.StringValue(_a0.NetworkInterfaceId))
+			return
+		}
+		unassigned[parsed.String()] = true
+	}
+	ipv6Ips := []*ec2.NetworkInterfaceIpv6Address{}
+	for _, ipv6Ip := range networkInterface.Ipv6Addresses {
+		if unassigned[aws.StringValue(ipv6Ip.Ipv6Address)] {
+			_m.releaseIpv6OnSubnet(*networkInterface.SubnetId, *ipv6Ip.Ipv6Address)
+			output.UnassignedIpv6Addresses = append(output.UnassignedIpv6Addresses, ipv6Ip.Ipv6Address)
+			continue
+		}
+		ipv6Ips = append(ipv6Ips, ipv6Ip)
+	}
+	networkInterface.Ipv6Addresses = ipv6Ips
+	output.NetworkInterfaceId = networkInterface.NetworkInterfaceId
+	_m.refreshInstanceNetworkInterface(networkInterface)
+	return
+}
+
+// AuthorizeSecurityGroupEgress provides a mock function with given fields: _a0
+func (_m *EC2API) AuthorizeSecurityGroupEgress(_a0 *ec2.AuthorizeSecurityGroupEgressInput) (*ec2.AuthorizeSecurityGroupEgressOutput, error) {
+	return _m.AuthorizeSecurityGroupEgressWithContext(aws.BackgroundContext(), _a0)
+}
+
+// AuthorizeSecurityGroupEgressWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) AuthorizeSecurityGroupEgressWithContext(ctx aws.Context, _a0 *ec2.AuthorizeSecurityGroupEgressInput, opts ...request.Option) (output *ec2.AuthorizeSecurityGroupEgressOutput, err error) {
+	output = &ec2.AuthorizeSecurityGroupEgressOutput{}
+	if err := _m.recorder.CheckErrorWithContext(ctx, "AuthorizeSecurityGroupEgress"); err != nil {
+		return output, err
+	}
+	defer func() { err = applyRequestOptions(ctx, "AuthorizeSecurityGroupEgress", _a0, output, err, opts) }()
+	_m.recorder.Record("AuthorizeSecurityGroupEgress")
+	returns, exist := _m.recorder.giveRecordedOutput("AuthorizeSecurityGroupEgress", _a0)
+	if exist {
+		assertedErr, _ := returns[1].(error)
+		return returns[0].(*ec2.AuthorizeSecurityGroupEgressOutput), assertedErr
+	}
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.AuthorizeSecurityGroupEgressOutput) }()
+	if err = _m.checkPermission("AuthorizeSecurityGroupEgress", _a0, _a0.DryRun); err != nil {
+		return output, err
+	}
+	_m.settleTransitions()
+	err = _m.changeSecurityGroupRules(_a0.GroupId, nil, true, _a0.IpPermissions, _a0.IpProtocol, _a0.FromPort, _a0.ToPort, _a0.CidrIp, _a0.SourceSecurityGroupName, _a0.SourceSecurityGroupOwnerId, authorizePermissions)
+	return
+}
+
+// RevokeSecurityGroupEgress provides a mock function with given fields: _a0
+func (_m *EC2API) RevokeSecurityGroupEgress(_a0 *ec2.RevokeSecurityGroupEgressInput) (*ec2.RevokeSecurityGroupEgressOutput, error) {
+	return _m.RevokeSecurityGroupEgressWithContext(aws.BackgroundContext(), _a0)
+}
+
+// RevokeSecurityGroupEgressWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) RevokeSecurityGroupEgressWithContext(ctx aws.Context, _a0 *ec2.RevokeSecurityGroupEgressInput, opts ...request.Option) (output *ec2.RevokeSecurityGroupEgressOutput, err error) {
+	output = &ec2.RevokeSecurityGroupEgressOutput{}
+	if err := _m.recorder.CheckErrorWithContext(ctx, "RevokeSecurityGroupEgress"); err != nil {
+		return output, err
+	}
+	defer func() { err = applyRequestOptions(ctx, "RevokeSecurityGroupEgress", _a0, output, err, opts) }()
+	_m.recorder.Record("RevokeSecurityGroupEgress")
+	returns, exist := _m.recorder.giveRecordedOutput("RevokeSecurityGroupEgress", _a0)
+	if exist {
+		assertedErr, _ := returns[1].(error)
+		return returns[0].(*ec2.RevokeSecurityGroupEgressOutput), assertedErr
+	}
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.RevokeSecurityGroupEgressOutput) }()
+	if err = _m.checkPermission("RevokeSecurityGroupEgress", _a0, _a0.DryRun); err != nil {
+		return output, err
+	}
+	_m.settleTransitions()
+	err = _m.changeSecurityGroupRules(_a0.GroupId, nil, true, _a0.IpPermissions, _a0.IpProtocol, _a0.FromPort, _a0.ToPort, _a0.CidrIp, _a0.SourceSecurityGroupName, _a0.SourceSecurityGroupOwnerId, revokePermissions)
+	return
+}
+
+// UpdateSecurityGroupRuleDescriptionsIngress provides a mock function with given fields: _a0
+func (_m *EC2API) UpdateSecurityGroupRuleDescriptionsIngress(_a0 *ec2.UpdateSecurityGroupRuleDescriptionsIngressInput) (*ec2.UpdateSecurityGroupRuleDescriptionsIngressOutput, error) {
+	return _m.UpdateSecurityGroupRuleDescriptionsIngressWithContext(aws.BackgroundContext(), _a0)
+}
+
+// UpdateSecurityGroupRuleDescriptionsIngressWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) UpdateSecurityGroupRuleDescriptionsIngressWithContext(ctx aws.Context, _a0 *ec2.UpdateSecurityGroupRuleDescriptionsIngressInput, opts ...request.Option) (output *ec2.UpdateSecurityGroupRuleDescriptionsIngressOutput, err error) {
+	output = &ec2.UpdateSecurityGroupRuleDescriptionsIngressOutput{}
+	if err := _m.recorder.CheckErrorWithContext(ctx, "UpdateSecurityGroupRuleDescriptionsIngress"); err != nil {
+		return output, err
+	}
+	defer func() {
+		err = applyRequestOptions(ctx, "UpdateSecurityGroupRuleDescriptionsIngress", _a0, output, err, opts)
+	}()
+	_m.recorder.Record("UpdateSecurityGroupRuleDescriptionsIngress")
+	returns, exist := _m.recorder.giveRecordedOutput("UpdateSecurityGroupRuleDescriptionsIngress", _a0)
+	if exist {
+		assertedErr, _ := returns[1].(error)
+		return returns[0].(*ec2.UpdateSecurityGroupRuleDescriptionsIngressOutput), assertedErr
+	}
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.UpdateSecurityGroupRuleDescriptionsIngressOutput) }()
+	if err = _m.checkPermission("UpdateSecurityGroupRuleDescriptionsIngress", _a0, _a0.DryRun); err != nil {
+		return output, err
+	}
+	_m.settleTransitions()
+	if err = _m.changeSecurityGroupRules(_a0.GroupId, _a0.GroupName, false, _a0.IpPermissions, nil, nil, nil, nil, nil, nil, describePermissions); err != nil {
+		return
+	}
+	output.Return = aws.Bool(true)
+	return
+}
+
+// UpdateSecurityGroupRuleDescriptionsEgress provides a mock function with given fields: _a0
+func (_m *EC2API) UpdateSecurityGroupRuleDescriptionsEgress(_a0 *ec2.UpdateSecurityGroupRuleDescriptionsEgressInput) (*ec2.UpdateSecurityGroupRuleDescriptionsEgressOutput, error) {
+	return _m.UpdateSecurityGroupRuleDescriptionsEgressWithContext(aws.BackgroundContext(), _a0)
+}
+
+// UpdateSecurityGroupRuleDescriptionsEgressWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) UpdateSecurityGroupRuleDescriptionsEgressWithContext(ctx aws.Context, _a0 *ec2.UpdateSecurityGroupRuleDescriptionsEgressInput, opts ...request.Option) (output *ec2.UpdateSecurityGroupRuleDescriptionsEgressOutput, err error) {
+	output = &ec2.UpdateSecurityGroupRuleDescriptionsEgressOutput{}
+	if err := _m.recorder.CheckErrorWithContext(ctx, "UpdateSecurityGroupRuleDescriptionsEgress"); err != nil {
+		return output, err
+	}
+	defer func() {
+		err = applyRequestOptions(ctx, "UpdateSecurityGroupRuleDescriptionsEgress", _a0, output, err, opts)
+	}()
+	_m.recorder.Record("UpdateSecurityGroupRuleDescriptionsEgress")
+	returns, exist := _m.recorder.giveRecordedOutput("UpdateSecurityGroupRuleDescriptionsEgress", _a0)
+	if exist {
+		assertedErr, _ := returns[1].(error)
+		return returns[0].(*ec2.UpdateSecurityGroupRuleDescriptionsEgressOutput), assertedErr
+	}
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.UpdateSecurityGroupRuleDescriptionsEgressOutput) }()
+	if err = _m.checkPermission("UpdateSecurityGroupRuleDescriptionsEgress", _a0, _a0.DryRun); err != nil {
+		return output, err
+	}
+	_m.settleTransitions()
+	if err = _m.changeSecurityGroupRules(_a0.GroupId, _a0.GroupName, true, _a0.IpPermissions, nil, nil, nil, nil, nil, nil, describePermissions); err != nil {
+		return
+	}
+	output.Return = aws.Bool(true)
+	return
+}
+
+// DescribeSecurityGroupReferences provides a mock function with given fields: _a0
+func (_m *EC2API) DescribeSecurityGroupReferences(_a0 *ec2.DescribeSecurityGroupReferencesInput) (*ec2.DescribeSecurityGroupReferencesOutput, error) {
+	return _m.DescribeSecurityGroupReferencesWithContext(aws.BackgroundContext(), _a0)
+}
+
+// DescribeSecurityGroupReferencesWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) DescribeSecurityGroupReferencesWithContext(ctx aws.Context, _a0 *ec2.DescribeSecurityGroupReferencesInput, opts ...request.Option) (output *ec2.DescribeSecurityGroupReferencesOutput, err error) {
+	output = &ec2.DescribeSecurityGroupReferencesOutput{}
+	if err := _m.recorder.CheckErrorWithContext(ctx, "DescribeSecurityGroupReferences"); err != nil {
+		return output, err
+	}
+	defer func() { err = applyRequestOptions(ctx, "DescribeSecurityGroupReferences", _a0, output, err, opts) }()
+	_m.recorder.Record("DescribeSecurityGroupReferences")
+	returns, exist := _m.recorder.giveRecordedOutput("DescribeSecurityGroupReferences", _a0)
+	if exist {
+		assertedErr, _ := returns[1].(error)
+		return returns[0].(*ec2.DescribeSecurityGroupReferencesOutput), assertedErr
+	}
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.DescribeSecurityGroupReferencesOutput) }()
+	_m.settleTransitions()
+	output.SecurityGroupReferenceSet = []*ec2.SecurityGroupReference{}
+	for _, groupId := range _a0.GroupId {
+		if _, ok := _m.assignedsecurityGroups[aws.StringValue(groupId)]; !ok {
+			return output, newAwsError("InvalidGroup.NotFound", "The security group '"+aws.StringValue(groupId)+"' does not exist")
+		}
+	}
+	// only groups of peered vpcs are reported, one reference per
+	// referencing vpc and peering connection
+	seen := map[string]bool{}
+	groupIds := make([]string, 0, len(_m.assignedsecurityGroups))
+	for groupId := range _m.assignedsecurityGroups {
+		groupIds = append(groupIds, groupId)
+	}
+	sort.Strings(groupIds)
+	for _, groupId := range _a0.GroupId {
+		for _, referencingId := range groupIds {
+			referencing := _m.assignedsecurityGroups[referencingId]
+			for _, single := range append(explodePermissions(referencing.IpPermissions), explodePermissions(referencing.IpPermissionsEgress)...) {
+				for _, pair := range single.UserIdGroupPairs {
+					if aws.StringValue(pair.GroupId) != *groupId || pair.VpcPeeringConnectionId == nil {
+						continue
+					}
+					key := *groupId + "/" + aws.StringValue(referencing.VpcId) + "/" + *pair.VpcPeeringConnectionId
+					if seen[key] {
+						continue
+					}
+					seen[key] = true
+					output.SecurityGroupReferenceSet = append(output.SecurityGroupReferenceSet, &ec2.SecurityGroupReference{
+						GroupId:                groupId,
+						ReferencingVpcId:       referencing.VpcId,
+						VpcPeeringConnectionId: pair.VpcPeeringConnectionId,
+					})
+				}
+			}
+		}
+	}
+	return
+}
+
+// DescribeStaleSecurityGroups provides a mock function with given fields: _a0
+func (_m *EC2API) DescribeStaleSecurityGroups(_a0 *ec2.DescribeStaleSecurityGroupsInput) (*ec2.DescribeStaleSecurityGroupsOutput, error) {
+	return _m.DescribeStaleSecurityGroupsWithContext(aws.BackgroundContext(), _a0)
+}
+
+// DescribeStaleSecurityGroupsWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) DescribeStaleSecurityGroupsWithContext(ctx aws.Context, _a0 *ec2.DescribeStaleSecurityGroupsInput, opts ...request.Option) (output *ec2.DescribeStaleSecurityGroupsOutput, err error) {
+	output = &ec2.DescribeStaleSecurityGroupsOutput{}
+	if err := _m.recorder.CheckErrorWithContext(ctx, "DescribeStaleSecurityGroups"); err != nil {
+		return output, err
+	}
+	defer func() { err = applyRequestOptions(ctx, "DescribeStaleSecurityGroups", _a0, output, err, opts) }()
+	_m.recorder.Record("DescribeStaleSecurityGroups")
+	returns, exist := _m.recorder.giveRecordedOutput("DescribeStaleSecurityGroups", _a0)
+	if exist {
+		assertedErr, _ := returns[1].(error)
+		return returns[0].(*ec2.DescribeStaleSecurityGroupsOutput), assertedErr
+	}
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.DescribeStaleSecurityGroupsOutput) }()
+	_m.settleTransitions()
+	if _, ok := _m.vpcs[aws.StringValue(_a0.VpcId)]; !ok {
+		return output, newAwsError("InvalidVpcID.NotFound", "The vpc ID '"+aws.StringValue(_a0.VpcId)+"' does not exist")
+	}
+	output.StaleSecurityGroupSet = []*ec2.StaleSecurityGroup{}
+	for _, securityGroup := range _m.assignedsecurityGroups {
+		if aws.StringValue(securityGroup.VpcId) != *_a0.VpcId {
+			continue
+		}
+		staleIngress := _m.staleIpPermissions(securityGroup.IpPermissions)
+		staleEgress := _m.staleIpPermissions(securityGroup.IpPermissionsEgress)
+		if len(staleIngress) == 0 && len(staleEgress) == 0 {
+			continue
+		}
+		output.StaleSecurityGroupSet = append(output.StaleSecurityGroupSet, &ec2.StaleSecurityGroup{
+			Description:              securityGroup.Description,
+			GroupId:                  securityGroup.GroupId,
+			GroupName:                securityGroup.GroupName,
+			StaleIpPermissions:       staleIngress,
+			StaleIpPermissionsEgress: staleEgress,
+			VpcId:                    securityGroup.VpcId,
+		})
+	}
+	sort.Slice(output.StaleSecurityGroupSet, func(i, j int) bool {
+		return *output.StaleSecurityGroupSet[i].GroupId < *output.StaleSecurityGroupSet[j].GroupId
+	})
+	start, end, nextToken, err := _m.page(len(output.StaleSecurityGroupSet), func(i int) string {
+		return *output.StaleSecurityGroupSet[i].GroupId
+	}, _a0.MaxResults, _a0.NextToken)
+	if err != nil {
+		return
+	}
+	output.StaleSecurityGroupSet = output.StaleSecurityGroupSet[start:end]
+	output.NextToken = nextToken
+	return
+}
+
+// CreateRouteTable provides a mock function with given fields: _a0
+func (_m *EC2API) CreateRouteTable(_a0 *ec2.CreateRouteTableInput) (*ec2.CreateRouteTableOutput, error) {
+	return _m.CreateRouteTableWithContext(aws.BackgroundContext(), _a0)
+}
+
+// CreateRouteTableWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) CreateRouteTableWithContext(ctx aws.Context, _a0 *ec2.CreateRouteTableInput, opts ...request.Option) (output *ec2.CreateRouteTableOutput, err error) {
+	output = &ec2.CreateRouteTableOutput{}
+	if err := _m.recorder.CheckErrorWithContext(ctx, "CreateRouteTable"); err != nil {
+		return output, err
+	}
+	defer func() { err = applyRequestOptions(ctx, "CreateRouteTable", _a0, output, err, opts) }()
+	_m.recorder.Record("CreateRouteTable")
+	returns, exist := _m.recorder.giveRecordedOutput("CreateRouteTable", _a0)
+	if exist {
+		assertedErr, _ := returns[1].(error)
+		return returns[0].(*ec2.CreateRouteTableOutput), assertedErr
+	}
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.CreateRouteTableOutput) }()
+	if err = _m.checkPermission("CreateRouteTable", _a0, _a0.DryRun); err != nil {
+		return output, err
+	}
+	_m.settleTransitions()
+	vpc, ok := _m.vpcs[aws.StringValue(_a0.VpcId)]
+	if !ok {
+		return output, newAwsError("InvalidVpcID.NotFound", "The vpc ID '"+aws.StringValue(_a0.VpcId)+"' does not exist")
+	}
+	// a new route table starts out with the local routes of the vpc
+	routes := []*ec2.Route{}
+	for _, routeTable := range _m.routeTable {
+		if aws.StringValue(routeTable.VpcId) != *vpc.VpcId {
+			continue
+		}
+		for _, association := range routeTable.Associations {
+			if !aws.BoolValue(association.Main) {
+				continue
+			}
+			for _, route := range routeTable.Routes {
+				if aws.StringValue(route.GatewayId) == "local" {
+					localRoute := *route
+					routes = append(routes, &localRoute)
+				}
+			}
+		}
+	}
+	routeTableID := GiveRandomId("rtb-")
+	routeTable := &ec2.RouteTable{
+		VpcId:           vpc.VpcId,
+		RouteTableId:    &routeTableID,
+		OwnerId:         aws.String(defaultOwnerId),
+		Associations:    []*ec2.RouteTableAssociation{},
+		PropagatingVgws: []*ec2.PropagatingVgw{},
+		Routes:          routes,
+		Tags:            []*ec2.Tag{},
+	}
+	_m.routeTable[routeTableID] = routeTable
+	output.RouteTable = routeTable
+	return
+}
+
+// DeleteRouteTable provides a mock function with given fields: _a0
+func (_m *EC2API) DeleteRouteTable(_a0 *ec2.DeleteRouteTableInput) (*ec2.DeleteRouteTableOutput, error) {
+	return _m.DeleteRouteTableWithContext(aws.BackgroundContext(), _a0)
+}
+
+// DeleteRouteTableWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) DeleteRouteTableWithContext(ctx aws.Context, _a0 *ec2.DeleteRouteTableInput, opts ...request.Option) (output *ec2.DeleteRouteTableOutput, err error) {
+	output = &ec2.DeleteRouteTableOutput{}
+	if err := _m.recorder.CheckErrorWithContext(ctx, "DeleteRouteTable"); err != nil {
+		return output, err
+	}
+	defer func() { err = applyRequestOptions(ctx, "DeleteRouteTable", _a0, output, err, opts) }()
+	_m.recorder.Record("DeleteRouteTable")
+	returns, exist := _m.recorder.giveRecordedOutput("DeleteRouteTable", _a0)
+	if exist {
+		assertedErr, _ := returns[1].(error)
+		return returns[0].(*ec2.DeleteRouteTableOutput), assertedErr
+	}
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.DeleteRouteTableOutput) }()
+	if err = _m.checkPermission("DeleteRouteTable", _a0, _a0.DryRun); err != nil {
+		return output, err
+	}
+	_m.settleTransitions()
+	routeTable, err := _m.routeTableOf(_a0.RouteTableId)
+	if err != nil {
+		return
+	}
+	if len(routeTable.Associations) != 0 {
+		return output, newAwsError("DependencyViolation", "The routeTable '"+*routeTable.RouteTableId+"' has dependencies and cannot be deleted.")
+	}
+	delete(_m.routeTable, *routeTable.RouteTableId)
+	return
+}
+
+// CreateRoute provides a mock function with given fields: _a0
+func (_m *EC2API) CreateRoute(_a0 *ec2.CreateRouteInput) (*ec2.CreateRouteOutput, error) {
+	return _m.CreateRouteWithContext(aws.BackgroundContext(), _a0)
+}
+
+// CreateRouteWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) CreateRouteWithContext(ctx aws.Context, _a0 *ec2.CreateRouteInput, opts ...request.Option) (output *ec2.CreateRouteOutput, err error) {
+	output = &ec2.CreateRouteOutput{}
+	if err := _m.recorder.CheckErrorWithContext(ctx, "CreateRoute"); err != nil {
+		return output, err
+	}
+	defer func() { err = applyRequestOptions(ctx, "CreateRoute", _a0, output, err, opts) }()
+	_m.recorder.Record("CreateRoute")
+	returns, exist := _m.recorder.giveRecordedOutput("CreateRoute", _a0)
+	if exist {
+		assertedErr, _ := returns[1].(error)
+		return returns[0].(*ec2.CreateRouteOutput), assertedErr
+	}
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.CreateRouteOutput) }()
+	if err = _m.checkPermission("CreateRoute", _a0, _a0.DryRun); err != nil {
+		return output, err
+	}
+	_m.settleTransitions()
+	routeTable, err := _m.routeTableOf(_a0.RouteTableId)
+	if err != nil {
+		return
+	}
+	route, err := routeDestination(_a0.DestinationCidrBlock, _a0.DestinationIpv6CidrBlock)
+	if err != nil {
+		return
+	}
+	if findRoute(routeTable, route) != -1 {
+		return output, newAwsError("RouteAlreadyExists", "The route identified by "+routeDestinationString(route)+" already exists.")
+	}
+	route.EgressOnlyInternetGatewayId = _a0.EgressOnlyInternetGatewayId
+	route.GatewayId = _a0.GatewayId
+	route.InstanceId = _a0.InstanceId
+	route.NatGatewayId = _a0.NatGatewayId
+	route.NetworkInterfaceId = _a0.NetworkInterfaceId
+	route.TransitGatewayId = _a0.TransitGatewayId
+	route.VpcPeeringConnectionId = _a0.VpcPeeringConnectionId
+	if err = _m.routeTarget(routeTable, route); err != nil {
+		return
+	}
+	route.Origin = aws.String(ec2.RouteOriginCreateRoute)
+	routeTable.Routes = append(routeTable.Routes, route)
+	output.Return = aws.Bool(true)
+	return
+}
+
+// ReplaceRoute provides a mock function with given fields: _a0
+func (_m *EC2API) ReplaceRoute(_a0 *ec2.ReplaceRouteInput) (*ec2.ReplaceRouteOutput, error) {
+	return _m.ReplaceRouteWithContext(aws.BackgroundContext(), _a0)
+}
+
+// ReplaceRouteWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) ReplaceRouteWithContext(ctx aws.Context, _a0 *ec2.ReplaceRouteInput, opts ...request.Option) (output *ec2.ReplaceRouteOutput, err error) {
+	output = &ec2.ReplaceRouteOutput{}
+	if err := _m.recorder.CheckErrorWithContext(ctx, "ReplaceRoute"); err != nil {
+		return output, err
+	}
+	defer func() { err = applyRequestOptions(ctx, "ReplaceRoute", _a0, output, err, opts) }()
+	_m.recorder.Record("ReplaceRoute")
+	returns, exist := _m.recorder.giveRecordedOutput("ReplaceRoute", _a0)
+	if exist {
+		assertedErr, _ := returns[1].(error)
+		return returns[0].(*ec2.ReplaceRouteOutput), assertedErr
+	}
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.ReplaceRouteOutput) }()
+	if err = _m.checkPermission("ReplaceRoute", _a0, _a0.DryRun); err != nil {
+		return output, err
+	}
+	_m.settleTransitions()
+	routeTable, err := _m.routeTableOf(_a0.RouteTableId)
+	if err != nil {
+		return
+	}
+	route, err := routeDestination(_a0.DestinationCidrBlock, _a0.DestinationIpv6CidrBlock)
+	if err != nil {
+		return
+	}
+	index := findRoute(routeTable, route)
+	if index == -1 {
+		return output, newAwsError("InvalidRoute.NotFound", "There is no route defined for '"+routeDestinationString(route)+"' in the route table. Use CreateRoute instead.")
+	}
+	if aws.StringValue(routeTable.Routes[index].GatewayId) == "local" {
+		return output, newAwsError("InvalidParameterValue", "cannot replace local route "+routeDestinationString(route)+" in route table "+*routeTable.RouteTableId)
+	}
+	route.EgressOnlyInternetGatewayId = _a0.EgressOnlyInternetGatewayId
+	route.GatewayId = _a0.GatewayId
+	route.InstanceId = _a0.InstanceId
+	route.NatGatewayId = _a0.NatGatewayId
+	route.NetworkInterfaceId = _a0.NetworkInterfaceId
+	route.TransitGatewayId = _a0.TransitGatewayId
+	route.VpcPeeringConnectionId = _a0.VpcPeeringConnectionId
+	if err = _m.routeTarget(routeTable, route); err != nil {
+		return
+	}
+	route.Origin = aws.String(ec2.RouteOriginCreateRoute)
+	routeTable.Routes[index] = route
+	return
+}
+
+// DeleteRoute provides a mock function with given fields: _a0
+func (_m *EC2API) DeleteRoute(_a0 *ec2.DeleteRouteInput) (*ec2.DeleteRouteOutput, error) {
+	return _m.DeleteRouteWithContext(aws.BackgroundContext(), _a0)
+}
+
+// DeleteRouteWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) DeleteRouteWithContext(ctx aws.Context, _a0 *ec2.DeleteRouteInput, opts ...request.Option) (output *ec2.DeleteRouteOutput, err error) {
+	output = &ec2.DeleteRouteOutput{}
+	if err := _m.recorder.CheckErrorWithContext(ctx, "DeleteRoute"); err != nil {
+		return output, err
+	}
+	defer func() { err = applyRequestOptions(ctx, "DeleteRoute", _a0, output, err, opts) }()
+	_m.recorder.Record("DeleteRoute")
+	returns, exist := _m.recorder.giveRecordedOutput("DeleteRoute", _a0)
+	if exist {
+		assertedErr, _ := returns[1].(error)
+		return returns[0].(*ec2.DeleteRouteOutput), assertedErr
+	}
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.DeleteRouteOutput) }()
+	if err = _m.checkPermission("DeleteRoute", _a0, _a0.DryRun); err != nil {
+		return output, err
+	}
+	_m.settleTransitions()
+	routeTable, err := _m.routeTableOf(_a0.RouteTableId)
+	if err != nil {
+		return
+	}
+	route, err := routeDestination(_a0.DestinationCidrBlock, _a0.DestinationIpv6CidrBlock)
+	if err != nil {
+		return
+	}
+	index := findRoute(routeTable, route)
+	if index == -1 {
+		return output, newAwsError("InvalidRoute.NotFound", "no route with destination-cidr-block "+routeDestinationString(route)+" in route table "+*routeTable.RouteTableId)
+	}
+	if aws.StringValue(routeTable.Routes[index].GatewayId) == "local" {
+		return output, newAwsError("InvalidParameterValue", "cannot remove local route "+routeDestinationString(route)+" in route table "+*routeTable.RouteTableId)
+	}
+	routeTable.Routes = append(routeTable.Routes[:index], routeTable.Routes[index+1:]...)
+	return
+}
+
+// AssociateRouteTable provides a mock function with given fields: _a0
+func (_m *EC2API) AssociateRouteTable(_a0 *ec2.AssociateRouteTableInput) (*ec2.AssociateRouteTableOutput, error) {
+	return _m.AssociateRouteTableWithContext(aws.BackgroundContext(), _a0)
+}
+
+// AssociateRouteTableWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) AssociateRouteTableWithContext(ctx aws.Context, _a0 *ec2.AssociateRouteTableInput, opts ...request.Option) (output *ec2.AssociateRouteTableOutput, err error) {
+	output = &ec2.AssociateRouteTableOutput{}
+	if err := _m.recorder.CheckErrorWithContext(ctx, "AssociateRouteTable"); err != nil {
+		return output, err
+	}
+	defer func() { err = applyRequestOptions(ctx, "AssociateRouteTable", _a0, output, err, opts) }()
+	_m.recorder.Record("AssociateRouteTable")
+	returns, exist := _m.recorder.giveRecordedOutput("AssociateRouteTable", _a0)
+	if exist {
+		assertedErr, _ := returns[1].(error)
+		return returns[0].(*ec2.AssociateRouteTableOutput), assertedErr
+	}
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.AssociateRouteTableOutput) }()
+	if err = _m.checkPermission("AssociateRouteTable", _a0, _a0.DryRun); err != nil {
+		return output, err
+	}
+	_m.settleTransitions()
+	routeTable, err := _m.routeTableOf(_a0.RouteTableId)
+	if err != nil {
+		return
+	}
+	subnet, ok := _m.subnets[aws.StringValue(_a0.SubnetId)]
+	if !ok {
+		return output, newAwsError("InvalidSubnetID.NotFound", "The subnet ID '"+aws.StringValue(_a0.SubnetId)+"' does not exist")
+	}
+	if aws.StringValue(subnet.VpcId) != aws.StringValue(routeTable.VpcId) {
+		return output, newAwsError("InvalidParameterValue", "route table "+*routeTable.RouteTableId+" and subnet "+*subnet.SubnetId+" belong to different networks")
+	}
+	// a subnet is explicitly associated with one route table at most
+	for _, other := range _m.routeTable {
+		for _, association := range other.Associations {
+			if aws.StringValue(association.SubnetId) == *subnet.SubnetId {
+				return output, newAwsError("Resource.AlreadyAssociated", "the specified association for route table "+*routeTable.RouteTableId+" conflicts with an existing association")
+			}
+		}
+	}
+	association := &ec2.RouteTableAssociation{
+		Main:                    aws.Bool(false),
+		RouteTableAssociationId: aws.String(GiveRandomId("rtbassoc-")),
+		RouteTableId:            routeTable.RouteTableId,
+		SubnetId:                subnet.SubnetId,
+	}
+	routeTable.Associations = append(routeTable.Associations, association)
+	output.AssociationId = association.RouteTableAssociationId
+	return
+}
+
+// DisassociateRouteTable provides a mock function with given fields: _a0
+func (_m *EC2API) DisassociateRouteTable(_a0 *ec2.DisassociateRouteTableInput) (*ec2.DisassociateRouteTableOutput, error) {
+	return _m.DisassociateRouteTableWithContext(aws.BackgroundContext(), _a0)
+}
+
+// DisassociateRouteTableWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) DisassociateRouteTableWithContext(ctx aws.Context, _a0 *ec2.DisassociateRouteTableInput, opts ...request.Option) (output *ec2.DisassociateRouteTableOutput, err error) {
+	output = &ec2.DisassociateRouteTableOutput{}
+	if err := _m.recorder.CheckErrorWithContext(ctx, "DisassociateRouteTable"); err != nil {
+		return output, err
+	}
+	defer func() { err = applyRequestOptions(ctx, "DisassociateRouteTable", _a0, output, err, opts) }()
+	_m.recorder.Record("DisassociateRouteTable")
+	returns, exist := _m.recorder.giveRecordedOutput("DisassociateRouteTable", _a0)
+	if exist {
+		assertedErr, _ := returns[1].(error)
+		return returns[0].(*ec2.DisassociateRouteTableOutput), assertedErr
+	}
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.DisassociateRouteTableOutput) }()
+	if err = _m.checkPermission("DisassociateRouteTable", _a0, _a0.DryRun); err != nil {
+		return output, err
+	}
+	_m.settleTransitions()
+	routeTable, association, err := _m.routeTableAssociationOf(_a0.AssociationId)
+	if err != nil {
+		return
+	}
+	if aws.BoolValue(association.Main) {
+		return output, newAwsError("InvalidParameterValue", "cannot disassociate the main route table association "+*association.RouteTableAssociationId)
+	}
+	associations := []*ec2.RouteTableAssociation{}
+	for _, other := range routeTable.Associations {
+		if other != association {
+			associations = append(associations, other)
+		}
+	}
+	routeTable.Associations = associations
+	return
+}
+
+// ReplaceRouteTableAssociation provides a mock function with given fields: _a0
+func (_m *EC2API) ReplaceRouteTableAssociation(_a0 *ec2.ReplaceRouteTableAssociationInput) (*ec2.ReplaceRouteTableAssociationOutput, error) {
+	return _m.ReplaceRouteTableAssociationWithContext(aws.BackgroundContext(), _a0)
+}
+
+// ReplaceRouteTableAssociationWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) ReplaceRouteTableAssociationWithContext(ctx aws.Context, _a0 *ec2.ReplaceRouteTableAssociationInput, opts ...request.Option) (output *ec2.ReplaceRouteTableAssociationOutput, err error) {
+	output = &ec2.ReplaceRouteTableAssociationOutput{}
+	if err := _m.recorder.CheckErrorWithContext(ctx, "ReplaceRouteTableAssociation"); err != nil {
+		return output, err
+	}
+	defer func() {
+		err = applyRequestOptions(ctx, "ReplaceRouteTableAssociation", _a0, output, err, opts)
+	}()
+	_m.recorder.Record("ReplaceRouteTableAssociation")
+	returns, exist := _m.recorder.giveRecordedOutput("ReplaceRouteTableAssociation", _a0)
+	if exist {
+		assertedErr, _ := returns[1].(error)
+		return returns[0].(*ec2.ReplaceRouteTableAssociationOutput), assertedErr
+	}
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.ReplaceRouteTableAssociationOutput) }()
+	if err = _m.checkPermission("ReplaceRouteTableAssociation", _a0, _a0.DryRun); err != nil {
+		return output, err
+	}
+	_m.settleTransitions()
+	oldRouteTable, association, err := _m.routeTableAssociationOf(_a0.AssociationId)
+	if err != nil {
+		return
+	}
+	routeTable, err := _m.routeTableOf(_a0.RouteTableId)
+	if err != nil {
+		return
+	}
+	if aws.StringValue(routeTable.VpcId) != aws.StringValue(oldRouteTable.VpcId) {
+		return output, newAwsError("InvalidParameterValue", "route table "+*routeTable.RouteTableId+" and association "+*association.RouteTableAssociationId+" belong to different networks")
+	}
+	associations := []*ec2.RouteTableAssociation{}
+	for _, other := range oldRouteTable.Associations {
+		if other != association {
+			associations = append(associations, other)
+		}
+	}
+	oldRouteTable.Associations = associations
+	// the association moves under a new id, the main one stays main
+	newAssociation := &ec2.RouteTableAssociation{
+		Main:                    association.Main,
+		RouteTableAssociationId: aws.String(GiveRandomId("rtbassoc-")),
+		RouteTableId:            routeTable.RouteTableId,
+		SubnetId:                association.SubnetId,
+	}
+	routeTable.Associations = append(routeTable.Associations, newAssociation)
+	output.NewAssociationId = newAssociation.RouteTableAssociationId
+	return
+}
+
+// CreateInternetGateway provides a mock function with given fields: _a0
+func (_m *EC2API) CreateInternetGateway(_a0 *ec2.CreateInternetGatewayInput) (*ec2.CreateInternetGatewayOutput, error) {
+	return _m.CreateInternetGatewayWithContext(aws.BackgroundContext(), _a0)
+}
+
+// CreateInternetGatewayWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) CreateInternetGatewayWithContext(ctx aws.Context, _a0 *ec2.CreateInternetGatewayInput, opts ...request.Option) (output *ec2.CreateInternetGatewayOutput, err error) {
+	output = &ec2.CreateInternetGatewayOutput{}
+	if err := _m.recorder.CheckErrorWithContext(ctx, "CreateInternetGateway"); err != nil {
+		return output, err
+	}
+	defer func() { err = applyRequestOptions(ctx, "CreateInternetGateway", _a0, output, err, opts) }()
+	_m.recorder.Record("CreateInternetGateway")
+	returns, exist := _m.recorder.giveRecordedOutput("CreateInternetGateway", _a0)
+	if exist {
+		assertedErr, _ := returns[1].(error)
+		return returns[0].(*ec2.CreateInternetGatewayOutput), assertedErr
+	}
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.CreateInternetGatewayOutput) }()
+	if err = _m.checkPermission("CreateInternetGateway", _a0, _a0.DryRun); err != nil {
+		return output, err
+	}
+	_m.settleTransitions()
+	internetGatewayID := GiveRandomId("igw-")
+	internetGateway := &ec2.InternetGateway{
+		InternetGatewayId: &internetGatewayID,
+		OwnerId:           aws.String(defaultOwnerId),
+		Attachments:       []*ec2.InternetGatewayAttachment{},
+		Tags:              []*ec2.Tag{},
+	}
+	_m.internetGateways[internetGatewayID] = internetGateway
+	output.InternetGateway = internetGateway
+	return
+}
+
+// AttachInternetGateway provides a mock function with given fields: _a0
+func (_m *EC2API) AttachInternetGateway(_a0 *ec2.AttachInternetGatewayInput) (*ec2.AttachInternetGatewayOutput, error) {
+	return _m.AttachInternetGatewayWithContext(aws.BackgroundContext(), _a0)
+}
+
+// AttachInternetGatewayWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) AttachInternetGatewayWithContext(ctx aws.Context, _a0 *ec2.AttachInternetGatewayInput, opts ...request.Option) (output *ec2.AttachInternetGatewayOutput, err error) {
+	output = &ec2.AttachInternetGatewayOutput{}
+	if err := _m.recorder.CheckErrorWithContext(ctx, "AttachInternetGateway"); err != nil {
+		return output, err
+	}
+	defer func() { err = applyRequestOptions(ctx, "AttachInternetGateway", _a0, output, err, opts) }()
+	_m.recorder.Record("AttachInternetGateway")
+	returns, exist := _m.recorder.giveRecordedOutput("AttachInternetGateway", _a0)
+	if exist {
+		assertedErr, _ := returns[1].(error)
+		return returns[0].(*ec2.AttachInternetGatewayOutput), assertedErr
+	}
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.AttachInternetGatewayOutput) }()
+	if err = _m.checkPermission("AttachInternetGateway", _a0, _a0.DryRun); err != nil {
+		return output, err
+	}
+	_m.settleTransitions()
+	internetGateway, err := _m.internetGatewayOf(_a0.InternetGatewayId)
+	if err != nil {
+		return
+	}
+	vpc, ok := _m.vpcs[aws.StringValue(_a0.VpcId)]
+	if !ok {
+		return output, newAwsError("InvalidVpcID.NotFound", "The vpc ID '"+aws.StringValue(_a0.VpcId)+"' does not exist")
+	}
+	if len(internetGateway.Attachments) != 0 {
+		return output, newAwsError("Resource.AlreadyAssociated", "resource "+*internetGateway.InternetGatewayId+" is already attached to network "+*internetGateway.Attachments[0].VpcId)
+	}
+	// a vpc takes one internet gateway at most
+	if other := _m.vpcInternetGateway(*vpc.VpcId); other != nil {
+		return output, newAwsError("Resource.AlreadyAssociated", "Network "+*vpc.VpcId+" already has an internet gateway attached")
+	}
+	internetGateway.Attachments = []*ec2.InternetGatewayAttachment{
+		&ec2.InternetGatewayAttachment{
+			State: aws.String(internetGatewayAttached),
+			VpcId: vpc.VpcId,
+		},
+	}
+	// routes through the gateway work again once it is back
+	for _, routeTable := range _m.routeTable {
+		if aws.StringValue(routeTable.VpcId) != *vpc.VpcId {
+			continue
+		}
+		for _, route := range routeTable.Routes {
+			if aws.StringValue(route.GatewayId) == *internetGateway.InternetGatewayId {
+				route.State = aws.String(ec2.RouteStateActive)
+			}
+		}
+	}
+	return
+}
+
+// DetachInternetGateway provides a mock function with given fields: _a0
+func (_m *EC2API) DetachInternetGateway(_a0 *ec2.DetachInternetGatewayInput) (*ec2.DetachInternetGatewayOutput, error) {
+	return _m.DetachInternetGatewayWithContext(aws.BackgroundContext(), _a0)
+}
+
+// DetachInternetGatewayWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) DetachInternetGatewayWithContext(ctx aws.Context, _a0 *ec2.DetachInternetGatewayInput, opts ...request.Option) (output *ec2.DetachInternetGatewayOutput, err error) {
+	output = &ec2.DetachInternetGatewayOutput{}
+	if err := _m.recorder.CheckErrorWithContext(ctx, "DetachInternetGateway"); err != nil {
+		return output, err
+	}
+	defer func() { err = applyRequestOptions(ctx, "DetachInternetGateway", _a0, output, err, opts) }()
+	_m.recorder.Record("DetachInternetGateway")
+	returns, exist := _m.recorder.giveRecordedOutput("DetachInternetGateway", _a0)
+	if exist {
+		assertedErr, _ := returns[1].(error)
+		return returns[0].(*ec2.DetachInternetGatewayOutput), assertedErr
+	}
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.DetachInternetGatewayOutput) }()
+	if err = _m.checkPermission("DetachInternetGateway", _a0, _a0.DryRun); err != nil {
+		return output, err
+	}
+	_m.settleTransitions()
+	internetGateway, err := _m.internetGatewayOf(_a0.InternetGatewayId)
+	if err != nil {
+		return
+	}
+	vpcID := aws.StringValue(_a0.VpcId)
+	if !attachedToVpc(internetGateway.Attachments, vpcID) {
+		return output, newAwsError("Gateway.NotAttached", "resource "+*internetGateway.InternetGatewayId+" is not attached to network "+vpcID)
+	}
+	if _m.vpcHasPublicIps(vpcID) {
+		return output, newAwsError("DependencyViolation", "Network "+vpcID+" has some mapped public address(es). Please unmap those public address(es) before detaching the gateway.")
+	}
+	internetGateway.Attachments = []*ec2.InternetGatewayAttachment{}
+	_m.blackholeRoutes(func(route *ec2.Route) bool {
+		return aws.StringValue(route.GatewayId) == *internetGateway.InternetGatewayId
+	})
+	return
+}
+
+// DeleteInternetGateway provides a mock function with given fields: _a0
+func (_m *EC2API) DeleteInternetGateway(_a0 *ec2.DeleteInternetGatewayInput) (*ec2.DeleteInternetGatewayOutput, error) {
+	return _m.DeleteInternetGatewayWithContext(aws.BackgroundContext(), _a0)
+}
+
+// DeleteInternetGatewayWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) DeleteInternetGatewayWithContext(ctx aws.Context, _a0 *ec2.DeleteInternetGatewayInput, opts ...request.Option) (output *ec2.DeleteInternetGatewayOutput, err error) {
+	output = &ec2.DeleteInternetGatewayOutput{}
+	if err := _m.recorder.CheckErrorWithContext(ctx, "DeleteInternetGateway"); err != nil {
+		return output, err
+	}
+	defer func() { err = applyRequestOptions(ctx, "DeleteInternetGateway", _a0, output, err, opts) }()
+	_m.recorder.Record("DeleteInternetGateway")
+	returns, exist := _m.recorder.giveRecordedOutput("DeleteInternetGateway", _a0)
+	if exist {
+		assertedErr, _ := returns[1].(error)
+		return returns[0].(*ec2.DeleteInternetGatewayOutput), assertedErr
+	}
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.DeleteInternetGatewayOutput) }()
+	if err = _m.checkPermission("DeleteInternetGateway", _a0, _a0.DryRun); err != nil {
+		return output, err
+	}
+	_m.settleTransitions()
+	internetGateway, err := _m.internetGatewayOf(_a0.InternetGatewayId)
+	if err != nil {
+		return
+	}
+	if len(internetGateway.Attachments) != 0 {
+		return output, newAwsError("DependencyViolation", "The internetGateway '"+*internetGateway.InternetGatewayId+"' has dependencies and cannot be deleted.")
+	}
+	delete(_m.internetGateways, *internetGateway.InternetGatewayId)
+	return
+}
+
+// DescribeInternetGateways provides a mock function with given fields: _a0
+func (_m *EC2API) DescribeInternetGateways(_a0 *ec2.DescribeInternetGatewaysInput) (*ec2.DescribeInternetGatewaysOutput, error) {
+	return _m.DescribeInternetGatewaysWithContext(aws.BackgroundContext(), _a0)
+}
+
+// DescribeInternetGatewaysWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) DescribeInternetGatewaysWithContext(ctx aws.Context, _a0 *ec2.DescribeInternetGatewaysInput, opts ...request.Option) (output *ec2.DescribeInternetGatewaysOutput, err error) {
+	output = &ec2.DescribeInternetGatewaysOutput{}
+	if err := _m.recorder.CheckErrorWithContext(ctx, "DescribeInternetGateways"); err != nil {
+		return output, err
+	}
+	defer func() { err = applyRequestOptions(ctx, "DescribeInternetGateways", _a0, output, err, opts) }()
+	_m.recorder.Record("DescribeInternetGateways")
+	returns, exist := _m.recorder.giveRecordedOutput("DescribeInternetGateways", _a0)
+	if exist {
+		assertedErr, _ := returns[1].(error)
+		return returns[0].(*ec2.DescribeInternetGatewaysOutput), assertedErr
+	}
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.DescribeInternetGatewaysOutput) }()
+	_m.settleTransitions()
+	if err = internetGatewayFilter.validate(_a0.Filters); err != nil {
+		return
+	}
+	for _, id := range _a0.InternetGatewayIds {
+		if _, err = _m.internetGatewayOf(id); err != nil {
+			return
+		}
+	}
+	output.InternetGateways = []*ec2.InternetGateway{}
+	for _, internetGateway := range _m.internetGateways {
+		if len(_a0.InternetGatewayIds) != 0 {
+			if exist, _ := in_array(*internetGateway.InternetGatewayId, aws.StringValueSlice(_a0.InternetGatewayIds)); !exist {
+				continue
+			}
+		}
+		if internetGatewayFilter.match(internetGateway, _a0.Filters) {
+			output.InternetGateways = append(output.InternetGateways, internetGateway)
+		}
+	}
+	sort.Slice(output.InternetGateways, func(i, j int) bool {
+		return *output.InternetGateways[i].InternetGatewayId < *output.InternetGateways[j].InternetGatewayId
+	})
+	start, end, nextToken, err := _m.page(len(output.InternetGateways), func(i int) string {
+		return *output.InternetGateways[i].InternetGatewayId
+	}, _a0.MaxResults, _a0.NextToken)
+	if err != nil {
+		return
+	}
+	output.InternetGateways = output.InternetGateways[start:end]
+	output.NextToken = nextToken
+	return
+}
+
+// CreateEgressOnlyInternetGateway provides a mock function with given fields: _a0
+func (_m *EC2API) CreateEgressOnlyInternetGateway(_a0 *ec2.CreateEgressOnlyInternetGatewayInput) (*ec2.CreateEgressOnlyInternetGatewayOutput, error) {
+	return _m.CreateEgressOnlyInternetGatewayWithContext(aws.BackgroundContext(), _a0)
+}
+
+// CreateEgressOnlyInternetGatewayWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) CreateEgressOnlyInternetGatewayWithContext(ctx aws.Context, _a0 *ec2.CreateEgressOnlyInternetGatewayInput, opts ...request.Option) (output *ec2.CreateEgressOnlyInternetGatewayOutput, err error) {
+	output = &ec2.CreateEgressOnlyInternetGatewayOutput{}
+	if err := _m.recorder.CheckErrorWithContext(ctx, "CreateEgressOnlyInternetGateway"); err != nil {
+		return output, err
+	}
+	defer func() {
+		err = applyRequestOptions(ctx, "CreateEgressOnlyInternetGateway", _a0, output, err, opts)
+	}()
+	_m.recorder.Record("CreateEgressOnlyInternetGateway")
+	returns, exist := _m.recorder.giveRecordedOutput("CreateEgressOnlyInternetGateway", _a0)
+	if exist {
+		assertedErr, _ := returns[1].(error)
+		return returns[0].(*ec2.CreateEgressOnlyInternetGatewayOutput), assertedErr
+	}
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.CreateEgressOnlyInternetGatewayOutput) }()
+	if err = _m.checkPermission("CreateEgressOnlyInternetGateway", _a0, _a0.DryRun); err != nil {
+		return output, err
+	}
+	_m.settleTransitions()
+	vpc, ok := _m.vpcs[aws.StringValue(_a0.VpcId)]
+	if !ok {
+		return output, newAwsError("InvalidVpcID.NotFound", "The vpc ID '"+aws.StringValue(_a0.VpcId)+"' does not exist")
+	}
+	egressOnlyInternetGatewayID := GiveRandomId("eigw-")
+	egressOnlyInternetGateway := &ec2.EgressOnlyInternetGateway{
+		EgressOnlyInternetGatewayId: &egressOnlyInternetGatewayID,
+		Attachments: []*ec2.InternetGatewayAttachment{
+			&ec2.InternetGatewayAttachment{
+				State: aws.String(ec2.AttachmentStatusAttached),
+				VpcId: vpc.VpcId,
+			},
+		},
+	}
+	_m.egressOnlyGateways[egressOnlyInternetGatewayID] = egressOnlyInternetGateway
+	output.ClientToken = _a0.ClientToken
+	output.EgressOnlyInternetGateway = egressOnlyInternetGateway
+	return
+}
+
+// DeleteEgressOnlyInternetGateway provides a mock function with given fields: _a0
+func (_m *EC2API) DeleteEgressOnlyInternetGateway(_a0 *ec2.DeleteEgressOnlyInternetGatewayInput) (*ec2.DeleteEgressOnlyInternetGatewayOutput, error) {
+	return _m.DeleteEgressOnlyInternetGatewayWithContext(aws.BackgroundContext(), _a0)
+}
+
+// DeleteEgressOnlyInternetGatewayWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) DeleteEgressOnlyInternetGatewayWithContext(ctx aws.Context, _a0 *ec2.DeleteEgressOnlyInternetGatewayInput, opts ...request.Option) (output *ec2.DeleteEgressOnlyInternetGatewayOutput, err error) {
+	output = &ec2.DeleteEgressOnlyInternetGatewayOutput{}
+	if err := _m.recorder.CheckErrorWithContext(ctx, "DeleteEgressOnlyInternetGateway"); err != nil {
+		return output, err
+	}
+	defer func() {
+		err = applyRequestOptions(ctx, "DeleteEgressOnlyInternetGateway", _a0, output, err, opts)
+	}()
+	_m.recorder.Record("DeleteEgressOnlyInternetGateway")
+	returns, exist := _m.recorder.giveRecordedOutput("DeleteEgressOnlyInternetGateway", _a0)
+	if exist {
+		assertedErr, _ := returns[1].(error)
+		return returns[0].(*ec2.DeleteEgressOnlyInternetGatewayOutput), assertedErr
+	}
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.DeleteEgressOnlyInternetGatewayOutput) }()
+	if err = _m.checkPermission("DeleteEgressOnlyInternetGateway", _a0, _a0.DryRun); err != nil {
+		return output, err
+	}
+	_m.settleTransitions()
+	egressOnlyInternetGateway, err := _m.egressOnlyInternetGatewayOf(_a0.EgressOnlyInternetGatewayId)
+	if err != nil {
+		return
+	}
+	delete(_m.egressOnlyGateways, *egressOnlyInternetGateway.EgressOnlyInternetGatewayId)
+	_m.blackholeRoutes(func(route *ec2.Route) bool {
+		return aws.StringValue(route.EgressOnlyInternetGatewayId) == *egressOnlyInternetGateway.EgressOnlyInternetGatewayId
+	})
+	output.ReturnCode = aws.Bool(true)
+	return
+}
+
+// DescribeEgressOnlyInternetGateways provides a mock function with given fields: _a0
+func (_m *EC2API) DescribeEgressOnlyInternetGateways(_a0 *ec2.DescribeEgressOnlyInternetGatewaysInput) (*ec2.DescribeEgressOnlyInternetGatewaysOutput, error) {
+	return _m.DescribeEgressOnlyInternetGatewaysWithContext(aws.BackgroundContext(), _a0)
+}
+
+// DescribeEgressOnlyInternetGatewaysWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) DescribeEgressOnlyInternetGatewaysWithContext(ctx aws.Context, _a0 *ec2.DescribeEgressOnlyInternetGatewaysInput, opts ...request.Option) (output *ec2.DescribeEgressOnlyInternetGatewaysOutput, err error) {
+	output = &ec2.DescribeEgressOnlyInternetGatewaysOutput{}
+	if err := _m.recorder.CheckErrorWithContext(ctx, "DescribeEgressOnlyInternetGateways"); err != nil {
+		return output, err
+	}
+	defer func() {
+		err = applyRequestOptions(ctx, "DescribeEgressOnlyInternetGateways", _a0, output, err, opts)
+	}()
+	_m.recorder.Record("DescribeEgressOnlyInternetGateways")
+	returns, exist := _m.recorder.giveRecordedOutput("DescribeEgressOnlyInternetGateways", _a0)
+	if exist {
+		assertedErr, _ := returns[1].(error)
+		return returns[0].(*ec2.DescribeEgressOnlyInternetGatewaysOutput), assertedErr
+	}
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.DescribeEgressOnlyInternetGatewaysOutput) }()
+	_m.settleTransitions()
+	for _, id := range _a0.EgressOnlyInternetGatewayIds {
+		if _, err = _m.egressOnlyInternetGatewayOf(id); err != nil {
+			return
+		}
+	}
+	output.EgressOnlyInternetGateways = []*ec2.EgressOnlyInternetGateway{}
+	for _, egressOnlyInternetGateway := range _m.egressOnlyGateways {
+		if len(_a0.EgressOnlyInternetGatewayIds) != 0 {
+			if exist, _ := in_array(*egressOnlyInternetGateway.EgressOnlyInternetGatewayId, aws.StringValueSlice(_a0.EgressOnlyInternetGatewayIds)); !exist {
+				continue
+			}
+		}
+		output.EgressOnlyInternetGateways = append(output.EgressOnlyInternetGateways, egressOnlyInternetGateway)
+	}
+	sort.Slice(output.EgressOnlyInternetGateways, func(i, j int) bool {
+		return *output.EgressOnlyInternetGateways[i].EgressOnlyInternetGatewayId < *output.EgressOnlyInternetGateways[j].EgressOnlyInternetGatewayId
+	})
+	start, end, nextToken, err := _m.page(len(output.EgressOnlyInternetGateways), func(i int) string {
+		return *output.EgressOnlyInternetGateways[i].EgressOnlyInternetGatewayId
+	}, _a0.MaxResults, _a0.NextToken)
+	if err != nil {
+		return
+	}
+	output.EgressOnlyInternetGateways = output.EgressOnlyInternetGateways[start:end]
+	output.NextToken = nextToken
+	return
+}
+
+// CreateNatGateway provides a mock function with given fields: _a0
+func (_m *EC2API) CreateNatGateway(_a0 *ec2.CreateNatGatewayInput) (*ec2.CreateNatGatewayOutput, error) {
+	return _m.CreateNatGatewayWithContext(aws.BackgroundContext(), _a0)
+}
+
+// CreateNatGatewayWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) CreateNatGatewayWithContext(ctx aws.Context, _a0 *ec2.CreateNatGatewayInput, opts ...request.Option) (output *ec2.CreateNatGatewayOutput, err error) {
+	output = &ec2.CreateNatGatewayOutput{}
+	if err := _m.recorder.CheckErrorWithContext(ctx, "CreateNatGateway"); err != nil {
+		return output, err
+	}
+	defer func() { err = applyRequestOptions(ctx, "CreateNatGateway", _a0, output, err, opts) }()
+	_m.recorder.Record("CreateNatGateway")
+	returns, exist := _m.recorder.giveRecordedOutput("CreateNatGateway", _a0)
+	if exist {
+		assertedErr, _ := returns[1].(error)
+		return returns[0].(*ec2.CreateNatGatewayOutput), assertedErr
+	}
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.CreateNatGatewayOutput) }()
+	if err = _m.checkPermission("CreateNatGateway", _a0, nil); err != nil {
+		return output, err
+	}
+	_m.settleTransitions()
+	subnet, ok := _m.subnets[aws.StringValue(_a0.SubnetId)]
+	if !ok {
+		return output, newAwsError("InvalidSubnetID.NotFound", "The subnet ID '"+aws.StringValue(_a0.SubnetId)+"' does not exist")
+	}
+	if _a0.AllocationId == nil {
+		return output, newAwsError("MissingParameter", "The request must contain the parameter allocationId")
+	}
+	address, err := _m.addressOf(_a0.AllocationId, nil)
+	if err != nil {
+		return
+	}
+	if address.AssociationId != nil || _m.natGatewayUsingAddress(*address.AllocationId) != nil {
+		return output, newAwsError("Resource.AlreadyAssociated", "Elastic IP address ["+*address.AllocationId+"] is already associated")
+	}
+	natGatewayId := GiveRandomId("nat-")
+	ntwInterface, err := _m.createNetworkInterface(&ec2.CreateNetworkInterfaceInput{
+		Description:   aws.String("Interface for NAT Gateway " + natGatewayId),
+		InterfaceType: aws.String("nat_gateway"),
+		SubnetId:      subnet.SubnetId,
+	})
+	if err != nil {
+		return
+	}
+	// the interface belongs to the nat gateway, not to any security group
+	ntwInterface.Groups = []*ec2.GroupIdentifier{}
+	ntwInterface.RequesterId = aws.String(defaultOwnerId)
+	ntwInterface.RequesterManaged = aws.Bool(true)
+	ntwInterface.SourceDestCheck = aws.Bool(false)
+	ntwInterface.Status = aws.String("in-use")
+	_m.associateAddress(address, ntwInterface, ntwInterface.PrivateIpAddresses[0])
+	natGateway := &ec2.NatGateway{
+		CreateTime: aws.Time(_m.now()),
+		NatGatewayAddresses: []*ec2.NatGatewayAddress{
+			&ec2.NatGatewayAddress{
+				AllocationId:       address.AllocationId,
+				NetworkInterfaceId: ntwInterface.NetworkInterfaceId,
+				PrivateIp:          ntwInterface.PrivateIpAddress,
+				PublicIp:           address.PublicIp,
+			},
+		},
+		NatGatewayId: &natGatewayId,
+		State:        aws.String(ec2.NatGatewayStatePending),
+		SubnetId:     subnet.SubnetId,
+		Tags:         []*ec2.Tag{},
+		VpcId:        subnet.VpcId,
+	}
+	_m.natGateways[natGatewayId] = natGateway
+	// the failure is decided when the nat gateway is created, so changing it
+	// later leaves pending nat gateways alone
+	failure := _m.natGatewayFailure
+	_m.scheduleTransition(natGatewayId, NatGatewayPending, func() {
+		if aws.StringValue(natGateway.State) != ec2.NatGatewayStatePending {
+			return
+		}
+		if failure == nil {
+			natGateway.State = aws.String(ec2.NatGatewayStateAvailable)
+			return
+		}
+		natGateway.State = aws.String(ec2.NatGatewayStateFailed)
+		natGateway.FailureCode = aws.String(failure.code)
+		natGateway.FailureMessage = aws.String(failure.message)
+		natGateway.DeleteTime = aws.Time(_m.now())
+		_m.releaseNatGateway(natGateway)
+	})
+	output.ClientToken = _a0.ClientToken
+	output.NatGateway = natGateway
+	return
+}
+
+// DeleteNatGateway provides a mock function with given fields: _a0
+func (_m *EC2API) DeleteNatGateway(_a0 *ec2.DeleteNatGatewayInput) (*ec2.DeleteNatGatewayOutput, error) {
+	return _m.DeleteNatGatewayWithContext(aws.BackgroundContext(), _a0)
+}
+
+// DeleteNatGatewayWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) DeleteNatGatewayWithContext(ctx aws.Context, _a0 *ec2.DeleteNatGatewayInput, opts ...request.Option) (output *ec2.DeleteNatGatewayOutput, err error) {
+	output = &ec2.DeleteNatGatewayOutput{}
+	if err := _m.recorder.CheckErrorWithContext(ctx, "DeleteNatGateway"); err != nil {
+		return output, err
+	}
+	defer func() { err = applyRequestOptions(ctx, "DeleteNatGateway", _a0, output, err, opts) }()
+	_m.recorder.Record("DeleteNatGateway")
+	returns, exist := _m.recorder.giveRecordedOutput("DeleteNatGateway", _a0)
+	if exist {
+		assertedErr, _ := returns[1].(error)
+		return returns[0].(*ec2.DeleteNatGatewayOutput), assertedErr
+	}
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.DeleteNatGatewayOutput) }()
+	if err = _m.checkPermission("DeleteNatGateway", _a0, nil); err != nil {
+		return output, err
+	}
+	_m.settleTransitions()
+	natGateway, err := _m.natGatewayOf(_a0.NatGatewayId)
+	if err != nil {
+		return
+	}
+	switch aws.StringValue(natGateway.State) {
+	case ec2.NatGatewayStateDeleted:
+		return output, newAwsError("NatGatewayNotFound", "The Nat Gateway "+*natGateway.NatGatewayId+" was not found")
+	case ec2.NatGatewayStateDeleting:
+		output.NatGatewayId = natGateway.NatGatewayId
+		return
+	}
+	natGateway.State = aws.String(ec2.NatGatewayStateDeleting)
+	natGateway.DeleteTime = aws.Time(_m.now())
+	_m.blackholeRoutes(func(route *ec2.Route) bool {
+		return aws.StringValue(route.NatGatewayId) == *natGateway.NatGatewayId
+	})
+	_m.scheduleTransition(*natGateway.NatGatewayId, NatGatewayDeleting, func() {
+		natGateway.State = aws.String(ec2.NatGatewayStateDeleted)
+		_m.releaseNatGateway(natGateway)
+	})
+	output.NatGatewayId = natGateway.NatGatewayId
+	return
+}
+
+// DescribeNatGateways provides a mock function with given fields: _a0
+func (_m *EC2API) DescribeNatGateways(_a0 *ec2.DescribeNatGatewaysInput) (*ec2.DescribeNatGatewaysOutput, error) {
+	return _m.DescribeNatGatewaysWithContext(aws.BackgroundContext(), _a0)
+}
+
+// DescribeNatGatewaysWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) DescribeNatGatewaysWithContext(ctx aws.Context, _a0 *ec2.DescribeNatGatewaysInput, opts ...request.Option) (output *ec2.DescribeNatGatewaysOutput, err error) {
+	output = &ec2.DescribeNatGatewaysOutput{}
+	if err := _m.recorder.CheckErrorWithContext(ctx, "DescribeNatGateways"); err != nil {
+		return output, err
+	}
+	defer func() { err = applyRequestOptions(ctx, "DescribeNatGateways", _a0, output, err, opts) }()
+	_m.recorder.Record("DescribeNatGateways")
+	returns, exist := _m.recorder.giveRecordedOutput("DescribeNatGateways", _a0)
+	if exist {
+		assertedErr, _ := returns[1].(error)
+		return returns[0].(*ec2.DescribeNatGatewaysOutput), assertedErr
+	}
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	defer func() { output = copyOutput(output).(*ec2.DescribeNatGatewaysOutput) }()
+	_m.settleTransitions()
+	if err = natGatewayFilter.validate(_a0.Filter); err != nil {
+		return
+	}
+	for _, id := range _a0.NatGatewayIds {
+		if _, err = _m.natGatewayOf(id); err != nil {
+			return
+		}
+	}
+	output.NatGateways = []*ec2.NatGateway{}
+	for _, natGateway := range _m.natGateways {
+		if len(_a0.NatGatewayIds) != 0 {
+			if exist, _ := in_array(*natGateway.NatGatewayId, aws.StringValueSlice(_a0.NatGatewayIds)); !exist {
+				continue
+			}
+		}
+		if natGatewayFilter.match(natGateway, _a0.Filter) {
+			output.NatGateways = append(output.NatGateways, natGateway)
+		}
+	}
+	sort.Slice(output.NatGateways, func(i, j int) bool {
+		return *output.NatGateways[i].NatGatewayId < *output.NatGateways[j].NatGatewayId
+	})
+	start, end, nextToken, err := _m.page(len(output.NatGateways), func(i int) string {
+		return *output.NatGateways[i].NatGatewayId
+	}, _a0.MaxResults, _a0.NextToken)
+	if err != nil {
+		return
 	}
+	output.NatGateways = output.NatGateways[start:end]
+	output.NextToken = nextToken
 	return
 }
@@ -0,0 +1,95 @@
+/*
+Copyright 2018 The Avi Networks.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package ec2
+
+import (
+	aws "github.com/aws/aws-sdk-go/aws"
+	ec2 "github.com/aws/aws-sdk-go/service/ec2"
+)
+
+// natGatewayFailure is the failure nat gateways created while it is set end
+// up with instead of becoming available.
+type natGatewayFailure struct {
+	code    string
+	message string
+}
+
+// SetNatGatewayFailure makes nat gateways created from now on move from
+// pending to failed with the given failure code and message. An empty code
+// lets them become available again.
+func (_m *EC2API) SetNatGatewayFailure(code, message string) {
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	if code == "" {
+		_m.natGatewayFailure = nil
+		return
+	}
+	_m.natGatewayFailure = &natGatewayFailure{code: code, message: message}
+}
+
+// natGatewayOf looks a nat gateway up by id.
+func (_m *EC2API) natGatewayOf(natGatewayId *string) (*ec2.NatGateway, error) {
+	natGateway, ok := _m.natGateways[aws.StringValue(natGatewayId)]
+	if !ok {
+		return nil, newAwsError("NatGatewayNotFound", "The Nat Gateway "+aws.StringValue(natGatewayId)+" was not found")
+	}
+	return natGateway, nil
+}
+
+// natGatewayActive tells whether the nat gateway is pending or available,
+// the states in which it holds on to its elastic ip and interface.
+func natGatewayActive(natGateway *ec2.NatGateway) bool {
+	switch aws.StringValue(natGateway.State) {
+	case ec2.NatGatewayStatePending, ec2.NatGatewayStateAvailable:
+		return true
+	}
+	return false
+}
+
+// natGatewayUsingAddress returns the active nat gateway the elastic ip is
+// allocated to, if any.
+func (_m *EC2API) natGatewayUsingAddress(allocationId string) *ec2.NatGateway {
+	for _, natGateway := range _m.natGateways {
+		if !natGatewayActive(natGateway) {
+			continue
+		}
+		for _, address := range natGateway.NatGatewayAddresses {
+			if aws.StringValue(address.AllocationId) == allocationId {
+				return natGateway
+			}
+		}
+	}
+	return nil
+}
+
+// checkAddressNotManaged refuses to touch an elastic ip a nat gateway holds,
+// whether it is found through the nat gateway or through its requester
+// managed interface. Only deleting the nat gateway lets go of the address.
+func (_m *EC2API) checkAddressNotManaged(address *ec2.Address) error {
+	ntwInterface, ok := _m.networkinterfaces[aws.StringValue(address.NetworkInterfaceId)]
+	if (ok && aws.BoolValue(ntwInterface.RequesterManaged)) || _m.natGatewayUsingAddress(aws.StringValue(address.AllocationId)) != nil {
+		return newAwsError("AuthFailure", "You do not have permission to access the specified resource.")
+	}
+	return nil
+}
+
+// releaseNatGateway removes the interfaces of the nat gateway, which hands
+// their private ips back to the subnet and their elastic ips back to the
+// account. The addresses stay listed on the nat gateway as ec2 does.
+func (_m *EC2API) releaseNatGateway(natGateway *ec2.NatGateway) {
+	for _, address := range natGateway.NatGatewayAddresses {
+		if ntwInterface, ok := _m.networkinterfaces[aws.StringValue(address.NetworkInterfaceId)]; ok {
+			_m.releaseNetworkInterface(ntwInterface)
+		}
+	}
+}
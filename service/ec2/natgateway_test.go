@@ -0,0 +1,150 @@
+/*
+Copyright 2018 The Avi Networks.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package ec2
+
+import (
+	"testing"
+
+	aws "github.com/aws/aws-sdk-go/aws"
+	ec2 "github.com/aws/aws-sdk-go/service/ec2"
+)
+
+func createTestNatGateway(t *testing.T, m *EC2API, subnetId, allocationId *string) *ec2.NatGateway {
+	t.Helper()
+	output, err := m.CreateNatGateway(&ec2.CreateNatGatewayInput{SubnetId: subnetId, AllocationId: allocationId})
+	if err != nil {
+		t.Fatalf("CreateNatGateway: %v", err)
+	}
+	return output.NatGateway
+}
+
+func describeTestNatGateway(t *testing.T, m *EC2API, natGatewayId *string) *ec2.NatGateway {
+	t.Helper()
+	output, err := m.DescribeNatGateways(&ec2.DescribeNatGatewaysInput{NatGatewayIds: []*string{natGatewayId}})
+	if err != nil {
+		t.Fatalf("DescribeNatGateways(%s): %v", aws.StringValue(natGatewayId), err)
+	}
+	return output.NatGateways[0]
+}
+
+func TestNatGatewayLifecycle(t *testing.T) {
+	m := newSeededMock(t)
+	m.SetManualTransitions(true)
+	vpc, subnet := createTestVpc(t, m, "10.1.0.0/16", "10.1.1.0/24")
+	allocationId := allocateTestAddress(t, m)
+	natGateway := createTestNatGateway(t, m, subnet.SubnetId, allocationId)
+	if state := aws.StringValue(natGateway.State); state != ec2.NatGatewayStatePending {
+		t.Fatalf("new nat gateway is %s", state)
+	}
+	_, err := m.CreateNatGateway(&ec2.CreateNatGatewayInput{SubnetId: subnet.SubnetId, AllocationId: allocationId})
+	expectErrorCode(t, err, "Resource.AlreadyAssociated")
+	m.CompleteTransitions()
+	if state := aws.StringValue(describeTestNatGateway(t, m, natGateway.NatGatewayId).State); state != ec2.NatGatewayStateAvailable {
+		t.Fatalf("nat gateway is %s, want available", state)
+	}
+
+	eniId := natGateway.NatGatewayAddresses[0].NetworkInterfaceId
+	interfaces, err := m.DescribeNetworkInterfaces(&ec2.DescribeNetworkInterfacesInput{NetworkInterfaceIds: []*string{eniId}})
+	if err != nil {
+		t.Fatalf("DescribeNetworkInterfaces: %v", err)
+	}
+	if ntwInterface := interfaces.NetworkInterfaces[0]; !aws.BoolValue(ntwInterface.RequesterManaged) || aws.StringValue(ntwInterface.InterfaceType) != "nat_gateway" {
+		t.Fatalf("nat gateway interface is %v", ntwInterface)
+	}
+	if address := describeTestAddress(t, m, allocationId); aws.StringValue(address.NetworkInterfaceId) != *eniId {
+		t.Fatalf("elastic ip is on %s, want the nat gateway interface %s", aws.StringValue(address.NetworkInterfaceId), *eniId)
+	}
+
+	routeTableId := mainTestRouteTable(t, m, vpc.VpcId)
+	if _, err := m.CreateRoute(&ec2.CreateRouteInput{RouteTableId: routeTableId, DestinationCidrBlock: aws.String("0.0.0.0/0"), NatGatewayId: natGateway.NatGatewayId}); err != nil {
+		t.Fatalf("CreateRoute: %v", err)
+	}
+	if _, err := m.DeleteNatGateway(&ec2.DeleteNatGatewayInput{NatGatewayId: natGateway.NatGatewayId}); err != nil {
+		t.Fatalf("DeleteNatGateway: %v", err)
+	}
+	routeTable := describeTestRouteTables(t, m, filter("route-table-id", *routeTableId))[0]
+	if state := aws.StringValue(testRoute(routeTable, "0.0.0.0/0").State); state != ec2.RouteStateBlackhole {
+		t.Fatalf("route through a deleting nat gateway is %s", state)
+	}
+	m.CompleteTransitions()
+	if state := aws.StringValue(describeTestNatGateway(t, m, natGateway.NatGatewayId).State); state != ec2.NatGatewayStateDeleted {
+		t.Fatalf("nat gateway is %s, want deleted", state)
+	}
+	_, err = m.DescribeNetworkInterfaces(&ec2.DescribeNetworkInterfacesInput{NetworkInterfaceIds: []*string{eniId}})
+	expectErrorCode(t, err, "InvalidNetworkInterfaceID.NotFound")
+	if _, err := m.ReleaseAddress(&ec2.ReleaseAddressInput{AllocationId: allocationId}); err != nil {
+		t.Fatalf("ReleaseAddress after the nat gateway is gone: %v", err)
+	}
+	_, err = m.DeleteNatGateway(&ec2.DeleteNatGatewayInput{NatGatewayId: natGateway.NatGatewayId})
+	expectErrorCode(t, err, "NatGatewayNotFound")
+}
+
+func TestNatGatewayFailureReleasesTheAddress(t *testing.T) {
+	m := newSeededMock(t)
+	vpc, subnet := createTestVpc(t, m, "10.1.0.0/16", "10.1.1.0/24")
+	m.SetNatGatewayFailure("Gateway.NotAttached", "Network vpc has no Internet gateway attached")
+	allocationId := allocateTestAddress(t, m)
+	natGateway := createTestNatGateway(t, m, subnet.SubnetId, allocationId)
+	m.CompleteTransitions()
+
+	failed := describeTestNatGateway(t, m, natGateway.NatGatewayId)
+	if aws.StringValue(failed.State) != ec2.NatGatewayStateFailed || aws.StringValue(failed.FailureCode) != "Gateway.NotAttached" {
+		t.Fatalf("nat gateway is %s with failure %s, want failed with Gateway.NotAttached", aws.StringValue(failed.State), aws.StringValue(failed.FailureCode))
+	}
+	if address := describeTestAddress(t, m, allocationId); address.AssociationId != nil {
+		t.Fatalf("failed nat gateway kept its elastic ip: %v", address)
+	}
+	_, err := m.CreateRoute(&ec2.CreateRouteInput{RouteTableId: mainTestRouteTable(t, m, vpc.VpcId), DestinationCidrBlock: aws.String("0.0.0.0/0"), NatGatewayId: natGateway.NatGatewayId})
+	expectErrorCode(t, err, "InvalidNatGatewayID.NotFound")
+}
+
+func TestNatGatewayElasticIpCannotBeTakenOver(t *testing.T) {
+	m := newSeededMock(t)
+	m.SetManualTransitions(true)
+	_, subnet := createTestVpc(t, m, "10.1.0.0/16", "10.1.1.0/24")
+	allocationId := allocateTestAddress(t, m)
+	natGateway := createTestNatGateway(t, m, subnet.SubnetId, allocationId)
+	natInterfaceId := natGateway.NatGatewayAddresses[0].NetworkInterfaceId
+	ntwInterface := createTestInterface(t, m, subnet.SubnetId)
+	otherAllocationId := allocateTestAddress(t, m)
+
+	// pending and available nat gateways both hold on to the address
+	for _, complete := range []bool{false, true} {
+		if complete {
+			m.CompleteTransitions()
+		}
+		_, err := m.DisassociateAddress(&ec2.DisassociateAddressInput{AssociationId: describeTestAddress(t, m, allocationId).AssociationId})
+		expectErrorCode(t, err, "AuthFailure")
+		_, err = m.AssociateAddress(&ec2.AssociateAddressInput{
+			AllocationId:       allocationId,
+			NetworkInterfaceId: ntwInterface.NetworkInterfaceId,
+			AllowReassociation: aws.Bool(true),
+		})
+		expectErrorCode(t, err, "AuthFailure")
+		_, err = m.AssociateAddress(&ec2.AssociateAddressInput{
+			AllocationId:       otherAllocationId,
+			NetworkInterfaceId: natInterfaceId,
+			AllowReassociation: aws.Bool(true),
+		})
+		expectErrorCode(t, err, "AuthFailure")
+		_, err = m.ReleaseAddress(&ec2.ReleaseAddressInput{AllocationId: allocationId})
+		expectErrorCode(t, err, "AuthFailure")
+	}
+
+	if address := describeTestAddress(t, m, allocationId); aws.StringValue(address.NetworkInterfaceId) != *natInterfaceId {
+		t.Fatalf("the nat gateway lost its elastic ip to %s", aws.StringValue(address.NetworkInterfaceId))
+	}
+	if publicIp := describeTestNatGateway(t, m, natGateway.NatGatewayId).NatGatewayAddresses[0].PublicIp; aws.StringValue(publicIp) != aws.StringValue(describeTestAddress(t, m, allocationId).PublicIp) {
+		t.Fatalf("nat gateway lists public ip %s", aws.StringValue(publicIp))
+	}
+}
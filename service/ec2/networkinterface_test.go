@@ -0,0 +1,313 @@
+/*
+Copyright 2018 The Avi Networks.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package ec2
+
+import (
+	"testing"
+
+	aws "github.com/aws/aws-sdk-go/aws"
+	ec2 "github.com/aws/aws-sdk-go/service/ec2"
+)
+
+func describeTestInterface(t *testing.T, m *EC2API, ntwInterfaceId *string) *ec2.NetworkInterface {
+	t.Helper()
+	output, err := m.DescribeNetworkInterfaces(&ec2.DescribeNetworkInterfacesInput{NetworkInterfaceIds: []*string{ntwInterfaceId}})
+	if err != nil {
+		t.Fatalf("DescribeNetworkInterfaces(%s): %v", aws.StringValue(ntwInterfaceId), err)
+	}
+	return output.NetworkInterfaces[0]
+}
+
+// expectFreeAddresses fails the test unless the subnet has free addresses
+// left.
+func expectFreeAddresses(t *testing.T, m *EC2API, subnetId *string, free int64) {
+	t.Helper()
+	if count := aws.Int64Value(describeTestSubnet(t, m, subnetId).AvailableIpAddressCount); count != free {
+		t.Fatalf("subnet has %d free addresses, want %d", count, free)
+	}
+}
+
+func TestUnassignPrivateIpAddressesReleasesThem(t *testing.T) {
+	m := newSeededMock(t)
+	subnet := createTestSubnet(t, m, "10.0.1.0/28")
+	free := aws.Int64Value(subnet.AvailableIpAddressCount)
+	ntwInterface := createTestInterface(t, m, subnet.SubnetId)
+	if _, err := m.AssignPrivateIpAddresses(&ec2.AssignPrivateIpAddressesInput{
+		NetworkInterfaceId:             ntwInterface.NetworkInterfaceId,
+		SecondaryPrivateIpAddressCount: aws.Int64(2),
+	}); err != nil {
+		t.Fatalf("AssignPrivateIpAddresses: %v", err)
+	}
+	expectFreeAddresses(t, m, subnet.SubnetId, free-3)
+
+	privateIps := describeTestInterface(t, m, ntwInterface.NetworkInterfaceId).PrivateIpAddresses
+	secondary := privateIps[1].PrivateIpAddress
+	_, err := m.UnassignPrivateIpAddresses(&ec2.UnassignPrivateIpAddressesInput{
+		NetworkInterfaceId: ntwInterface.NetworkInterfaceId,
+		PrivateIpAddresses: []*string{ntwInterface.PrivateIpAddress},
+	})
+	expectErrorCode(t, err, "InvalidParameterValue")
+	_, err = m.UnassignPrivateIpAddresses(&ec2.UnassignPrivateIpAddressesInput{
+		NetworkInterfaceId: ntwInterface.NetworkInterfaceId,
+		PrivateIpAddresses: []*string{secondary, aws.String("10.0.1.14")},
+	})
+	expectErrorCode(t, err, "InvalidParameterValue")
+	if len(describeTestInterface(t, m, ntwInterface.NetworkInterfaceId).PrivateIpAddresses) != 3 {
+		t.Fatal("a rejected unassign removed addresses")
+	}
+	expectFreeAddresses(t, m, subnet.SubnetId, free-3)
+
+	if _, err := m.UnassignPrivateIpAddresses(&ec2.UnassignPrivateIpAddressesInput{
+		NetworkInterfaceId: ntwInterface.NetworkInterfaceId,
+		PrivateIpAddresses: []*string{secondary},
+	}); err != nil {
+		t.Fatalf("UnassignPrivateIpAddresses: %v", err)
+	}
+	expectFreeAddresses(t, m, subnet.SubnetId, free-2)
+	if privateIps := describeTestInterface(t, m, ntwInterface.NetworkInterfaceId).PrivateIpAddresses; len(privateIps) != 2 || !aws.BoolValue(privateIps[0].Primary) {
+		t.Fatalf("interface has addresses %v, want the primary and one secondary", privateIps)
+	}
+	// the address is back in the pool, the sequential allocator hands out
+	// the lowest free one
+	if other := createTestInterface(t, m, subnet.SubnetId); aws.StringValue(other.PrivateIpAddress) != *secondary {
+		t.Fatalf("new interface got %s, want the released %s", aws.StringValue(other.PrivateIpAddress), *secondary)
+	}
+}
+
+func TestDeleteNetworkInterfaceReleasesItsAddresses(t *testing.T) {
+	m := newSeededMock(t)
+	subnet := createTestSubnet(t, m, "10.0.1.0/28")
+	free := aws.Int64Value(subnet.AvailableIpAddressCount)
+	created, err := m.CreateNetworkInterface(&ec2.CreateNetworkInterfaceInput{
+		SubnetId:                       subnet.SubnetId,
+		SecondaryPrivateIpAddressCount: aws.Int64(2),
+	})
+	if err != nil {
+		t.Fatalf("CreateNetworkInterface: %v", err)
+	}
+	ntwInterface := created.NetworkInterface
+	expectFreeAddresses(t, m, subnet.SubnetId, free-3)
+	if exist, _ := in_array(*ntwInterface.MacAddress, m.assignedMacAddress); !exist {
+		t.Fatalf("mac %s is not booked", *ntwInterface.MacAddress)
+	}
+
+	if _, err := m.DeleteNetworkInterface(&ec2.DeleteNetworkInterfaceInput{NetworkInterfaceId: ntwInterface.NetworkInterfaceId}); err != nil {
+		t.Fatalf("DeleteNetworkInterface: %v", err)
+	}
+	expectFreeAddresses(t, m, subnet.SubnetId, free)
+	if exist, _ := in_array(*ntwInterface.MacAddress, m.assignedMacAddress); exist {
+		t.Fatalf("mac %s is still booked", *ntwInterface.MacAddress)
+	}
+	_, err = m.DescribeNetworkInterfaces(&ec2.DescribeNetworkInterfacesInput{NetworkInterfaceIds: []*string{ntwInterface.NetworkInterfaceId}})
+	expectErrorCode(t, err, "InvalidNetworkInterfaceID.NotFound")
+	privateIps := []*string{}
+	for _, privateIp := range ntwInterface.PrivateIpAddresses {
+		privateIps = append(privateIps, privateIp.PrivateIpAddress)
+	}
+	if _, err := m.CreateNetworkInterface(&ec2.CreateNetworkInterfaceInput{
+		SubnetId:         subnet.SubnetId,
+		PrivateIpAddress: privateIps[0],
+	}); err != nil {
+		t.Fatalf("CreateNetworkInterface(%s): %v", *privateIps[0], err)
+	}
+	if _, err := m.CreateNetworkInterface(&ec2.CreateNetworkInterfaceInput{
+		SubnetId:         subnet.SubnetId,
+		PrivateIpAddress: privateIps[1],
+	}); err != nil {
+		t.Fatalf("CreateNetworkInterface(%s): %v", *privateIps[1], err)
+	}
+}
+
+func TestDeleteNetworkInterfaceRefusesAttachedOnes(t *testing.T) {
+	m := newSeededMock(t)
+	instance := runTestInstances(t, m, aws.String(m.GetDefaultSubnetID()), 1)[0]
+	ntwInterfaceId := instance.NetworkInterfaces[0].NetworkInterfaceId
+	_, err := m.DeleteNetworkInterface(&ec2.DeleteNetworkInterfaceInput{NetworkInterfaceId: ntwInterfaceId})
+	expectErrorCode(t, err, "InvalidNetworkInterface.InUse")
+	if aws.StringValue(describeTestInterface(t, m, ntwInterfaceId).Status) != "in-use" {
+		t.Fatal("attached interface is not in use")
+	}
+	_, err = m.DeleteNetworkInterface(&ec2.DeleteNetworkInterfaceInput{NetworkInterfaceId: aws.String("eni-unknown")})
+	expectErrorCode(t, err, "InvalidNetworkInterfaceID.NotFound")
+}
+
+func TestNetworkInterfaceAttributes(t *testing.T) {
+	m := newSeededMock(t)
+	instance := runTestInstances(t, m, aws.String(m.GetDefaultSubnetID()), 1)[0]
+	ntwInterfaceId := instance.NetworkInterfaces[0].NetworkInterfaceId
+	attachmentId := instance.NetworkInterfaces[0].Attachment.AttachmentId
+	groupId := createTestSecurityGroup(t, m, m.GetDefaultVPCID(), "web")
+	modify := func(input *ec2.ModifyNetworkInterfaceAttributeInput) {
+		t.Helper()
+		input.NetworkInterfaceId = ntwInterfaceId
+		if _, err := m.ModifyNetworkInterfaceAttribute(input); err != nil {
+			t.Fatalf("ModifyNetworkInterfaceAttribute: %v", err)
+		}
+	}
+	describe := func(attribute string) *ec2.DescribeNetworkInterfaceAttributeOutput {
+		t.Helper()
+		output, err := m.DescribeNetworkInterfaceAttribute(&ec2.DescribeNetworkInterfaceAttributeInput{
+			NetworkInterfaceId: ntwInterfaceId,
+			Attribute:          aws.String(attribute),
+		})
+		if err != nil {
+			t.Fatalf("DescribeNetworkInterfaceAttribute(%s): %v", attribute, err)
+		}
+		return output
+	}
+
+	modify(&ec2.ModifyNetworkInterfaceAttributeInput{Groups: []*string{groupId}})
+	modify(&ec2.ModifyNetworkInterfaceAttributeInput{SourceDestCheck: &ec2.AttributeBooleanValue{Value: aws.Bool(false)}})
+	modify(&ec2.ModifyNetworkInterfaceAttributeInput{Description: &ec2.AttributeValue{Value: aws.String("frontend")}})
+	modify(&ec2.ModifyNetworkInterfaceAttributeInput{Attachment: &ec2.NetworkInterfaceAttachmentChanges{
+		AttachmentId:        attachmentId,
+		DeleteOnTermination: aws.Bool(false),
+	}})
+
+	if groups := describe("groupSet").Groups; len(groups) != 1 || *groups[0].GroupId != *groupId {
+		t.Errorf("groupSet is %v, want %s", groups, *groupId)
+	}
+	if aws.BoolValue(describe("sourceDestCheck").SourceDestCheck.Value) {
+		t.Error("sourceDestCheck is still on")
+	}
+	if description := aws.StringValue(describe("description").Description.Value); description != "frontend" {
+		t.Errorf("description is %q", description)
+	}
+	if aws.BoolValue(describe("attachment").Attachment.DeleteOnTermination) {
+		t.Error("attachment is still deleted on termination")
+	}
+	ntwInterface := describeTestInterface(t, m, ntwInterfaceId)
+	if len(ntwInterface.Groups) != 1 || aws.BoolValue(ntwInterface.SourceDestCheck) || aws.StringValue(ntwInterface.Description) != "frontend" {
+		t.Errorf("interface is %v", ntwInterface)
+	}
+
+	// the instance shows the interface as it is now
+	described := describeTestInstance(t, m, instance.InstanceId)
+	instanceInterface := described.NetworkInterfaces[0]
+	if len(instanceInterface.Groups) != 1 || *instanceInterface.Groups[0].GroupId != *groupId {
+		t.Errorf("instance lists interface groups %v", instanceInterface.Groups)
+	}
+	if aws.BoolValue(instanceInterface.SourceDestCheck) || aws.StringValue(instanceInterface.Description) != "frontend" {
+		t.Errorf("instance lists interface %v", instanceInterface)
+	}
+	if aws.BoolValue(instanceInterface.Attachment.DeleteOnTermination) {
+		t.Error("instance lists the attachment as deleted on termination")
+	}
+	if len(described.SecurityGroups) != 1 || aws.BoolValue(described.SourceDestCheck) {
+		t.Errorf("instance does not mirror its primary interface: groups %v, source dest check %v", described.SecurityGroups, aws.BoolValue(described.SourceDestCheck))
+	}
+
+	if _, err := m.ResetNetworkInterfaceAttribute(&ec2.ResetNetworkInterfaceAttributeInput{
+		NetworkInterfaceId: ntwInterfaceId,
+		SourceDestCheck:    aws.String("sourceDestCheck"),
+	}); err != nil {
+		t.Fatalf("ResetNetworkInterfaceAttribute: %v", err)
+	}
+	if !aws.BoolValue(describe("sourceDestCheck").SourceDestCheck.Value) {
+		t.Error("sourceDestCheck is not reset")
+	}
+	if described := describeTestInstance(t, m, instance.InstanceId); !aws.BoolValue(described.NetworkInterfaces[0].SourceDestCheck) || !aws.BoolValue(described.SourceDestCheck) {
+		t.Error("instance does not see the reset sourceDestCheck")
+	}
+}
+
+func TestNetworkInterfaceAttributeErrors(t *testing.T) {
+	m := newSeededMock(t)
+	ntwInterface := createTestInterface(t, m, aws.String(m.GetDefaultSubnetID()))
+	vpc, _ := createTestVpc(t, m, "10.1.0.0/16", "10.1.1.0/24")
+	otherGroupId := createTestSecurityGroup(t, m, *vpc.VpcId, "other")
+	for _, test := range []struct {
+		name  string
+		input *ec2.ModifyNetworkInterfaceAttributeInput
+		code  string
+	}{
+		{"unknown interface", &ec2.ModifyNetworkInterfaceAttributeInput{
+			NetworkInterfaceId: aws.String("eni-unknown"),
+			Description:        &ec2.AttributeValue{Value: aws.String("x")},
+		}, "InvalidNetworkInterfaceID.NotFound"},
+		{"no attribute", &ec2.ModifyNetworkInterfaceAttributeInput{NetworkInterfaceId: ntwInterface.NetworkInterfaceId}, "InvalidParameterCombination"},
+		{"two attributes", &ec2.ModifyNetworkInterfaceAttributeInput{
+			NetworkInterfaceId: ntwInterface.NetworkInterfaceId,
+			Description:        &ec2.AttributeValue{Value: aws.String("x")},
+			SourceDestCheck:    &ec2.AttributeBooleanValue{Value: aws.Bool(false)},
+		}, "InvalidParameterCombination"},
+		{"unknown group", &ec2.ModifyNetworkInterfaceAttributeInput{
+			NetworkInterfaceId: ntwInterface.NetworkInterfaceId,
+			Groups:             []*string{aws.String("sg-unknown")},
+		}, "InvalidGroup.NotFound"},
+		{"group of another vpc", &ec2.ModifyNetworkInterfaceAttributeInput{
+			NetworkInterfaceId: ntwInterface.NetworkInterfaceId,
+			Groups:             []*string{otherGroupId},
+		}, "InvalidParameter"},
+		{"detached interface", &ec2.ModifyNetworkInterfaceAttributeInput{
+			NetworkInterfaceId: ntwInterface.NetworkInterfaceId,
+			Attachment:         &ec2.NetworkInterfaceAttachmentChanges{AttachmentId: aws.String("eni-attach-unknown"), DeleteOnTermination: aws.Bool(true)},
+		}, "InvalidAttachmentID.NotFound"},
+	} {
+		_, err := m.ModifyNetworkInterfaceAttribute(test.input)
+		if errorCode(err) != test.code {
+			t.Errorf("%s: got error %v, want code %s", test.name, err, test.code)
+		}
+	}
+	_, err := m.DescribeNetworkInterfaceAttribute(&ec2.DescribeNetworkInterfaceAttributeInput{
+		NetworkInterfaceId: ntwInterface.NetworkInterfaceId,
+		Attribute:          aws.String("macAddress"),
+	})
+	expectErrorCode(t, err, "InvalidParameterValue")
+}
+
+func TestTerminateInstancesHonoursDeleteOnTermination(t *testing.T) {
+	m := newSeededMock(t)
+	instance := runTestInstances(t, m, aws.String(m.GetDefaultSubnetID()), 1)[0]
+	m.CompleteTransitions()
+	primary := instance.NetworkInterfaces[0]
+	secondary := createTestInterface(t, m, aws.String(m.GetDefaultSubnetID()))
+	attached, err := m.AttachNetworkInterface(&ec2.AttachNetworkInterfaceInput{
+		DeviceIndex:        aws.Int64(1),
+		InstanceId:         instance.InstanceId,
+		NetworkInterfaceId: secondary.NetworkInterfaceId,
+	})
+	if err != nil {
+		t.Fatalf("AttachNetworkInterface: %v", err)
+	}
+	m.CompleteTransitions()
+	// flip both: keep the interface created at launch, delete the attached one
+	for _, change := range []struct {
+		ntwInterfaceId, attachmentId *string
+		deleteOnTermination          bool
+	}{
+		{primary.NetworkInterfaceId, primary.Attachment.AttachmentId, false},
+		{secondary.NetworkInterfaceId, attached.AttachmentId, true},
+	} {
+		if _, err := m.ModifyNetworkInterfaceAttribute(&ec2.ModifyNetworkInterfaceAttributeInput{
+			NetworkInterfaceId: change.ntwInterfaceId,
+			Attachment: &ec2.NetworkInterfaceAttachmentChanges{
+				AttachmentId:        change.attachmentId,
+				DeleteOnTermination: aws.Bool(change.deleteOnTermination),
+			},
+		}); err != nil {
+			t.Fatalf("ModifyNetworkInterfaceAttribute(%s): %v", *change.ntwInterfaceId, err)
+		}
+	}
+
+	if _, err := m.TerminateInstances(&ec2.TerminateInstancesInput{InstanceIds: []*string{instance.InstanceId}}); err != nil {
+		t.Fatalf("TerminateInstances: %v", err)
+	}
+	m.CompleteTransitions()
+	kept := describeTestInterface(t, m, primary.NetworkInterfaceId)
+	if kept.Attachment != nil || aws.StringValue(kept.Status) != "available" {
+		t.Fatalf("kept interface is %v, want it detached and available", kept)
+	}
+	_, err = m.DescribeNetworkInterfaces(&ec2.DescribeNetworkInterfacesInput{NetworkInterfaceIds: []*string{secondary.NetworkInterfaceId}})
+	expectErrorCode(t, err, "InvalidNetworkInterfaceID.NotFound")
+}
@@ -0,0 +1,233 @@
+/*
+Copyright 2018 The Avi Networks.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package ec2
+
+import (
+	"encoding/base64"
+	"sort"
+	"strconv"
+
+	aws "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	ec2 "github.com/aws/aws-sdk-go/service/ec2"
+)
+
+const minPageSize int64 = 5
+const maxPageSize int64 = 1000
+
+// SetDefaultPageSize makes the paginated Describe calls answer at most size
+// results when the request carries no MaxResults. Zero, the default, answers
+// everything in one page as ec2 does.
+func (_m *EC2API) SetDefaultPageSize(size int64) {
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	_m.defaultPageSize = size
+}
+
+// page cuts the page asked for out of count results sorted by id. The token
+// is the id the previous page ended with, so a page never repeats or skips a
+// result that was there for the whole listing, whatever happened in between.
+func (_m *EC2API) page(count int, id func(int) string, maxResults *int64, nextToken *string) (start, end int, next *string, err error) {
+	size := _m.defaultPageSize
+	if maxResults != nil {
+		size = *maxResults
+		if size < minPageSize || size > maxPageSize {
+			err = newAwsError("InvalidParameterValue", "Value ( "+strconv.FormatInt(size, 10)+" ) for parameter maxResults is invalid. Parameter must be between "+strconv.FormatInt(minPageSize, 10)+" and "+strconv.FormatInt(maxPageSize, 10)+".")
+			return
+		}
+	}
+	if aws.StringValue(nextToken) != "" {
+		last, decodeErr := base64.RawURLEncoding.DecodeString(*nextToken)
+		if decodeErr != nil || len(last) == 0 {
+			err = newAwsError("InvalidPaginationToken", "Invalid pagination token: "+*nextToken)
+			return
+		}
+		start = sort.Search(count, func(i int) bool {
+			return id(i) > string(last)
+		})
+	}
+	end = count
+	if size > 0 && int64(end-start) > size {
+		end = start + int(size)
+		next = aws.String(base64.RawURLEncoding.EncodeToString([]byte(id(end - 1))))
+	}
+	return
+}
+
+// DescribeInstancesPages provides a mock function with given fields: _a0, _a1
+func (_m *EC2API) DescribeInstancesPages(_a0 *ec2.DescribeInstancesInput, _a1 func(*ec2.DescribeInstancesOutput, bool) bool) error {
+	return _m.DescribeInstancesPagesWithContext(aws.BackgroundContext(), _a0, _a1)
+}
+
+// DescribeInstancesPagesWithContext provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *EC2API) DescribeInstancesPagesWithContext(_a0 aws.Context, _a1 *ec2.DescribeInstancesInput, _a2 func(*ec2.DescribeInstancesOutput, bool) bool, _a3 ...request.Option) error {
+	input := *_a1
+	for {
+		output, err := _m.DescribeInstancesWithContext(_a0, &input, _a3...)
+		if err != nil {
+			return err
+		}
+		lastPage := aws.StringValue(output.NextToken) == ""
+		if !_a2(output, lastPage) || lastPage {
+			return nil
+		}
+		input.NextToken = output.NextToken
+	}
+}
+
+// DescribeSubnetsPages provides a mock function with given fields: _a0, _a1
+func (_m *EC2API) DescribeSubnetsPages(_a0 *ec2.DescribeSubnetsInput, _a1 func(*ec2.DescribeSubnetsOutput, bool) bool) error {
+	return _m.DescribeSubnetsPagesWithContext(aws.BackgroundContext(), _a0, _a1)
+}
+
+// DescribeSubnetsPagesWithContext provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *EC2API) DescribeSubnetsPagesWithContext(_a0 aws.Context, _a1 *ec2.DescribeSubnetsInput, _a2 func(*ec2.DescribeSubnetsOutput, bool) bool, _a3 ...request.Option) error {
+	input := *_a1
+	for {
+		output, err := _m.DescribeSubnetsWithContext(_a0, &input, _a3...)
+		if err != nil {
+			return err
+		}
+		lastPage := aws.StringValue(output.NextToken) == ""
+		if !_a2(output, lastPage) || lastPage {
+			return nil
+		}
+		input.NextToken = output.NextToken
+	}
+}
+
+// DescribeNetworkInterfacesPages provides a mock function with given fields: _a0, _a1
+func (_m *EC2API) DescribeNetworkInterfacesPages(_a0 *ec2.DescribeNetworkInterfacesInput, _a1 func(*ec2.DescribeNetworkInterfacesOutput, bool) bool) error {
+	return _m.DescribeNetworkInterfacesPagesWithContext(aws.BackgroundContext(), _a0, _a1)
+}
+
+// DescribeNetworkInterfacesPagesWithContext provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *EC2API) DescribeNetworkInterfacesPagesWithContext(_a0 aws.Context, _a1 *ec2.DescribeNetworkInterfacesInput, _a2 func(*ec2.DescribeNetworkInterfacesOutput, bool) bool, _a3 ...request.Option) error {
+	input := *_a1
+	for {
+		output, err := _m.DescribeNetworkInterfacesWithContext(_a0, &input, _a3...)
+		if err != nil {
+			return err
+		}
+		lastPage := aws.StringValue(output.NextToken) == ""
+		if !_a2(output, lastPage) || lastPage {
+			return nil
+		}
+		input.NextToken = output.NextToken
+	}
+}
+
+// DescribeSecurityGroupsPages provides a mock function with given fields: _a0, _a1
+func (_m *EC2API) DescribeSecurityGroupsPages(_a0 *ec2.DescribeSecurityGroupsInput, _a1 func(*ec2.DescribeSecurityGroupsOutput, bool) bool) error {
+	return _m.DescribeSecurityGroupsPagesWithContext(aws.BackgroundContext(), _a0, _a1)
+}
+
+// DescribeSecurityGroupsPagesWithContext provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *EC2API) DescribeSecurityGroupsPagesWithContext(_a0 aws.Context, _a1 *ec2.DescribeSecurityGroupsInput, _a2 func(*ec2.DescribeSecurityGroupsOutput, bool) bool, _a3 ...request.Option) error {
+	input := *_a1
+	for {
+		output, err := _m.DescribeSecurityGroupsWithContext(_a0, &input, _a3...)
+		if err != nil {
+			return err
+		}
+		lastPage := aws.StringValue(output.NextToken) == ""
+		if !_a2(output, lastPage) || lastPage {
+			return nil
+		}
+		input.NextToken = output.NextToken
+	}
+}
+
+// DescribeRouteTablesPages provides a mock function with given fields: _a0, _a1
+func (_m *EC2API) DescribeRouteTablesPages(_a0 *ec2.DescribeRouteTablesInput, _a1 func(*ec2.DescribeRouteTablesOutput, bool) bool) error {
+	return _m.DescribeRouteTablesPagesWithContext(aws.BackgroundContext(), _a0, _a1)
+}
+
+// DescribeRouteTablesPagesWithContext provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *EC2API) DescribeRouteTablesPagesWithContext(_a0 aws.Context, _a1 *ec2.DescribeRouteTablesInput, _a2 func(*ec2.DescribeRouteTablesOutput, bool) bool, _a3 ...request.Option) error {
+	input := *_a1
+	for {
+		output, err := _m.DescribeRouteTablesWithContext(_a0, &input, _a3...)
+		if err != nil {
+			return err
+		}
+		lastPage := aws.StringValue(output.NextToken) == ""
+		if !_a2(output, lastPage) || lastPage {
+			return nil
+		}
+		input.NextToken = output.NextToken
+	}
+}
+
+// DescribeVpcsPages provides a mock function with given fields: _a0, _a1
+func (_m *EC2API) DescribeVpcsPages(_a0 *ec2.DescribeVpcsInput, _a1 func(*ec2.DescribeVpcsOutput, bool) bool) error {
+	return _m.DescribeVpcsPagesWithContext(aws.BackgroundContext(), _a0, _a1)
+}
+
+// DescribeVpcsPagesWithContext provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *EC2API) DescribeVpcsPagesWithContext(_a0 aws.Context, _a1 *ec2.DescribeVpcsInput, _a2 func(*ec2.DescribeVpcsOutput, bool) bool, _a3 ...request.Option) error {
+	input := *_a1
+	for {
+		output, err := _m.DescribeVpcsWithContext(_a0, &input, _a3...)
+		if err != nil {
+			return err
+		}
+		lastPage := aws.StringValue(output.NextToken) == ""
+		if !_a2(output, lastPage) || lastPage {
+			return nil
+		}
+		input.NextToken = output.NextToken
+	}
+}
+
+// DescribeNetworkAclsPages provides a mock function with given fields: _a0, _a1
+func (_m *EC2API) DescribeNetworkAclsPages(_a0 *ec2.DescribeNetworkAclsInput, _a1 func(*ec2.DescribeNetworkAclsOutput, bool) bool) error {
+	return _m.DescribeNetworkAclsPagesWithContext(aws.BackgroundContext(), _a0, _a1)
+}
+
+// DescribeNetworkAclsPagesWithContext provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *EC2API) DescribeNetworkAclsPagesWithContext(_a0 aws.Context, _a1 *ec2.DescribeNetworkAclsInput, _a2 func(*ec2.DescribeNetworkAclsOutput, bool) bool, _a3 ...request.Option) error {
+	input := *_a1
+	for {
+		output, err := _m.DescribeNetworkAclsWithContext(_a0, &input, _a3...)
+		if err != nil {
+			return err
+		}
+		lastPage := aws.StringValue(output.NextToken) == ""
+		if !_a2(output, lastPage) || lastPage {
+			return nil
+		}
+		input.NextToken = output.NextToken
+	}
+}
+
+// DescribeStaleSecurityGroupsPages provides a mock function with given fields: _a0, _a1
+func (_m *EC2API) DescribeStaleSecurityGroupsPages(_a0 *ec2.DescribeStaleSecurityGroupsInput, _a1 func(*ec2.DescribeStaleSecurityGroupsOutput, bool) bool) error {
+	return _m.DescribeStaleSecurityGroupsPagesWithContext(aws.BackgroundContext(), _a0, _a1)
+}
+
+// DescribeStaleSecurityGroupsPagesWithContext provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *EC2API) DescribeStaleSecurityGroupsPagesWithContext(_a0 aws.Context, _a1 *ec2.DescribeStaleSecurityGroupsInput, _a2 func(*ec2.DescribeStaleSecurityGroupsOutput, bool) bool, _a3 ...request.Option) error {
+	input := *_a1
+	for {
+		output, err := _m.DescribeStaleSecurityGroupsWithContext(_a0, &input, _a3...)
+		if err != nil {
+			return err
+		}
+		lastPage := aws.StringValue(output.NextToken) == ""
+		if !_a2(output, lastPage) || lastPage {
+			return nil
+		}
+		input.NextToken = output.NextToken
+	}
+}
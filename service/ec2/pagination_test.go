@@ -0,0 +1,145 @@
+/*
+Copyright 2018 The Avi Networks.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package ec2
+
+import (
+	"fmt"
+	"testing"
+
+	aws "github.com/aws/aws-sdk-go/aws"
+	ec2 "github.com/aws/aws-sdk-go/service/ec2"
+)
+
+// createTestSubnets creates count /24 subnets of the default vpc, starting at
+// 10.0.100.0/24.
+func createTestSubnets(t *testing.T, m *EC2API, count int) []*ec2.Subnet {
+	t.Helper()
+	subnets := []*ec2.Subnet{}
+	for i := 0; i < count; i++ {
+		subnets = append(subnets, createTestSubnet(t, m, fmt.Sprintf("10.0.%d.0/24", 100+i)))
+	}
+	return subnets
+}
+
+func describeSubnetPage(t *testing.T, m *EC2API, maxResults int64, nextToken *string) *ec2.DescribeSubnetsOutput {
+	t.Helper()
+	output, err := m.DescribeSubnets(&ec2.DescribeSubnetsInput{MaxResults: aws.Int64(maxResults), NextToken: nextToken})
+	if err != nil {
+		t.Fatalf("DescribeSubnets: %v", err)
+	}
+	return output
+}
+
+func TestPaginationWalksEveryResultOnce(t *testing.T) {
+	m := newSeededMock(t)
+	createTestSubnets(t, m, 11)
+	// the 11 subnets and the default one
+	sizes := []int{}
+	seen := map[string]bool{}
+	var nextToken *string
+	for {
+		output := describeSubnetPage(t, m, 5, nextToken)
+		sizes = append(sizes, len(output.Subnets))
+		for _, subnet := range output.Subnets {
+			if seen[*subnet.SubnetId] {
+				t.Fatalf("subnet %s listed twice", *subnet.SubnetId)
+			}
+			seen[*subnet.SubnetId] = true
+		}
+		if output.NextToken == nil {
+			break
+		}
+		nextToken = output.NextToken
+	}
+	if fmt.Sprint(sizes) != "[5 5 2]" {
+		t.Fatalf("got pages of %v, want [5 5 2]", sizes)
+	}
+}
+
+func TestPaginationSurvivesChangesBetweenPages(t *testing.T) {
+	m := newSeededMock(t)
+	createTestSubnets(t, m, 11)
+	first := describeSubnetPage(t, m, 5, nil)
+
+	// delete one subnet already listed and one still to come
+	all, err := m.DescribeSubnets(&ec2.DescribeSubnetsInput{})
+	if err != nil {
+		t.Fatalf("DescribeSubnets: %v", err)
+	}
+	listed := map[string]bool{}
+	for _, subnet := range first.Subnets {
+		listed[*subnet.SubnetId] = true
+	}
+	deleted := map[string]bool{}
+	for _, subnet := range all.Subnets {
+		if *subnet.SubnetId == m.GetDefaultSubnetID() || len(deleted) == 2 {
+			continue
+		}
+		if len(deleted) == 0 && listed[*subnet.SubnetId] || len(deleted) == 1 && !listed[*subnet.SubnetId] {
+			if _, err := m.DeleteSubnet(&ec2.DeleteSubnetInput{SubnetId: subnet.SubnetId}); err != nil {
+				t.Fatalf("DeleteSubnet: %v", err)
+			}
+			deleted[*subnet.SubnetId] = true
+		}
+	}
+
+	rest := []*ec2.Subnet{}
+	for nextToken := first.NextToken; nextToken != nil; {
+		output := describeSubnetPage(t, m, 5, nextToken)
+		rest = append(rest, output.Subnets...)
+		nextToken = output.NextToken
+	}
+	for _, subnet := range rest {
+		if listed[*subnet.SubnetId] || deleted[*subnet.SubnetId] {
+			t.Fatalf("subnet %s listed again or after its deletion", *subnet.SubnetId)
+		}
+	}
+	if len(first.Subnets)+len(rest) != len(all.Subnets)-1 {
+		t.Fatalf("listed %d subnets in all, want the %d that were there throughout", len(first.Subnets)+len(rest), len(all.Subnets)-1)
+	}
+}
+
+func TestPaginationValidatesParameters(t *testing.T) {
+	m := newSeededMock(t)
+	for _, maxResults := range []int64{4, 1001} {
+		_, err := m.DescribeSubnets(&ec2.DescribeSubnetsInput{MaxResults: aws.Int64(maxResults)})
+		expectErrorCode(t, err, "InvalidParameterValue")
+	}
+	_, err := m.DescribeSubnets(&ec2.DescribeSubnetsInput{NextToken: aws.String("not a token")})
+	expectErrorCode(t, err, "InvalidPaginationToken")
+}
+
+func TestPagesHelpersFollowTheDefaultPageSize(t *testing.T) {
+	m := newSeededMock(t)
+	createTestSubnets(t, m, 11)
+	m.SetDefaultPageSize(5)
+
+	pages, subnets := 0, 0
+	err := m.DescribeSubnetsPages(&ec2.DescribeSubnetsInput{}, func(output *ec2.DescribeSubnetsOutput, lastPage bool) bool {
+		pages++
+		subnets += len(output.Subnets)
+		return true
+	})
+	if err != nil || pages != 3 || subnets != 12 {
+		t.Fatalf("DescribeSubnetsPages walked %d subnets in %d pages (%v), want 12 in 3", subnets, pages, err)
+	}
+
+	pages = 0
+	err = m.DescribeSubnetsPages(&ec2.DescribeSubnetsInput{}, func(output *ec2.DescribeSubnetsOutput, lastPage bool) bool {
+		pages++
+		return false
+	})
+	if err != nil || pages != 1 {
+		t.Fatalf("DescribeSubnetsPages went on for %d pages (%v) after the callback stopped it", pages, err)
+	}
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2018 The Avi Networks.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package ec2
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	aws "github.com/aws/aws-sdk-go/aws"
+	ec2 "github.com/aws/aws-sdk-go/service/ec2"
+)
+
+func TestDryRunChangesNothing(t *testing.T) {
+	m := newSeededMock(t)
+	_, err := m.CreateSecurityGroup(&ec2.CreateSecurityGroupInput{
+		DryRun:      aws.Bool(true),
+		GroupName:   aws.String("dry-run"),
+		Description: aws.String("dry run"),
+		VpcId:       aws.String(m.GetDefaultVPCID()),
+	})
+	expectRequestFailure(t, err, "DryRunOperation", http.StatusPreconditionFailed, "Request would have succeeded, but DryRun flag is set.")
+	groups, err := m.DescribeSecurityGroups(&ec2.DescribeSecurityGroupsInput{Filters: []*ec2.Filter{filter("group-name", "dry-run")}})
+	if err != nil {
+		t.Fatalf("DescribeSecurityGroups: %v", err)
+	}
+	if len(groups.SecurityGroups) != 0 {
+		t.Fatalf("dry run created %d security groups", len(groups.SecurityGroups))
+	}
+
+	_, err = m.AllocateAddress(&ec2.AllocateAddressInput{DryRun: aws.Bool(true), Domain: aws.String("vpc")})
+	expectErrorCode(t, err, "DryRunOperation")
+	addresses, err := m.DescribeAddresses(&ec2.DescribeAddressesInput{})
+	if err != nil {
+		t.Fatalf("DescribeAddresses: %v", err)
+	}
+	if len(addresses.Addresses) != 0 {
+		t.Fatalf("dry run allocated %d addresses", len(addresses.Addresses))
+	}
+}
+
+func TestDryRunLeavesDueTransitionsPending(t *testing.T) {
+	m := newSeededMock(t)
+	m.SetTransitionDelay(InstancePending, time.Minute)
+	instance := runTestInstances(t, m, aws.String(m.GetDefaultSubnetID()), 1)[0]
+	m.clock.(*FakeClock).Advance(time.Minute)
+
+	_, err := m.StopInstances(&ec2.StopInstancesInput{DryRun: aws.Bool(true), InstanceIds: []*string{instance.InstanceId}})
+	expectErrorCode(t, err, "DryRunOperation")
+	if len(m.transitions) != 1 {
+		t.Fatalf("dry run settled the launch, %d transitions left", len(m.transitions))
+	}
+	if state := describeTestInstance(t, m, instance.InstanceId).State; aws.StringValue(state.Name) != "running" {
+		t.Fatalf("instance is %s, want running", aws.StringValue(state.Name))
+	}
+}
+
+func TestPermissionCheckDeniesCalls(t *testing.T) {
+	m := newSeededMock(t)
+	var checked []string
+	m.SetPermissionCheck(func(apiName string, input interface{}) bool {
+		checked = append(checked, apiName)
+		if apiName != "CreateVpc" {
+			return true
+		}
+		return aws.StringValue(input.(*ec2.CreateVpcInput).CidrBlock) != "10.9.0.0/16"
+	})
+
+	_, err := m.CreateVpc(&ec2.CreateVpcInput{CidrBlock: aws.String("10.9.0.0/16")})
+	expectRequestFailure(t, err, "UnauthorizedOperation", http.StatusForbidden, "You are not authorized to perform this operation.")
+	// a denied dry run is denied, not a dry run
+	_, err = m.CreateVpc(&ec2.CreateVpcInput{CidrBlock: aws.String("10.9.0.0/16"), DryRun: aws.Bool(true)})
+	expectErrorCode(t, err, "UnauthorizedOperation")
+	vpcs, err := m.DescribeVpcs(&ec2.DescribeVpcsInput{Filters: []*ec2.Filter{filter("cidr", "10.9.0.0/16")}})
+	if err != nil {
+		t.Fatalf("DescribeVpcs: %v", err)
+	}
+	if len(vpcs.Vpcs) != 0 {
+		t.Fatalf("denied call created %d vpcs", len(vpcs.Vpcs))
+	}
+
+	if _, err := m.CreateVpc(&ec2.CreateVpcInput{CidrBlock: aws.String("10.8.0.0/16")}); err != nil {
+		t.Fatalf("CreateVpc: %v", err)
+	}
+	if len(checked) != 3 {
+		t.Fatalf("permission check consulted for %v, want the three CreateVpc calls", checked)
+	}
+
+	m.SetPermissionCheck(nil)
+	if _, err := m.CreateVpc(&ec2.CreateVpcInput{CidrBlock: aws.String("10.9.0.0/16")}); err != nil {
+		t.Fatalf("CreateVpc without a permission check: %v", err)
+	}
+}
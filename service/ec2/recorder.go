@@ -13,17 +13,21 @@ limitations under the License.
 package ec2
 
 import (
-	"errors"
 	"fmt"
 	"reflect"
 	"sync"
 	"sync/atomic"
 	"time"
+
+	aws "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
 )
 
 type ApiErrorByCount struct {
-	errorStr string
-	count    int64
+	errorCode string
+	errorStr  string
+	count     int64
 }
 
 type methodArgs struct {
@@ -43,7 +47,9 @@ type Recorder struct {
 	giveErrorByApiName                    map[string]ApiErrorByCount // key will be api name
 	giveErrorNow                          bool
 	globalErrorString                     string
+	globalErrorCode                       string
 	apiErrorString                        map[string]string
+	apiErrorCode                          map[string]string
 	flagApiCountSet                       bool
 	flaggedApiCount                       int64
 	methodArgs                            map[string]methodArgs
@@ -52,6 +58,7 @@ type Recorder struct {
 	assignPrivateIpFailNetworkInterfaceId string
 	delay                                 time.Duration
 	delayByApiName                        map[string]time.Duration
+	clock                                 Clock
 	sync.Mutex
 }
 
@@ -65,6 +72,7 @@ func (r *Recorder) init() {
 	r.delayByApiName = make(map[string]time.Duration, 0)
 	r.activeByApiName = make(map[string]bool, 0)
 	r.apiErrorString = make(map[string]string, 0)
+	r.apiErrorCode = make(map[string]string, 0)
 	r.giveErrorByApiName = make(map[string]ApiErrorByCount, 0)
 	r.giveErrorNow = false
 	r.flagApiCountSet = false
@@ -72,6 +80,7 @@ func (r *Recorder) init() {
 	r.flaggedApiCount = 0
 	r.globalErrorString = "dummy error, please fill with your's :)"
 	r.delay = time.Second * 0
+	r.clock = realClock{}
 	r.NthErrorCheck = make(map[string]int64)
 	r.assignPrivateIpFailNetworkInterfaceId = ""
 	for i := 0; i < totalMethods; i++ {
@@ -94,24 +103,42 @@ type ReturnExpecter struct {
 }
 
 func (r *Recorder) SetApiActive(apiName string) {
-    r.activeByApiName[apiName] = true
-    r.apiErrorString[apiName] = "mocked error"
+	r.Lock()
+	defer r.Unlock()
+	r.activeByApiName[apiName] = true
+	r.apiErrorString[apiName] = "mocked error"
+	r.apiErrorCode[apiName] = ""
 }
 
 func (r *Recorder) SetApiInactive(apiName string, errorStr string) {
-    r.activeByApiName[apiName] = false
-    r.apiErrorString[apiName] = errorStr
+	r.SetApiInactiveWithCode(apiName, "", errorStr)
+}
+
+// SetApiInactiveWithCode fails every call of the api with an awserr carrying
+// the ec2 error code, e.g. "RequestLimitExceeded".
+func (r *Recorder) SetApiInactiveWithCode(apiName string, errorCode string, errorStr string) {
+	r.Lock()
+	defer r.Unlock()
+	r.activeByApiName[apiName] = false
+	r.apiErrorString[apiName] = errorStr
+	r.apiErrorCode[apiName] = errorCode
 }
 
 func (r *Recorder) SetError(errorStr string) {
+	r.Lock()
+	defer r.Unlock()
 	r.globalErrorString = errorStr
 }
 
 func (r *Recorder) SetAssignIpFail(nicID string) {
+	r.Lock()
+	defer r.Unlock()
 	r.assignPrivateIpFailNetworkInterfaceId = nicID
 }
 
 func (r *Recorder) GetAssignIpFailNetworkInterfaceId() string {
+	r.Lock()
+	defer r.Unlock()
 	return r.assignPrivateIpFailNetworkInterfaceId
 }
 func (re *ReturnExpecter) Return(args ...interface{}) {
@@ -133,6 +160,8 @@ func (re *ReturnExpecter) Return(args ...interface{}) {
 		Input:  re.Input,
 		Return: args,
 	}
+	re.recorder.Lock()
+	defer re.recorder.Unlock()
 	re.recorder.expectedInput[re.methodName] = expectedArguments
 }
 
@@ -153,6 +182,8 @@ func (r *Recorder) Input(methodName string, args ...interface{}) *ReturnExpecter
 }
 
 func (r *Recorder) giveRecordedOutput(methodName string, args ...interface{}) ([]interface{}, bool) {
+	r.Lock()
+	defer r.Unlock()
 	expectedArguments, ok := r.expectedInput[methodName]
 	if !ok {
 		return []interface{}{}, false
@@ -166,6 +197,8 @@ func (r *Recorder) giveRecordedOutput(methodName string, args ...interface{}) ([
 }
 
 func (r *Recorder) GiveErrorNthTime(apiName string, nth int64) {
+	r.Lock()
+	defer r.Unlock()
 	r.NthErrorCheck[apiName] = nth
 }
 
@@ -180,79 +213,141 @@ func (r *Recorder) Record(apiName string) {
 	r.countByApiName[apiName] = r.countByApiName[apiName] + 1
 }
 
+// currentClock reads the clock under the lock, SetClock may swap it at any
+// time. The timers are scheduled unlocked as they may fire right away.
+func (r *Recorder) currentClock() Clock {
+	r.Lock()
+	defer r.Unlock()
+	return r.clock
+}
+
 func (r *Recorder) GiveErrorByTimeOut(t time.Duration, errorString string) {
-	go func() {
-		time.Sleep(t)
+	r.GiveErrorCodeByTimeOut(t, "", errorString)
+}
+
+// GiveErrorCodeByTimeOut fails every call made once t has elapsed with an
+// awserr carrying the ec2 error code.
+func (r *Recorder) GiveErrorCodeByTimeOut(t time.Duration, errorCode string, errorString string) {
+	r.currentClock().AfterFunc(t, func() {
 		r.Lock()
 		defer r.Unlock()
 		r.giveErrorNow = true
+		r.globalErrorCode = errorCode
 		r.globalErrorString = errorString
-	}()
+	})
 }
 
 func (r *Recorder) GiveErrorByApiNameByTimeOut(apiName string, t time.Duration, errorString string) {
-	go func() {
-		time.Sleep(t)
+	r.GiveErrorCodeByApiNameByTimeOut(apiName, t, "", errorString)
+}
+
+// GiveErrorCodeByApiNameByTimeOut fails every call of the api made once t has
+// elapsed with an awserr carrying the ec2 error code.
+func (r *Recorder) GiveErrorCodeByApiNameByTimeOut(apiName string, t time.Duration, errorCode string, errorString string) {
+	r.currentClock().AfterFunc(t, func() {
 		r.Lock()
 		defer r.Unlock()
 		r.activeByApiName[apiName] = false
+		r.apiErrorCode[apiName] = errorCode
 		r.apiErrorString[apiName] = errorString
-	}()
+	})
 }
 
 func (r *Recorder) GiveErrorNow(errorStr string) {
+	r.GiveErrorCodeNow("", errorStr)
+}
+
+// GiveErrorCodeNow fails every following call with an awserr carrying the
+// ec2 error code.
+func (r *Recorder) GiveErrorCodeNow(errorCode string, errorStr string) {
+	r.Lock()
+	defer r.Unlock()
 	r.giveErrorNow = true
+	r.globalErrorCode = errorCode
 	r.globalErrorString = errorStr
 }
 
 func (r *Recorder) GiveErrorForApiByTime(apiName, errorString string, t time.Duration) {
-	go func() {
-		time.Sleep(t)
+	r.GiveErrorCodeForApiByTime(apiName, "", errorString, t)
+}
+
+// GiveErrorCodeForApiByTime fails the api once t has elapsed with an awserr
+// carrying the ec2 error code.
+func (r *Recorder) GiveErrorCodeForApiByTime(apiName, errorCode, errorString string, t time.Duration) {
+	r.currentClock().AfterFunc(t, func() {
 		r.Lock()
 		defer r.Unlock()
 		r.giveErrorByApiName[apiName] = ApiErrorByCount{
-			count:    0,
-			errorStr: errorString,
+			count:     0,
+			errorCode: errorCode,
+			errorStr:  errorString,
 		}
-	}()
+	})
 }
 
 func (r *Recorder) GiveErrorByApiNameCount(apiName string, count int64, errorString string) {
+	r.GiveErrorCodeByApiNameCount(apiName, count, "", errorString)
+}
+
+// GiveErrorCodeByApiNameCount fails the api with an awserr carrying the ec2
+// error code once it has been called count times.
+func (r *Recorder) GiveErrorCodeByApiNameCount(apiName string, count int64, errorCode string, errorString string) {
 	r.Lock()
 	defer r.Unlock()
 	r.giveErrorByApiName[apiName] = ApiErrorByCount{
-		count:    count,
-		errorStr: errorString,
+		count:     count,
+		errorCode: errorCode,
+		errorStr:  errorString,
 	}
 }
 
 func (r *Recorder) GiveErrorByApiCount(limit int64) {
+	r.Lock()
+	defer r.Unlock()
 	r.flagApiCountSet = true
 	atomic.StoreInt64(&r.flaggedApiCount, limit)
 }
 
 func (r *Recorder) SetDelay(duration time.Duration) {
+	r.Lock()
+	defer r.Unlock()
 	r.delay = duration
 }
 
 func (r *Recorder) SetDelayByApiName(apiName string, duration time.Duration) {
-    r.delayByApiName[apiName] = duration
+	r.Lock()
+	defer r.Unlock()
+	r.delayByApiName[apiName] = duration
 }
 
 func (r *Recorder) CheckError(apiName string) error {
-    time.Sleep(r.delay)
-    time.Sleep(r.delayByApiName[apiName])
+	return r.CheckErrorWithContext(aws.BackgroundContext(), apiName)
+}
+
+// CheckErrorWithContext is CheckError for the WithContext apis, a context
+// done before or during the delay fails the call with
+// request.CanceledErrorCode as the sdk does.
+func (r *Recorder) CheckErrorWithContext(ctx aws.Context, apiName string) error {
+	r.Lock()
+	clock, delay := r.clock, r.delay+r.delayByApiName[apiName]
+	r.Unlock()
+	// the delay is slept unlocked so concurrent calls are delayed side by side
+	if err := clock.SleepWithContext(ctx, delay); err != nil {
+		return awserr.New(request.CanceledErrorCode, "request context canceled", err)
+	}
+	r.Lock()
+	defer r.Unlock()
 	if r.giveErrorNow == true {
-		return errors.New(r.globalErrorString)
+		return r.globalError()
 	}
 
-    if r.activeByApiName[apiName] == false {
-        return errors.New(r.apiErrorString[apiName])
-    }
+	if r.activeByApiName[apiName] == false {
+		return mockedError{code: r.apiErrorCode[apiName], message: r.apiErrorString[apiName]}.err()
+	}
 
 	if r.flagApiCountSet {
-		if r.totalApiCall >= r.flaggedApiCount {
-			return errors.New(r.globalErrorString)
+		if atomic.LoadInt64(&r.totalApiCall) >= r.flaggedApiCount {
+			return r.globalError()
 		}
 	}
 
@@ -263,7 +358,7 @@ func (r *Recorder) CheckError(apiName string) error {
 		if ok {
 			if countByApiName%nth == 0 {
 				r.countByApiName[apiName] = r.countByApiName[apiName] + 1
-				return errors.New(r.globalErrorString)
+				return r.globalError()
 			}
 		}
 	}
@@ -274,9 +369,13 @@ func (r *Recorder) CheckError(apiName string) error {
 		countByApiName, ok := r.countByApiName[apiName]
 		if ok {
 			if countByApiName >= val.count {
-				return errors.New(val.errorStr)
+				return mockedError{code: val.errorCode, message: val.errorStr}.err()
 			}
 		}
 	}
 	return nil
 }
+
+func (r *Recorder) globalError() error {
+	return mockedError{code: r.globalErrorCode, message: r.globalErrorString}.err()
+}
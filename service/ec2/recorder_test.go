@@ -0,0 +1,135 @@
+/*
+Copyright 2018 The Avi Networks.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package ec2
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	ec2 "github.com/aws/aws-sdk-go/service/ec2"
+)
+
+// expectRequestFailure fails the test unless err is the awserr.RequestFailure
+// ec2 answers with.
+func expectRequestFailure(t *testing.T, err error, code string, statusCode int, message string) {
+	t.Helper()
+	failure, ok := err.(awserr.RequestFailure)
+	if !ok {
+		t.Fatalf("got error %v, want an awserr.RequestFailure", err)
+	}
+	if failure.Code() != code || failure.StatusCode() != statusCode || failure.Message() != message {
+		t.Fatalf("got %s (%d) %q, want %s (%d) %q", failure.Code(), failure.StatusCode(), failure.Message(), code, statusCode, message)
+	}
+}
+
+func TestErrorsWithoutCodeAreInternalErrors(t *testing.T) {
+	expectInternalError := func(name string, call func() error) {
+		t.Helper()
+		err := call()
+		if err == nil {
+			t.Fatalf("%s: call succeeded", name)
+		}
+		expectRequestFailure(t, err, defaultErrorCode, http.StatusInternalServerError, name)
+	}
+
+	m := New()
+	m.EXPECT().GiveErrorNow("GiveErrorNow")
+	expectInternalError("GiveErrorNow", func() error {
+		_, err := m.DescribeVpcs(&ec2.DescribeVpcsInput{})
+		return err
+	})
+
+	m = New()
+	m.EXPECT().GiveErrorByApiNameCount("DescribeVpcs", 1, "GiveErrorByApiNameCount")
+	if _, err := m.DescribeVpcs(&ec2.DescribeVpcsInput{}); err != nil {
+		t.Fatalf("DescribeVpcs failed before its count: %v", err)
+	}
+	expectInternalError("GiveErrorByApiNameCount", func() error {
+		_, err := m.DescribeVpcs(&ec2.DescribeVpcsInput{})
+		return err
+	})
+
+	m = New()
+	m.EXPECT().SetApiInactive("DescribeVpcs", "SetApiInactive")
+	expectInternalError("SetApiInactive", func() error {
+		_, err := m.DescribeVpcs(&ec2.DescribeVpcsInput{})
+		return err
+	})
+
+	m = New()
+	clock := NewFakeClock(time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC))
+	m.SetClock(clock)
+	m.EXPECT().GiveErrorForApiByTime("DescribeSubnets", "GiveErrorForApiByTime", time.Minute)
+	m.EXPECT().GiveErrorByApiNameByTimeOut("DescribeVpcs", time.Minute, "GiveErrorByApiNameByTimeOut")
+	clock.Advance(time.Minute)
+	expectInternalError("GiveErrorForApiByTime", func() error {
+		_, err := m.DescribeSubnets(&ec2.DescribeSubnetsInput{})
+		return err
+	})
+	expectInternalError("GiveErrorByApiNameByTimeOut", func() error {
+		_, err := m.DescribeVpcs(&ec2.DescribeVpcsInput{})
+		return err
+	})
+	m.EXPECT().GiveErrorByTimeOut(time.Minute, "GiveErrorByTimeOut")
+	clock.Advance(time.Minute)
+	expectInternalError("GiveErrorByTimeOut", func() error {
+		_, err := m.DescribeInstances(&ec2.DescribeInstancesInput{})
+		return err
+	})
+}
+
+func TestTimedErrorsCarryTheirOwnCode(t *testing.T) {
+	m := New()
+	clock := NewFakeClock(time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC))
+	m.SetClock(clock)
+
+	m.EXPECT().GiveErrorCodeNow("RequestLimitExceeded", "slow down")
+	_, err := m.DescribeVpcs(&ec2.DescribeVpcsInput{})
+	expectErrorCode(t, err, "RequestLimitExceeded")
+
+	// a timed error without a code doesn't inherit the earlier one
+	m.EXPECT().GiveErrorByTimeOut(time.Minute, "timed out")
+	clock.Advance(time.Minute)
+	_, err = m.DescribeVpcs(&ec2.DescribeVpcsInput{})
+	expectRequestFailure(t, err, defaultErrorCode, http.StatusInternalServerError, "timed out")
+	m.EXPECT().GiveErrorCodeByTimeOut(time.Minute, "Unavailable", "gone")
+	clock.Advance(time.Minute)
+	_, err = m.DescribeVpcs(&ec2.DescribeVpcsInput{})
+	expectErrorCode(t, err, "Unavailable")
+}
+
+func TestTimedApiErrorsCarryTheirOwnCode(t *testing.T) {
+	m := New()
+	clock := NewFakeClock(time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC))
+	m.SetClock(clock)
+
+	m.EXPECT().SetApiInactiveWithCode("DescribeVpcs", "RequestLimitExceeded", "slow down")
+	m.EXPECT().GiveErrorByApiNameByTimeOut("DescribeVpcs", time.Minute, "timed out")
+	clock.Advance(time.Minute)
+	_, err := m.DescribeVpcs(&ec2.DescribeVpcsInput{})
+	expectRequestFailure(t, err, defaultErrorCode, http.StatusInternalServerError, "timed out")
+	m.EXPECT().GiveErrorCodeByApiNameByTimeOut("DescribeVpcs", time.Minute, "Unavailable", "gone")
+	clock.Advance(time.Minute)
+	_, err = m.DescribeVpcs(&ec2.DescribeVpcsInput{})
+	expectErrorCode(t, err, "Unavailable")
+
+	m.EXPECT().GiveErrorCodeForApiByTime("DescribeSubnets", "InternalError", "boom", time.Minute)
+	if _, err := m.DescribeSubnets(&ec2.DescribeSubnetsInput{}); err != nil {
+		t.Fatalf("DescribeSubnets failed before its time: %v", err)
+	}
+	clock.Advance(time.Minute)
+	_, err = m.DescribeSubnets(&ec2.DescribeSubnetsInput{})
+	expectErrorCode(t, err, "InternalError")
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2018 The Avi Networks.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package ec2
+
+import (
+	"net"
+	"strings"
+
+	aws "github.com/aws/aws-sdk-go/aws"
+	ec2 "github.com/aws/aws-sdk-go/service/ec2"
+)
+
+// routeTableOf looks a route table up by id.
+func (_m *EC2API) routeTableOf(routeTableId *string) (*ec2.RouteTable, error) {
+	routeTable, ok := _m.routeTable[aws.StringValue(routeTableId)]
+	if !ok {
+		return nil, newAwsError("InvalidRouteTableID.NotFound", "The routeTable ID '"+aws.StringValue(routeTableId)+"' does not exist")
+	}
+	return routeTable, nil
+}
+
+// routeTableAssociationOf looks a route table association up by id, along
+// with the route table it belongs to.
+func (_m *EC2API) routeTableAssociationOf(associationId *string) (*ec2.RouteTable, *ec2.RouteTableAssociation, error) {
+	for _, routeTable := range _m.routeTable {
+		for _, association := range routeTable.Associations {
+			if aws.StringValue(association.RouteTableAssociationId) == aws.StringValue(associationId) {
+				return routeTable, association, nil
+			}
+		}
+	}
+	return nil, nil, newAwsError("InvalidAssociationID.NotFound", "The association ID '"+aws.StringValue(associationId)+"' does not exist")
+}
+
+// routeDestination checks the destination of a route request and returns a
+// route holding it in canonical form.
+func routeDestination(cidrBlock *string, ipv6CidrBlock *string) (*ec2.Route, error) {
+	switch {
+	case cidrBlock == nil && ipv6CidrBlock == nil:
+		return nil, newAwsError("MissingParameter", "The request must contain the parameter destinationCidrBlock or destinationIpv6CidrBlock")
+	case cidrBlock != nil && ipv6CidrBlock != nil:
+		return nil, newAwsError("InvalidParameterCombination", "The parameter destinationCidrBlock cannot be used with the parameter destinationIpv6CidrBlock")
+	case cidrBlock != nil:
+		ip, block, err := net.ParseCIDR(*cidrBlock)
+		if err != nil || ip.To4() == nil {
+			return nil, newAwsError("InvalidParameterValue", "Value ("+*cidrBlock+") for parameter destinationCidrBlock is invalid. This is not a valid CIDR block.")
+		}
+		return &ec2.Route{DestinationCidrBlock: aws.String(block.String())}, nil
+	default:
+		ip, block, err := net.ParseCIDR(*ipv6CidrBlock)
+		if err != nil || ip.To4() != nil {
+			return nil, newAwsError("InvalidParameterValue", "Value ("+*ipv6CidrBlock+") for parameter destinationIpv6CidrBlock is invalid. This is not a valid IPv6 CIDR block.")
+		}
+		return &ec2.Route{DestinationIpv6CidrBlock: aws.String(block.String())}, nil
+	}
+}
+
+// routeDestinationString gives the destination of a route as ec2 quotes it
+// in its errors.
+func routeDestinationString(route *ec2.Route) string {
+	if route.DestinationIpv6CidrBlock != nil {
+		return *route.DestinationIpv6CidrBlock
+	}
+	return aws.StringValue(route.DestinationCidrBlock)
+}
+
+// findRoute returns the index of the route to the destination in the route
+// table, -1 when there is none.
+func findRoute(routeTable *ec2.RouteTable, destination *ec2.Route) int {
+	for index, route := range routeTable.Routes {
+		if aws.StringValue(route.DestinationCidrBlock) == aws.StringValue(destination.DestinationCidrBlock) &&
+			aws.StringValue(route.DestinationIpv6CidrBlock) == aws.StringValue(destination.DestinationIpv6CidrBlock) {
+			return index
+		}
+	}
+	return -1
+}
+
+// routeTarget checks that the route names exactly one target that exists in
+// the route table's vpc and fills in what ec2 derives from it: an instance
+// target routes through its only interface, an interface target shows the
+// instance it is attached to.
+func (_m *EC2API) routeTarget(routeTable *ec2.RouteTable, route *ec2.Route) error {
+	targets := 0
+	for _, target := range []*string{route.EgressOnlyInternetGatewayId, route.GatewayId, route.InstanceId, route.NatGatewayId, route.NetworkInterfaceId, route.TransitGatewayId, route.VpcPeeringConnectionId} {
+		if target != nil {
+			targets++
+		}
+	}
+	switch {
+	case targets == 0:
+		return newAwsError("MissingParameter", "The request must contain exactly one of gatewayId, natGatewayId, networkInterfaceId, vpcPeeringConnectionId, egressOnlyInternetGatewayId, transitGatewayId or instanceId")
+	case targets > 1:
+		return newAwsError("InvalidParameterCombination", "More than one target has been specified for the route")
+	case aws.StringValue(route.GatewayId) == "local":
+		return newAwsError("InvalidParameterValue", "The local route target is reserved for the routes ec2 creates for the vpc")
+	}
+	if strings.HasPrefix(aws.StringValue(route.GatewayId), "igw-") {
+		internetGateway, ok := _m.internetGateways[*route.GatewayId]
+		if !ok {
+			return newAwsError("InvalidGatewayID.NotFound", "The gateway ID '"+*route.GatewayId+"' does not exist")
+		}
+		if !attachedToVpc(internetGateway.Attachments, aws.StringValue(routeTable.VpcId)) {
+			return newAwsError("InvalidParameterValue", "route table "+*routeTable.RouteTableId+" and network gateway "+*route.GatewayId+" belong to different networks")
+		}
+	}
+	if route.EgressOnlyInternetGatewayId != nil {
+		egressOnlyInternetGateway, err := _m.egressOnlyInternetGatewayOf(route.EgressOnlyInternetGatewayId)
+		if err != nil {
+			return err
+		}
+		if !attachedToVpc(egressOnlyInternetGateway.Attachments, aws.StringValue(routeTable.VpcId)) {
+			return newAwsError("InvalidParameterValue", "route table "+*routeTable.RouteTableId+" and network gateway "+*route.EgressOnlyInternetGatewayId+" belong to different networks")
+		}
+		if route.DestinationIpv6CidrBlock == nil {
+			return newAwsError("InvalidParameterValue", "Egress only internet gateways can only route IPv6 destinations")
+		}
+	}
+	if route.NatGatewayId != nil {
+		natGateway, err := _m.natGatewayOf(route.NatGatewayId)
+		if err != nil {
+			return err
+		}
+		if !natGatewayActive(natGateway) {
+			return newAwsError("InvalidNatGatewayID.NotFound", "The natGateway ID '"+*route.NatGatewayId+"' does not exist")
+		}
+		if aws.StringValue(natGateway.VpcId) != aws.StringValue(routeTable.VpcId) {
+			return newAwsError("InvalidParameterValue", "route table "+*routeTable.RouteTableId+" and nat gateway "+*route.NatGatewayId+" belong to different networks")
+		}
+	}
+	if route.InstanceId != nil {
+		instance, ok := _m.getInstance(*route.InstanceId)
+		if !ok || aws.Int64Value(instance.State.Code) == TERMINATED {
+			return newAwsError("InvalidInstanceID.NotFound", "The instance ID '"+*route.InstanceId+"' does not exist")
+		}
+		if len(instance.NetworkInterfaces) != 1 {
+			return newAwsError("InvalidInstanceID", "There are multiple interfaces attached to instance '"+*route.InstanceId+"'. Please specify an interface ID for the operation instead.")
+		}
+		route.NetworkInterfaceId = instance.NetworkInterfaces[0].NetworkInterfaceId
+	}
+	if route.NetworkInterfaceId != nil {
+		ntwInterface, ok := _m.networkinterfaces[*route.NetworkInterfaceId]
+		if !ok {
+			return newAwsError("InvalidNetworkInterfaceID.NotFound", "The networkInterface ID '"+*route.NetworkInterfaceId+"' does not exist")
+		}
+		if aws.StringValue(ntwInterface.VpcId) != aws.StringValue(routeTable.VpcId) {
+			return newAwsError("InvalidParameterValue", "route table "+*routeTable.RouteTableId+" and network interface "+*route.NetworkInterfaceId+" belong to different networks")
+		}
+		if ntwInterface.Attachment != nil {
+			route.InstanceId = ntwInterface.Attachment.InstanceId
+			route.InstanceOwnerId = aws.String(defaultOwnerId)
+		}
+	}
+	route.State = aws.String(ec2.RouteStateActive)
+	return nil
+}
+
+// blackholeRoutes marks the routes whose target went away, ec2 keeps them
+// around until they are deleted or replaced.
+func (_m *EC2API) blackholeRoutes(gone func(route *ec2.Route) bool) {
+	for _, routeTable := range _m.routeTable {
+		for _, route := range routeTable.Routes {
+			if aws.StringValue(route.GatewayId) != "local" && gone(route) {
+				route.State = aws.String(ec2.RouteStateBlackhole)
+			}
+		}
+	}
+}
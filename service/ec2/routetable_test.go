@@ -0,0 +1,174 @@
+/*
+Copyright 2018 The Avi Networks.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package ec2
+
+import (
+	"testing"
+
+	aws "github.com/aws/aws-sdk-go/aws"
+	ec2 "github.com/aws/aws-sdk-go/service/ec2"
+)
+
+// createTestVpc creates a vpc with a subnet of the default availability zone.
+func createTestVpc(t *testing.T, m *EC2API, cidrBlock, subnetCidrBlock string) (*ec2.Vpc, *ec2.Subnet) {
+	t.Helper()
+	vpc, err := m.CreateVpc(&ec2.CreateVpcInput{CidrBlock: aws.String(cidrBlock)})
+	if err != nil {
+		t.Fatalf("CreateVpc(%s): %v", cidrBlock, err)
+	}
+	subnet, err := m.CreateSubnet(&ec2.CreateSubnetInput{
+		VpcId:            vpc.Vpc.VpcId,
+		CidrBlock:        aws.String(subnetCidrBlock),
+		AvailabilityZone: aws.String(m.GetDefaultAvailabiltyZone()),
+	})
+	if err != nil {
+		t.Fatalf("CreateSubnet(%s): %v", subnetCidrBlock, err)
+	}
+	return vpc.Vpc, subnet.Subnet
+}
+
+func describeTestRouteTables(t *testing.T, m *EC2API, filters ...*ec2.Filter) []*ec2.RouteTable {
+	t.Helper()
+	output, err := m.DescribeRouteTables(&ec2.DescribeRouteTablesInput{Filters: filters})
+	if err != nil {
+		t.Fatalf("DescribeRouteTables: %v", err)
+	}
+	return output.RouteTables
+}
+
+// testRoute gives the route of the table to the destination, nil if there is
+// none.
+func testRoute(routeTable *ec2.RouteTable, destination string) *ec2.Route {
+	for _, route := range routeTable.Routes {
+		if aws.StringValue(route.DestinationCidrBlock) == destination {
+			return route
+		}
+	}
+	return nil
+}
+
+func TestRoutesNeedOneTargetOfTheVpc(t *testing.T) {
+	m := newSeededMock(t)
+	vpc, subnet := createTestVpc(t, m, "10.1.0.0/16", "10.1.1.0/24")
+	created, err := m.CreateRouteTable(&ec2.CreateRouteTableInput{VpcId: vpc.VpcId})
+	if err != nil {
+		t.Fatalf("CreateRouteTable: %v", err)
+	}
+	routeTableId := created.RouteTable.RouteTableId
+	if local := testRoute(created.RouteTable, "10.1.0.0/16"); local == nil || aws.StringValue(local.GatewayId) != "local" {
+		t.Fatalf("new route table has routes %v, want the local route of the vpc", created.RouteTable.Routes)
+	}
+
+	ntwInterface := createTestInterface(t, m, subnet.SubnetId)
+	otherInterface := createTestInterface(t, m, aws.String(m.GetDefaultSubnetID()))
+	for _, test := range []struct {
+		name  string
+		input *ec2.CreateRouteInput
+		code  string
+	}{
+		{"no destination", &ec2.CreateRouteInput{NetworkInterfaceId: ntwInterface.NetworkInterfaceId}, "MissingParameter"},
+		{"malformed destination", &ec2.CreateRouteInput{DestinationCidrBlock: aws.String("10.2.0.0"), NetworkInterfaceId: ntwInterface.NetworkInterfaceId}, "InvalidParameterValue"},
+		{"no target", &ec2.CreateRouteInput{DestinationCidrBlock: aws.String("0.0.0.0/0")}, "MissingParameter"},
+		{"two targets", &ec2.CreateRouteInput{DestinationCidrBlock: aws.String("0.0.0.0/0"), NetworkInterfaceId: ntwInterface.NetworkInterfaceId, GatewayId: aws.String("igw-x")}, "InvalidParameterCombination"},
+		{"local target", &ec2.CreateRouteInput{DestinationCidrBlock: aws.String("0.0.0.0/0"), GatewayId: aws.String("local")}, "InvalidParameterValue"},
+		{"interface of another vpc", &ec2.CreateRouteInput{DestinationCidrBlock: aws.String("0.0.0.0/0"), NetworkInterfaceId: otherInterface.NetworkInterfaceId}, "InvalidParameterValue"},
+		{"existing destination", &ec2.CreateRouteInput{DestinationCidrBlock: aws.String("10.1.0.0/16"), NetworkInterfaceId: ntwInterface.NetworkInterfaceId}, "RouteAlreadyExists"},
+	} {
+		test.input.RouteTableId = routeTableId
+		_, err := m.CreateRoute(test.input)
+		if errorCode(err) != test.code {
+			t.Errorf("%s: got error %v, want code %s", test.name, err, test.code)
+		}
+	}
+
+	if _, err := m.CreateRoute(&ec2.CreateRouteInput{
+		RouteTableId:         routeTableId,
+		DestinationCidrBlock: aws.String("0.0.0.0/0"),
+		NetworkInterfaceId:   ntwInterface.NetworkInterfaceId,
+	}); err != nil {
+		t.Fatalf("CreateRoute: %v", err)
+	}
+	_, err = m.ReplaceRoute(&ec2.ReplaceRouteInput{RouteTableId: routeTableId, DestinationCidrBlock: aws.String("10.1.0.0/16"), NetworkInterfaceId: ntwInterface.NetworkInterfaceId})
+	expectErrorCode(t, err, "InvalidParameterValue")
+	_, err = m.ReplaceRoute(&ec2.ReplaceRouteInput{RouteTableId: routeTableId, DestinationCidrBlock: aws.String("192.168.0.0/16"), NetworkInterfaceId: ntwInterface.NetworkInterfaceId})
+	expectErrorCode(t, err, "InvalidRoute.NotFound")
+	_, err = m.DeleteRoute(&ec2.DeleteRouteInput{RouteTableId: routeTableId, DestinationCidrBlock: aws.String("10.1.0.0/16")})
+	expectErrorCode(t, err, "InvalidParameterValue")
+
+	// a route outlives its target as a blackhole
+	if _, err := m.DeleteNetworkInterface(&ec2.DeleteNetworkInterfaceInput{NetworkInterfaceId: ntwInterface.NetworkInterfaceId}); err != nil {
+		t.Fatalf("DeleteNetworkInterface: %v", err)
+	}
+	routeTable := describeTestRouteTables(t, m, filter("route-table-id", *routeTableId))[0]
+	if route := testRoute(routeTable, "0.0.0.0/0"); aws.StringValue(route.State) != ec2.RouteStateBlackhole {
+		t.Fatalf("route to a deleted interface is %s", aws.StringValue(route.State))
+	}
+	if _, err := m.DeleteRoute(&ec2.DeleteRouteInput{RouteTableId: routeTableId, DestinationCidrBlock: aws.String("0.0.0.0/0")}); err != nil {
+		t.Fatalf("DeleteRoute: %v", err)
+	}
+	routeTable = describeTestRouteTables(t, m, filter("route-table-id", *routeTableId))[0]
+	if testRoute(routeTable, "0.0.0.0/0") != nil {
+		t.Fatal("deleted route is still there")
+	}
+}
+
+func TestRouteTableAssociations(t *testing.T) {
+	m := newSeededMock(t)
+	vpc, subnet := createTestVpc(t, m, "10.1.0.0/16", "10.1.1.0/24")
+	main := describeTestRouteTables(t, m, filter("vpc-id", *vpc.VpcId), filter("association.main", "true"))
+	if len(main) != 1 {
+		t.Fatalf("vpc has %d main route tables, want 1", len(main))
+	}
+	created, err := m.CreateRouteTable(&ec2.CreateRouteTableInput{VpcId: vpc.VpcId})
+	if err != nil {
+		t.Fatalf("CreateRouteTable: %v", err)
+	}
+	routeTableId := created.RouteTable.RouteTableId
+
+	associated, err := m.AssociateRouteTable(&ec2.AssociateRouteTableInput{RouteTableId: routeTableId, SubnetId: subnet.SubnetId})
+	if err != nil {
+		t.Fatalf("AssociateRouteTable: %v", err)
+	}
+	_, err = m.AssociateRouteTable(&ec2.AssociateRouteTableInput{RouteTableId: main[0].RouteTableId, SubnetId: subnet.SubnetId})
+	expectErrorCode(t, err, "Resource.AlreadyAssociated")
+	_, err = m.AssociateRouteTable(&ec2.AssociateRouteTableInput{RouteTableId: routeTableId, SubnetId: aws.String(m.GetDefaultSubnetID())})
+	expectErrorCode(t, err, "InvalidParameterValue")
+	_, err = m.DeleteRouteTable(&ec2.DeleteRouteTableInput{RouteTableId: routeTableId})
+	expectErrorCode(t, err, "DependencyViolation")
+
+	replaced, err := m.ReplaceRouteTableAssociation(&ec2.ReplaceRouteTableAssociationInput{AssociationId: associated.AssociationId, RouteTableId: main[0].RouteTableId})
+	if err != nil {
+		t.Fatalf("ReplaceRouteTableAssociation: %v", err)
+	}
+	if *replaced.NewAssociationId == *associated.AssociationId {
+		t.Fatal("the replaced association kept its id")
+	}
+	if len(describeTestRouteTables(t, m, filter("association.subnet-id", *subnet.SubnetId), filter("route-table-id", *main[0].RouteTableId))) != 1 {
+		t.Fatal("the subnet did not move to the main route table")
+	}
+	if _, err := m.DeleteRouteTable(&ec2.DeleteRouteTableInput{RouteTableId: routeTableId}); err != nil {
+		t.Fatalf("DeleteRouteTable: %v", err)
+	}
+
+	for _, association := range describeTestRouteTables(t, m, filter("route-table-id", *main[0].RouteTableId))[0].Associations {
+		_, err := m.DisassociateRouteTable(&ec2.DisassociateRouteTableInput{AssociationId: association.RouteTableAssociationId})
+		if aws.BoolValue(association.Main) {
+			expectErrorCode(t, err, "InvalidParameterValue")
+		} else if err != nil {
+			t.Fatalf("DisassociateRouteTable: %v", err)
+		}
+	}
+	if len(describeTestRouteTables(t, m, filter("association.subnet-id", *subnet.SubnetId))) != 0 {
+		t.Fatal("the subnet is still explicitly associated")
+	}
+}
@@ -0,0 +1,482 @@
+/*
+Copyright 2018 The Avi Networks.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package ec2
+
+import (
+	"net"
+	"strconv"
+	"strings"
+
+	aws "github.com/aws/aws-sdk-go/aws"
+	ec2 "github.com/aws/aws-sdk-go/service/ec2"
+)
+
+// ruleProtocols maps the protocol names and numbers ec2 accepts to the form
+// it reports them in.
+var ruleProtocols = map[string]string{
+	"-1":     "-1",
+	"all":    "-1",
+	"tcp":    "tcp",
+	"6":      "tcp",
+	"udp":    "udp",
+	"17":     "udp",
+	"icmp":   "icmp",
+	"1":      "icmp",
+	"icmpv6": "icmpv6",
+	"58":     "icmpv6",
+}
+
+// allowAllEgress is the rule ec2 puts on every new security group.
+func allowAllEgress() []*ec2.IpPermission {
+	return []*ec2.IpPermission{
+		&ec2.IpPermission{
+			IpProtocol: aws.String("-1"),
+			IpRanges:   []*ec2.IpRange{&ec2.IpRange{CidrIp: aws.String("0.0.0.0/0")}},
+		},
+	}
+}
+
+// securityGroupRules gives the ingress or the egress rules of the group.
+func securityGroupRules(group *ec2.SecurityGroup, egress bool) *[]*ec2.IpPermission {
+	if egress {
+		return &group.IpPermissionsEgress
+	}
+	return &group.IpPermissions
+}
+
+// securityGroupOf resolves the group a rule request targets, by id or, for
+// the requests that still take it, by name.
+func (_m *EC2API) securityGroupOf(groupId, groupName *string) (*ec2.SecurityGroup, error) {
+	if groupId != nil {
+		group, ok := _m.assignedsecurityGroups[*groupId]
+		if !ok {
+			return nil, newAwsError("InvalidGroup.NotFound", "The security group '"+*groupId+"' does not exist")
+		}
+		return group, nil
+	}
+	if groupName == nil {
+		return nil, newAwsError("MissingParameter", "The request must contain the parameter groupName or groupId")
+	}
+	for _, group := range _m.assignedsecurityGroups {
+		if aws.StringValue(group.GroupName) == *groupName && aws.StringValue(group.VpcId) == defaultVpcID {
+			return group, nil
+		}
+	}
+	for _, group := range _m.assignedsecurityGroups {
+		if aws.StringValue(group.GroupName) == *groupName {
+			return group, nil
+		}
+	}
+	return nil, newAwsError("InvalidGroup.NotFound", "The security group '"+*groupName+"' does not exist in default VPC '"+defaultVpcID+"'")
+}
+
+// legacyPermissions turns the top level fields of a rule request into ip
+// permissions, ec2 takes either those or IpPermissions.
+func legacyPermissions(permissions []*ec2.IpPermission, protocol *string, fromPort, toPort *int64, cidrIp, sourceGroupName, sourceGroupOwnerId *string) ([]*ec2.IpPermission, error) {
+	legacy := protocol != nil || fromPort != nil || toPort != nil || cidrIp != nil || sourceGroupName != nil
+	if !legacy {
+		if len(permissions) == 0 {
+			return nil, newAwsError("MissingParameter", "The request must contain the parameter ipPermissions")
+		}
+		return permissions, nil
+	}
+	if len(permissions) != 0 {
+		return nil, newAwsError("InvalidParameterCombination", "The parameter 'ipPermissions' may not be used in combination with 'ipProtocol', 'fromPort', 'toPort', 'cidrIp' or 'sourceSecurityGroupName'")
+	}
+	permission := &ec2.IpPermission{
+		IpProtocol: protocol,
+		FromPort:   fromPort,
+		ToPort:     toPort,
+	}
+	if permission.IpProtocol == nil {
+		permission.IpProtocol = aws.String("-1")
+	}
+	if cidrIp != nil {
+		permission.IpRanges = []*ec2.IpRange{&ec2.IpRange{CidrIp: cidrIp}}
+	}
+	if sourceGroupName != nil {
+		permission.UserIdGroupPairs = []*ec2.UserIdGroupPair{
+			&ec2.UserIdGroupPair{GroupName: sourceGroupName, UserId: sourceGroupOwnerId},
+		}
+	}
+	return []*ec2.IpPermission{permission}, nil
+}
+
+// normalizePermissions validates the requested permissions against the group
+// and splits them in one permission per peer, in the form ec2 stores them.
+func (_m *EC2API) normalizePermissions(group *ec2.SecurityGroup, permissions []*ec2.IpPermission) ([]*ec2.IpPermission, error) {
+	singles := []*ec2.IpPermission{}
+	for _, permission := range permissions {
+		rule, err := normalizeRule(permission)
+		if err != nil {
+			return nil, err
+		}
+		peers := 0
+		for _, ipRange := range permission.IpRanges {
+			ip, _, err := net.ParseCIDR(aws.StringValue(ipRange.CidrIp))
+			if err != nil || ip.To4() == nil {
+				return nil, newAwsError("InvalidParameterValue", "CIDR block "+aws.StringValue(ipRange.CidrIp)+" is malformed")
+			}
+			single := *rule
+			single.IpRanges = []*ec2.IpRange{&ec2.IpRange{CidrIp: ipRange.CidrIp, Description: ipRange.Description}}
+			singles = append(singles, &single)
+			peers++
+		}
+		for _, ipv6Range := range permission.Ipv6Ranges {
+			ip, _, err := net.ParseCIDR(aws.StringValue(ipv6Range.CidrIpv6))
+			if err != nil || ip.To4() != nil {
+				return nil, newAwsError("InvalidParameterValue", "CIDR block "+aws.StringValue(ipv6Range.CidrIpv6)+" is malformed")
+			}
+			single := *rule
+			single.Ipv6Ranges = []*ec2.Ipv6Range{&ec2.Ipv6Range{CidrIpv6: ipv6Range.CidrIpv6, Description: ipv6Range.Description}}
+			singles = append(singles, &single)
+			peers++
+		}
+		for _, prefixList := range permission.PrefixListIds {
+			if !strings.HasPrefix(aws.StringValue(prefixList.PrefixListId), "pl-") {
+				return nil, newAwsError("InvalidPrefixListId.Malformed", "The prefix list ID '"+aws.StringValue(prefixList.PrefixListId)+"' is malformed")
+			}
+			single := *rule
+			single.PrefixListIds = []*ec2.PrefixListId{&ec2.PrefixListId{PrefixListId: prefixList.PrefixListId, Description: prefixList.Description}}
+			singles = append(singles, &single)
+			peers++
+		}
+		for _, pair := range permission.UserIdGroupPairs {
+			resolved, err := _m.resolveGroupPair(group, pair)
+			if err != nil {
+				return nil, err
+			}
+			single := *rule
+			single.UserIdGroupPairs = []*ec2.UserIdGroupPair{resolved}
+			singles = append(singles, &single)
+			peers++
+		}
+		if peers == 0 {
+			return nil, newAwsError("MissingParameter", "Each permission must specify an ip range, an ipv6 range, a prefix list or a security group")
+		}
+	}
+	return singles, nil
+}
+
+// normalizeRule gives the protocol and port range of the permission the way
+// ec2 reports them.
+func normalizeRule(permission *ec2.IpPermission) (*ec2.IpPermission, error) {
+	requested := strings.ToLower(aws.StringValue(permission.IpProtocol))
+	protocol, ok := ruleProtocols[requested]
+	if !ok {
+		number, err := strconv.Atoi(requested)
+		if err != nil || number < 0 || number > 255 {
+			return nil, newAwsError("InvalidParameterValue", "Invalid value '"+aws.StringValue(permission.IpProtocol)+"' for IP protocol. Unknown protocol.")
+		}
+		protocol = requested
+	}
+	rule := &ec2.IpPermission{IpProtocol: aws.String(protocol)}
+	switch protocol {
+	case "tcp", "udp":
+		if permission.FromPort == nil || permission.ToPort == nil {
+			return nil, newAwsError("InvalidParameterValue", "Invalid value 'null' for portRange. Must specify both from and to ports with TCP/UDP.")
+		}
+		from, to := *permission.FromPort, *permission.ToPort
+		if from < 0 || to > 65535 || from > to {
+			return nil, newAwsError("InvalidParameterValue", "Invalid value '"+strconv.FormatInt(from, 10)+"-"+strconv.FormatInt(to, 10)+"' for portRange.")
+		}
+		rule.FromPort = aws.Int64(from)
+		rule.ToPort = aws.Int64(to)
+	case "icmp", "icmpv6":
+		// for icmp the ports hold the type and the code, -1 means any
+		icmpType, icmpCode := int64(-1), int64(-1)
+		if permission.FromPort != nil {
+			icmpType = *permission.FromPort
+		}
+		if permission.ToPort != nil {
+			icmpCode = *permission.ToPort
+		}
+		if icmpType < -1 || icmpType > 255 || icmpCode < -1 || icmpCode > 255 {
+			return nil, newAwsError("InvalidParameterValue", "Invalid value for ICMP type or code.")
+		}
+		rule.FromPort = aws.Int64(icmpType)
+		rule.ToPort = aws.Int64(icmpCode)
+	}
+	return rule, nil
+}
+
+// resolveGroupPair fills in the group id and owner of a referenced group, a
+// group of another vpc can only be referenced through a peering connection.
+func (_m *EC2API) resolveGroupPair(group *ec2.SecurityGroup, pair *ec2.UserIdGroupPair) (*ec2.UserIdGroupPair, error) {
+	var referenced *ec2.SecurityGroup
+	if pair.GroupId != nil {
+		referenced = _m.assignedsecurityGroups[*pair.GroupId]
+		if referenced == nil {
+			return nil, newAwsError("InvalidGroup.NotFound", "The security group '"+*pair.GroupId+"' does not exist")
+		}
+	} else {
+		for _, candidate := range _m.assignedsecurityGroups {
+			if aws.StringValue(candidate.GroupName) == aws.StringValue(pair.GroupName) && aws.StringValue(candidate.VpcId) == aws.StringValue(group.VpcId) {
+				referenced = candidate
+				break
+			}
+		}
+		if referenced == nil {
+			return nil, newAwsError("InvalidGroup.NotFound", "The security group '"+aws.StringValue(pair.GroupName)+"' does not exist in VPC '"+aws.StringValue(group.VpcId)+"'")
+		}
+	}
+	resolved := &ec2.UserIdGroupPair{
+		GroupId:     referenced.GroupId,
+		UserId:      aws.String(defaultOwnerId),
+		Description: pair.Description,
+	}
+	if pair.UserId != nil {
+		resolved.UserId = aws.String(*pair.UserId)
+	}
+	if aws.StringValue(referenced.VpcId) != aws.StringValue(group.VpcId) {
+		if pair.VpcPeeringConnectionId == nil {
+			return nil, newAwsError("InvalidGroup.NotFound", "You have specified two resources that belong to different networks.")
+		}
+		resolved.VpcId = referenced.VpcId
+		resolved.VpcPeeringConnectionId = aws.String(*pair.VpcPeeringConnectionId)
+		resolved.PeeringStatus = aws.String("active")
+	}
+	return resolved, nil
+}
+
+// explodePermissions splits stored permissions in one permission per peer.
+func explodePermissions(permissions []*ec2.IpPermission) []*ec2.IpPermission {
+	singles := []*ec2.IpPermission{}
+	for _, permission := range permissions {
+		rule := ec2.IpPermission{IpProtocol: permission.IpProtocol, FromPort: permission.FromPort, ToPort: permission.ToPort}
+		for _, ipRange := range permission.IpRanges {
+			single := rule
+			single.IpRanges = []*ec2.IpRange{ipRange}
+			singles = append(singles, &single)
+		}
+		for _, ipv6Range := range permission.Ipv6Ranges {
+			single := rule
+			single.Ipv6Ranges = []*ec2.Ipv6Range{ipv6Range}
+			singles = append(singles, &single)
+		}
+		for _, prefixList := range permission.PrefixListIds {
+			single := rule
+			single.PrefixListIds = []*ec2.PrefixListId{prefixList}
+			singles = append(singles, &single)
+		}
+		for _, pair := range permission.UserIdGroupPairs {
+			single := rule
+			single.UserIdGroupPairs = []*ec2.UserIdGroupPair{pair}
+			singles = append(singles, &single)
+		}
+	}
+	return singles
+}
+
+// groupPermissions merges single peer permissions back into one permission
+// per protocol and port range, in the order they first appear.
+func groupPermissions(singles []*ec2.IpPermission) []*ec2.IpPermission {
+	permissions := []*ec2.IpPermission{}
+	byRule := map[string]*ec2.IpPermission{}
+	for _, single := range singles {
+		key := ruleKey(single)
+		permission, ok := byRule[key]
+		if !ok {
+			permission = &ec2.IpPermission{
+				IpProtocol:       single.IpProtocol,
+				FromPort:         single.FromPort,
+				ToPort:           single.ToPort,
+				IpRanges:         []*ec2.IpRange{},
+				Ipv6Ranges:       []*ec2.Ipv6Range{},
+				PrefixListIds:    []*ec2.PrefixListId{},
+				UserIdGroupPairs: []*ec2.UserIdGroupPair{},
+			}
+			byRule[key] = permission
+			permissions = append(permissions, permission)
+		}
+		permission.IpRanges = append(permission.IpRanges, single.IpRanges...)
+		permission.Ipv6Ranges = append(permission.Ipv6Ranges, single.Ipv6Ranges...)
+		permission.PrefixListIds = append(permission.PrefixListIds, single.PrefixListIds...)
+		permission.UserIdGroupPairs = append(permission.UserIdGroupPairs, single.UserIdGroupPairs...)
+	}
+	return permissions
+}
+
+func ruleKey(permission *ec2.IpPermission) string {
+	key := aws.StringValue(permission.IpProtocol)
+	if permission.FromPort != nil {
+		key += ":" + strconv.FormatInt(*permission.FromPort, 10)
+	}
+	if permission.ToPort != nil {
+		key += "-" + strconv.FormatInt(*permission.ToPort, 10)
+	}
+	return key
+}
+
+// peerOf names the peer of a single peer permission, descriptions aside.
+func peerOf(single *ec2.IpPermission) string {
+	switch {
+	case len(single.IpRanges) != 0:
+		return aws.StringValue(single.IpRanges[0].CidrIp)
+	case len(single.Ipv6Ranges) != 0:
+		return aws.StringValue(single.Ipv6Ranges[0].CidrIpv6)
+	case len(single.PrefixListIds) != 0:
+		return aws.StringValue(single.PrefixListIds[0].PrefixListId)
+	case len(single.UserIdGroupPairs) != 0:
+		return aws.StringValue(single.UserIdGroupPairs[0].GroupId)
+	}
+	return ""
+}
+
+// describeRule renders a single peer permission the way ec2 quotes it in
+// its errors.
+func describeRule(single *ec2.IpPermission) string {
+	protocol := strings.ToUpper(aws.StringValue(single.IpProtocol))
+	if protocol == "-1" {
+		protocol = "ALL"
+	}
+	rule := "peer: " + peerOf(single) + ", " + protocol
+	if single.FromPort != nil && single.ToPort != nil {
+		rule += ", from port: " + strconv.FormatInt(*single.FromPort, 10) + ", to port: " + strconv.FormatInt(*single.ToPort, 10)
+	}
+	return rule + ", ALLOW"
+}
+
+// findRule gives the stored single peer permission matching the requested
+// one, nil if there is none.
+func findRule(stored []*ec2.IpPermission, single *ec2.IpPermission) *ec2.IpPermission {
+	for _, candidate := range stored {
+		if ruleKey(candidate) == ruleKey(single) && peerOf(candidate) == peerOf(single) {
+			return candidate
+		}
+	}
+	return nil
+}
+
+// authorizePermissions adds the single peer permissions to the rules,
+// failing the whole request if one of them is already there.
+func authorizePermissions(rules []*ec2.IpPermission, singles []*ec2.IpPermission) ([]*ec2.IpPermission, error) {
+	stored := explodePermissions(rules)
+	for _, single := range singles {
+		if findRule(stored, single) != nil {
+			return nil, newAwsError("InvalidPermission.Duplicate", "the specified rule \""+describeRule(single)+"\" already exists")
+		}
+		stored = append(stored, single)
+	}
+	return groupPermissions(stored), nil
+}
+
+// revokePermissions removes the single peer permissions from the rules,
+// failing the whole request if one of them is missing.
+func revokePermissions(rules []*ec2.IpPermission, singles []*ec2.IpPermission) ([]*ec2.IpPermission, error) {
+	stored := explodePermissions(rules)
+	for _, single := range singles {
+		found := findRule(stored, single)
+		if found == nil {
+			return nil, newAwsError("InvalidPermission.NotFound", "The specified rule does not exist in this security group.")
+		}
+		kept := []*ec2.IpPermission{}
+		for _, candidate := range stored {
+			if candidate != found {
+				kept = append(kept, candidate)
+			}
+		}
+		stored = kept
+	}
+	return groupPermissions(stored), nil
+}
+
+// describePermissions sets the descriptions of existing rules to those of the
+// single peer permissions.
+func describePermissions(rules []*ec2.IpPermission, singles []*ec2.IpPermission) ([]*ec2.IpPermission, error) {
+	stored := explodePermissions(rules)
+	for _, single := range singles {
+		found := findRule(stored, single)
+		if found == nil {
+			return nil, newAwsError("InvalidPermission.NotFound", "The specified rule does not exist in this security group.")
+		}
+		// the stored rule may share its peer with the caller's copy, swap it
+		// for a new one rather than writing through
+		switch {
+		case len(found.IpRanges) != 0:
+			found.IpRanges = []*ec2.IpRange{&ec2.IpRange{CidrIp: found.IpRanges[0].CidrIp, Description: single.IpRanges[0].Description}}
+		case len(found.Ipv6Ranges) != 0:
+			found.Ipv6Ranges = []*ec2.Ipv6Range{&ec2.Ipv6Range{CidrIpv6: found.Ipv6Ranges[0].CidrIpv6, Description: single.Ipv6Ranges[0].Description}}
+		case len(found.PrefixListIds) != 0:
+			found.PrefixListIds = []*ec2.PrefixListId{&ec2.PrefixListId{PrefixListId: found.PrefixListIds[0].PrefixListId, Description: single.PrefixListIds[0].Description}}
+		case len(found.UserIdGroupPairs) != 0:
+			pair := *found.UserIdGroupPairs[0]
+			pair.Description = single.UserIdGroupPairs[0].Description
+			found.UserIdGroupPairs = []*ec2.UserIdGroupPair{&pair}
+		}
+	}
+	return groupPermissions(stored), nil
+}
+
+// securityGroupInUse tells whether an interface, a live instance or another
+// group of the same vpc still refers to the group. References through a
+// peering connection don't count, deleting the group leaves them stale.
+func (_m *EC2API) securityGroupInUse(groupId string) bool {
+	usesGroup := func(groups []*ec2.GroupIdentifier) bool {
+		for _, group := range groups {
+			if aws.StringValue(group.GroupId) == groupId {
+				return true
+			}
+		}
+		return false
+	}
+	for _, ntwInterface := range _m.networkinterfaces {
+		if usesGroup(ntwInterface.Groups) {
+			return true
+		}
+	}
+	for _, instance := range _m.createdEc2instances {
+		if aws.Int64Value(instance.State.Code) != TERMINATED && usesGroup(instance.SecurityGroups) {
+			return true
+		}
+	}
+	for _, other := range _m.assignedsecurityGroups {
+		if aws.StringValue(other.GroupId) == groupId {
+			continue
+		}
+		for _, single := range append(explodePermissions(other.IpPermissions), explodePermissions(other.IpPermissionsEgress)...) {
+			for _, pair := range single.UserIdGroupPairs {
+				if aws.StringValue(pair.GroupId) == groupId && pair.VpcPeeringConnectionId == nil {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// staleIpPermissions gives the rules that reference a group of a peered vpc
+// that no longer exists.
+func (_m *EC2API) staleIpPermissions(rules []*ec2.IpPermission) []*ec2.StaleIpPermission {
+	stale := []*ec2.StaleIpPermission{}
+	for _, permission := range rules {
+		pairs := []*ec2.UserIdGroupPair{}
+		for _, pair := range permission.UserIdGroupPairs {
+			if _, ok := _m.assignedsecurityGroups[aws.StringValue(pair.GroupId)]; !ok && pair.VpcPeeringConnectionId != nil {
+				pairs = append(pairs, pair)
+			}
+		}
+		if len(pairs) == 0 {
+			continue
+		}
+		stale = append(stale, &ec2.StaleIpPermission{
+			IpProtocol:       permission.IpProtocol,
+			FromPort:         permission.FromPort,
+			ToPort:           permission.ToPort,
+			IpRanges:         []*string{},
+			PrefixListIds:    []*string{},
+			UserIdGroupPairs: pairs,
+		})
+	}
+	return stale
+}
@@ -0,0 +1,292 @@
+/*
+Copyright 2018 The Avi Networks.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package ec2
+
+import (
+	"testing"
+
+	aws "github.com/aws/aws-sdk-go/aws"
+	ec2 "github.com/aws/aws-sdk-go/service/ec2"
+)
+
+func createTestSecurityGroup(t *testing.T, m *EC2API, vpcId, name string) *string {
+	t.Helper()
+	output, err := m.CreateSecurityGroup(&ec2.CreateSecurityGroupInput{
+		GroupName:   aws.String(name),
+		Description: aws.String(name + " servers"),
+		VpcId:       aws.String(vpcId),
+	})
+	if err != nil {
+		t.Fatalf("CreateSecurityGroup(%s): %v", name, err)
+	}
+	return output.GroupId
+}
+
+func describeTestSecurityGroup(t *testing.T, m *EC2API, groupId *string) *ec2.SecurityGroup {
+	t.Helper()
+	output, err := m.DescribeSecurityGroups(&ec2.DescribeSecurityGroupsInput{GroupIds: []*string{groupId}})
+	if err != nil {
+		t.Fatalf("DescribeSecurityGroups(%s): %v", aws.StringValue(groupId), err)
+	}
+	return output.SecurityGroups[0]
+}
+
+func tcpPermission(port int64, cidrs ...string) *ec2.IpPermission {
+	permission := &ec2.IpPermission{IpProtocol: aws.String("tcp"), FromPort: aws.Int64(port), ToPort: aws.Int64(port)}
+	for _, cidr := range cidrs {
+		permission.IpRanges = append(permission.IpRanges, &ec2.IpRange{CidrIp: aws.String(cidr)})
+	}
+	return permission
+}
+
+// rangesByRule gives the ipv4 peers of the rules keyed by protocol and ports.
+func rangesByRule(permissions []*ec2.IpPermission) map[string][]string {
+	ranges := map[string][]string{}
+	for _, permission := range permissions {
+		key := ruleKey(permission)
+		for _, ipRange := range permission.IpRanges {
+			ranges[key] = append(ranges[key], aws.StringValue(ipRange.CidrIp))
+		}
+	}
+	return ranges
+}
+
+func TestAuthorizeSecurityGroupIngressGroupsRulesByPort(t *testing.T) {
+	m := newSeededMock(t)
+	groupId := createTestSecurityGroup(t, m, m.GetDefaultVPCID(), "web")
+	if _, err := m.AuthorizeSecurityGroupIngress(&ec2.AuthorizeSecurityGroupIngressInput{
+		GroupId:       groupId,
+		IpPermissions: []*ec2.IpPermission{tcpPermission(80, "10.0.0.0/8", "192.168.0.0/16")},
+	}); err != nil {
+		t.Fatalf("AuthorizeSecurityGroupIngress: %v", err)
+	}
+	if _, err := m.AuthorizeSecurityGroupIngress(&ec2.AuthorizeSecurityGroupIngressInput{
+		GroupId:    groupId,
+		IpProtocol: aws.String("6"),
+		FromPort:   aws.Int64(80),
+		ToPort:     aws.Int64(80),
+		CidrIp:     aws.String("172.16.0.0/12"),
+	}); err != nil {
+		t.Fatalf("AuthorizeSecurityGroupIngress with the legacy fields: %v", err)
+	}
+
+	group := describeTestSecurityGroup(t, m, groupId)
+	if got := rangesByRule(group.IpPermissions); len(got) != 1 || len(got["tcp:80-80"]) != 3 {
+		t.Fatalf("ingress rules are %v, want one tcp 80 rule with three ranges", got)
+	}
+	if got := rangesByRule(group.IpPermissionsEgress); len(got) != 1 || len(got["-1"]) != 1 {
+		t.Fatalf("egress rules of a new group are %v, want allow all", got)
+	}
+
+	// a duplicate fails the whole request
+	_, err := m.AuthorizeSecurityGroupIngress(&ec2.AuthorizeSecurityGroupIngressInput{
+		GroupId:       groupId,
+		IpPermissions: []*ec2.IpPermission{tcpPermission(443, "10.0.0.0/8"), tcpPermission(80, "10.0.0.0/8")},
+	})
+	expectErrorCode(t, err, "InvalidPermission.Duplicate")
+	if got := rangesByRule(describeTestSecurityGroup(t, m, groupId).IpPermissions); len(got) != 1 {
+		t.Fatalf("a failed authorization left rules %v", got)
+	}
+
+	if _, err := m.RevokeSecurityGroupIngress(&ec2.RevokeSecurityGroupIngressInput{
+		GroupId:       groupId,
+		IpPermissions: []*ec2.IpPermission{tcpPermission(80, "192.168.0.0/16")},
+	}); err != nil {
+		t.Fatalf("RevokeSecurityGroupIngress: %v", err)
+	}
+	if got := rangesByRule(describeTestSecurityGroup(t, m, groupId).IpPermissions); len(got["tcp:80-80"]) != 2 {
+		t.Fatalf("ingress rules after a revoke are %v", got)
+	}
+	_, err = m.RevokeSecurityGroupIngress(&ec2.RevokeSecurityGroupIngressInput{
+		GroupId:       groupId,
+		IpPermissions: []*ec2.IpPermission{tcpPermission(80, "192.168.0.0/16")},
+	})
+	expectErrorCode(t, err, "InvalidPermission.NotFound")
+}
+
+func TestSecurityGroupRulesAreValidated(t *testing.T) {
+	m := newSeededMock(t)
+	groupId := createTestSecurityGroup(t, m, m.GetDefaultVPCID(), "web")
+	for _, test := range []struct {
+		name  string
+		input *ec2.AuthorizeSecurityGroupIngressInput
+		code  string
+	}{
+		{"no permissions", &ec2.AuthorizeSecurityGroupIngressInput{GroupId: groupId}, "MissingParameter"},
+		{"no ports", &ec2.AuthorizeSecurityGroupIngressInput{GroupId: groupId, IpPermissions: []*ec2.IpPermission{
+			{IpProtocol: aws.String("tcp"), IpRanges: []*ec2.IpRange{{CidrIp: aws.String("10.0.0.0/8")}}},
+		}}, "InvalidParameterValue"},
+		{"reversed ports", &ec2.AuthorizeSecurityGroupIngressInput{GroupId: groupId, IpPermissions: []*ec2.IpPermission{
+			{IpProtocol: aws.String("udp"), FromPort: aws.Int64(90), ToPort: aws.Int64(80), IpRanges: []*ec2.IpRange{{CidrIp: aws.String("10.0.0.0/8")}}},
+		}}, "InvalidParameterValue"},
+		{"unknown protocol", &ec2.AuthorizeSecurityGroupIngressInput{GroupId: groupId, IpPermissions: []*ec2.IpPermission{
+			{IpProtocol: aws.String("smtp"), IpRanges: []*ec2.IpRange{{CidrIp: aws.String("10.0.0.0/8")}}},
+		}}, "InvalidParameterValue"},
+		{"malformed cidr", &ec2.AuthorizeSecurityGroupIngressInput{GroupId: groupId, IpPermissions: []*ec2.IpPermission{tcpPermission(22, "10.0.0.0")}}, "InvalidParameterValue"},
+		{"no peer", &ec2.AuthorizeSecurityGroupIngressInput{GroupId: groupId, IpPermissions: []*ec2.IpPermission{tcpPermission(22)}}, "MissingParameter"},
+		{"both forms", &ec2.AuthorizeSecurityGroupIngressInput{
+			GroupId:       groupId,
+			CidrIp:        aws.String("10.0.0.0/8"),
+			IpPermissions: []*ec2.IpPermission{tcpPermission(22, "10.0.0.0/8")},
+		}, "InvalidParameterCombination"},
+		{"unknown source group", &ec2.AuthorizeSecurityGroupIngressInput{GroupId: groupId, IpPermissions: []*ec2.IpPermission{
+			{IpProtocol: aws.String("-1"), UserIdGroupPairs: []*ec2.UserIdGroupPair{{GroupId: aws.String("sg-missing")}}},
+		}}, "InvalidGroup.NotFound"},
+	} {
+		_, err := m.AuthorizeSecurityGroupIngress(test.input)
+		if errorCode(err) != test.code {
+			t.Errorf("%s: got error %v, want code %s", test.name, err, test.code)
+		}
+	}
+}
+
+func TestSecurityGroupEgressAndDescriptions(t *testing.T) {
+	m := newSeededMock(t)
+	groupId := createTestSecurityGroup(t, m, m.GetDefaultVPCID(), "web")
+	sourceId := createTestSecurityGroup(t, m, m.GetDefaultVPCID(), "lb")
+	if _, err := m.RevokeSecurityGroupEgress(&ec2.RevokeSecurityGroupEgressInput{
+		GroupId:       groupId,
+		IpPermissions: []*ec2.IpPermission{{IpProtocol: aws.String("-1"), IpRanges: []*ec2.IpRange{{CidrIp: aws.String("0.0.0.0/0")}}}},
+	}); err != nil {
+		t.Fatalf("RevokeSecurityGroupEgress: %v", err)
+	}
+	if _, err := m.AuthorizeSecurityGroupEgress(&ec2.AuthorizeSecurityGroupEgressInput{
+		GroupId: groupId,
+		IpPermissions: []*ec2.IpPermission{{
+			IpProtocol:       aws.String("tcp"),
+			FromPort:         aws.Int64(8080),
+			ToPort:           aws.Int64(8080),
+			UserIdGroupPairs: []*ec2.UserIdGroupPair{{GroupId: sourceId}},
+		}},
+	}); err != nil {
+		t.Fatalf("AuthorizeSecurityGroupEgress: %v", err)
+	}
+	if _, err := m.UpdateSecurityGroupRuleDescriptionsEgress(&ec2.UpdateSecurityGroupRuleDescriptionsEgressInput{
+		GroupId: groupId,
+		IpPermissions: []*ec2.IpPermission{{
+			IpProtocol:       aws.String("tcp"),
+			FromPort:         aws.Int64(8080),
+			ToPort:           aws.Int64(8080),
+			UserIdGroupPairs: []*ec2.UserIdGroupPair{{GroupId: sourceId, Description: aws.String("to the balancer")}},
+		}},
+	}); err != nil {
+		t.Fatalf("UpdateSecurityGroupRuleDescriptionsEgress: %v", err)
+	}
+
+	egress := describeTestSecurityGroup(t, m, groupId).IpPermissionsEgress
+	if len(egress) != 1 || len(egress[0].UserIdGroupPairs) != 1 {
+		t.Fatalf("egress rules are %v, want the one rule to the balancer", egress)
+	}
+	pair := egress[0].UserIdGroupPairs[0]
+	if aws.StringValue(pair.GroupId) != *sourceId || aws.StringValue(pair.UserId) != defaultOwnerId || aws.StringValue(pair.Description) != "to the balancer" {
+		t.Fatalf("egress rule peer is %v", pair)
+	}
+}
+
+func TestDeleteSecurityGroupRefusesGroupsInUse(t *testing.T) {
+	m := newSeededMock(t)
+	webId := createTestSecurityGroup(t, m, m.GetDefaultVPCID(), "web")
+	lbId := createTestSecurityGroup(t, m, m.GetDefaultVPCID(), "lb")
+	if _, err := m.AuthorizeSecurityGroupIngress(&ec2.AuthorizeSecurityGroupIngressInput{
+		GroupId:       webId,
+		IpPermissions: []*ec2.IpPermission{{IpProtocol: aws.String("-1"), UserIdGroupPairs: []*ec2.UserIdGroupPair{{GroupId: lbId}}}},
+	}); err != nil {
+		t.Fatalf("AuthorizeSecurityGroupIngress: %v", err)
+	}
+	ntwInterface, err := m.CreateNetworkInterface(&ec2.CreateNetworkInterfaceInput{
+		SubnetId: aws.String(m.GetDefaultSubnetID()),
+		Groups:   []*string{webId},
+	})
+	if err != nil {
+		t.Fatalf("CreateNetworkInterface: %v", err)
+	}
+
+	_, err = m.DeleteSecurityGroup(&ec2.DeleteSecurityGroupInput{GroupId: lbId})
+	expectErrorCode(t, err, "DependencyViolation")
+	_, err = m.DeleteSecurityGroup(&ec2.DeleteSecurityGroupInput{GroupId: webId})
+	expectErrorCode(t, err, "DependencyViolation")
+
+	if _, err := m.DeleteNetworkInterface(&ec2.DeleteNetworkInterfaceInput{NetworkInterfaceId: ntwInterface.NetworkInterface.NetworkInterfaceId}); err != nil {
+		t.Fatalf("DeleteNetworkInterface: %v", err)
+	}
+	for _, groupId := range []*string{webId, lbId} {
+		if _, err := m.DeleteSecurityGroup(&ec2.DeleteSecurityGroupInput{GroupId: groupId}); err != nil {
+			t.Fatalf("DeleteSecurityGroup(%s): %v", *groupId, err)
+		}
+	}
+
+	vpc, err := m.CreateVpc(&ec2.CreateVpcInput{CidrBlock: aws.String("10.1.0.0/16")})
+	if err != nil {
+		t.Fatalf("CreateVpc: %v", err)
+	}
+	groups, err := m.DescribeSecurityGroups(&ec2.DescribeSecurityGroupsInput{Filters: []*ec2.Filter{filter("vpc-id", *vpc.Vpc.VpcId)}})
+	if err != nil || len(groups.SecurityGroups) != 1 {
+		t.Fatalf("DescribeSecurityGroups of the new vpc gave %v, %v", groups, err)
+	}
+	_, err = m.DeleteSecurityGroup(&ec2.DeleteSecurityGroupInput{GroupId: groups.SecurityGroups[0].GroupId})
+	expectErrorCode(t, err, "CannotDelete")
+}
+
+func TestPeeredGroupReferencesAndStaleRules(t *testing.T) {
+	m := newSeededMock(t)
+	vpc, err := m.CreateVpc(&ec2.CreateVpcInput{CidrBlock: aws.String("10.1.0.0/16")})
+	if err != nil {
+		t.Fatalf("CreateVpc: %v", err)
+	}
+	remoteId := createTestSecurityGroup(t, m, *vpc.Vpc.VpcId, "remote")
+	webId := createTestSecurityGroup(t, m, m.GetDefaultVPCID(), "web")
+
+	pair := &ec2.UserIdGroupPair{GroupId: remoteId}
+	_, err = m.AuthorizeSecurityGroupIngress(&ec2.AuthorizeSecurityGroupIngressInput{
+		GroupId:       webId,
+		IpPermissions: []*ec2.IpPermission{{IpProtocol: aws.String("-1"), UserIdGroupPairs: []*ec2.UserIdGroupPair{pair}}},
+	})
+	expectErrorCode(t, err, "InvalidGroup.NotFound")
+	pair.VpcPeeringConnectionId = aws.String("pcx-test")
+	if _, err := m.AuthorizeSecurityGroupIngress(&ec2.AuthorizeSecurityGroupIngressInput{
+		GroupId:       webId,
+		IpPermissions: []*ec2.IpPermission{{IpProtocol: aws.String("-1"), UserIdGroupPairs: []*ec2.UserIdGroupPair{pair}}},
+	}); err != nil {
+		t.Fatalf("AuthorizeSecurityGroupIngress through a peering: %v", err)
+	}
+
+	references, err := m.DescribeSecurityGroupReferences(&ec2.DescribeSecurityGroupReferencesInput{GroupId: []*string{remoteId}})
+	if err != nil {
+		t.Fatalf("DescribeSecurityGroupReferences: %v", err)
+	}
+	if len(references.SecurityGroupReferenceSet) != 1 {
+		t.Fatalf("got %d references, want 1", len(references.SecurityGroupReferenceSet))
+	}
+	if reference := references.SecurityGroupReferenceSet[0]; aws.StringValue(reference.ReferencingVpcId) != m.GetDefaultVPCID() || aws.StringValue(reference.VpcPeeringConnectionId) != "pcx-test" {
+		t.Fatalf("reference is %v", reference)
+	}
+
+	stale, err := m.DescribeStaleSecurityGroups(&ec2.DescribeStaleSecurityGroupsInput{VpcId: aws.String(m.GetDefaultVPCID())})
+	if err != nil || len(stale.StaleSecurityGroupSet) != 0 {
+		t.Fatalf("DescribeStaleSecurityGroups before the delete gave %v, %v", stale, err)
+	}
+	// a reference through a peering does not keep the group
+	if _, err := m.DeleteSecurityGroup(&ec2.DeleteSecurityGroupInput{GroupId: remoteId}); err != nil {
+		t.Fatalf("DeleteSecurityGroup: %v", err)
+	}
+	stale, err = m.DescribeStaleSecurityGroups(&ec2.DescribeStaleSecurityGroupsInput{VpcId: aws.String(m.GetDefaultVPCID())})
+	if err != nil {
+		t.Fatalf("DescribeStaleSecurityGroups: %v", err)
+	}
+	if len(stale.StaleSecurityGroupSet) != 1 || aws.StringValue(stale.StaleSecurityGroupSet[0].GroupId) != *webId {
+		t.Fatalf("stale groups are %v, want web", stale.StaleSecurityGroupSet)
+	}
+	if rules := stale.StaleSecurityGroupSet[0].StaleIpPermissions; len(rules) != 1 || aws.StringValue(rules[0].UserIdGroupPairs[0].GroupId) != *remoteId {
+		t.Fatalf("stale rules are %v", rules)
+	}
+}
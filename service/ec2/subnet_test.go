@@ -0,0 +1,143 @@
+/*
+Copyright 2018 The Avi Networks.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package ec2
+
+import (
+	"testing"
+
+	aws "github.com/aws/aws-sdk-go/aws"
+	ec2 "github.com/aws/aws-sdk-go/service/ec2"
+)
+
+func describeTestSubnet(t *testing.T, m *EC2API, subnetId *string) *ec2.Subnet {
+	t.Helper()
+	output, err := m.DescribeSubnets(&ec2.DescribeSubnetsInput{SubnetIds: []*string{subnetId}})
+	if err != nil {
+		t.Fatalf("DescribeSubnets(%s): %v", aws.StringValue(subnetId), err)
+	}
+	return output.Subnets[0]
+}
+
+func TestCreateSubnetValidatesTheCidrBlock(t *testing.T) {
+	m := newSeededMock(t)
+	createTestSubnet(t, m, "10.0.1.0/24")
+	vpcId := aws.String(m.GetDefaultVPCID())
+	for _, test := range []struct {
+		name  string
+		input *ec2.CreateSubnetInput
+		code  string
+	}{
+		{"not a cidr", &ec2.CreateSubnetInput{VpcId: vpcId, CidrBlock: aws.String("10.0.2.0")}, "InvalidParameterValue"},
+		{"unknown vpc", &ec2.CreateSubnetInput{VpcId: aws.String("vpc-unknown"), CidrBlock: aws.String("10.0.2.0/24")}, "InvalidVpcID.NotFound"},
+		{"outside the vpc", &ec2.CreateSubnetInput{VpcId: vpcId, CidrBlock: aws.String("10.1.0.0/24")}, "InvalidSubnet.Range"},
+		{"too small", &ec2.CreateSubnetInput{VpcId: vpcId, CidrBlock: aws.String("10.0.2.0/29")}, "InvalidSubnet.Range"},
+		{"overlaps a subnet", &ec2.CreateSubnetInput{VpcId: vpcId, CidrBlock: aws.String("10.0.1.128/25")}, "InvalidSubnet.Conflict"},
+		{"contains a subnet", &ec2.CreateSubnetInput{VpcId: vpcId, CidrBlock: aws.String("10.0.0.0/23")}, "InvalidSubnet.Conflict"},
+	} {
+		output, err := m.CreateSubnet(test.input)
+		if errorCode(err) != test.code {
+			t.Errorf("%s: got error %v, want code %s", test.name, err, test.code)
+		}
+		if output == nil || output.Subnet != nil {
+			t.Errorf("%s: got output %v, want an empty one", test.name, output)
+		}
+	}
+}
+
+func TestDeleteSubnetWaitsForItsDependencies(t *testing.T) {
+	m := newSeededMock(t)
+	vpc, subnet := createTestVpc(t, m, "10.1.0.0/16", "10.1.1.0/24")
+	routeTable, err := m.CreateRouteTable(&ec2.CreateRouteTableInput{VpcId: vpc.VpcId})
+	if err != nil {
+		t.Fatalf("CreateRouteTable: %v", err)
+	}
+	if _, err := m.AssociateRouteTable(&ec2.AssociateRouteTableInput{RouteTableId: routeTable.RouteTable.RouteTableId, SubnetId: subnet.SubnetId}); err != nil {
+		t.Fatalf("AssociateRouteTable: %v", err)
+	}
+
+	ntwInterface := createTestInterface(t, m, subnet.SubnetId)
+	_, err = m.DeleteSubnet(&ec2.DeleteSubnetInput{SubnetId: subnet.SubnetId})
+	expectErrorCode(t, err, "DependencyViolation")
+	if _, err := m.DeleteNetworkInterface(&ec2.DeleteNetworkInterfaceInput{NetworkInterfaceId: ntwInterface.NetworkInterfaceId}); err != nil {
+		t.Fatalf("DeleteNetworkInterface: %v", err)
+	}
+
+	instance := runTestInstances(t, m, subnet.SubnetId, 1)[0]
+	_, err = m.DeleteSubnet(&ec2.DeleteSubnetInput{SubnetId: subnet.SubnetId})
+	expectErrorCode(t, err, "DependencyViolation")
+	if _, err := m.TerminateInstances(&ec2.TerminateInstancesInput{InstanceIds: []*string{instance.InstanceId}}); err != nil {
+		t.Fatalf("TerminateInstances: %v", err)
+	}
+	m.CompleteTransitions()
+
+	if _, err := m.DeleteSubnet(&ec2.DeleteSubnetInput{SubnetId: subnet.SubnetId}); err != nil {
+		t.Fatalf("DeleteSubnet: %v", err)
+	}
+	_, err = m.DescribeSubnets(&ec2.DescribeSubnetsInput{SubnetIds: []*string{subnet.SubnetId}})
+	expectErrorCode(t, err, "InvalidSubnetID.NotFound")
+	_, err = m.DeleteSubnet(&ec2.DeleteSubnetInput{SubnetId: subnet.SubnetId})
+	expectErrorCode(t, err, "InvalidSubnetID.NotFound")
+	if routeTables := describeTestRouteTables(t, m, filter("association.subnet-id", *subnet.SubnetId)); len(routeTables) != 0 {
+		t.Errorf("deleted subnet is still associated with %d route tables", len(routeTables))
+	}
+	networkAcls, err := m.DescribeNetworkAcls(&ec2.DescribeNetworkAclsInput{Filters: []*ec2.Filter{filter("association.subnet-id", *subnet.SubnetId)}})
+	if err != nil {
+		t.Fatalf("DescribeNetworkAcls: %v", err)
+	}
+	if len(networkAcls.NetworkAcls) != 0 {
+		t.Errorf("deleted subnet is still associated with %d network acls", len(networkAcls.NetworkAcls))
+	}
+	// the range is free for a new subnet
+	if _, err := m.CreateSubnet(&ec2.CreateSubnetInput{VpcId: vpc.VpcId, CidrBlock: aws.String("10.1.1.0/24")}); err != nil {
+		t.Fatalf("CreateSubnet in the range of the deleted subnet: %v", err)
+	}
+}
+
+func TestModifySubnetAttribute(t *testing.T) {
+	m := newSeededMock(t)
+	subnet := createTestSubnet(t, m, "10.0.1.0/24")
+	enable := &ec2.AttributeBooleanValue{Value: aws.Bool(true)}
+	for _, test := range []struct {
+		name  string
+		input *ec2.ModifySubnetAttributeInput
+		code  string
+	}{
+		{"unknown subnet", &ec2.ModifySubnetAttributeInput{SubnetId: aws.String("subnet-unknown"), MapPublicIpOnLaunch: enable}, "InvalidSubnetID.NotFound"},
+		{"no attribute", &ec2.ModifySubnetAttributeInput{SubnetId: subnet.SubnetId}, "InvalidParameterCombination"},
+		{"two attributes", &ec2.ModifySubnetAttributeInput{SubnetId: subnet.SubnetId, MapPublicIpOnLaunch: enable, AssignIpv6AddressOnCreation: enable}, "InvalidParameterCombination"},
+		{"ipv6 without a block", &ec2.ModifySubnetAttributeInput{SubnetId: subnet.SubnetId, AssignIpv6AddressOnCreation: enable}, "InvalidParameterValue"},
+	} {
+		_, err := m.ModifySubnetAttribute(test.input)
+		if errorCode(err) != test.code {
+			t.Errorf("%s: got error %v, want code %s", test.name, err, test.code)
+		}
+	}
+	described := describeTestSubnet(t, m, subnet.SubnetId)
+	if aws.BoolValue(described.MapPublicIpOnLaunch) || aws.BoolValue(described.AssignIpv6AddressOnCreation) {
+		t.Fatalf("rejected changes were applied: %v", described)
+	}
+
+	if _, err := m.ModifySubnetAttribute(&ec2.ModifySubnetAttributeInput{SubnetId: subnet.SubnetId, MapPublicIpOnLaunch: enable}); err != nil {
+		t.Fatalf("ModifySubnetAttribute: %v", err)
+	}
+	if !aws.BoolValue(describeTestSubnet(t, m, subnet.SubnetId).MapPublicIpOnLaunch) {
+		t.Fatal("MapPublicIpOnLaunch is not set")
+	}
+	// disabling needs no ipv6 block
+	if _, err := m.ModifySubnetAttribute(&ec2.ModifySubnetAttributeInput{
+		SubnetId:                    subnet.SubnetId,
+		AssignIpv6AddressOnCreation: &ec2.AttributeBooleanValue{Value: aws.Bool(false)},
+	}); err != nil {
+		t.Fatalf("ModifySubnetAttribute: %v", err)
+	}
+}
@@ -435,36 +435,6 @@ func (_m *EC2API) AllocateAddressRequest(_a0 *ec2.AllocateAddressInput) (*reques
 	return r0, r1
 }
 
-// AllocateAddressWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) AllocateAddressWithContext(_a0 context.Context, _a1 *ec2.AllocateAddressInput, _a2 ...request.Option) (*ec2.AllocateAddressOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.AllocateAddressOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.AllocateAddressInput, ...request.Option) *ec2.AllocateAddressOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.AllocateAddressOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.AllocateAddressInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // AllocateHosts provides a mock function with given fields: _a0
 func (_m *EC2API) AllocateHosts(_a0 *ec2.AllocateHostsInput) (*ec2.AllocateHostsOutput, error) {
 	ret := _m.Called(_a0)
@@ -621,29 +591,6 @@ func (_m *EC2API) ApplySecurityGroupsToClientVpnTargetNetworkWithContext(_a0 con
 	return r0, r1
 }
 
-// AssignIpv6Addresses provides a mock function with given fields: _a0
-func (_m *EC2API) AssignIpv6Addresses(_a0 *ec2.AssignIpv6AddressesInput) (*ec2.AssignIpv6AddressesOutput, error) {
-	ret := _m.Called(_a0)
-
-	var r0 *ec2.AssignIpv6AddressesOutput
-	if rf, ok := ret.Get(0).(func(*ec2.AssignIpv6AddressesInput) *ec2.AssignIpv6AddressesOutput); ok {
-		r0 = rf(_a0)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.AssignIpv6AddressesOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(*ec2.AssignIpv6AddressesInput) error); ok {
-		r1 = rf(_a0)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // AssignIpv6AddressesRequest provides a mock function with given fields: _a0
 func (_m *EC2API) AssignIpv6AddressesRequest(_a0 *ec2.AssignIpv6AddressesInput) (*request.Request, *ec2.AssignIpv6AddressesOutput) {
 	ret := _m.Called(_a0)
@@ -669,36 +616,6 @@ func (_m *EC2API) AssignIpv6AddressesRequest(_a0 *ec2.AssignIpv6AddressesInput)
 	return r0, r1
 }
 
-// AssignIpv6AddressesWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) AssignIpv6AddressesWithContext(_a0 context.Context, _a1 *ec2.AssignIpv6AddressesInput, _a2 ...request.Option) (*ec2.AssignIpv6AddressesOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.AssignIpv6AddressesOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.AssignIpv6AddressesInput, ...request.Option) *ec2.AssignIpv6AddressesOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.AssignIpv6AddressesOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.AssignIpv6AddressesInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // AssignPrivateIpAddressesRequest provides a mock function with given fields: _a0
 func (_m *EC2API) AssignPrivateIpAddressesRequest(_a0 *ec2.AssignPrivateIpAddressesInput) (*request.Request, *ec2.AssignPrivateIpAddressesOutput) {
 	ret := _m.Called(_a0)
@@ -724,36 +641,6 @@ func (_m *EC2API) AssignPrivateIpAddressesRequest(_a0 *ec2.AssignPrivateIpAddres
 	return r0, r1
 }
 
-// AssignPrivateIpAddressesWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) AssignPrivateIpAddressesWithContext(_a0 context.Context, _a1 *ec2.AssignPrivateIpAddressesInput, _a2 ...request.Option) (*ec2.AssignPrivateIpAddressesOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.AssignPrivateIpAddressesOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.AssignPrivateIpAddressesInput, ...request.Option) *ec2.AssignPrivateIpAddressesOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.AssignPrivateIpAddressesOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.AssignPrivateIpAddressesInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // AssociateAddressRequest provides a mock function with given fields: _a0
 func (_m *EC2API) AssociateAddressRequest(_a0 *ec2.AssociateAddressInput) (*request.Request, *ec2.AssociateAddressOutput) {
 	ret := _m.Called(_a0)
@@ -779,36 +666,6 @@ func (_m *EC2API) AssociateAddressRequest(_a0 *ec2.AssociateAddressInput) (*requ
 	return r0, r1
 }
 
-// AssociateAddressWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) AssociateAddressWithContext(_a0 context.Context, _a1 *ec2.AssociateAddressInput, _a2 ...request.Option) (*ec2.AssociateAddressOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.AssociateAddressOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.AssociateAddressInput, ...request.Option) *ec2.AssociateAddressOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.AssociateAddressOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.AssociateAddressInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // AssociateClientVpnTargetNetwork provides a mock function with given fields: _a0
 func (_m *EC2API) AssociateClientVpnTargetNetwork(_a0 *ec2.AssociateClientVpnTargetNetworkInput) (*ec2.AssociateClientVpnTargetNetworkOutput, error) {
 	ret := _m.Called(_a0)
@@ -1043,29 +900,6 @@ func (_m *EC2API) AssociateIamInstanceProfileWithContext(_a0 context.Context, _a
 	return r0, r1
 }
 
-// AssociateRouteTable provides a mock function with given fields: _a0
-func (_m *EC2API) AssociateRouteTable(_a0 *ec2.AssociateRouteTableInput) (*ec2.AssociateRouteTableOutput, error) {
-	ret := _m.Called(_a0)
-
-	var r0 *ec2.AssociateRouteTableOutput
-	if rf, ok := ret.Get(0).(func(*ec2.AssociateRouteTableInput) *ec2.AssociateRouteTableOutput); ok {
-		r0 = rf(_a0)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.AssociateRouteTableOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(*ec2.AssociateRouteTableInput) error); ok {
-		r1 = rf(_a0)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // AssociateRouteTableRequest provides a mock function with given fields: _a0
 func (_m *EC2API) AssociateRouteTableRequest(_a0 *ec2.AssociateRouteTableInput) (*request.Request, *ec2.AssociateRouteTableOutput) {
 	ret := _m.Called(_a0)
@@ -1091,59 +925,6 @@ func (_m *EC2API) AssociateRouteTableRequest(_a0 *ec2.AssociateRouteTableInput)
 	return r0, r1
 }
 
-// AssociateRouteTableWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) AssociateRouteTableWithContext(_a0 context.Context, _a1 *ec2.AssociateRouteTableInput, _a2 ...request.Option) (*ec2.AssociateRouteTableOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.AssociateRouteTableOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.AssociateRouteTableInput, ...request.Option) *ec2.AssociateRouteTableOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.AssociateRouteTableOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.AssociateRouteTableInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
-// AssociateSubnetCidrBlock provides a mock function with given fields: _a0
-func (_m *EC2API) AssociateSubnetCidrBlock(_a0 *ec2.AssociateSubnetCidrBlockInput) (*ec2.AssociateSubnetCidrBlockOutput, error) {
-	ret := _m.Called(_a0)
-
-	var r0 *ec2.AssociateSubnetCidrBlockOutput
-	if rf, ok := ret.Get(0).(func(*ec2.AssociateSubnetCidrBlockInput) *ec2.AssociateSubnetCidrBlockOutput); ok {
-		r0 = rf(_a0)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.AssociateSubnetCidrBlockOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(*ec2.AssociateSubnetCidrBlockInput) error); ok {
-		r1 = rf(_a0)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // AssociateSubnetCidrBlockRequest provides a mock function with given fields: _a0
 func (_m *EC2API) AssociateSubnetCidrBlockRequest(_a0 *ec2.AssociateSubnetCidrBlockInput) (*request.Request, *ec2.AssociateSubnetCidrBlockOutput) {
 	ret := _m.Called(_a0)
@@ -1169,36 +950,6 @@ func (_m *EC2API) AssociateSubnetCidrBlockRequest(_a0 *ec2.AssociateSubnetCidrBl
 	return r0, r1
 }
 
-// AssociateSubnetCidrBlockWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) AssociateSubnetCidrBlockWithContext(_a0 context.Context, _a1 *ec2.AssociateSubnetCidrBlockInput, _a2 ...request.Option) (*ec2.AssociateSubnetCidrBlockOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.AssociateSubnetCidrBlockOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.AssociateSubnetCidrBlockInput, ...request.Option) *ec2.AssociateSubnetCidrBlockOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.AssociateSubnetCidrBlockOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.AssociateSubnetCidrBlockInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // AssociateTransitGatewayRouteTable provides a mock function with given fields: _a0
 func (_m *EC2API) AssociateTransitGatewayRouteTable(_a0 *ec2.AssociateTransitGatewayRouteTableInput) (*ec2.AssociateTransitGatewayRouteTableOutput, error) {
 	ret := _m.Called(_a0)
@@ -1277,29 +1028,6 @@ func (_m *EC2API) AssociateTransitGatewayRouteTableWithContext(_a0 context.Conte
 	return r0, r1
 }
 
-// AssociateVpcCidrBlock provides a mock function with given fields: _a0
-func (_m *EC2API) AssociateVpcCidrBlock(_a0 *ec2.AssociateVpcCidrBlockInput) (*ec2.AssociateVpcCidrBlockOutput, error) {
-	ret := _m.Called(_a0)
-
-	var r0 *ec2.AssociateVpcCidrBlockOutput
-	if rf, ok := ret.Get(0).(func(*ec2.AssociateVpcCidrBlockInput) *ec2.AssociateVpcCidrBlockOutput); ok {
-		r0 = rf(_a0)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.AssociateVpcCidrBlockOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(*ec2.AssociateVpcCidrBlockInput) error); ok {
-		r1 = rf(_a0)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // AssociateVpcCidrBlockRequest provides a mock function with given fields: _a0
 func (_m *EC2API) AssociateVpcCidrBlockRequest(_a0 *ec2.AssociateVpcCidrBlockInput) (*request.Request, *ec2.AssociateVpcCidrBlockOutput) {
 	ret := _m.Called(_a0)
@@ -1325,36 +1053,6 @@ func (_m *EC2API) AssociateVpcCidrBlockRequest(_a0 *ec2.AssociateVpcCidrBlockInp
 	return r0, r1
 }
 
-// AssociateVpcCidrBlockWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) AssociateVpcCidrBlockWithContext(_a0 context.Context, _a1 *ec2.AssociateVpcCidrBlockInput, _a2 ...request.Option) (*ec2.AssociateVpcCidrBlockOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.AssociateVpcCidrBlockOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.AssociateVpcCidrBlockInput, ...request.Option) *ec2.AssociateVpcCidrBlockOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.AssociateVpcCidrBlockOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.AssociateVpcCidrBlockInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // AttachClassicLinkVpc provides a mock function with given fields: _a0
 func (_m *EC2API) AttachClassicLinkVpc(_a0 *ec2.AttachClassicLinkVpcInput) (*ec2.AttachClassicLinkVpcOutput, error) {
 	ret := _m.Called(_a0)
@@ -1433,29 +1131,6 @@ func (_m *EC2API) AttachClassicLinkVpcWithContext(_a0 context.Context, _a1 *ec2.
 	return r0, r1
 }
 
-// AttachInternetGateway provides a mock function with given fields: _a0
-func (_m *EC2API) AttachInternetGateway(_a0 *ec2.AttachInternetGatewayInput) (*ec2.AttachInternetGatewayOutput, error) {
-	ret := _m.Called(_a0)
-
-	var r0 *ec2.AttachInternetGatewayOutput
-	if rf, ok := ret.Get(0).(func(*ec2.AttachInternetGatewayInput) *ec2.AttachInternetGatewayOutput); ok {
-		r0 = rf(_a0)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.AttachInternetGatewayOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(*ec2.AttachInternetGatewayInput) error); ok {
-		r1 = rf(_a0)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // AttachInternetGatewayRequest provides a mock function with given fields: _a0
 func (_m *EC2API) AttachInternetGatewayRequest(_a0 *ec2.AttachInternetGatewayInput) (*request.Request, *ec2.AttachInternetGatewayOutput) {
 	ret := _m.Called(_a0)
@@ -1481,36 +1156,6 @@ func (_m *EC2API) AttachInternetGatewayRequest(_a0 *ec2.AttachInternetGatewayInp
 	return r0, r1
 }
 
-// AttachInternetGatewayWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) AttachInternetGatewayWithContext(_a0 context.Context, _a1 *ec2.AttachInternetGatewayInput, _a2 ...request.Option) (*ec2.AttachInternetGatewayOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.AttachInternetGatewayOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.AttachInternetGatewayInput, ...request.Option) *ec2.AttachInternetGatewayOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.AttachInternetGatewayOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.AttachInternetGatewayInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // AttachNetworkInterfaceRequest provides a mock function with given fields: _a0
 func (_m *EC2API) AttachNetworkInterfaceRequest(_a0 *ec2.AttachNetworkInterfaceInput) (*request.Request, *ec2.AttachNetworkInterfaceOutput) {
 	ret := _m.Called(_a0)
@@ -1536,36 +1181,6 @@ func (_m *EC2API) AttachNetworkInterfaceRequest(_a0 *ec2.AttachNetworkInterfaceI
 	return r0, r1
 }
 
-// AttachNetworkInterfaceWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) AttachNetworkInterfaceWithContext(_a0 context.Context, _a1 *ec2.AttachNetworkInterfaceInput, _a2 ...request.Option) (*ec2.AttachNetworkInterfaceOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.AttachNetworkInterfaceOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.AttachNetworkInterfaceInput, ...request.Option) *ec2.AttachNetworkInterfaceOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.AttachNetworkInterfaceOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.AttachNetworkInterfaceInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // AttachVolume provides a mock function with given fields: _a0
 func (_m *EC2API) AttachVolume(_a0 *ec2.AttachVolumeInput) (*ec2.VolumeAttachment, error) {
 	ret := _m.Called(_a0)
@@ -1800,29 +1415,6 @@ func (_m *EC2API) AuthorizeClientVpnIngressWithContext(_a0 context.Context, _a1
 	return r0, r1
 }
 
-// AuthorizeSecurityGroupEgress provides a mock function with given fields: _a0
-func (_m *EC2API) AuthorizeSecurityGroupEgress(_a0 *ec2.AuthorizeSecurityGroupEgressInput) (*ec2.AuthorizeSecurityGroupEgressOutput, error) {
-	ret := _m.Called(_a0)
-
-	var r0 *ec2.AuthorizeSecurityGroupEgressOutput
-	if rf, ok := ret.Get(0).(func(*ec2.AuthorizeSecurityGroupEgressInput) *ec2.AuthorizeSecurityGroupEgressOutput); ok {
-		r0 = rf(_a0)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.AuthorizeSecurityGroupEgressOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(*ec2.AuthorizeSecurityGroupEgressInput) error); ok {
-		r1 = rf(_a0)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // AuthorizeSecurityGroupEgressRequest provides a mock function with given fields: _a0
 func (_m *EC2API) AuthorizeSecurityGroupEgressRequest(_a0 *ec2.AuthorizeSecurityGroupEgressInput) (*request.Request, *ec2.AuthorizeSecurityGroupEgressOutput) {
 	ret := _m.Called(_a0)
@@ -1848,36 +1440,6 @@ func (_m *EC2API) AuthorizeSecurityGroupEgressRequest(_a0 *ec2.AuthorizeSecurity
 	return r0, r1
 }
 
-// AuthorizeSecurityGroupEgressWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) AuthorizeSecurityGroupEgressWithContext(_a0 context.Context, _a1 *ec2.AuthorizeSecurityGroupEgressInput, _a2 ...request.Option) (*ec2.AuthorizeSecurityGroupEgressOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.AuthorizeSecurityGroupEgressOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.AuthorizeSecurityGroupEgressInput, ...request.Option) *ec2.AuthorizeSecurityGroupEgressOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.AuthorizeSecurityGroupEgressOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.AuthorizeSecurityGroupEgressInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // AuthorizeSecurityGroupIngressRequest provides a mock function with given fields: _a0
 func (_m *EC2API) AuthorizeSecurityGroupIngressRequest(_a0 *ec2.AuthorizeSecurityGroupIngressInput) (*request.Request, *ec2.AuthorizeSecurityGroupIngressOutput) {
 	ret := _m.Called(_a0)
@@ -1903,36 +1465,6 @@ func (_m *EC2API) AuthorizeSecurityGroupIngressRequest(_a0 *ec2.AuthorizeSecurit
 	return r0, r1
 }
 
-// AuthorizeSecurityGroupIngressWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) AuthorizeSecurityGroupIngressWithContext(_a0 context.Context, _a1 *ec2.AuthorizeSecurityGroupIngressInput, _a2 ...request.Option) (*ec2.AuthorizeSecurityGroupIngressOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.AuthorizeSecurityGroupIngressOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.AuthorizeSecurityGroupIngressInput, ...request.Option) *ec2.AuthorizeSecurityGroupIngressOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.AuthorizeSecurityGroupIngressOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.AuthorizeSecurityGroupIngressInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // BundleInstance provides a mock function with given fields: _a0
 func (_m *EC2API) BundleInstance(_a0 *ec2.BundleInstanceInput) (*ec2.BundleInstanceOutput, error) {
 	ret := _m.Called(_a0)
@@ -3493,29 +3025,6 @@ func (_m *EC2API) CreateDhcpOptionsWithContext(_a0 context.Context, _a1 *ec2.Cre
 	return r0, r1
 }
 
-// CreateEgressOnlyInternetGateway provides a mock function with given fields: _a0
-func (_m *EC2API) CreateEgressOnlyInternetGateway(_a0 *ec2.CreateEgressOnlyInternetGatewayInput) (*ec2.CreateEgressOnlyInternetGatewayOutput, error) {
-	ret := _m.Called(_a0)
-
-	var r0 *ec2.CreateEgressOnlyInternetGatewayOutput
-	if rf, ok := ret.Get(0).(func(*ec2.CreateEgressOnlyInternetGatewayInput) *ec2.CreateEgressOnlyInternetGatewayOutput); ok {
-		r0 = rf(_a0)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.CreateEgressOnlyInternetGatewayOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(*ec2.CreateEgressOnlyInternetGatewayInput) error); ok {
-		r1 = rf(_a0)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // CreateEgressOnlyInternetGatewayRequest provides a mock function with given fields: _a0
 func (_m *EC2API) CreateEgressOnlyInternetGatewayRequest(_a0 *ec2.CreateEgressOnlyInternetGatewayInput) (*request.Request, *ec2.CreateEgressOnlyInternetGatewayOutput) {
 	ret := _m.Called(_a0)
@@ -3541,36 +3050,6 @@ func (_m *EC2API) CreateEgressOnlyInternetGatewayRequest(_a0 *ec2.CreateEgressOn
 	return r0, r1
 }
 
-// CreateEgressOnlyInternetGatewayWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) CreateEgressOnlyInternetGatewayWithContext(_a0 context.Context, _a1 *ec2.CreateEgressOnlyInternetGatewayInput, _a2 ...request.Option) (*ec2.CreateEgressOnlyInternetGatewayOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.CreateEgressOnlyInternetGatewayOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.CreateEgressOnlyInternetGatewayInput, ...request.Option) *ec2.CreateEgressOnlyInternetGatewayOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.CreateEgressOnlyInternetGatewayOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.CreateEgressOnlyInternetGatewayInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // CreateFleet provides a mock function with given fields: _a0
 func (_m *EC2API) CreateFleet(_a0 *ec2.CreateFleetInput) (*ec2.CreateFleetOutput, error) {
 	ret := _m.Called(_a0)
@@ -3961,29 +3440,6 @@ func (_m *EC2API) CreateInstanceExportTaskWithContext(_a0 context.Context, _a1 *
 	return r0, r1
 }
 
-// CreateInternetGateway provides a mock function with given fields: _a0
-func (_m *EC2API) CreateInternetGateway(_a0 *ec2.CreateInternetGatewayInput) (*ec2.CreateInternetGatewayOutput, error) {
-	ret := _m.Called(_a0)
-
-	var r0 *ec2.CreateInternetGatewayOutput
-	if rf, ok := ret.Get(0).(func(*ec2.CreateInternetGatewayInput) *ec2.CreateInternetGatewayOutput); ok {
-		r0 = rf(_a0)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.CreateInternetGatewayOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(*ec2.CreateInternetGatewayInput) error); ok {
-		r1 = rf(_a0)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // CreateInternetGatewayRequest provides a mock function with given fields: _a0
 func (_m *EC2API) CreateInternetGatewayRequest(_a0 *ec2.CreateInternetGatewayInput) (*request.Request, *ec2.CreateInternetGatewayOutput) {
 	ret := _m.Called(_a0)
@@ -4009,36 +3465,6 @@ func (_m *EC2API) CreateInternetGatewayRequest(_a0 *ec2.CreateInternetGatewayInp
 	return r0, r1
 }
 
-// CreateInternetGatewayWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) CreateInternetGatewayWithContext(_a0 context.Context, _a1 *ec2.CreateInternetGatewayInput, _a2 ...request.Option) (*ec2.CreateInternetGatewayOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.CreateInternetGatewayOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.CreateInternetGatewayInput, ...request.Option) *ec2.CreateInternetGatewayOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.CreateInternetGatewayOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.CreateInternetGatewayInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // CreateKeyPair provides a mock function with given fields: _a0
 func (_m *EC2API) CreateKeyPair(_a0 *ec2.CreateKeyPairInput) (*ec2.CreateKeyPairOutput, error) {
 	ret := _m.Called(_a0)
@@ -4273,29 +3699,6 @@ func (_m *EC2API) CreateLaunchTemplateWithContext(_a0 context.Context, _a1 *ec2.
 	return r0, r1
 }
 
-// CreateNatGateway provides a mock function with given fields: _a0
-func (_m *EC2API) CreateNatGateway(_a0 *ec2.CreateNatGatewayInput) (*ec2.CreateNatGatewayOutput, error) {
-	ret := _m.Called(_a0)
-
-	var r0 *ec2.CreateNatGatewayOutput
-	if rf, ok := ret.Get(0).(func(*ec2.CreateNatGatewayInput) *ec2.CreateNatGatewayOutput); ok {
-		r0 = rf(_a0)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.CreateNatGatewayOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(*ec2.CreateNatGatewayInput) error); ok {
-		r1 = rf(_a0)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // CreateNatGatewayRequest provides a mock function with given fields: _a0
 func (_m *EC2API) CreateNatGatewayRequest(_a0 *ec2.CreateNatGatewayInput) (*request.Request, *ec2.CreateNatGatewayOutput) {
 	ret := _m.Called(_a0)
@@ -4321,36 +3724,6 @@ func (_m *EC2API) CreateNatGatewayRequest(_a0 *ec2.CreateNatGatewayInput) (*requ
 	return r0, r1
 }
 
-// CreateNatGatewayWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) CreateNatGatewayWithContext(_a0 context.Context, _a1 *ec2.CreateNatGatewayInput, _a2 ...request.Option) (*ec2.CreateNatGatewayOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.CreateNatGatewayOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.CreateNatGatewayInput, ...request.Option) *ec2.CreateNatGatewayOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.CreateNatGatewayOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.CreateNatGatewayInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // CreateNetworkAcl provides a mock function with given fields: _a0
 func (_m *EC2API) CreateNetworkAcl(_a0 *ec2.CreateNetworkAclInput) (*ec2.CreateNetworkAclOutput, error) {
 	ret := _m.Called(_a0)
@@ -4610,29 +3983,22 @@ func (_m *EC2API) CreateNetworkInterfaceRequest(_a0 *ec2.CreateNetworkInterfaceI
 	return r0, r1
 }
 
-// CreateNetworkInterfaceWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) CreateNetworkInterfaceWithContext(_a0 context.Context, _a1 *ec2.CreateNetworkInterfaceInput, _a2 ...request.Option) (*ec2.CreateNetworkInterfaceOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
+// CreatePlacementGroup provides a mock function with given fields: _a0
+func (_m *EC2API) CreatePlacementGroup(_a0 *ec2.CreatePlacementGroupInput) (*ec2.CreatePlacementGroupOutput, error) {
+	ret := _m.Called(_a0)
 
-	var r0 *ec2.CreateNetworkInterfaceOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.CreateNetworkInterfaceInput, ...request.Option) *ec2.CreateNetworkInterfaceOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
+	var r0 *ec2.CreatePlacementGroupOutput
+	if rf, ok := ret.Get(0).(func(*ec2.CreatePlacementGroupInput) *ec2.CreatePlacementGroupOutput); ok {
+		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.CreateNetworkInterfaceOutput)
+			r0 = ret.Get(0).(*ec2.CreatePlacementGroupOutput)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.CreateNetworkInterfaceInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
+	if rf, ok := ret.Get(1).(func(*ec2.CreatePlacementGroupInput) error); ok {
+		r1 = rf(_a0)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -4640,31 +4006,8 @@ func (_m *EC2API) CreateNetworkInterfaceWithContext(_a0 context.Context, _a1 *ec
 	return r0, r1
 }
 
-// CreatePlacementGroup provides a mock function with given fields: _a0
-func (_m *EC2API) CreatePlacementGroup(_a0 *ec2.CreatePlacementGroupInput) (*ec2.CreatePlacementGroupOutput, error) {
-	ret := _m.Called(_a0)
-
-	var r0 *ec2.CreatePlacementGroupOutput
-	if rf, ok := ret.Get(0).(func(*ec2.CreatePlacementGroupInput) *ec2.CreatePlacementGroupOutput); ok {
-		r0 = rf(_a0)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.CreatePlacementGroupOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(*ec2.CreatePlacementGroupInput) error); ok {
-		r1 = rf(_a0)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
-// CreatePlacementGroupRequest provides a mock function with given fields: _a0
-func (_m *EC2API) CreatePlacementGroupRequest(_a0 *ec2.CreatePlacementGroupInput) (*request.Request, *ec2.CreatePlacementGroupOutput) {
+// CreatePlacementGroupRequest provides a mock function with given fields: _a0
+func (_m *EC2API) CreatePlacementGroupRequest(_a0 *ec2.CreatePlacementGroupInput) (*request.Request, *ec2.CreatePlacementGroupOutput) {
 	ret := _m.Called(_a0)
 
 	var r0 *request.Request
@@ -4796,29 +4139,6 @@ func (_m *EC2API) CreateReservedInstancesListingWithContext(_a0 context.Context,
 	return r0, r1
 }
 
-// CreateRoute provides a mock function with given fields: _a0
-func (_m *EC2API) CreateRoute(_a0 *ec2.CreateRouteInput) (*ec2.CreateRouteOutput, error) {
-	ret := _m.Called(_a0)
-
-	var r0 *ec2.CreateRouteOutput
-	if rf, ok := ret.Get(0).(func(*ec2.CreateRouteInput) *ec2.CreateRouteOutput); ok {
-		r0 = rf(_a0)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.CreateRouteOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(*ec2.CreateRouteInput) error); ok {
-		r1 = rf(_a0)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // CreateRouteRequest provides a mock function with given fields: _a0
 func (_m *EC2API) CreateRouteRequest(_a0 *ec2.CreateRouteInput) (*request.Request, *ec2.CreateRouteOutput) {
 	ret := _m.Called(_a0)
@@ -4844,29 +4164,6 @@ func (_m *EC2API) CreateRouteRequest(_a0 *ec2.CreateRouteInput) (*request.Reques
 	return r0, r1
 }
 
-// CreateRouteTable provides a mock function with given fields: _a0
-func (_m *EC2API) CreateRouteTable(_a0 *ec2.CreateRouteTableInput) (*ec2.CreateRouteTableOutput, error) {
-	ret := _m.Called(_a0)
-
-	var r0 *ec2.CreateRouteTableOutput
-	if rf, ok := ret.Get(0).(func(*ec2.CreateRouteTableInput) *ec2.CreateRouteTableOutput); ok {
-		r0 = rf(_a0)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.CreateRouteTableOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(*ec2.CreateRouteTableInput) error); ok {
-		r1 = rf(_a0)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // CreateRouteTableRequest provides a mock function with given fields: _a0
 func (_m *EC2API) CreateRouteTableRequest(_a0 *ec2.CreateRouteTableInput) (*request.Request, *ec2.CreateRouteTableOutput) {
 	ret := _m.Called(_a0)
@@ -4892,66 +4189,6 @@ func (_m *EC2API) CreateRouteTableRequest(_a0 *ec2.CreateRouteTableInput) (*requ
 	return r0, r1
 }
 
-// CreateRouteTableWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) CreateRouteTableWithContext(_a0 context.Context, _a1 *ec2.CreateRouteTableInput, _a2 ...request.Option) (*ec2.CreateRouteTableOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.CreateRouteTableOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.CreateRouteTableInput, ...request.Option) *ec2.CreateRouteTableOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.CreateRouteTableOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.CreateRouteTableInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
-// CreateRouteWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) CreateRouteWithContext(_a0 context.Context, _a1 *ec2.CreateRouteInput, _a2 ...request.Option) (*ec2.CreateRouteOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.CreateRouteOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.CreateRouteInput, ...request.Option) *ec2.CreateRouteOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.CreateRouteOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.CreateRouteInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // CreateSecurityGroupRequest provides a mock function with given fields: _a0
 func (_m *EC2API) CreateSecurityGroupRequest(_a0 *ec2.CreateSecurityGroupInput) (*request.Request, *ec2.CreateSecurityGroupOutput) {
 	ret := _m.Called(_a0)
@@ -4977,36 +4214,6 @@ func (_m *EC2API) CreateSecurityGroupRequest(_a0 *ec2.CreateSecurityGroupInput)
 	return r0, r1
 }
 
-// CreateSecurityGroupWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) CreateSecurityGroupWithContext(_a0 context.Context, _a1 *ec2.CreateSecurityGroupInput, _a2 ...request.Option) (*ec2.CreateSecurityGroupOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.CreateSecurityGroupOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.CreateSecurityGroupInput, ...request.Option) *ec2.CreateSecurityGroupOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.CreateSecurityGroupOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.CreateSecurityGroupInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // CreateSnapshot provides a mock function with given fields: _a0
 func (_m *EC2API) CreateSnapshot(_a0 *ec2.CreateSnapshotInput) (*ec2.Snapshot, error) {
 	ret := _m.Called(_a0)
@@ -5266,36 +4473,6 @@ func (_m *EC2API) CreateSubnetRequest(_a0 *ec2.CreateSubnetInput) (*request.Requ
 	return r0, r1
 }
 
-// CreateSubnetWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) CreateSubnetWithContext(_a0 context.Context, _a1 *ec2.CreateSubnetInput, _a2 ...request.Option) (*ec2.CreateSubnetOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.CreateSubnetOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.CreateSubnetInput, ...request.Option) *ec2.CreateSubnetOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.CreateSubnetOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.CreateSubnetInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // CreateTagsRequest provides a mock function with given fields: _a0
 func (_m *EC2API) CreateTagsRequest(_a0 *ec2.CreateTagsInput) (*request.Request, *ec2.CreateTagsOutput) {
 	ret := _m.Called(_a0)
@@ -5321,36 +4498,6 @@ func (_m *EC2API) CreateTagsRequest(_a0 *ec2.CreateTagsInput) (*request.Request,
 	return r0, r1
 }
 
-// CreateTagsWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) CreateTagsWithContext(_a0 context.Context, _a1 *ec2.CreateTagsInput, _a2 ...request.Option) (*ec2.CreateTagsOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.CreateTagsOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.CreateTagsInput, ...request.Option) *ec2.CreateTagsOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.CreateTagsOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.CreateTagsInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // CreateTrafficMirrorFilter provides a mock function with given fields: _a0
 func (_m *EC2API) CreateTrafficMirrorFilter(_a0 *ec2.CreateTrafficMirrorFilterInput) (*ec2.CreateTrafficMirrorFilterOutput, error) {
 	ret := _m.Called(_a0)
@@ -5975,29 +5122,6 @@ func (_m *EC2API) CreateTransitGatewayWithContext(_a0 context.Context, _a1 *ec2.
 	return r0, r1
 }
 
-// CreateVolume provides a mock function with given fields: _a0
-func (_m *EC2API) CreateVolume(_a0 *ec2.CreateVolumeInput) (*ec2.Volume, error) {
-	ret := _m.Called(_a0)
-
-	var r0 *ec2.Volume
-	if rf, ok := ret.Get(0).(func(*ec2.CreateVolumeInput) *ec2.Volume); ok {
-		r0 = rf(_a0)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.Volume)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(*ec2.CreateVolumeInput) error); ok {
-		r1 = rf(_a0)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // CreateVolumeRequest provides a mock function with given fields: _a0
 func (_m *EC2API) CreateVolumeRequest(_a0 *ec2.CreateVolumeInput) (*request.Request, *ec2.Volume) {
 	ret := _m.Called(_a0)
@@ -6023,59 +5147,6 @@ func (_m *EC2API) CreateVolumeRequest(_a0 *ec2.CreateVolumeInput) (*request.Requ
 	return r0, r1
 }
 
-// CreateVolumeWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) CreateVolumeWithContext(_a0 context.Context, _a1 *ec2.CreateVolumeInput, _a2 ...request.Option) (*ec2.Volume, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.Volume
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.CreateVolumeInput, ...request.Option) *ec2.Volume); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.Volume)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.CreateVolumeInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
-// CreateVpc provides a mock function with given fields: _a0
-func (_m *EC2API) CreateVpc(_a0 *ec2.CreateVpcInput) (*ec2.CreateVpcOutput, error) {
-	ret := _m.Called(_a0)
-
-	var r0 *ec2.CreateVpcOutput
-	if rf, ok := ret.Get(0).(func(*ec2.CreateVpcInput) *ec2.CreateVpcOutput); ok {
-		r0 = rf(_a0)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.CreateVpcOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(*ec2.CreateVpcInput) error); ok {
-		r1 = rf(_a0)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // CreateVpcEndpoint provides a mock function with given fields: _a0
 func (_m *EC2API) CreateVpcEndpoint(_a0 *ec2.CreateVpcEndpointInput) (*ec2.CreateVpcEndpointOutput, error) {
 	ret := _m.Called(_a0)
@@ -6413,52 +5484,22 @@ func (_m *EC2API) CreateVpcRequest(_a0 *ec2.CreateVpcInput) (*request.Request, *
 	return r0, r1
 }
 
-// CreateVpcWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) CreateVpcWithContext(_a0 context.Context, _a1 *ec2.CreateVpcInput, _a2 ...request.Option) (*ec2.CreateVpcOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
+// CreateVpnConnection provides a mock function with given fields: _a0
+func (_m *EC2API) CreateVpnConnection(_a0 *ec2.CreateVpnConnectionInput) (*ec2.CreateVpnConnectionOutput, error) {
+	ret := _m.Called(_a0)
 
-	var r0 *ec2.CreateVpcOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.CreateVpcInput, ...request.Option) *ec2.CreateVpcOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
+	var r0 *ec2.CreateVpnConnectionOutput
+	if rf, ok := ret.Get(0).(func(*ec2.CreateVpnConnectionInput) *ec2.CreateVpnConnectionOutput); ok {
+		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.CreateVpcOutput)
+			r0 = ret.Get(0).(*ec2.CreateVpnConnectionOutput)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.CreateVpcInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
-// CreateVpnConnection provides a mock function with given fields: _a0
-func (_m *EC2API) CreateVpnConnection(_a0 *ec2.CreateVpnConnectionInput) (*ec2.CreateVpnConnectionOutput, error) {
-	ret := _m.Called(_a0)
-
-	var r0 *ec2.CreateVpnConnectionOutput
-	if rf, ok := ret.Get(0).(func(*ec2.CreateVpnConnectionInput) *ec2.CreateVpnConnectionOutput); ok {
-		r0 = rf(_a0)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.CreateVpnConnectionOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(*ec2.CreateVpnConnectionInput) error); ok {
-		r1 = rf(_a0)
+	if rf, ok := ret.Get(1).(func(*ec2.CreateVpnConnectionInput) error); ok {
+		r1 = rf(_a0)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -6989,29 +6030,6 @@ func (_m *EC2API) DeleteDhcpOptionsWithContext(_a0 context.Context, _a1 *ec2.Del
 	return r0, r1
 }
 
-// DeleteEgressOnlyInternetGateway provides a mock function with given fields: _a0
-func (_m *EC2API) DeleteEgressOnlyInternetGateway(_a0 *ec2.DeleteEgressOnlyInternetGatewayInput) (*ec2.DeleteEgressOnlyInternetGatewayOutput, error) {
-	ret := _m.Called(_a0)
-
-	var r0 *ec2.DeleteEgressOnlyInternetGatewayOutput
-	if rf, ok := ret.Get(0).(func(*ec2.DeleteEgressOnlyInternetGatewayInput) *ec2.DeleteEgressOnlyInternetGatewayOutput); ok {
-		r0 = rf(_a0)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.DeleteEgressOnlyInternetGatewayOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(*ec2.DeleteEgressOnlyInternetGatewayInput) error); ok {
-		r1 = rf(_a0)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // DeleteEgressOnlyInternetGatewayRequest provides a mock function with given fields: _a0
 func (_m *EC2API) DeleteEgressOnlyInternetGatewayRequest(_a0 *ec2.DeleteEgressOnlyInternetGatewayInput) (*request.Request, *ec2.DeleteEgressOnlyInternetGatewayOutput) {
 	ret := _m.Called(_a0)
@@ -7037,36 +6055,6 @@ func (_m *EC2API) DeleteEgressOnlyInternetGatewayRequest(_a0 *ec2.DeleteEgressOn
 	return r0, r1
 }
 
-// DeleteEgressOnlyInternetGatewayWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) DeleteEgressOnlyInternetGatewayWithContext(_a0 context.Context, _a1 *ec2.DeleteEgressOnlyInternetGatewayInput, _a2 ...request.Option) (*ec2.DeleteEgressOnlyInternetGatewayOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.DeleteEgressOnlyInternetGatewayOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.DeleteEgressOnlyInternetGatewayInput, ...request.Option) *ec2.DeleteEgressOnlyInternetGatewayOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.DeleteEgressOnlyInternetGatewayOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.DeleteEgressOnlyInternetGatewayInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // DeleteFleets provides a mock function with given fields: _a0
 func (_m *EC2API) DeleteFleets(_a0 *ec2.DeleteFleetsInput) (*ec2.DeleteFleetsOutput, error) {
 	ret := _m.Called(_a0)
@@ -7301,29 +6289,6 @@ func (_m *EC2API) DeleteFpgaImageWithContext(_a0 context.Context, _a1 *ec2.Delet
 	return r0, r1
 }
 
-// DeleteInternetGateway provides a mock function with given fields: _a0
-func (_m *EC2API) DeleteInternetGateway(_a0 *ec2.DeleteInternetGatewayInput) (*ec2.DeleteInternetGatewayOutput, error) {
-	ret := _m.Called(_a0)
-
-	var r0 *ec2.DeleteInternetGatewayOutput
-	if rf, ok := ret.Get(0).(func(*ec2.DeleteInternetGatewayInput) *ec2.DeleteInternetGatewayOutput); ok {
-		r0 = rf(_a0)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.DeleteInternetGatewayOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(*ec2.DeleteInternetGatewayInput) error); ok {
-		r1 = rf(_a0)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // DeleteInternetGatewayRequest provides a mock function with given fields: _a0
 func (_m *EC2API) DeleteInternetGatewayRequest(_a0 *ec2.DeleteInternetGatewayInput) (*request.Request, *ec2.DeleteInternetGatewayOutput) {
 	ret := _m.Called(_a0)
@@ -7349,36 +6314,6 @@ func (_m *EC2API) DeleteInternetGatewayRequest(_a0 *ec2.DeleteInternetGatewayInp
 	return r0, r1
 }
 
-// DeleteInternetGatewayWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) DeleteInternetGatewayWithContext(_a0 context.Context, _a1 *ec2.DeleteInternetGatewayInput, _a2 ...request.Option) (*ec2.DeleteInternetGatewayOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.DeleteInternetGatewayOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.DeleteInternetGatewayInput, ...request.Option) *ec2.DeleteInternetGatewayOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.DeleteInternetGatewayOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.DeleteInternetGatewayInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // DeleteKeyPair provides a mock function with given fields: _a0
 func (_m *EC2API) DeleteKeyPair(_a0 *ec2.DeleteKeyPairInput) (*ec2.DeleteKeyPairOutput, error) {
 	ret := _m.Called(_a0)
@@ -7613,29 +6548,6 @@ func (_m *EC2API) DeleteLaunchTemplateWithContext(_a0 context.Context, _a1 *ec2.
 	return r0, r1
 }
 
-// DeleteNatGateway provides a mock function with given fields: _a0
-func (_m *EC2API) DeleteNatGateway(_a0 *ec2.DeleteNatGatewayInput) (*ec2.DeleteNatGatewayOutput, error) {
-	ret := _m.Called(_a0)
-
-	var r0 *ec2.DeleteNatGatewayOutput
-	if rf, ok := ret.Get(0).(func(*ec2.DeleteNatGatewayInput) *ec2.DeleteNatGatewayOutput); ok {
-		r0 = rf(_a0)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.DeleteNatGatewayOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(*ec2.DeleteNatGatewayInput) error); ok {
-		r1 = rf(_a0)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // DeleteNatGatewayRequest provides a mock function with given fields: _a0
 func (_m *EC2API) DeleteNatGatewayRequest(_a0 *ec2.DeleteNatGatewayInput) (*request.Request, *ec2.DeleteNatGatewayOutput) {
 	ret := _m.Called(_a0)
@@ -7661,36 +6573,6 @@ func (_m *EC2API) DeleteNatGatewayRequest(_a0 *ec2.DeleteNatGatewayInput) (*requ
 	return r0, r1
 }
 
-// DeleteNatGatewayWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) DeleteNatGatewayWithContext(_a0 context.Context, _a1 *ec2.DeleteNatGatewayInput, _a2 ...request.Option) (*ec2.DeleteNatGatewayOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.DeleteNatGatewayOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.DeleteNatGatewayInput, ...request.Option) *ec2.DeleteNatGatewayOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.DeleteNatGatewayOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.DeleteNatGatewayInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // DeleteNetworkAcl provides a mock function with given fields: _a0
 func (_m *EC2API) DeleteNetworkAcl(_a0 *ec2.DeleteNetworkAclInput) (*ec2.DeleteNetworkAclOutput, error) {
 	ret := _m.Called(_a0)
@@ -7950,36 +6832,6 @@ func (_m *EC2API) DeleteNetworkInterfaceRequest(_a0 *ec2.DeleteNetworkInterfaceI
 	return r0, r1
 }
 
-// DeleteNetworkInterfaceWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) DeleteNetworkInterfaceWithContext(_a0 context.Context, _a1 *ec2.DeleteNetworkInterfaceInput, _a2 ...request.Option) (*ec2.DeleteNetworkInterfaceOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.DeleteNetworkInterfaceOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.DeleteNetworkInterfaceInput, ...request.Option) *ec2.DeleteNetworkInterfaceOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.DeleteNetworkInterfaceOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.DeleteNetworkInterfaceInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // DeletePlacementGroup provides a mock function with given fields: _a0
 func (_m *EC2API) DeletePlacementGroup(_a0 *ec2.DeletePlacementGroupInput) (*ec2.DeletePlacementGroupOutput, error) {
 	ret := _m.Called(_a0)
@@ -8136,35 +6988,37 @@ func (_m *EC2API) DeleteQueuedReservedInstancesWithContext(_a0 context.Context,
 	return r0, r1
 }
 
-// DeleteRoute provides a mock function with given fields: _a0
-func (_m *EC2API) DeleteRoute(_a0 *ec2.DeleteRouteInput) (*ec2.DeleteRouteOutput, error) {
+// DeleteRouteRequest provides a mock function with given fields: _a0
+func (_m *EC2API) DeleteRouteRequest(_a0 *ec2.DeleteRouteInput) (*request.Request, *ec2.DeleteRouteOutput) {
 	ret := _m.Called(_a0)
 
-	var r0 *ec2.DeleteRouteOutput
-	if rf, ok := ret.Get(0).(func(*ec2.DeleteRouteInput) *ec2.DeleteRouteOutput); ok {
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*ec2.DeleteRouteInput) *request.Request); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.DeleteRouteOutput)
+			r0 = ret.Get(0).(*request.Request)
 		}
 	}
 
-	var r1 error
-	if rf, ok := ret.Get(1).(func(*ec2.DeleteRouteInput) error); ok {
+	var r1 *ec2.DeleteRouteOutput
+	if rf, ok := ret.Get(1).(func(*ec2.DeleteRouteInput) *ec2.DeleteRouteOutput); ok {
 		r1 = rf(_a0)
 	} else {
-		r1 = ret.Error(1)
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ec2.DeleteRouteOutput)
+		}
 	}
 
 	return r0, r1
 }
 
-// DeleteRouteRequest provides a mock function with given fields: _a0
-func (_m *EC2API) DeleteRouteRequest(_a0 *ec2.DeleteRouteInput) (*request.Request, *ec2.DeleteRouteOutput) {
+// DeleteRouteTableRequest provides a mock function with given fields: _a0
+func (_m *EC2API) DeleteRouteTableRequest(_a0 *ec2.DeleteRouteTableInput) (*request.Request, *ec2.DeleteRouteTableOutput) {
 	ret := _m.Called(_a0)
 
 	var r0 *request.Request
-	if rf, ok := ret.Get(0).(func(*ec2.DeleteRouteInput) *request.Request); ok {
+	if rf, ok := ret.Get(0).(func(*ec2.DeleteRouteTableInput) *request.Request); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
@@ -8172,98 +7026,93 @@ func (_m *EC2API) DeleteRouteRequest(_a0 *ec2.DeleteRouteInput) (*request.Reques
 		}
 	}
 
-	var r1 *ec2.DeleteRouteOutput
-	if rf, ok := ret.Get(1).(func(*ec2.DeleteRouteInput) *ec2.DeleteRouteOutput); ok {
+	var r1 *ec2.DeleteRouteTableOutput
+	if rf, ok := ret.Get(1).(func(*ec2.DeleteRouteTableInput) *ec2.DeleteRouteTableOutput); ok {
 		r1 = rf(_a0)
 	} else {
 		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(*ec2.DeleteRouteOutput)
+			r1 = ret.Get(1).(*ec2.DeleteRouteTableOutput)
 		}
 	}
 
 	return r0, r1
 }
 
-// DeleteRouteTable provides a mock function with given fields: _a0
-func (_m *EC2API) DeleteRouteTable(_a0 *ec2.DeleteRouteTableInput) (*ec2.DeleteRouteTableOutput, error) {
+// DeleteSecurityGroupRequest provides a mock function with given fields: _a0
+func (_m *EC2API) DeleteSecurityGroupRequest(_a0 *ec2.DeleteSecurityGroupInput) (*request.Request, *ec2.DeleteSecurityGroupOutput) {
 	ret := _m.Called(_a0)
 
-	var r0 *ec2.DeleteRouteTableOutput
-	if rf, ok := ret.Get(0).(func(*ec2.DeleteRouteTableInput) *ec2.DeleteRouteTableOutput); ok {
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*ec2.DeleteSecurityGroupInput) *request.Request); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.DeleteRouteTableOutput)
+			r0 = ret.Get(0).(*request.Request)
 		}
 	}
 
-	var r1 error
-	if rf, ok := ret.Get(1).(func(*ec2.DeleteRouteTableInput) error); ok {
+	var r1 *ec2.DeleteSecurityGroupOutput
+	if rf, ok := ret.Get(1).(func(*ec2.DeleteSecurityGroupInput) *ec2.DeleteSecurityGroupOutput); ok {
 		r1 = rf(_a0)
 	} else {
-		r1 = ret.Error(1)
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ec2.DeleteSecurityGroupOutput)
+		}
 	}
 
 	return r0, r1
 }
 
-// DeleteRouteTableRequest provides a mock function with given fields: _a0
-func (_m *EC2API) DeleteRouteTableRequest(_a0 *ec2.DeleteRouteTableInput) (*request.Request, *ec2.DeleteRouteTableOutput) {
+// DeleteSnapshot provides a mock function with given fields: _a0
+func (_m *EC2API) DeleteSnapshot(_a0 *ec2.DeleteSnapshotInput) (*ec2.DeleteSnapshotOutput, error) {
 	ret := _m.Called(_a0)
 
-	var r0 *request.Request
-	if rf, ok := ret.Get(0).(func(*ec2.DeleteRouteTableInput) *request.Request); ok {
+	var r0 *ec2.DeleteSnapshotOutput
+	if rf, ok := ret.Get(0).(func(*ec2.DeleteSnapshotInput) *ec2.DeleteSnapshotOutput); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*request.Request)
+			r0 = ret.Get(0).(*ec2.DeleteSnapshotOutput)
 		}
 	}
 
-	var r1 *ec2.DeleteRouteTableOutput
-	if rf, ok := ret.Get(1).(func(*ec2.DeleteRouteTableInput) *ec2.DeleteRouteTableOutput); ok {
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*ec2.DeleteSnapshotInput) error); ok {
 		r1 = rf(_a0)
 	} else {
-		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(*ec2.DeleteRouteTableOutput)
-		}
+		r1 = ret.Error(1)
 	}
 
 	return r0, r1
 }
 
-// DeleteRouteTableWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) DeleteRouteTableWithContext(_a0 context.Context, _a1 *ec2.DeleteRouteTableInput, _a2 ...request.Option) (*ec2.DeleteRouteTableOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
+// DeleteSnapshotRequest provides a mock function with given fields: _a0
+func (_m *EC2API) DeleteSnapshotRequest(_a0 *ec2.DeleteSnapshotInput) (*request.Request, *ec2.DeleteSnapshotOutput) {
+	ret := _m.Called(_a0)
 
-	var r0 *ec2.DeleteRouteTableOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.DeleteRouteTableInput, ...request.Option) *ec2.DeleteRouteTableOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*ec2.DeleteSnapshotInput) *request.Request); ok {
+		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.DeleteRouteTableOutput)
+			r0 = ret.Get(0).(*request.Request)
 		}
 	}
 
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.DeleteRouteTableInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
+	var r1 *ec2.DeleteSnapshotOutput
+	if rf, ok := ret.Get(1).(func(*ec2.DeleteSnapshotInput) *ec2.DeleteSnapshotOutput); ok {
+		r1 = rf(_a0)
 	} else {
-		r1 = ret.Error(1)
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ec2.DeleteSnapshotOutput)
+		}
 	}
 
 	return r0, r1
 }
 
-// DeleteRouteWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) DeleteRouteWithContext(_a0 context.Context, _a1 *ec2.DeleteRouteInput, _a2 ...request.Option) (*ec2.DeleteRouteOutput, error) {
+// DeleteSnapshotWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *EC2API) DeleteSnapshotWithContext(_a0 context.Context, _a1 *ec2.DeleteSnapshotInput, _a2 ...request.Option) (*ec2.DeleteSnapshotOutput, error) {
 	_va := make([]interface{}, len(_a2))
 	for _i := range _a2 {
 		_va[_i] = _a2[_i]
@@ -8273,150 +7122,17 @@ func (_m *EC2API) DeleteRouteWithContext(_a0 context.Context, _a1 *ec2.DeleteRou
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
-	var r0 *ec2.DeleteRouteOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.DeleteRouteInput, ...request.Option) *ec2.DeleteRouteOutput); ok {
+	var r0 *ec2.DeleteSnapshotOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *ec2.DeleteSnapshotInput, ...request.Option) *ec2.DeleteSnapshotOutput); ok {
 		r0 = rf(_a0, _a1, _a2...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.DeleteRouteOutput)
+			r0 = ret.Get(0).(*ec2.DeleteSnapshotOutput)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.DeleteRouteInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
-// DeleteSecurityGroupRequest provides a mock function with given fields: _a0
-func (_m *EC2API) DeleteSecurityGroupRequest(_a0 *ec2.DeleteSecurityGroupInput) (*request.Request, *ec2.DeleteSecurityGroupOutput) {
-	ret := _m.Called(_a0)
-
-	var r0 *request.Request
-	if rf, ok := ret.Get(0).(func(*ec2.DeleteSecurityGroupInput) *request.Request); ok {
-		r0 = rf(_a0)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*request.Request)
-		}
-	}
-
-	var r1 *ec2.DeleteSecurityGroupOutput
-	if rf, ok := ret.Get(1).(func(*ec2.DeleteSecurityGroupInput) *ec2.DeleteSecurityGroupOutput); ok {
-		r1 = rf(_a0)
-	} else {
-		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(*ec2.DeleteSecurityGroupOutput)
-		}
-	}
-
-	return r0, r1
-}
-
-// DeleteSecurityGroupWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) DeleteSecurityGroupWithContext(_a0 context.Context, _a1 *ec2.DeleteSecurityGroupInput, _a2 ...request.Option) (*ec2.DeleteSecurityGroupOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.DeleteSecurityGroupOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.DeleteSecurityGroupInput, ...request.Option) *ec2.DeleteSecurityGroupOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.DeleteSecurityGroupOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.DeleteSecurityGroupInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
-// DeleteSnapshot provides a mock function with given fields: _a0
-func (_m *EC2API) DeleteSnapshot(_a0 *ec2.DeleteSnapshotInput) (*ec2.DeleteSnapshotOutput, error) {
-	ret := _m.Called(_a0)
-
-	var r0 *ec2.DeleteSnapshotOutput
-	if rf, ok := ret.Get(0).(func(*ec2.DeleteSnapshotInput) *ec2.DeleteSnapshotOutput); ok {
-		r0 = rf(_a0)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.DeleteSnapshotOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(*ec2.DeleteSnapshotInput) error); ok {
-		r1 = rf(_a0)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
-// DeleteSnapshotRequest provides a mock function with given fields: _a0
-func (_m *EC2API) DeleteSnapshotRequest(_a0 *ec2.DeleteSnapshotInput) (*request.Request, *ec2.DeleteSnapshotOutput) {
-	ret := _m.Called(_a0)
-
-	var r0 *request.Request
-	if rf, ok := ret.Get(0).(func(*ec2.DeleteSnapshotInput) *request.Request); ok {
-		r0 = rf(_a0)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*request.Request)
-		}
-	}
-
-	var r1 *ec2.DeleteSnapshotOutput
-	if rf, ok := ret.Get(1).(func(*ec2.DeleteSnapshotInput) *ec2.DeleteSnapshotOutput); ok {
-		r1 = rf(_a0)
-	} else {
-		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(*ec2.DeleteSnapshotOutput)
-		}
-	}
-
-	return r0, r1
-}
-
-// DeleteSnapshotWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) DeleteSnapshotWithContext(_a0 context.Context, _a1 *ec2.DeleteSnapshotInput, _a2 ...request.Option) (*ec2.DeleteSnapshotOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.DeleteSnapshotOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.DeleteSnapshotInput, ...request.Option) *ec2.DeleteSnapshotOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.DeleteSnapshotOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.DeleteSnapshotInput, ...request.Option) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *ec2.DeleteSnapshotInput, ...request.Option) error); ok {
 		r1 = rf(_a0, _a1, _a2...)
 	} else {
 		r1 = ret.Error(1)
@@ -8503,29 +7219,6 @@ func (_m *EC2API) DeleteSpotDatafeedSubscriptionWithContext(_a0 context.Context,
 	return r0, r1
 }
 
-// DeleteSubnet provides a mock function with given fields: _a0
-func (_m *EC2API) DeleteSubnet(_a0 *ec2.DeleteSubnetInput) (*ec2.DeleteSubnetOutput, error) {
-	ret := _m.Called(_a0)
-
-	var r0 *ec2.DeleteSubnetOutput
-	if rf, ok := ret.Get(0).(func(*ec2.DeleteSubnetInput) *ec2.DeleteSubnetOutput); ok {
-		r0 = rf(_a0)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.DeleteSubnetOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(*ec2.DeleteSubnetInput) error); ok {
-		r1 = rf(_a0)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // DeleteSubnetRequest provides a mock function with given fields: _a0
 func (_m *EC2API) DeleteSubnetRequest(_a0 *ec2.DeleteSubnetInput) (*request.Request, *ec2.DeleteSubnetOutput) {
 	ret := _m.Called(_a0)
@@ -8551,36 +7244,6 @@ func (_m *EC2API) DeleteSubnetRequest(_a0 *ec2.DeleteSubnetInput) (*request.Requ
 	return r0, r1
 }
 
-// DeleteSubnetWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) DeleteSubnetWithContext(_a0 context.Context, _a1 *ec2.DeleteSubnetInput, _a2 ...request.Option) (*ec2.DeleteSubnetOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.DeleteSubnetOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.DeleteSubnetInput, ...request.Option) *ec2.DeleteSubnetOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.DeleteSubnetOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.DeleteSubnetInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // DeleteTags provides a mock function with given fields: _a0
 func (_m *EC2API) DeleteTags(_a0 *ec2.DeleteTagsInput) (*ec2.DeleteTagsOutput, error) {
 	ret := _m.Called(_a0)
@@ -9283,29 +7946,6 @@ func (_m *EC2API) DeleteTransitGatewayWithContext(_a0 context.Context, _a1 *ec2.
 	return r0, r1
 }
 
-// DeleteVolume provides a mock function with given fields: _a0
-func (_m *EC2API) DeleteVolume(_a0 *ec2.DeleteVolumeInput) (*ec2.DeleteVolumeOutput, error) {
-	ret := _m.Called(_a0)
-
-	var r0 *ec2.DeleteVolumeOutput
-	if rf, ok := ret.Get(0).(func(*ec2.DeleteVolumeInput) *ec2.DeleteVolumeOutput); ok {
-		r0 = rf(_a0)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.DeleteVolumeOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(*ec2.DeleteVolumeInput) error); ok {
-		r1 = rf(_a0)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // DeleteVolumeRequest provides a mock function with given fields: _a0
 func (_m *EC2API) DeleteVolumeRequest(_a0 *ec2.DeleteVolumeInput) (*request.Request, *ec2.DeleteVolumeOutput) {
 	ret := _m.Called(_a0)
@@ -9331,59 +7971,6 @@ func (_m *EC2API) DeleteVolumeRequest(_a0 *ec2.DeleteVolumeInput) (*request.Requ
 	return r0, r1
 }
 
-// DeleteVolumeWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) DeleteVolumeWithContext(_a0 context.Context, _a1 *ec2.DeleteVolumeInput, _a2 ...request.Option) (*ec2.DeleteVolumeOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.DeleteVolumeOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.DeleteVolumeInput, ...request.Option) *ec2.DeleteVolumeOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.DeleteVolumeOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.DeleteVolumeInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
-// DeleteVpc provides a mock function with given fields: _a0
-func (_m *EC2API) DeleteVpc(_a0 *ec2.DeleteVpcInput) (*ec2.DeleteVpcOutput, error) {
-	ret := _m.Called(_a0)
-
-	var r0 *ec2.DeleteVpcOutput
-	if rf, ok := ret.Get(0).(func(*ec2.DeleteVpcInput) *ec2.DeleteVpcOutput); ok {
-		r0 = rf(_a0)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.DeleteVpcOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(*ec2.DeleteVpcInput) error); ok {
-		r1 = rf(_a0)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // DeleteVpcEndpointConnectionNotifications provides a mock function with given fields: _a0
 func (_m *EC2API) DeleteVpcEndpointConnectionNotifications(_a0 *ec2.DeleteVpcEndpointConnectionNotificationsInput) (*ec2.DeleteVpcEndpointConnectionNotificationsOutput, error) {
 	ret := _m.Called(_a0)
@@ -9721,36 +8308,6 @@ func (_m *EC2API) DeleteVpcRequest(_a0 *ec2.DeleteVpcInput) (*request.Request, *
 	return r0, r1
 }
 
-// DeleteVpcWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) DeleteVpcWithContext(_a0 context.Context, _a1 *ec2.DeleteVpcInput, _a2 ...request.Option) (*ec2.DeleteVpcOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.DeleteVpcOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.DeleteVpcInput, ...request.Option) *ec2.DeleteVpcOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.DeleteVpcOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.DeleteVpcInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // DeleteVpnConnection provides a mock function with given fields: _a0
 func (_m *EC2API) DeleteVpnConnection(_a0 *ec2.DeleteVpnConnectionInput) (*ec2.DeleteVpnConnectionOutput, error) {
 	ret := _m.Called(_a0)
@@ -10244,36 +8801,6 @@ func (_m *EC2API) DescribeAddressesRequest(_a0 *ec2.DescribeAddressesInput) (*re
 	return r0, r1
 }
 
-// DescribeAddressesWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) DescribeAddressesWithContext(_a0 context.Context, _a1 *ec2.DescribeAddressesInput, _a2 ...request.Option) (*ec2.DescribeAddressesOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.DescribeAddressesOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.DescribeAddressesInput, ...request.Option) *ec2.DescribeAddressesOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.DescribeAddressesOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.DescribeAddressesInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // DescribeAggregateIdFormat provides a mock function with given fields: _a0
 func (_m *EC2API) DescribeAggregateIdFormat(_a0 *ec2.DescribeAggregateIdFormatInput) (*ec2.DescribeAggregateIdFormatOutput, error) {
 	ret := _m.Called(_a0)
@@ -10377,36 +8904,6 @@ func (_m *EC2API) DescribeAvailabilityZonesRequest(_a0 *ec2.DescribeAvailability
 	return r0, r1
 }
 
-// DescribeAvailabilityZonesWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) DescribeAvailabilityZonesWithContext(_a0 context.Context, _a1 *ec2.DescribeAvailabilityZonesInput, _a2 ...request.Option) (*ec2.DescribeAvailabilityZonesOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.DescribeAvailabilityZonesOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.DescribeAvailabilityZonesInput, ...request.Option) *ec2.DescribeAvailabilityZonesOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.DescribeAvailabilityZonesOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.DescribeAvailabilityZonesInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // DescribeBundleTasks provides a mock function with given fields: _a0
 func (_m *EC2API) DescribeBundleTasks(_a0 *ec2.DescribeBundleTasksInput) (*ec2.DescribeBundleTasksOutput, error) {
 	ret := _m.Called(_a0)
@@ -11658,29 +10155,6 @@ func (_m *EC2API) DescribeDhcpOptionsWithContext(_a0 context.Context, _a1 *ec2.D
 	return r0, r1
 }
 
-// DescribeEgressOnlyInternetGateways provides a mock function with given fields: _a0
-func (_m *EC2API) DescribeEgressOnlyInternetGateways(_a0 *ec2.DescribeEgressOnlyInternetGatewaysInput) (*ec2.DescribeEgressOnlyInternetGatewaysOutput, error) {
-	ret := _m.Called(_a0)
-
-	var r0 *ec2.DescribeEgressOnlyInternetGatewaysOutput
-	if rf, ok := ret.Get(0).(func(*ec2.DescribeEgressOnlyInternetGatewaysInput) *ec2.DescribeEgressOnlyInternetGatewaysOutput); ok {
-		r0 = rf(_a0)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.DescribeEgressOnlyInternetGatewaysOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(*ec2.DescribeEgressOnlyInternetGatewaysInput) error); ok {
-		r1 = rf(_a0)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // DescribeEgressOnlyInternetGatewaysPages provides a mock function with given fields: _a0, _a1
 func (_m *EC2API) DescribeEgressOnlyInternetGatewaysPages(_a0 *ec2.DescribeEgressOnlyInternetGatewaysInput, _a1 func(*ec2.DescribeEgressOnlyInternetGatewaysOutput, bool) bool) error {
 	ret := _m.Called(_a0, _a1)
@@ -11741,36 +10215,6 @@ func (_m *EC2API) DescribeEgressOnlyInternetGatewaysRequest(_a0 *ec2.DescribeEgr
 	return r0, r1
 }
 
-// DescribeEgressOnlyInternetGatewaysWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) DescribeEgressOnlyInternetGatewaysWithContext(_a0 context.Context, _a1 *ec2.DescribeEgressOnlyInternetGatewaysInput, _a2 ...request.Option) (*ec2.DescribeEgressOnlyInternetGatewaysOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.DescribeEgressOnlyInternetGatewaysOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.DescribeEgressOnlyInternetGatewaysInput, ...request.Option) *ec2.DescribeEgressOnlyInternetGatewaysOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.DescribeEgressOnlyInternetGatewaysOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.DescribeEgressOnlyInternetGatewaysInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // DescribeElasticGpus provides a mock function with given fields: _a0
 func (_m *EC2API) DescribeElasticGpus(_a0 *ec2.DescribeElasticGpusInput) (*ec2.DescribeElasticGpusOutput, error) {
 	ret := _m.Called(_a0)
@@ -13593,36 +12037,6 @@ func (_m *EC2API) DescribeInstanceAttributeRequest(_a0 *ec2.DescribeInstanceAttr
 	return r0, r1
 }
 
-// DescribeInstanceAttributeWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) DescribeInstanceAttributeWithContext(_a0 context.Context, _a1 *ec2.DescribeInstanceAttributeInput, _a2 ...request.Option) (*ec2.DescribeInstanceAttributeOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.DescribeInstanceAttributeOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.DescribeInstanceAttributeInput, ...request.Option) *ec2.DescribeInstanceAttributeOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.DescribeInstanceAttributeOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.DescribeInstanceAttributeInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // DescribeInstanceCreditSpecifications provides a mock function with given fields: _a0
 func (_m *EC2API) DescribeInstanceCreditSpecifications(_a0 *ec2.DescribeInstanceCreditSpecificationsInput) (*ec2.DescribeInstanceCreditSpecificationsOutput, error) {
 	ret := _m.Called(_a0)
@@ -13849,41 +12263,6 @@ func (_m *EC2API) DescribeInstanceStatusWithContext(_a0 context.Context, _a1 *ec
 	return r0, r1
 }
 
-// DescribeInstancesPages provides a mock function with given fields: _a0, _a1
-func (_m *EC2API) DescribeInstancesPages(_a0 *ec2.DescribeInstancesInput, _a1 func(*ec2.DescribeInstancesOutput, bool) bool) error {
-	ret := _m.Called(_a0, _a1)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(*ec2.DescribeInstancesInput, func(*ec2.DescribeInstancesOutput, bool) bool) error); ok {
-		r0 = rf(_a0, _a1)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
-// DescribeInstancesPagesWithContext provides a mock function with given fields: _a0, _a1, _a2, _a3
-func (_m *EC2API) DescribeInstancesPagesWithContext(_a0 context.Context, _a1 *ec2.DescribeInstancesInput, _a2 func(*ec2.DescribeInstancesOutput, bool) bool, _a3 ...request.Option) error {
-	_va := make([]interface{}, len(_a3))
-	for _i := range _a3 {
-		_va[_i] = _a3[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1, _a2)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.DescribeInstancesInput, func(*ec2.DescribeInstancesOutput, bool) bool, ...request.Option) error); ok {
-		r0 = rf(_a0, _a1, _a2, _a3...)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
 // DescribeInstancesRequest provides a mock function with given fields: _a0
 func (_m *EC2API) DescribeInstancesRequest(_a0 *ec2.DescribeInstancesInput) (*request.Request, *ec2.DescribeInstancesOutput) {
 	ret := _m.Called(_a0)
@@ -13909,59 +12288,6 @@ func (_m *EC2API) DescribeInstancesRequest(_a0 *ec2.DescribeInstancesInput) (*re
 	return r0, r1
 }
 
-// DescribeInstancesWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) DescribeInstancesWithContext(_a0 context.Context, _a1 *ec2.DescribeInstancesInput, _a2 ...request.Option) (*ec2.DescribeInstancesOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.DescribeInstancesOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.DescribeInstancesInput, ...request.Option) *ec2.DescribeInstancesOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.DescribeInstancesOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.DescribeInstancesInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
-// DescribeInternetGateways provides a mock function with given fields: _a0
-func (_m *EC2API) DescribeInternetGateways(_a0 *ec2.DescribeInternetGatewaysInput) (*ec2.DescribeInternetGatewaysOutput, error) {
-	ret := _m.Called(_a0)
-
-	var r0 *ec2.DescribeInternetGatewaysOutput
-	if rf, ok := ret.Get(0).(func(*ec2.DescribeInternetGatewaysInput) *ec2.DescribeInternetGatewaysOutput); ok {
-		r0 = rf(_a0)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.DescribeInternetGatewaysOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(*ec2.DescribeInternetGatewaysInput) error); ok {
-		r1 = rf(_a0)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // DescribeInternetGatewaysPages provides a mock function with given fields: _a0, _a1
 func (_m *EC2API) DescribeInternetGatewaysPages(_a0 *ec2.DescribeInternetGatewaysInput, _a1 func(*ec2.DescribeInternetGatewaysOutput, bool) bool) error {
 	ret := _m.Called(_a0, _a1)
@@ -14022,36 +12348,6 @@ func (_m *EC2API) DescribeInternetGatewaysRequest(_a0 *ec2.DescribeInternetGatew
 	return r0, r1
 }
 
-// DescribeInternetGatewaysWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) DescribeInternetGatewaysWithContext(_a0 context.Context, _a1 *ec2.DescribeInternetGatewaysInput, _a2 ...request.Option) (*ec2.DescribeInternetGatewaysOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.DescribeInternetGatewaysOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.DescribeInternetGatewaysInput, ...request.Option) *ec2.DescribeInternetGatewaysOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.DescribeInternetGatewaysOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.DescribeInternetGatewaysInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // DescribeKeyPairs provides a mock function with given fields: _a0
 func (_m *EC2API) DescribeKeyPairs(_a0 *ec2.DescribeKeyPairsInput) (*ec2.DescribeKeyPairsOutput, error) {
 	ret := _m.Called(_a0)
@@ -14469,29 +12765,6 @@ func (_m *EC2API) DescribeMovingAddressesWithContext(_a0 context.Context, _a1 *e
 	return r0, r1
 }
 
-// DescribeNatGateways provides a mock function with given fields: _a0
-func (_m *EC2API) DescribeNatGateways(_a0 *ec2.DescribeNatGatewaysInput) (*ec2.DescribeNatGatewaysOutput, error) {
-	ret := _m.Called(_a0)
-
-	var r0 *ec2.DescribeNatGatewaysOutput
-	if rf, ok := ret.Get(0).(func(*ec2.DescribeNatGatewaysInput) *ec2.DescribeNatGatewaysOutput); ok {
-		r0 = rf(_a0)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.DescribeNatGatewaysOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(*ec2.DescribeNatGatewaysInput) error); ok {
-		r1 = rf(_a0)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // DescribeNatGatewaysPages provides a mock function with given fields: _a0, _a1
 func (_m *EC2API) DescribeNatGatewaysPages(_a0 *ec2.DescribeNatGatewaysInput, _a1 func(*ec2.DescribeNatGatewaysOutput, bool) bool) error {
 	ret := _m.Called(_a0, _a1)
@@ -14552,230 +12825,59 @@ func (_m *EC2API) DescribeNatGatewaysRequest(_a0 *ec2.DescribeNatGatewaysInput)
 	return r0, r1
 }
 
-// DescribeNatGatewaysWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) DescribeNatGatewaysWithContext(_a0 context.Context, _a1 *ec2.DescribeNatGatewaysInput, _a2 ...request.Option) (*ec2.DescribeNatGatewaysOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
+// DescribeNetworkAclsRequest provides a mock function with given fields: _a0
+func (_m *EC2API) DescribeNetworkAclsRequest(_a0 *ec2.DescribeNetworkAclsInput) (*request.Request, *ec2.DescribeNetworkAclsOutput) {
+	ret := _m.Called(_a0)
 
-	var r0 *ec2.DescribeNatGatewaysOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.DescribeNatGatewaysInput, ...request.Option) *ec2.DescribeNatGatewaysOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*ec2.DescribeNetworkAclsInput) *request.Request); ok {
+		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.DescribeNatGatewaysOutput)
+			r0 = ret.Get(0).(*request.Request)
 		}
 	}
 
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.DescribeNatGatewaysInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
+	var r1 *ec2.DescribeNetworkAclsOutput
+	if rf, ok := ret.Get(1).(func(*ec2.DescribeNetworkAclsInput) *ec2.DescribeNetworkAclsOutput); ok {
+		r1 = rf(_a0)
 	} else {
-		r1 = ret.Error(1)
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ec2.DescribeNetworkAclsOutput)
+		}
 	}
 
 	return r0, r1
 }
 
-// DescribeNetworkAcls provides a mock function with given fields: _a0
-func (_m *EC2API) DescribeNetworkAcls(_a0 *ec2.DescribeNetworkAclsInput) (*ec2.DescribeNetworkAclsOutput, error) {
+// DescribeNetworkInterfaceAttributeRequest provides a mock function with given fields: _a0
+func (_m *EC2API) DescribeNetworkInterfaceAttributeRequest(_a0 *ec2.DescribeNetworkInterfaceAttributeInput) (*request.Request, *ec2.DescribeNetworkInterfaceAttributeOutput) {
 	ret := _m.Called(_a0)
 
-	var r0 *ec2.DescribeNetworkAclsOutput
-	if rf, ok := ret.Get(0).(func(*ec2.DescribeNetworkAclsInput) *ec2.DescribeNetworkAclsOutput); ok {
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*ec2.DescribeNetworkInterfaceAttributeInput) *request.Request); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.DescribeNetworkAclsOutput)
+			r0 = ret.Get(0).(*request.Request)
 		}
 	}
 
-	var r1 error
-	if rf, ok := ret.Get(1).(func(*ec2.DescribeNetworkAclsInput) error); ok {
+	var r1 *ec2.DescribeNetworkInterfaceAttributeOutput
+	if rf, ok := ret.Get(1).(func(*ec2.DescribeNetworkInterfaceAttributeInput) *ec2.DescribeNetworkInterfaceAttributeOutput); ok {
 		r1 = rf(_a0)
 	} else {
-		r1 = ret.Error(1)
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ec2.DescribeNetworkInterfaceAttributeOutput)
+		}
 	}
 
 	return r0, r1
 }
 
-// DescribeNetworkAclsPages provides a mock function with given fields: _a0, _a1
-func (_m *EC2API) DescribeNetworkAclsPages(_a0 *ec2.DescribeNetworkAclsInput, _a1 func(*ec2.DescribeNetworkAclsOutput, bool) bool) error {
-	ret := _m.Called(_a0, _a1)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(*ec2.DescribeNetworkAclsInput, func(*ec2.DescribeNetworkAclsOutput, bool) bool) error); ok {
-		r0 = rf(_a0, _a1)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
-// DescribeNetworkAclsPagesWithContext provides a mock function with given fields: _a0, _a1, _a2, _a3
-func (_m *EC2API) DescribeNetworkAclsPagesWithContext(_a0 context.Context, _a1 *ec2.DescribeNetworkAclsInput, _a2 func(*ec2.DescribeNetworkAclsOutput, bool) bool, _a3 ...request.Option) error {
-	_va := make([]interface{}, len(_a3))
-	for _i := range _a3 {
-		_va[_i] = _a3[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1, _a2)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.DescribeNetworkAclsInput, func(*ec2.DescribeNetworkAclsOutput, bool) bool, ...request.Option) error); ok {
-		r0 = rf(_a0, _a1, _a2, _a3...)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
-// DescribeNetworkAclsRequest provides a mock function with given fields: _a0
-func (_m *EC2API) DescribeNetworkAclsRequest(_a0 *ec2.DescribeNetworkAclsInput) (*request.Request, *ec2.DescribeNetworkAclsOutput) {
-	ret := _m.Called(_a0)
-
-	var r0 *request.Request
-	if rf, ok := ret.Get(0).(func(*ec2.DescribeNetworkAclsInput) *request.Request); ok {
-		r0 = rf(_a0)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*request.Request)
-		}
-	}
-
-	var r1 *ec2.DescribeNetworkAclsOutput
-	if rf, ok := ret.Get(1).(func(*ec2.DescribeNetworkAclsInput) *ec2.DescribeNetworkAclsOutput); ok {
-		r1 = rf(_a0)
-	} else {
-		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(*ec2.DescribeNetworkAclsOutput)
-		}
-	}
-
-	return r0, r1
-}
-
-// DescribeNetworkAclsWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) DescribeNetworkAclsWithContext(_a0 context.Context, _a1 *ec2.DescribeNetworkAclsInput, _a2 ...request.Option) (*ec2.DescribeNetworkAclsOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.DescribeNetworkAclsOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.DescribeNetworkAclsInput, ...request.Option) *ec2.DescribeNetworkAclsOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.DescribeNetworkAclsOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.DescribeNetworkAclsInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
-// DescribeNetworkInterfaceAttribute provides a mock function with given fields: _a0
-func (_m *EC2API) DescribeNetworkInterfaceAttribute(_a0 *ec2.DescribeNetworkInterfaceAttributeInput) (*ec2.DescribeNetworkInterfaceAttributeOutput, error) {
-	ret := _m.Called(_a0)
-
-	var r0 *ec2.DescribeNetworkInterfaceAttributeOutput
-	if rf, ok := ret.Get(0).(func(*ec2.DescribeNetworkInterfaceAttributeInput) *ec2.DescribeNetworkInterfaceAttributeOutput); ok {
-		r0 = rf(_a0)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.DescribeNetworkInterfaceAttributeOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(*ec2.DescribeNetworkInterfaceAttributeInput) error); ok {
-		r1 = rf(_a0)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
-// DescribeNetworkInterfaceAttributeRequest provides a mock function with given fields: _a0
-func (_m *EC2API) DescribeNetworkInterfaceAttributeRequest(_a0 *ec2.DescribeNetworkInterfaceAttributeInput) (*request.Request, *ec2.DescribeNetworkInterfaceAttributeOutput) {
-	ret := _m.Called(_a0)
-
-	var r0 *request.Request
-	if rf, ok := ret.Get(0).(func(*ec2.DescribeNetworkInterfaceAttributeInput) *request.Request); ok {
-		r0 = rf(_a0)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*request.Request)
-		}
-	}
-
-	var r1 *ec2.DescribeNetworkInterfaceAttributeOutput
-	if rf, ok := ret.Get(1).(func(*ec2.DescribeNetworkInterfaceAttributeInput) *ec2.DescribeNetworkInterfaceAttributeOutput); ok {
-		r1 = rf(_a0)
-	} else {
-		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(*ec2.DescribeNetworkInterfaceAttributeOutput)
-		}
-	}
-
-	return r0, r1
-}
-
-// DescribeNetworkInterfaceAttributeWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) DescribeNetworkInterfaceAttributeWithContext(_a0 context.Context, _a1 *ec2.DescribeNetworkInterfaceAttributeInput, _a2 ...request.Option) (*ec2.DescribeNetworkInterfaceAttributeOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.DescribeNetworkInterfaceAttributeOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.DescribeNetworkInterfaceAttributeInput, ...request.Option) *ec2.DescribeNetworkInterfaceAttributeOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.DescribeNetworkInterfaceAttributeOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.DescribeNetworkInterfaceAttributeInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
-// DescribeNetworkInterfacePermissions provides a mock function with given fields: _a0
-func (_m *EC2API) DescribeNetworkInterfacePermissions(_a0 *ec2.DescribeNetworkInterfacePermissionsInput) (*ec2.DescribeNetworkInterfacePermissionsOutput, error) {
-	ret := _m.Called(_a0)
+// DescribeNetworkInterfacePermissions provides a mock function with given fields: _a0
+func (_m *EC2API) DescribeNetworkInterfacePermissions(_a0 *ec2.DescribeNetworkInterfacePermissionsInput) (*ec2.DescribeNetworkInterfacePermissionsOutput, error) {
+	ret := _m.Called(_a0)
 
 	var r0 *ec2.DescribeNetworkInterfacePermissionsOutput
 	if rf, ok := ret.Get(0).(func(*ec2.DescribeNetworkInterfacePermissionsInput) *ec2.DescribeNetworkInterfacePermissionsOutput); ok {
@@ -14886,41 +12988,6 @@ func (_m *EC2API) DescribeNetworkInterfacePermissionsWithContext(_a0 context.Con
 	return r0, r1
 }
 
-// DescribeNetworkInterfacesPages provides a mock function with given fields: _a0, _a1
-func (_m *EC2API) DescribeNetworkInterfacesPages(_a0 *ec2.DescribeNetworkInterfacesInput, _a1 func(*ec2.DescribeNetworkInterfacesOutput, bool) bool) error {
-	ret := _m.Called(_a0, _a1)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(*ec2.DescribeNetworkInterfacesInput, func(*ec2.DescribeNetworkInterfacesOutput, bool) bool) error); ok {
-		r0 = rf(_a0, _a1)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
-// DescribeNetworkInterfacesPagesWithContext provides a mock function with given fields: _a0, _a1, _a2, _a3
-func (_m *EC2API) DescribeNetworkInterfacesPagesWithContext(_a0 context.Context, _a1 *ec2.DescribeNetworkInterfacesInput, _a2 func(*ec2.DescribeNetworkInterfacesOutput, bool) bool, _a3 ...request.Option) error {
-	_va := make([]interface{}, len(_a3))
-	for _i := range _a3 {
-		_va[_i] = _a3[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1, _a2)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.DescribeNetworkInterfacesInput, func(*ec2.DescribeNetworkInterfacesOutput, bool) bool, ...request.Option) error); ok {
-		r0 = rf(_a0, _a1, _a2, _a3...)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
 // DescribeNetworkInterfacesRequest provides a mock function with given fields: _a0
 func (_m *EC2API) DescribeNetworkInterfacesRequest(_a0 *ec2.DescribeNetworkInterfacesInput) (*request.Request, *ec2.DescribeNetworkInterfacesOutput) {
 	ret := _m.Called(_a0)
@@ -14946,36 +13013,6 @@ func (_m *EC2API) DescribeNetworkInterfacesRequest(_a0 *ec2.DescribeNetworkInter
 	return r0, r1
 }
 
-// DescribeNetworkInterfacesWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) DescribeNetworkInterfacesWithContext(_a0 context.Context, _a1 *ec2.DescribeNetworkInterfacesInput, _a2 ...request.Option) (*ec2.DescribeNetworkInterfacesOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.DescribeNetworkInterfacesOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.DescribeNetworkInterfacesInput, ...request.Option) *ec2.DescribeNetworkInterfacesOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.DescribeNetworkInterfacesOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.DescribeNetworkInterfacesInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // DescribePlacementGroups provides a mock function with given fields: _a0
 func (_m *EC2API) DescribePlacementGroups(_a0 *ec2.DescribePlacementGroupsInput) (*ec2.DescribePlacementGroupsOutput, error) {
 	ret := _m.Called(_a0)
@@ -15853,41 +13890,6 @@ func (_m *EC2API) DescribeReservedInstancesWithContext(_a0 context.Context, _a1
 	return r0, r1
 }
 
-// DescribeRouteTablesPages provides a mock function with given fields: _a0, _a1
-func (_m *EC2API) DescribeRouteTablesPages(_a0 *ec2.DescribeRouteTablesInput, _a1 func(*ec2.DescribeRouteTablesOutput, bool) bool) error {
-	ret := _m.Called(_a0, _a1)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(*ec2.DescribeRouteTablesInput, func(*ec2.DescribeRouteTablesOutput, bool) bool) error); ok {
-		r0 = rf(_a0, _a1)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
-// DescribeRouteTablesPagesWithContext provides a mock function with given fields: _a0, _a1, _a2, _a3
-func (_m *EC2API) DescribeRouteTablesPagesWithContext(_a0 context.Context, _a1 *ec2.DescribeRouteTablesInput, _a2 func(*ec2.DescribeRouteTablesOutput, bool) bool, _a3 ...request.Option) error {
-	_va := make([]interface{}, len(_a3))
-	for _i := range _a3 {
-		_va[_i] = _a3[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1, _a2)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.DescribeRouteTablesInput, func(*ec2.DescribeRouteTablesOutput, bool) bool, ...request.Option) error); ok {
-		r0 = rf(_a0, _a1, _a2, _a3...)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
 // DescribeRouteTablesRequest provides a mock function with given fields: _a0
 func (_m *EC2API) DescribeRouteTablesRequest(_a0 *ec2.DescribeRouteTablesInput) (*request.Request, *ec2.DescribeRouteTablesOutput) {
 	ret := _m.Called(_a0)
@@ -15913,36 +13915,6 @@ func (_m *EC2API) DescribeRouteTablesRequest(_a0 *ec2.DescribeRouteTablesInput)
 	return r0, r1
 }
 
-// DescribeRouteTablesWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) DescribeRouteTablesWithContext(_a0 context.Context, _a1 *ec2.DescribeRouteTablesInput, _a2 ...request.Option) (*ec2.DescribeRouteTablesOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.DescribeRouteTablesOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.DescribeRouteTablesInput, ...request.Option) *ec2.DescribeRouteTablesOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.DescribeRouteTablesOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.DescribeRouteTablesInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // DescribeScheduledInstanceAvailability provides a mock function with given fields: _a0
 func (_m *EC2API) DescribeScheduledInstanceAvailability(_a0 *ec2.DescribeScheduledInstanceAvailabilityInput) (*ec2.DescribeScheduledInstanceAvailabilityOutput, error) {
 	ret := _m.Called(_a0)
@@ -16169,35 +14141,12 @@ func (_m *EC2API) DescribeScheduledInstancesWithContext(_a0 context.Context, _a1
 	return r0, r1
 }
 
-// DescribeSecurityGroupReferences provides a mock function with given fields: _a0
-func (_m *EC2API) DescribeSecurityGroupReferences(_a0 *ec2.DescribeSecurityGroupReferencesInput) (*ec2.DescribeSecurityGroupReferencesOutput, error) {
+// DescribeSecurityGroupReferencesRequest provides a mock function with given fields: _a0
+func (_m *EC2API) DescribeSecurityGroupReferencesRequest(_a0 *ec2.DescribeSecurityGroupReferencesInput) (*request.Request, *ec2.DescribeSecurityGroupReferencesOutput) {
 	ret := _m.Called(_a0)
 
-	var r0 *ec2.DescribeSecurityGroupReferencesOutput
-	if rf, ok := ret.Get(0).(func(*ec2.DescribeSecurityGroupReferencesInput) *ec2.DescribeSecurityGroupReferencesOutput); ok {
-		r0 = rf(_a0)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.DescribeSecurityGroupReferencesOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(*ec2.DescribeSecurityGroupReferencesInput) error); ok {
-		r1 = rf(_a0)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
-// DescribeSecurityGroupReferencesRequest provides a mock function with given fields: _a0
-func (_m *EC2API) DescribeSecurityGroupReferencesRequest(_a0 *ec2.DescribeSecurityGroupReferencesInput) (*request.Request, *ec2.DescribeSecurityGroupReferencesOutput) {
-	ret := _m.Called(_a0)
-
-	var r0 *request.Request
-	if rf, ok := ret.Get(0).(func(*ec2.DescribeSecurityGroupReferencesInput) *request.Request); ok {
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*ec2.DescribeSecurityGroupReferencesInput) *request.Request); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
@@ -16217,71 +14166,6 @@ func (_m *EC2API) DescribeSecurityGroupReferencesRequest(_a0 *ec2.DescribeSecuri
 	return r0, r1
 }
 
-// DescribeSecurityGroupReferencesWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) DescribeSecurityGroupReferencesWithContext(_a0 context.Context, _a1 *ec2.DescribeSecurityGroupReferencesInput, _a2 ...request.Option) (*ec2.DescribeSecurityGroupReferencesOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.DescribeSecurityGroupReferencesOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.DescribeSecurityGroupReferencesInput, ...request.Option) *ec2.DescribeSecurityGroupReferencesOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.DescribeSecurityGroupReferencesOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.DescribeSecurityGroupReferencesInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
-// DescribeSecurityGroupsPages provides a mock function with given fields: _a0, _a1
-func (_m *EC2API) DescribeSecurityGroupsPages(_a0 *ec2.DescribeSecurityGroupsInput, _a1 func(*ec2.DescribeSecurityGroupsOutput, bool) bool) error {
-	ret := _m.Called(_a0, _a1)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(*ec2.DescribeSecurityGroupsInput, func(*ec2.DescribeSecurityGroupsOutput, bool) bool) error); ok {
-		r0 = rf(_a0, _a1)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
-// DescribeSecurityGroupsPagesWithContext provides a mock function with given fields: _a0, _a1, _a2, _a3
-func (_m *EC2API) DescribeSecurityGroupsPagesWithContext(_a0 context.Context, _a1 *ec2.DescribeSecurityGroupsInput, _a2 func(*ec2.DescribeSecurityGroupsOutput, bool) bool, _a3 ...request.Option) error {
-	_va := make([]interface{}, len(_a3))
-	for _i := range _a3 {
-		_va[_i] = _a3[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1, _a2)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.DescribeSecurityGroupsInput, func(*ec2.DescribeSecurityGroupsOutput, bool) bool, ...request.Option) error); ok {
-		r0 = rf(_a0, _a1, _a2, _a3...)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
 // DescribeSecurityGroupsRequest provides a mock function with given fields: _a0
 func (_m *EC2API) DescribeSecurityGroupsRequest(_a0 *ec2.DescribeSecurityGroupsInput) (*request.Request, *ec2.DescribeSecurityGroupsOutput) {
 	ret := _m.Called(_a0)
@@ -16307,36 +14191,6 @@ func (_m *EC2API) DescribeSecurityGroupsRequest(_a0 *ec2.DescribeSecurityGroupsI
 	return r0, r1
 }
 
-// DescribeSecurityGroupsWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) DescribeSecurityGroupsWithContext(_a0 context.Context, _a1 *ec2.DescribeSecurityGroupsInput, _a2 ...request.Option) (*ec2.DescribeSecurityGroupsOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.DescribeSecurityGroupsOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.DescribeSecurityGroupsInput, ...request.Option) *ec2.DescribeSecurityGroupsOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.DescribeSecurityGroupsOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.DescribeSecurityGroupsInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // DescribeSnapshotAttribute provides a mock function with given fields: _a0
 func (_m *EC2API) DescribeSnapshotAttribute(_a0 *ec2.DescribeSnapshotAttributeInput) (*ec2.DescribeSnapshotAttributeOutput, error) {
 	ret := _m.Called(_a0)
@@ -17101,64 +14955,6 @@ func (_m *EC2API) DescribeSpotPriceHistoryWithContext(_a0 context.Context, _a1 *
 	return r0, r1
 }
 
-// DescribeStaleSecurityGroups provides a mock function with given fields: _a0
-func (_m *EC2API) DescribeStaleSecurityGroups(_a0 *ec2.DescribeStaleSecurityGroupsInput) (*ec2.DescribeStaleSecurityGroupsOutput, error) {
-	ret := _m.Called(_a0)
-
-	var r0 *ec2.DescribeStaleSecurityGroupsOutput
-	if rf, ok := ret.Get(0).(func(*ec2.DescribeStaleSecurityGroupsInput) *ec2.DescribeStaleSecurityGroupsOutput); ok {
-		r0 = rf(_a0)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.DescribeStaleSecurityGroupsOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(*ec2.DescribeStaleSecurityGroupsInput) error); ok {
-		r1 = rf(_a0)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
-// DescribeStaleSecurityGroupsPages provides a mock function with given fields: _a0, _a1
-func (_m *EC2API) DescribeStaleSecurityGroupsPages(_a0 *ec2.DescribeStaleSecurityGroupsInput, _a1 func(*ec2.DescribeStaleSecurityGroupsOutput, bool) bool) error {
-	ret := _m.Called(_a0, _a1)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(*ec2.DescribeStaleSecurityGroupsInput, func(*ec2.DescribeStaleSecurityGroupsOutput, bool) bool) error); ok {
-		r0 = rf(_a0, _a1)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
-// DescribeStaleSecurityGroupsPagesWithContext provides a mock function with given fields: _a0, _a1, _a2, _a3
-func (_m *EC2API) DescribeStaleSecurityGroupsPagesWithContext(_a0 context.Context, _a1 *ec2.DescribeStaleSecurityGroupsInput, _a2 func(*ec2.DescribeStaleSecurityGroupsOutput, bool) bool, _a3 ...request.Option) error {
-	_va := make([]interface{}, len(_a3))
-	for _i := range _a3 {
-		_va[_i] = _a3[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1, _a2)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.DescribeStaleSecurityGroupsInput, func(*ec2.DescribeStaleSecurityGroupsOutput, bool) bool, ...request.Option) error); ok {
-		r0 = rf(_a0, _a1, _a2, _a3...)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
 // DescribeStaleSecurityGroupsRequest provides a mock function with given fields: _a0
 func (_m *EC2API) DescribeStaleSecurityGroupsRequest(_a0 *ec2.DescribeStaleSecurityGroupsInput) (*request.Request, *ec2.DescribeStaleSecurityGroupsOutput) {
 	ret := _m.Called(_a0)
@@ -17184,71 +14980,6 @@ func (_m *EC2API) DescribeStaleSecurityGroupsRequest(_a0 *ec2.DescribeStaleSecur
 	return r0, r1
 }
 
-// DescribeStaleSecurityGroupsWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) DescribeStaleSecurityGroupsWithContext(_a0 context.Context, _a1 *ec2.DescribeStaleSecurityGroupsInput, _a2 ...request.Option) (*ec2.DescribeStaleSecurityGroupsOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.DescribeStaleSecurityGroupsOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.DescribeStaleSecurityGroupsInput, ...request.Option) *ec2.DescribeStaleSecurityGroupsOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.DescribeStaleSecurityGroupsOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.DescribeStaleSecurityGroupsInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
-// DescribeSubnetsPages provides a mock function with given fields: _a0, _a1
-func (_m *EC2API) DescribeSubnetsPages(_a0 *ec2.DescribeSubnetsInput, _a1 func(*ec2.DescribeSubnetsOutput, bool) bool) error {
-	ret := _m.Called(_a0, _a1)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(*ec2.DescribeSubnetsInput, func(*ec2.DescribeSubnetsOutput, bool) bool) error); ok {
-		r0 = rf(_a0, _a1)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
-// DescribeSubnetsPagesWithContext provides a mock function with given fields: _a0, _a1, _a2, _a3
-func (_m *EC2API) DescribeSubnetsPagesWithContext(_a0 context.Context, _a1 *ec2.DescribeSubnetsInput, _a2 func(*ec2.DescribeSubnetsOutput, bool) bool, _a3 ...request.Option) error {
-	_va := make([]interface{}, len(_a3))
-	for _i := range _a3 {
-		_va[_i] = _a3[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1, _a2)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.DescribeSubnetsInput, func(*ec2.DescribeSubnetsOutput, bool) bool, ...request.Option) error); ok {
-		r0 = rf(_a0, _a1, _a2, _a3...)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
 // DescribeSubnetsRequest provides a mock function with given fields: _a0
 func (_m *EC2API) DescribeSubnetsRequest(_a0 *ec2.DescribeSubnetsInput) (*request.Request, *ec2.DescribeSubnetsOutput) {
 	ret := _m.Called(_a0)
@@ -17274,36 +15005,6 @@ func (_m *EC2API) DescribeSubnetsRequest(_a0 *ec2.DescribeSubnetsInput) (*reques
 	return r0, r1
 }
 
-// DescribeSubnetsWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) DescribeSubnetsWithContext(_a0 context.Context, _a1 *ec2.DescribeSubnetsInput, _a2 ...request.Option) (*ec2.DescribeSubnetsOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.DescribeSubnetsOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.DescribeSubnetsInput, ...request.Option) *ec2.DescribeSubnetsOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.DescribeSubnetsOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.DescribeSubnetsInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // DescribeTags provides a mock function with given fields: _a0
 func (_m *EC2API) DescribeTags(_a0 *ec2.DescribeTagsInput) (*ec2.DescribeTagsOutput, error) {
 	ret := _m.Called(_a0)
@@ -18399,29 +16100,6 @@ func (_m *EC2API) DescribeVolumeStatusWithContext(_a0 context.Context, _a1 *ec2.
 	return r0, r1
 }
 
-// DescribeVolumes provides a mock function with given fields: _a0
-func (_m *EC2API) DescribeVolumes(_a0 *ec2.DescribeVolumesInput) (*ec2.DescribeVolumesOutput, error) {
-	ret := _m.Called(_a0)
-
-	var r0 *ec2.DescribeVolumesOutput
-	if rf, ok := ret.Get(0).(func(*ec2.DescribeVolumesInput) *ec2.DescribeVolumesOutput); ok {
-		r0 = rf(_a0)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.DescribeVolumesOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(*ec2.DescribeVolumesInput) error); ok {
-		r1 = rf(_a0)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // DescribeVolumesModifications provides a mock function with given fields: _a0
 func (_m *EC2API) DescribeVolumesModifications(_a0 *ec2.DescribeVolumesModificationsInput) (*ec2.DescribeVolumesModificationsOutput, error) {
 	ret := _m.Called(_a0)
@@ -18595,69 +16273,16 @@ func (_m *EC2API) DescribeVolumesRequest(_a0 *ec2.DescribeVolumesInput) (*reques
 	return r0, r1
 }
 
-// DescribeVolumesWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) DescribeVolumesWithContext(_a0 context.Context, _a1 *ec2.DescribeVolumesInput, _a2 ...request.Option) (*ec2.DescribeVolumesOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
+// DescribeVpcAttributeRequest provides a mock function with given fields: _a0
+func (_m *EC2API) DescribeVpcAttributeRequest(_a0 *ec2.DescribeVpcAttributeInput) (*request.Request, *ec2.DescribeVpcAttributeOutput) {
+	ret := _m.Called(_a0)
 
-	var r0 *ec2.DescribeVolumesOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.DescribeVolumesInput, ...request.Option) *ec2.DescribeVolumesOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*ec2.DescribeVpcAttributeInput) *request.Request); ok {
+		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.DescribeVolumesOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.DescribeVolumesInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
-// DescribeVpcAttribute provides a mock function with given fields: _a0
-func (_m *EC2API) DescribeVpcAttribute(_a0 *ec2.DescribeVpcAttributeInput) (*ec2.DescribeVpcAttributeOutput, error) {
-	ret := _m.Called(_a0)
-
-	var r0 *ec2.DescribeVpcAttributeOutput
-	if rf, ok := ret.Get(0).(func(*ec2.DescribeVpcAttributeInput) *ec2.DescribeVpcAttributeOutput); ok {
-		r0 = rf(_a0)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.DescribeVpcAttributeOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(*ec2.DescribeVpcAttributeInput) error); ok {
-		r1 = rf(_a0)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
-// DescribeVpcAttributeRequest provides a mock function with given fields: _a0
-func (_m *EC2API) DescribeVpcAttributeRequest(_a0 *ec2.DescribeVpcAttributeInput) (*request.Request, *ec2.DescribeVpcAttributeOutput) {
-	ret := _m.Called(_a0)
-
-	var r0 *request.Request
-	if rf, ok := ret.Get(0).(func(*ec2.DescribeVpcAttributeInput) *request.Request); ok {
-		r0 = rf(_a0)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*request.Request)
+			r0 = ret.Get(0).(*request.Request)
 		}
 	}
 
@@ -18673,36 +16298,6 @@ func (_m *EC2API) DescribeVpcAttributeRequest(_a0 *ec2.DescribeVpcAttributeInput
 	return r0, r1
 }
 
-// DescribeVpcAttributeWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) DescribeVpcAttributeWithContext(_a0 context.Context, _a1 *ec2.DescribeVpcAttributeInput, _a2 ...request.Option) (*ec2.DescribeVpcAttributeOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.DescribeVpcAttributeOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.DescribeVpcAttributeInput, ...request.Option) *ec2.DescribeVpcAttributeOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.DescribeVpcAttributeOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.DescribeVpcAttributeInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // DescribeVpcClassicLink provides a mock function with given fields: _a0
 func (_m *EC2API) DescribeVpcClassicLink(_a0 *ec2.DescribeVpcClassicLinkInput) (*ec2.DescribeVpcClassicLinkOutput, error) {
 	ret := _m.Called(_a0)
@@ -19650,41 +17245,6 @@ func (_m *EC2API) DescribeVpcPeeringConnectionsWithContext(_a0 context.Context,
 	return r0, r1
 }
 
-// DescribeVpcsPages provides a mock function with given fields: _a0, _a1
-func (_m *EC2API) DescribeVpcsPages(_a0 *ec2.DescribeVpcsInput, _a1 func(*ec2.DescribeVpcsOutput, bool) bool) error {
-	ret := _m.Called(_a0, _a1)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(*ec2.DescribeVpcsInput, func(*ec2.DescribeVpcsOutput, bool) bool) error); ok {
-		r0 = rf(_a0, _a1)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
-// DescribeVpcsPagesWithContext provides a mock function with given fields: _a0, _a1, _a2, _a3
-func (_m *EC2API) DescribeVpcsPagesWithContext(_a0 context.Context, _a1 *ec2.DescribeVpcsInput, _a2 func(*ec2.DescribeVpcsOutput, bool) bool, _a3 ...request.Option) error {
-	_va := make([]interface{}, len(_a3))
-	for _i := range _a3 {
-		_va[_i] = _a3[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1, _a2)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.DescribeVpcsInput, func(*ec2.DescribeVpcsOutput, bool) bool, ...request.Option) error); ok {
-		r0 = rf(_a0, _a1, _a2, _a3...)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
 // DescribeVpcsRequest provides a mock function with given fields: _a0
 func (_m *EC2API) DescribeVpcsRequest(_a0 *ec2.DescribeVpcsInput) (*request.Request, *ec2.DescribeVpcsOutput) {
 	ret := _m.Called(_a0)
@@ -19710,36 +17270,6 @@ func (_m *EC2API) DescribeVpcsRequest(_a0 *ec2.DescribeVpcsInput) (*request.Requ
 	return r0, r1
 }
 
-// DescribeVpcsWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) DescribeVpcsWithContext(_a0 context.Context, _a1 *ec2.DescribeVpcsInput, _a2 ...request.Option) (*ec2.DescribeVpcsOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.DescribeVpcsOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.DescribeVpcsInput, ...request.Option) *ec2.DescribeVpcsOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.DescribeVpcsOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.DescribeVpcsInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // DescribeVpnConnections provides a mock function with given fields: _a0
 func (_m *EC2API) DescribeVpnConnections(_a0 *ec2.DescribeVpnConnectionsInput) (*ec2.DescribeVpnConnectionsOutput, error) {
 	ret := _m.Called(_a0)
@@ -19974,29 +17504,6 @@ func (_m *EC2API) DetachClassicLinkVpcWithContext(_a0 context.Context, _a1 *ec2.
 	return r0, r1
 }
 
-// DetachInternetGateway provides a mock function with given fields: _a0
-func (_m *EC2API) DetachInternetGateway(_a0 *ec2.DetachInternetGatewayInput) (*ec2.DetachInternetGatewayOutput, error) {
-	ret := _m.Called(_a0)
-
-	var r0 *ec2.DetachInternetGatewayOutput
-	if rf, ok := ret.Get(0).(func(*ec2.DetachInternetGatewayInput) *ec2.DetachInternetGatewayOutput); ok {
-		r0 = rf(_a0)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.DetachInternetGatewayOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(*ec2.DetachInternetGatewayInput) error); ok {
-		r1 = rf(_a0)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // DetachInternetGatewayRequest provides a mock function with given fields: _a0
 func (_m *EC2API) DetachInternetGatewayRequest(_a0 *ec2.DetachInternetGatewayInput) (*request.Request, *ec2.DetachInternetGatewayOutput) {
 	ret := _m.Called(_a0)
@@ -20022,59 +17529,6 @@ func (_m *EC2API) DetachInternetGatewayRequest(_a0 *ec2.DetachInternetGatewayInp
 	return r0, r1
 }
 
-// DetachInternetGatewayWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) DetachInternetGatewayWithContext(_a0 context.Context, _a1 *ec2.DetachInternetGatewayInput, _a2 ...request.Option) (*ec2.DetachInternetGatewayOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.DetachInternetGatewayOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.DetachInternetGatewayInput, ...request.Option) *ec2.DetachInternetGatewayOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.DetachInternetGatewayOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.DetachInternetGatewayInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
-// DetachNetworkInterface provides a mock function with given fields: _a0
-func (_m *EC2API) DetachNetworkInterface(_a0 *ec2.DetachNetworkInterfaceInput) (*ec2.DetachNetworkInterfaceOutput, error) {
-	ret := _m.Called(_a0)
-
-	var r0 *ec2.DetachNetworkInterfaceOutput
-	if rf, ok := ret.Get(0).(func(*ec2.DetachNetworkInterfaceInput) *ec2.DetachNetworkInterfaceOutput); ok {
-		r0 = rf(_a0)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.DetachNetworkInterfaceOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(*ec2.DetachNetworkInterfaceInput) error); ok {
-		r1 = rf(_a0)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // DetachNetworkInterfaceRequest provides a mock function with given fields: _a0
 func (_m *EC2API) DetachNetworkInterfaceRequest(_a0 *ec2.DetachNetworkInterfaceInput) (*request.Request, *ec2.DetachNetworkInterfaceOutput) {
 	ret := _m.Called(_a0)
@@ -20100,36 +17554,6 @@ func (_m *EC2API) DetachNetworkInterfaceRequest(_a0 *ec2.DetachNetworkInterfaceI
 	return r0, r1
 }
 
-// DetachNetworkInterfaceWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) DetachNetworkInterfaceWithContext(_a0 context.Context, _a1 *ec2.DetachNetworkInterfaceInput, _a2 ...request.Option) (*ec2.DetachNetworkInterfaceOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.DetachNetworkInterfaceOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.DetachNetworkInterfaceInput, ...request.Option) *ec2.DetachNetworkInterfaceOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.DetachNetworkInterfaceOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.DetachNetworkInterfaceInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // DetachVolume provides a mock function with given fields: _a0
 func (_m *EC2API) DetachVolume(_a0 *ec2.DetachVolumeInput) (*ec2.VolumeAttachment, error) {
 	ret := _m.Called(_a0)
@@ -20701,36 +18125,6 @@ func (_m *EC2API) DisassociateAddressRequest(_a0 *ec2.DisassociateAddressInput)
 	return r0, r1
 }
 
-// DisassociateAddressWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) DisassociateAddressWithContext(_a0 context.Context, _a1 *ec2.DisassociateAddressInput, _a2 ...request.Option) (*ec2.DisassociateAddressOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.DisassociateAddressOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.DisassociateAddressInput, ...request.Option) *ec2.DisassociateAddressOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.DisassociateAddressOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.DisassociateAddressInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // DisassociateClientVpnTargetNetwork provides a mock function with given fields: _a0
 func (_m *EC2API) DisassociateClientVpnTargetNetwork(_a0 *ec2.DisassociateClientVpnTargetNetworkInput) (*ec2.DisassociateClientVpnTargetNetworkOutput, error) {
 	ret := _m.Called(_a0)
@@ -20887,35 +18281,37 @@ func (_m *EC2API) DisassociateIamInstanceProfileWithContext(_a0 context.Context,
 	return r0, r1
 }
 
-// DisassociateRouteTable provides a mock function with given fields: _a0
-func (_m *EC2API) DisassociateRouteTable(_a0 *ec2.DisassociateRouteTableInput) (*ec2.DisassociateRouteTableOutput, error) {
+// DisassociateRouteTableRequest provides a mock function with given fields: _a0
+func (_m *EC2API) DisassociateRouteTableRequest(_a0 *ec2.DisassociateRouteTableInput) (*request.Request, *ec2.DisassociateRouteTableOutput) {
 	ret := _m.Called(_a0)
 
-	var r0 *ec2.DisassociateRouteTableOutput
-	if rf, ok := ret.Get(0).(func(*ec2.DisassociateRouteTableInput) *ec2.DisassociateRouteTableOutput); ok {
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*ec2.DisassociateRouteTableInput) *request.Request); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.DisassociateRouteTableOutput)
+			r0 = ret.Get(0).(*request.Request)
 		}
 	}
 
-	var r1 error
-	if rf, ok := ret.Get(1).(func(*ec2.DisassociateRouteTableInput) error); ok {
+	var r1 *ec2.DisassociateRouteTableOutput
+	if rf, ok := ret.Get(1).(func(*ec2.DisassociateRouteTableInput) *ec2.DisassociateRouteTableOutput); ok {
 		r1 = rf(_a0)
 	} else {
-		r1 = ret.Error(1)
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ec2.DisassociateRouteTableOutput)
+		}
 	}
 
 	return r0, r1
 }
 
-// DisassociateRouteTableRequest provides a mock function with given fields: _a0
-func (_m *EC2API) DisassociateRouteTableRequest(_a0 *ec2.DisassociateRouteTableInput) (*request.Request, *ec2.DisassociateRouteTableOutput) {
+// DisassociateSubnetCidrBlockRequest provides a mock function with given fields: _a0
+func (_m *EC2API) DisassociateSubnetCidrBlockRequest(_a0 *ec2.DisassociateSubnetCidrBlockInput) (*request.Request, *ec2.DisassociateSubnetCidrBlockOutput) {
 	ret := _m.Called(_a0)
 
 	var r0 *request.Request
-	if rf, ok := ret.Get(0).(func(*ec2.DisassociateRouteTableInput) *request.Request); ok {
+	if rf, ok := ret.Get(0).(func(*ec2.DisassociateSubnetCidrBlockInput) *request.Request); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
@@ -20923,129 +18319,21 @@ func (_m *EC2API) DisassociateRouteTableRequest(_a0 *ec2.DisassociateRouteTableI
 		}
 	}
 
-	var r1 *ec2.DisassociateRouteTableOutput
-	if rf, ok := ret.Get(1).(func(*ec2.DisassociateRouteTableInput) *ec2.DisassociateRouteTableOutput); ok {
+	var r1 *ec2.DisassociateSubnetCidrBlockOutput
+	if rf, ok := ret.Get(1).(func(*ec2.DisassociateSubnetCidrBlockInput) *ec2.DisassociateSubnetCidrBlockOutput); ok {
 		r1 = rf(_a0)
 	} else {
 		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(*ec2.DisassociateRouteTableOutput)
+			r1 = ret.Get(1).(*ec2.DisassociateSubnetCidrBlockOutput)
 		}
 	}
 
 	return r0, r1
 }
 
-// DisassociateRouteTableWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) DisassociateRouteTableWithContext(_a0 context.Context, _a1 *ec2.DisassociateRouteTableInput, _a2 ...request.Option) (*ec2.DisassociateRouteTableOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.DisassociateRouteTableOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.DisassociateRouteTableInput, ...request.Option) *ec2.DisassociateRouteTableOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.DisassociateRouteTableOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.DisassociateRouteTableInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
-// DisassociateSubnetCidrBlock provides a mock function with given fields: _a0
-func (_m *EC2API) DisassociateSubnetCidrBlock(_a0 *ec2.DisassociateSubnetCidrBlockInput) (*ec2.DisassociateSubnetCidrBlockOutput, error) {
-	ret := _m.Called(_a0)
-
-	var r0 *ec2.DisassociateSubnetCidrBlockOutput
-	if rf, ok := ret.Get(0).(func(*ec2.DisassociateSubnetCidrBlockInput) *ec2.DisassociateSubnetCidrBlockOutput); ok {
-		r0 = rf(_a0)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.DisassociateSubnetCidrBlockOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(*ec2.DisassociateSubnetCidrBlockInput) error); ok {
-		r1 = rf(_a0)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
-// DisassociateSubnetCidrBlockRequest provides a mock function with given fields: _a0
-func (_m *EC2API) DisassociateSubnetCidrBlockRequest(_a0 *ec2.DisassociateSubnetCidrBlockInput) (*request.Request, *ec2.DisassociateSubnetCidrBlockOutput) {
-	ret := _m.Called(_a0)
-
-	var r0 *request.Request
-	if rf, ok := ret.Get(0).(func(*ec2.DisassociateSubnetCidrBlockInput) *request.Request); ok {
-		r0 = rf(_a0)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*request.Request)
-		}
-	}
-
-	var r1 *ec2.DisassociateSubnetCidrBlockOutput
-	if rf, ok := ret.Get(1).(func(*ec2.DisassociateSubnetCidrBlockInput) *ec2.DisassociateSubnetCidrBlockOutput); ok {
-		r1 = rf(_a0)
-	} else {
-		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(*ec2.DisassociateSubnetCidrBlockOutput)
-		}
-	}
-
-	return r0, r1
-}
-
-// DisassociateSubnetCidrBlockWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) DisassociateSubnetCidrBlockWithContext(_a0 context.Context, _a1 *ec2.DisassociateSubnetCidrBlockInput, _a2 ...request.Option) (*ec2.DisassociateSubnetCidrBlockOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.DisassociateSubnetCidrBlockOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.DisassociateSubnetCidrBlockInput, ...request.Option) *ec2.DisassociateSubnetCidrBlockOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.DisassociateSubnetCidrBlockOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.DisassociateSubnetCidrBlockInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
-// DisassociateTransitGatewayRouteTable provides a mock function with given fields: _a0
-func (_m *EC2API) DisassociateTransitGatewayRouteTable(_a0 *ec2.DisassociateTransitGatewayRouteTableInput) (*ec2.DisassociateTransitGatewayRouteTableOutput, error) {
-	ret := _m.Called(_a0)
+// DisassociateTransitGatewayRouteTable provides a mock function with given fields: _a0
+func (_m *EC2API) DisassociateTransitGatewayRouteTable(_a0 *ec2.DisassociateTransitGatewayRouteTableInput) (*ec2.DisassociateTransitGatewayRouteTableOutput, error) {
+	ret := _m.Called(_a0)
 
 	var r0 *ec2.DisassociateTransitGatewayRouteTableOutput
 	if rf, ok := ret.Get(0).(func(*ec2.DisassociateTransitGatewayRouteTableInput) *ec2.DisassociateTransitGatewayRouteTableOutput); ok {
@@ -21121,29 +18409,6 @@ func (_m *EC2API) DisassociateTransitGatewayRouteTableWithContext(_a0 context.Co
 	return r0, r1
 }
 
-// DisassociateVpcCidrBlock provides a mock function with given fields: _a0
-func (_m *EC2API) DisassociateVpcCidrBlock(_a0 *ec2.DisassociateVpcCidrBlockInput) (*ec2.DisassociateVpcCidrBlockOutput, error) {
-	ret := _m.Called(_a0)
-
-	var r0 *ec2.DisassociateVpcCidrBlockOutput
-	if rf, ok := ret.Get(0).(func(*ec2.DisassociateVpcCidrBlockInput) *ec2.DisassociateVpcCidrBlockOutput); ok {
-		r0 = rf(_a0)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.DisassociateVpcCidrBlockOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(*ec2.DisassociateVpcCidrBlockInput) error); ok {
-		r1 = rf(_a0)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // DisassociateVpcCidrBlockRequest provides a mock function with given fields: _a0
 func (_m *EC2API) DisassociateVpcCidrBlockRequest(_a0 *ec2.DisassociateVpcCidrBlockInput) (*request.Request, *ec2.DisassociateVpcCidrBlockOutput) {
 	ret := _m.Called(_a0)
@@ -21169,36 +18434,6 @@ func (_m *EC2API) DisassociateVpcCidrBlockRequest(_a0 *ec2.DisassociateVpcCidrBl
 	return r0, r1
 }
 
-// DisassociateVpcCidrBlockWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) DisassociateVpcCidrBlockWithContext(_a0 context.Context, _a1 *ec2.DisassociateVpcCidrBlockInput, _a2 ...request.Option) (*ec2.DisassociateVpcCidrBlockOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.DisassociateVpcCidrBlockOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.DisassociateVpcCidrBlockInput, ...request.Option) *ec2.DisassociateVpcCidrBlockOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.DisassociateVpcCidrBlockOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.DisassociateVpcCidrBlockInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // EnableEbsEncryptionByDefault provides a mock function with given fields: _a0
 func (_m *EC2API) EnableEbsEncryptionByDefault(_a0 *ec2.EnableEbsEncryptionByDefaultInput) (*ec2.EnableEbsEncryptionByDefaultOutput, error) {
 	ret := _m.Called(_a0)
@@ -24761,36 +21996,6 @@ func (_m *EC2API) ModifyNetworkInterfaceAttributeRequest(_a0 *ec2.ModifyNetworkI
 	return r0, r1
 }
 
-// ModifyNetworkInterfaceAttributeWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) ModifyNetworkInterfaceAttributeWithContext(_a0 context.Context, _a1 *ec2.ModifyNetworkInterfaceAttributeInput, _a2 ...request.Option) (*ec2.ModifyNetworkInterfaceAttributeOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.ModifyNetworkInterfaceAttributeOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.ModifyNetworkInterfaceAttributeInput, ...request.Option) *ec2.ModifyNetworkInterfaceAttributeOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.ModifyNetworkInterfaceAttributeOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.ModifyNetworkInterfaceAttributeInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // ModifyReservedInstances provides a mock function with given fields: _a0
 func (_m *EC2API) ModifyReservedInstances(_a0 *ec2.ModifyReservedInstancesInput) (*ec2.ModifyReservedInstancesOutput, error) {
 	ret := _m.Called(_a0)
@@ -25025,29 +22230,6 @@ func (_m *EC2API) ModifySpotFleetRequestWithContext(_a0 context.Context, _a1 *ec
 	return r0, r1
 }
 
-// ModifySubnetAttribute provides a mock function with given fields: _a0
-func (_m *EC2API) ModifySubnetAttribute(_a0 *ec2.ModifySubnetAttributeInput) (*ec2.ModifySubnetAttributeOutput, error) {
-	ret := _m.Called(_a0)
-
-	var r0 *ec2.ModifySubnetAttributeOutput
-	if rf, ok := ret.Get(0).(func(*ec2.ModifySubnetAttributeInput) *ec2.ModifySubnetAttributeOutput); ok {
-		r0 = rf(_a0)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.ModifySubnetAttributeOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(*ec2.ModifySubnetAttributeInput) error); ok {
-		r1 = rf(_a0)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // ModifySubnetAttributeRequest provides a mock function with given fields: _a0
 func (_m *EC2API) ModifySubnetAttributeRequest(_a0 *ec2.ModifySubnetAttributeInput) (*request.Request, *ec2.ModifySubnetAttributeOutput) {
 	ret := _m.Called(_a0)
@@ -25073,36 +22255,6 @@ func (_m *EC2API) ModifySubnetAttributeRequest(_a0 *ec2.ModifySubnetAttributeInp
 	return r0, r1
 }
 
-// ModifySubnetAttributeWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) ModifySubnetAttributeWithContext(_a0 context.Context, _a1 *ec2.ModifySubnetAttributeInput, _a2 ...request.Option) (*ec2.ModifySubnetAttributeOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.ModifySubnetAttributeOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.ModifySubnetAttributeInput, ...request.Option) *ec2.ModifySubnetAttributeOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.ModifySubnetAttributeOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.ModifySubnetAttributeInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // ModifyTrafficMirrorFilterNetworkServices provides a mock function with given fields: _a0
 func (_m *EC2API) ModifyTrafficMirrorFilterNetworkServices(_a0 *ec2.ModifyTrafficMirrorFilterNetworkServicesInput) (*ec2.ModifyTrafficMirrorFilterNetworkServicesOutput, error) {
 	ret := _m.Called(_a0)
@@ -25571,29 +22723,6 @@ func (_m *EC2API) ModifyVolumeWithContext(_a0 context.Context, _a1 *ec2.ModifyVo
 	return r0, r1
 }
 
-// ModifyVpcAttribute provides a mock function with given fields: _a0
-func (_m *EC2API) ModifyVpcAttribute(_a0 *ec2.ModifyVpcAttributeInput) (*ec2.ModifyVpcAttributeOutput, error) {
-	ret := _m.Called(_a0)
-
-	var r0 *ec2.ModifyVpcAttributeOutput
-	if rf, ok := ret.Get(0).(func(*ec2.ModifyVpcAttributeInput) *ec2.ModifyVpcAttributeOutput); ok {
-		r0 = rf(_a0)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.ModifyVpcAttributeOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(*ec2.ModifyVpcAttributeInput) error); ok {
-		r1 = rf(_a0)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // ModifyVpcAttributeRequest provides a mock function with given fields: _a0
 func (_m *EC2API) ModifyVpcAttributeRequest(_a0 *ec2.ModifyVpcAttributeInput) (*request.Request, *ec2.ModifyVpcAttributeOutput) {
 	ret := _m.Called(_a0)
@@ -25619,36 +22748,6 @@ func (_m *EC2API) ModifyVpcAttributeRequest(_a0 *ec2.ModifyVpcAttributeInput) (*
 	return r0, r1
 }
 
-// ModifyVpcAttributeWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) ModifyVpcAttributeWithContext(_a0 context.Context, _a1 *ec2.ModifyVpcAttributeInput, _a2 ...request.Option) (*ec2.ModifyVpcAttributeOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.ModifyVpcAttributeOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.ModifyVpcAttributeInput, ...request.Option) *ec2.ModifyVpcAttributeOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.ModifyVpcAttributeOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.ModifyVpcAttributeInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // ModifyVpcEndpoint provides a mock function with given fields: _a0
 func (_m *EC2API) ModifyVpcEndpoint(_a0 *ec2.ModifyVpcEndpointInput) (*ec2.ModifyVpcEndpointOutput, error) {
 	ret := _m.Called(_a0)
@@ -26844,36 +23943,6 @@ func (_m *EC2API) RebootInstancesRequest(_a0 *ec2.RebootInstancesInput) (*reques
 	return r0, r1
 }
 
-// RebootInstancesWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) RebootInstancesWithContext(_a0 context.Context, _a1 *ec2.RebootInstancesInput, _a2 ...request.Option) (*ec2.RebootInstancesOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.RebootInstancesOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.RebootInstancesInput, ...request.Option) *ec2.RebootInstancesOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.RebootInstancesOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.RebootInstancesInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // RegisterImage provides a mock function with given fields: _a0
 func (_m *EC2API) RegisterImage(_a0 *ec2.RegisterImageInput) (*ec2.RegisterImageOutput, error) {
 	ret := _m.Called(_a0)
@@ -27211,36 +24280,6 @@ func (_m *EC2API) ReleaseAddressRequest(_a0 *ec2.ReleaseAddressInput) (*request.
 	return r0, r1
 }
 
-// ReleaseAddressWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) ReleaseAddressWithContext(_a0 context.Context, _a1 *ec2.ReleaseAddressInput, _a2 ...request.Option) (*ec2.ReleaseAddressOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.ReleaseAddressOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.ReleaseAddressInput, ...request.Option) *ec2.ReleaseAddressOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.ReleaseAddressOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.ReleaseAddressInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // ReleaseHosts provides a mock function with given fields: _a0
 func (_m *EC2API) ReleaseHosts(_a0 *ec2.ReleaseHostsInput) (*ec2.ReleaseHostsOutput, error) {
 	ret := _m.Called(_a0)
@@ -27553,29 +24592,6 @@ func (_m *EC2API) ReplaceNetworkAclEntryWithContext(_a0 context.Context, _a1 *ec
 	return r0, r1
 }
 
-// ReplaceRoute provides a mock function with given fields: _a0
-func (_m *EC2API) ReplaceRoute(_a0 *ec2.ReplaceRouteInput) (*ec2.ReplaceRouteOutput, error) {
-	ret := _m.Called(_a0)
-
-	var r0 *ec2.ReplaceRouteOutput
-	if rf, ok := ret.Get(0).(func(*ec2.ReplaceRouteInput) *ec2.ReplaceRouteOutput); ok {
-		r0 = rf(_a0)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.ReplaceRouteOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(*ec2.ReplaceRouteInput) error); ok {
-		r1 = rf(_a0)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // ReplaceRouteRequest provides a mock function with given fields: _a0
 func (_m *EC2API) ReplaceRouteRequest(_a0 *ec2.ReplaceRouteInput) (*request.Request, *ec2.ReplaceRouteOutput) {
 	ret := _m.Called(_a0)
@@ -27601,29 +24617,6 @@ func (_m *EC2API) ReplaceRouteRequest(_a0 *ec2.ReplaceRouteInput) (*request.Requ
 	return r0, r1
 }
 
-// ReplaceRouteTableAssociation provides a mock function with given fields: _a0
-func (_m *EC2API) ReplaceRouteTableAssociation(_a0 *ec2.ReplaceRouteTableAssociationInput) (*ec2.ReplaceRouteTableAssociationOutput, error) {
-	ret := _m.Called(_a0)
-
-	var r0 *ec2.ReplaceRouteTableAssociationOutput
-	if rf, ok := ret.Get(0).(func(*ec2.ReplaceRouteTableAssociationInput) *ec2.ReplaceRouteTableAssociationOutput); ok {
-		r0 = rf(_a0)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.ReplaceRouteTableAssociationOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(*ec2.ReplaceRouteTableAssociationInput) error); ok {
-		r1 = rf(_a0)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // ReplaceRouteTableAssociationRequest provides a mock function with given fields: _a0
 func (_m *EC2API) ReplaceRouteTableAssociationRequest(_a0 *ec2.ReplaceRouteTableAssociationInput) (*request.Request, *ec2.ReplaceRouteTableAssociationOutput) {
 	ret := _m.Called(_a0)
@@ -27649,66 +24642,6 @@ func (_m *EC2API) ReplaceRouteTableAssociationRequest(_a0 *ec2.ReplaceRouteTable
 	return r0, r1
 }
 
-// ReplaceRouteTableAssociationWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) ReplaceRouteTableAssociationWithContext(_a0 context.Context, _a1 *ec2.ReplaceRouteTableAssociationInput, _a2 ...request.Option) (*ec2.ReplaceRouteTableAssociationOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.ReplaceRouteTableAssociationOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.ReplaceRouteTableAssociationInput, ...request.Option) *ec2.ReplaceRouteTableAssociationOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.ReplaceRouteTableAssociationOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.ReplaceRouteTableAssociationInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
-// ReplaceRouteWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) ReplaceRouteWithContext(_a0 context.Context, _a1 *ec2.ReplaceRouteInput, _a2 ...request.Option) (*ec2.ReplaceRouteOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.ReplaceRouteOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.ReplaceRouteInput, ...request.Option) *ec2.ReplaceRouteOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.ReplaceRouteOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.ReplaceRouteInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // ReplaceTransitGatewayRoute provides a mock function with given fields: _a0
 func (_m *EC2API) ReplaceTransitGatewayRoute(_a0 *ec2.ReplaceTransitGatewayRouteInput) (*ec2.ReplaceTransitGatewayRouteOutput, error) {
 	ret := _m.Called(_a0)
@@ -28333,29 +25266,6 @@ func (_m *EC2API) ResetInstanceAttributeWithContext(_a0 context.Context, _a1 *ec
 	return r0, r1
 }
 
-// ResetNetworkInterfaceAttribute provides a mock function with given fields: _a0
-func (_m *EC2API) ResetNetworkInterfaceAttribute(_a0 *ec2.ResetNetworkInterfaceAttributeInput) (*ec2.ResetNetworkInterfaceAttributeOutput, error) {
-	ret := _m.Called(_a0)
-
-	var r0 *ec2.ResetNetworkInterfaceAttributeOutput
-	if rf, ok := ret.Get(0).(func(*ec2.ResetNetworkInterfaceAttributeInput) *ec2.ResetNetworkInterfaceAttributeOutput); ok {
-		r0 = rf(_a0)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.ResetNetworkInterfaceAttributeOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(*ec2.ResetNetworkInterfaceAttributeInput) error); ok {
-		r1 = rf(_a0)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // ResetNetworkInterfaceAttributeRequest provides a mock function with given fields: _a0
 func (_m *EC2API) ResetNetworkInterfaceAttributeRequest(_a0 *ec2.ResetNetworkInterfaceAttributeInput) (*request.Request, *ec2.ResetNetworkInterfaceAttributeOutput) {
 	ret := _m.Called(_a0)
@@ -28381,36 +25291,6 @@ func (_m *EC2API) ResetNetworkInterfaceAttributeRequest(_a0 *ec2.ResetNetworkInt
 	return r0, r1
 }
 
-// ResetNetworkInterfaceAttributeWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) ResetNetworkInterfaceAttributeWithContext(_a0 context.Context, _a1 *ec2.ResetNetworkInterfaceAttributeInput, _a2 ...request.Option) (*ec2.ResetNetworkInterfaceAttributeOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.ResetNetworkInterfaceAttributeOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.ResetNetworkInterfaceAttributeInput, ...request.Option) *ec2.ResetNetworkInterfaceAttributeOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.ResetNetworkInterfaceAttributeOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.ResetNetworkInterfaceAttributeInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // ResetSnapshotAttribute provides a mock function with given fields: _a0
 func (_m *EC2API) ResetSnapshotAttribute(_a0 *ec2.ResetSnapshotAttributeInput) (*ec2.ResetSnapshotAttributeOutput, error) {
 	ret := _m.Called(_a0)
@@ -28645,29 +25525,6 @@ func (_m *EC2API) RevokeClientVpnIngressWithContext(_a0 context.Context, _a1 *ec
 	return r0, r1
 }
 
-// RevokeSecurityGroupEgress provides a mock function with given fields: _a0
-func (_m *EC2API) RevokeSecurityGroupEgress(_a0 *ec2.RevokeSecurityGroupEgressInput) (*ec2.RevokeSecurityGroupEgressOutput, error) {
-	ret := _m.Called(_a0)
-
-	var r0 *ec2.RevokeSecurityGroupEgressOutput
-	if rf, ok := ret.Get(0).(func(*ec2.RevokeSecurityGroupEgressInput) *ec2.RevokeSecurityGroupEgressOutput); ok {
-		r0 = rf(_a0)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.RevokeSecurityGroupEgressOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(*ec2.RevokeSecurityGroupEgressInput) error); ok {
-		r1 = rf(_a0)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // RevokeSecurityGroupEgressRequest provides a mock function with given fields: _a0
 func (_m *EC2API) RevokeSecurityGroupEgressRequest(_a0 *ec2.RevokeSecurityGroupEgressInput) (*request.Request, *ec2.RevokeSecurityGroupEgressOutput) {
 	ret := _m.Called(_a0)
@@ -28693,36 +25550,6 @@ func (_m *EC2API) RevokeSecurityGroupEgressRequest(_a0 *ec2.RevokeSecurityGroupE
 	return r0, r1
 }
 
-// RevokeSecurityGroupEgressWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) RevokeSecurityGroupEgressWithContext(_a0 context.Context, _a1 *ec2.RevokeSecurityGroupEgressInput, _a2 ...request.Option) (*ec2.RevokeSecurityGroupEgressOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.RevokeSecurityGroupEgressOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.RevokeSecurityGroupEgressInput, ...request.Option) *ec2.RevokeSecurityGroupEgressOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.RevokeSecurityGroupEgressOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.RevokeSecurityGroupEgressInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // RevokeSecurityGroupIngressRequest provides a mock function with given fields: _a0
 func (_m *EC2API) RevokeSecurityGroupIngressRequest(_a0 *ec2.RevokeSecurityGroupIngressInput) (*request.Request, *ec2.RevokeSecurityGroupIngressOutput) {
 	ret := _m.Called(_a0)
@@ -28748,59 +25575,6 @@ func (_m *EC2API) RevokeSecurityGroupIngressRequest(_a0 *ec2.RevokeSecurityGroup
 	return r0, r1
 }
 
-// RevokeSecurityGroupIngressWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) RevokeSecurityGroupIngressWithContext(_a0 context.Context, _a1 *ec2.RevokeSecurityGroupIngressInput, _a2 ...request.Option) (*ec2.RevokeSecurityGroupIngressOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.RevokeSecurityGroupIngressOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.RevokeSecurityGroupIngressInput, ...request.Option) *ec2.RevokeSecurityGroupIngressOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.RevokeSecurityGroupIngressOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.RevokeSecurityGroupIngressInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
-// RunInstances provides a mock function with given fields: _a0
-func (_m *EC2API) RunInstances(_a0 *ec2.RunInstancesInput) (*ec2.Reservation, error) {
-	ret := _m.Called(_a0)
-
-	var r0 *ec2.Reservation
-	if rf, ok := ret.Get(0).(func(*ec2.RunInstancesInput) *ec2.Reservation); ok {
-		r0 = rf(_a0)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.Reservation)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(*ec2.RunInstancesInput) error); ok {
-		r1 = rf(_a0)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // RunInstancesRequest provides a mock function with given fields: _a0
 func (_m *EC2API) RunInstancesRequest(_a0 *ec2.RunInstancesInput) (*request.Request, *ec2.Reservation) {
 	ret := _m.Called(_a0)
@@ -28826,36 +25600,6 @@ func (_m *EC2API) RunInstancesRequest(_a0 *ec2.RunInstancesInput) (*request.Requ
 	return r0, r1
 }
 
-// RunInstancesWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) RunInstancesWithContext(_a0 context.Context, _a1 *ec2.RunInstancesInput, _a2 ...request.Option) (*ec2.Reservation, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.Reservation
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.RunInstancesInput, ...request.Option) *ec2.Reservation); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.Reservation)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.RunInstancesInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // RunScheduledInstances provides a mock function with given fields: _a0
 func (_m *EC2API) RunScheduledInstances(_a0 *ec2.RunScheduledInstancesInput) (*ec2.RunScheduledInstancesOutput, error) {
 	ret := _m.Called(_a0)
@@ -29115,36 +25859,6 @@ func (_m *EC2API) StartInstancesRequest(_a0 *ec2.StartInstancesInput) (*request.
 	return r0, r1
 }
 
-// StartInstancesWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) StartInstancesWithContext(_a0 context.Context, _a1 *ec2.StartInstancesInput, _a2 ...request.Option) (*ec2.StartInstancesOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.StartInstancesOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.StartInstancesInput, ...request.Option) *ec2.StartInstancesOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.StartInstancesOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.StartInstancesInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // StopInstancesRequest provides a mock function with given fields: _a0
 func (_m *EC2API) StopInstancesRequest(_a0 *ec2.StopInstancesInput) (*request.Request, *ec2.StopInstancesOutput) {
 	ret := _m.Called(_a0)
@@ -29170,36 +25884,6 @@ func (_m *EC2API) StopInstancesRequest(_a0 *ec2.StopInstancesInput) (*request.Re
 	return r0, r1
 }
 
-// StopInstancesWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) StopInstancesWithContext(_a0 context.Context, _a1 *ec2.StopInstancesInput, _a2 ...request.Option) (*ec2.StopInstancesOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.StopInstancesOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.StopInstancesInput, ...request.Option) *ec2.StopInstancesOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.StopInstancesOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.StopInstancesInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // TerminateClientVpnConnections provides a mock function with given fields: _a0
 func (_m *EC2API) TerminateClientVpnConnections(_a0 *ec2.TerminateClientVpnConnectionsInput) (*ec2.TerminateClientVpnConnectionsOutput, error) {
 	ret := _m.Called(_a0)
@@ -29278,29 +25962,6 @@ func (_m *EC2API) TerminateClientVpnConnectionsWithContext(_a0 context.Context,
 	return r0, r1
 }
 
-// TerminateInstances provides a mock function with given fields: _a0
-func (_m *EC2API) TerminateInstances(_a0 *ec2.TerminateInstancesInput) (*ec2.TerminateInstancesOutput, error) {
-	ret := _m.Called(_a0)
-
-	var r0 *ec2.TerminateInstancesOutput
-	if rf, ok := ret.Get(0).(func(*ec2.TerminateInstancesInput) *ec2.TerminateInstancesOutput); ok {
-		r0 = rf(_a0)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.TerminateInstancesOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(*ec2.TerminateInstancesInput) error); ok {
-		r1 = rf(_a0)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // TerminateInstancesRequest provides a mock function with given fields: _a0
 func (_m *EC2API) TerminateInstancesRequest(_a0 *ec2.TerminateInstancesInput) (*request.Request, *ec2.TerminateInstancesOutput) {
 	ret := _m.Called(_a0)
@@ -29326,59 +25987,6 @@ func (_m *EC2API) TerminateInstancesRequest(_a0 *ec2.TerminateInstancesInput) (*
 	return r0, r1
 }
 
-// TerminateInstancesWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) TerminateInstancesWithContext(_a0 context.Context, _a1 *ec2.TerminateInstancesInput, _a2 ...request.Option) (*ec2.TerminateInstancesOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.TerminateInstancesOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.TerminateInstancesInput, ...request.Option) *ec2.TerminateInstancesOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.TerminateInstancesOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.TerminateInstancesInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
-// UnassignIpv6Addresses provides a mock function with given fields: _a0
-func (_m *EC2API) UnassignIpv6Addresses(_a0 *ec2.UnassignIpv6AddressesInput) (*ec2.UnassignIpv6AddressesOutput, error) {
-	ret := _m.Called(_a0)
-
-	var r0 *ec2.UnassignIpv6AddressesOutput
-	if rf, ok := ret.Get(0).(func(*ec2.UnassignIpv6AddressesInput) *ec2.UnassignIpv6AddressesOutput); ok {
-		r0 = rf(_a0)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.UnassignIpv6AddressesOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(*ec2.UnassignIpv6AddressesInput) error); ok {
-		r1 = rf(_a0)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // UnassignIpv6AddressesRequest provides a mock function with given fields: _a0
 func (_m *EC2API) UnassignIpv6AddressesRequest(_a0 *ec2.UnassignIpv6AddressesInput) (*request.Request, *ec2.UnassignIpv6AddressesOutput) {
 	ret := _m.Called(_a0)
@@ -29404,36 +26012,6 @@ func (_m *EC2API) UnassignIpv6AddressesRequest(_a0 *ec2.UnassignIpv6AddressesInp
 	return r0, r1
 }
 
-// UnassignIpv6AddressesWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) UnassignIpv6AddressesWithContext(_a0 context.Context, _a1 *ec2.UnassignIpv6AddressesInput, _a2 ...request.Option) (*ec2.UnassignIpv6AddressesOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.UnassignIpv6AddressesOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.UnassignIpv6AddressesInput, ...request.Option) *ec2.UnassignIpv6AddressesOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.UnassignIpv6AddressesOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.UnassignIpv6AddressesInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // UnassignPrivateIpAddressesRequest provides a mock function with given fields: _a0
 func (_m *EC2API) UnassignPrivateIpAddressesRequest(_a0 *ec2.UnassignPrivateIpAddressesInput) (*request.Request, *ec2.UnassignPrivateIpAddressesOutput) {
 	ret := _m.Called(_a0)
@@ -29459,36 +26037,6 @@ func (_m *EC2API) UnassignPrivateIpAddressesRequest(_a0 *ec2.UnassignPrivateIpAd
 	return r0, r1
 }
 
-// UnassignPrivateIpAddressesWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) UnassignPrivateIpAddressesWithContext(_a0 context.Context, _a1 *ec2.UnassignPrivateIpAddressesInput, _a2 ...request.Option) (*ec2.UnassignPrivateIpAddressesOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.UnassignPrivateIpAddressesOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.UnassignPrivateIpAddressesInput, ...request.Option) *ec2.UnassignPrivateIpAddressesOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.UnassignPrivateIpAddressesOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.UnassignPrivateIpAddressesInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // UnmonitorInstances provides a mock function with given fields: _a0
 func (_m *EC2API) UnmonitorInstances(_a0 *ec2.UnmonitorInstancesInput) (*ec2.UnmonitorInstancesOutput, error) {
 	ret := _m.Called(_a0)
@@ -29567,29 +26115,6 @@ func (_m *EC2API) UnmonitorInstancesWithContext(_a0 context.Context, _a1 *ec2.Un
 	return r0, r1
 }
 
-// UpdateSecurityGroupRuleDescriptionsEgress provides a mock function with given fields: _a0
-func (_m *EC2API) UpdateSecurityGroupRuleDescriptionsEgress(_a0 *ec2.UpdateSecurityGroupRuleDescriptionsEgressInput) (*ec2.UpdateSecurityGroupRuleDescriptionsEgressOutput, error) {
-	ret := _m.Called(_a0)
-
-	var r0 *ec2.UpdateSecurityGroupRuleDescriptionsEgressOutput
-	if rf, ok := ret.Get(0).(func(*ec2.UpdateSecurityGroupRuleDescriptionsEgressInput) *ec2.UpdateSecurityGroupRuleDescriptionsEgressOutput); ok {
-		r0 = rf(_a0)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.UpdateSecurityGroupRuleDescriptionsEgressOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(*ec2.UpdateSecurityGroupRuleDescriptionsEgressInput) error); ok {
-		r1 = rf(_a0)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // UpdateSecurityGroupRuleDescriptionsEgressRequest provides a mock function with given fields: _a0
 func (_m *EC2API) UpdateSecurityGroupRuleDescriptionsEgressRequest(_a0 *ec2.UpdateSecurityGroupRuleDescriptionsEgressInput) (*request.Request, *ec2.UpdateSecurityGroupRuleDescriptionsEgressOutput) {
 	ret := _m.Called(_a0)
@@ -29615,59 +26140,6 @@ func (_m *EC2API) UpdateSecurityGroupRuleDescriptionsEgressRequest(_a0 *ec2.Upda
 	return r0, r1
 }
 
-// UpdateSecurityGroupRuleDescriptionsEgressWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) UpdateSecurityGroupRuleDescriptionsEgressWithContext(_a0 context.Context, _a1 *ec2.UpdateSecurityGroupRuleDescriptionsEgressInput, _a2 ...request.Option) (*ec2.UpdateSecurityGroupRuleDescriptionsEgressOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.UpdateSecurityGroupRuleDescriptionsEgressOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.UpdateSecurityGroupRuleDescriptionsEgressInput, ...request.Option) *ec2.UpdateSecurityGroupRuleDescriptionsEgressOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.UpdateSecurityGroupRuleDescriptionsEgressOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.UpdateSecurityGroupRuleDescriptionsEgressInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
-// UpdateSecurityGroupRuleDescriptionsIngress provides a mock function with given fields: _a0
-func (_m *EC2API) UpdateSecurityGroupRuleDescriptionsIngress(_a0 *ec2.UpdateSecurityGroupRuleDescriptionsIngressInput) (*ec2.UpdateSecurityGroupRuleDescriptionsIngressOutput, error) {
-	ret := _m.Called(_a0)
-
-	var r0 *ec2.UpdateSecurityGroupRuleDescriptionsIngressOutput
-	if rf, ok := ret.Get(0).(func(*ec2.UpdateSecurityGroupRuleDescriptionsIngressInput) *ec2.UpdateSecurityGroupRuleDescriptionsIngressOutput); ok {
-		r0 = rf(_a0)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.UpdateSecurityGroupRuleDescriptionsIngressOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(*ec2.UpdateSecurityGroupRuleDescriptionsIngressInput) error); ok {
-		r1 = rf(_a0)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // UpdateSecurityGroupRuleDescriptionsIngressRequest provides a mock function with given fields: _a0
 func (_m *EC2API) UpdateSecurityGroupRuleDescriptionsIngressRequest(_a0 *ec2.UpdateSecurityGroupRuleDescriptionsIngressInput) (*request.Request, *ec2.UpdateSecurityGroupRuleDescriptionsIngressOutput) {
 	ret := _m.Called(_a0)
@@ -29693,36 +26165,6 @@ func (_m *EC2API) UpdateSecurityGroupRuleDescriptionsIngressRequest(_a0 *ec2.Upd
 	return r0, r1
 }
 
-// UpdateSecurityGroupRuleDescriptionsIngressWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) UpdateSecurityGroupRuleDescriptionsIngressWithContext(_a0 context.Context, _a1 *ec2.UpdateSecurityGroupRuleDescriptionsIngressInput, _a2 ...request.Option) (*ec2.UpdateSecurityGroupRuleDescriptionsIngressOutput, error) {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 *ec2.UpdateSecurityGroupRuleDescriptionsIngressOutput
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.UpdateSecurityGroupRuleDescriptionsIngressInput, ...request.Option) *ec2.UpdateSecurityGroupRuleDescriptionsIngressOutput); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ec2.UpdateSecurityGroupRuleDescriptionsIngressOutput)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *ec2.UpdateSecurityGroupRuleDescriptionsIngressInput, ...request.Option) error); ok {
-		r1 = rf(_a0, _a1, _a2...)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // WaitUntilBundleTaskComplete provides a mock function with given fields: _a0
 func (_m *EC2API) WaitUntilBundleTaskComplete(_a0 *ec2.DescribeBundleTasksInput) error {
 	ret := _m.Called(_a0)
@@ -30038,12 +26480,12 @@ func (_m *EC2API) WaitUntilImageExistsWithContext(_a0 context.Context, _a1 *ec2.
 	return r0
 }
 
-// WaitUntilInstanceExists provides a mock function with given fields: _a0
-func (_m *EC2API) WaitUntilInstanceExists(_a0 *ec2.DescribeInstancesInput) error {
+// WaitUntilInstanceStatusOk provides a mock function with given fields: _a0
+func (_m *EC2API) WaitUntilInstanceStatusOk(_a0 *ec2.DescribeInstanceStatusInput) error {
 	ret := _m.Called(_a0)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*ec2.DescribeInstancesInput) error); ok {
+	if rf, ok := ret.Get(0).(func(*ec2.DescribeInstanceStatusInput) error); ok {
 		r0 = rf(_a0)
 	} else {
 		r0 = ret.Error(0)
@@ -30052,8 +26494,8 @@ func (_m *EC2API) WaitUntilInstanceExists(_a0 *ec2.DescribeInstancesInput) error
 	return r0
 }
 
-// WaitUntilInstanceExistsWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) WaitUntilInstanceExistsWithContext(_a0 context.Context, _a1 *ec2.DescribeInstancesInput, _a2 ...request.WaiterOption) error {
+// WaitUntilInstanceStatusOkWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *EC2API) WaitUntilInstanceStatusOkWithContext(_a0 context.Context, _a1 *ec2.DescribeInstanceStatusInput, _a2 ...request.WaiterOption) error {
 	_va := make([]interface{}, len(_a2))
 	for _i := range _a2 {
 		_va[_i] = _a2[_i]
@@ -30064,7 +26506,7 @@ func (_m *EC2API) WaitUntilInstanceExistsWithContext(_a0 context.Context, _a1 *e
 	ret := _m.Called(_ca...)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.DescribeInstancesInput, ...request.WaiterOption) error); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *ec2.DescribeInstanceStatusInput, ...request.WaiterOption) error); ok {
 		r0 = rf(_a0, _a1, _a2...)
 	} else {
 		r0 = ret.Error(0)
@@ -30073,12 +26515,12 @@ func (_m *EC2API) WaitUntilInstanceExistsWithContext(_a0 context.Context, _a1 *e
 	return r0
 }
 
-// WaitUntilInstanceRunning provides a mock function with given fields: _a0
-func (_m *EC2API) WaitUntilInstanceRunning(_a0 *ec2.DescribeInstancesInput) error {
+// WaitUntilKeyPairExists provides a mock function with given fields: _a0
+func (_m *EC2API) WaitUntilKeyPairExists(_a0 *ec2.DescribeKeyPairsInput) error {
 	ret := _m.Called(_a0)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*ec2.DescribeInstancesInput) error); ok {
+	if rf, ok := ret.Get(0).(func(*ec2.DescribeKeyPairsInput) error); ok {
 		r0 = rf(_a0)
 	} else {
 		r0 = ret.Error(0)
@@ -30087,8 +26529,8 @@ func (_m *EC2API) WaitUntilInstanceRunning(_a0 *ec2.DescribeInstancesInput) erro
 	return r0
 }
 
-// WaitUntilInstanceRunningWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) WaitUntilInstanceRunningWithContext(_a0 context.Context, _a1 *ec2.DescribeInstancesInput, _a2 ...request.WaiterOption) error {
+// WaitUntilKeyPairExistsWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *EC2API) WaitUntilKeyPairExistsWithContext(_a0 context.Context, _a1 *ec2.DescribeKeyPairsInput, _a2 ...request.WaiterOption) error {
 	_va := make([]interface{}, len(_a2))
 	for _i := range _a2 {
 		_va[_i] = _a2[_i]
@@ -30099,7 +26541,7 @@ func (_m *EC2API) WaitUntilInstanceRunningWithContext(_a0 context.Context, _a1 *
 	ret := _m.Called(_ca...)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.DescribeInstancesInput, ...request.WaiterOption) error); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *ec2.DescribeKeyPairsInput, ...request.WaiterOption) error); ok {
 		r0 = rf(_a0, _a1, _a2...)
 	} else {
 		r0 = ret.Error(0)
@@ -30108,12 +26550,12 @@ func (_m *EC2API) WaitUntilInstanceRunningWithContext(_a0 context.Context, _a1 *
 	return r0
 }
 
-// WaitUntilInstanceStatusOk provides a mock function with given fields: _a0
-func (_m *EC2API) WaitUntilInstanceStatusOk(_a0 *ec2.DescribeInstanceStatusInput) error {
+// WaitUntilPasswordDataAvailable provides a mock function with given fields: _a0
+func (_m *EC2API) WaitUntilPasswordDataAvailable(_a0 *ec2.GetPasswordDataInput) error {
 	ret := _m.Called(_a0)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*ec2.DescribeInstanceStatusInput) error); ok {
+	if rf, ok := ret.Get(0).(func(*ec2.GetPasswordDataInput) error); ok {
 		r0 = rf(_a0)
 	} else {
 		r0 = ret.Error(0)
@@ -30122,8 +26564,8 @@ func (_m *EC2API) WaitUntilInstanceStatusOk(_a0 *ec2.DescribeInstanceStatusInput
 	return r0
 }
 
-// WaitUntilInstanceStatusOkWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) WaitUntilInstanceStatusOkWithContext(_a0 context.Context, _a1 *ec2.DescribeInstanceStatusInput, _a2 ...request.WaiterOption) error {
+// WaitUntilPasswordDataAvailableWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *EC2API) WaitUntilPasswordDataAvailableWithContext(_a0 context.Context, _a1 *ec2.GetPasswordDataInput, _a2 ...request.WaiterOption) error {
 	_va := make([]interface{}, len(_a2))
 	for _i := range _a2 {
 		_va[_i] = _a2[_i]
@@ -30134,217 +26576,7 @@ func (_m *EC2API) WaitUntilInstanceStatusOkWithContext(_a0 context.Context, _a1
 	ret := _m.Called(_ca...)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.DescribeInstanceStatusInput, ...request.WaiterOption) error); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
-// WaitUntilInstanceStopped provides a mock function with given fields: _a0
-func (_m *EC2API) WaitUntilInstanceStopped(_a0 *ec2.DescribeInstancesInput) error {
-	ret := _m.Called(_a0)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(*ec2.DescribeInstancesInput) error); ok {
-		r0 = rf(_a0)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
-// WaitUntilInstanceStoppedWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) WaitUntilInstanceStoppedWithContext(_a0 context.Context, _a1 *ec2.DescribeInstancesInput, _a2 ...request.WaiterOption) error {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.DescribeInstancesInput, ...request.WaiterOption) error); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
-// WaitUntilInstanceTerminated provides a mock function with given fields: _a0
-func (_m *EC2API) WaitUntilInstanceTerminated(_a0 *ec2.DescribeInstancesInput) error {
-	ret := _m.Called(_a0)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(*ec2.DescribeInstancesInput) error); ok {
-		r0 = rf(_a0)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
-// WaitUntilInstanceTerminatedWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) WaitUntilInstanceTerminatedWithContext(_a0 context.Context, _a1 *ec2.DescribeInstancesInput, _a2 ...request.WaiterOption) error {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.DescribeInstancesInput, ...request.WaiterOption) error); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
-// WaitUntilKeyPairExists provides a mock function with given fields: _a0
-func (_m *EC2API) WaitUntilKeyPairExists(_a0 *ec2.DescribeKeyPairsInput) error {
-	ret := _m.Called(_a0)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(*ec2.DescribeKeyPairsInput) error); ok {
-		r0 = rf(_a0)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
-// WaitUntilKeyPairExistsWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) WaitUntilKeyPairExistsWithContext(_a0 context.Context, _a1 *ec2.DescribeKeyPairsInput, _a2 ...request.WaiterOption) error {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.DescribeKeyPairsInput, ...request.WaiterOption) error); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
-// WaitUntilNatGatewayAvailable provides a mock function with given fields: _a0
-func (_m *EC2API) WaitUntilNatGatewayAvailable(_a0 *ec2.DescribeNatGatewaysInput) error {
-	ret := _m.Called(_a0)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(*ec2.DescribeNatGatewaysInput) error); ok {
-		r0 = rf(_a0)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
-// WaitUntilNatGatewayAvailableWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) WaitUntilNatGatewayAvailableWithContext(_a0 context.Context, _a1 *ec2.DescribeNatGatewaysInput, _a2 ...request.WaiterOption) error {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.DescribeNatGatewaysInput, ...request.WaiterOption) error); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
-// WaitUntilNetworkInterfaceAvailable provides a mock function with given fields: _a0
-func (_m *EC2API) WaitUntilNetworkInterfaceAvailable(_a0 *ec2.DescribeNetworkInterfacesInput) error {
-	ret := _m.Called(_a0)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(*ec2.DescribeNetworkInterfacesInput) error); ok {
-		r0 = rf(_a0)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
-// WaitUntilNetworkInterfaceAvailableWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) WaitUntilNetworkInterfaceAvailableWithContext(_a0 context.Context, _a1 *ec2.DescribeNetworkInterfacesInput, _a2 ...request.WaiterOption) error {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.DescribeNetworkInterfacesInput, ...request.WaiterOption) error); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
-// WaitUntilPasswordDataAvailable provides a mock function with given fields: _a0
-func (_m *EC2API) WaitUntilPasswordDataAvailable(_a0 *ec2.GetPasswordDataInput) error {
-	ret := _m.Called(_a0)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(*ec2.GetPasswordDataInput) error); ok {
-		r0 = rf(_a0)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
-// WaitUntilPasswordDataAvailableWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) WaitUntilPasswordDataAvailableWithContext(_a0 context.Context, _a1 *ec2.GetPasswordDataInput, _a2 ...request.WaiterOption) error {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.GetPasswordDataInput, ...request.WaiterOption) error); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *ec2.GetPasswordDataInput, ...request.WaiterOption) error); ok {
 		r0 = rf(_a0, _a1, _a2...)
 	} else {
 		r0 = ret.Error(0)
@@ -30423,41 +26655,6 @@ func (_m *EC2API) WaitUntilSpotInstanceRequestFulfilledWithContext(_a0 context.C
 	return r0
 }
 
-// WaitUntilSubnetAvailable provides a mock function with given fields: _a0
-func (_m *EC2API) WaitUntilSubnetAvailable(_a0 *ec2.DescribeSubnetsInput) error {
-	ret := _m.Called(_a0)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(*ec2.DescribeSubnetsInput) error); ok {
-		r0 = rf(_a0)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
-// WaitUntilSubnetAvailableWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) WaitUntilSubnetAvailableWithContext(_a0 context.Context, _a1 *ec2.DescribeSubnetsInput, _a2 ...request.WaiterOption) error {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.DescribeSubnetsInput, ...request.WaiterOption) error); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
 // WaitUntilSystemStatusOk provides a mock function with given fields: _a0
 func (_m *EC2API) WaitUntilSystemStatusOk(_a0 *ec2.DescribeInstanceStatusInput) error {
 	ret := _m.Called(_a0)
@@ -30493,76 +26690,6 @@ func (_m *EC2API) WaitUntilSystemStatusOkWithContext(_a0 context.Context, _a1 *e
 	return r0
 }
 
-// WaitUntilVolumeAvailable provides a mock function with given fields: _a0
-func (_m *EC2API) WaitUntilVolumeAvailable(_a0 *ec2.DescribeVolumesInput) error {
-	ret := _m.Called(_a0)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(*ec2.DescribeVolumesInput) error); ok {
-		r0 = rf(_a0)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
-// WaitUntilVolumeAvailableWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) WaitUntilVolumeAvailableWithContext(_a0 context.Context, _a1 *ec2.DescribeVolumesInput, _a2 ...request.WaiterOption) error {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.DescribeVolumesInput, ...request.WaiterOption) error); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
-// WaitUntilVolumeDeleted provides a mock function with given fields: _a0
-func (_m *EC2API) WaitUntilVolumeDeleted(_a0 *ec2.DescribeVolumesInput) error {
-	ret := _m.Called(_a0)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(*ec2.DescribeVolumesInput) error); ok {
-		r0 = rf(_a0)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
-// WaitUntilVolumeDeletedWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) WaitUntilVolumeDeletedWithContext(_a0 context.Context, _a1 *ec2.DescribeVolumesInput, _a2 ...request.WaiterOption) error {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.DescribeVolumesInput, ...request.WaiterOption) error); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
 // WaitUntilVolumeInUse provides a mock function with given fields: _a0
 func (_m *EC2API) WaitUntilVolumeInUse(_a0 *ec2.DescribeVolumesInput) error {
 	ret := _m.Called(_a0)
@@ -30598,76 +26725,6 @@ func (_m *EC2API) WaitUntilVolumeInUseWithContext(_a0 context.Context, _a1 *ec2.
 	return r0
 }
 
-// WaitUntilVpcAvailable provides a mock function with given fields: _a0
-func (_m *EC2API) WaitUntilVpcAvailable(_a0 *ec2.DescribeVpcsInput) error {
-	ret := _m.Called(_a0)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(*ec2.DescribeVpcsInput) error); ok {
-		r0 = rf(_a0)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
-// WaitUntilVpcAvailableWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) WaitUntilVpcAvailableWithContext(_a0 context.Context, _a1 *ec2.DescribeVpcsInput, _a2 ...request.WaiterOption) error {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.DescribeVpcsInput, ...request.WaiterOption) error); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
-// WaitUntilVpcExists provides a mock function with given fields: _a0
-func (_m *EC2API) WaitUntilVpcExists(_a0 *ec2.DescribeVpcsInput) error {
-	ret := _m.Called(_a0)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(*ec2.DescribeVpcsInput) error); ok {
-		r0 = rf(_a0)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
-// WaitUntilVpcExistsWithContext provides a mock function with given fields: _a0, _a1, _a2
-func (_m *EC2API) WaitUntilVpcExistsWithContext(_a0 context.Context, _a1 *ec2.DescribeVpcsInput, _a2 ...request.WaiterOption) error {
-	_va := make([]interface{}, len(_a2))
-	for _i := range _a2 {
-		_va[_i] = _a2[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _a0, _a1)
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *ec2.DescribeVpcsInput, ...request.WaiterOption) error); ok {
-		r0 = rf(_a0, _a1, _a2...)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
 // WaitUntilVpcPeeringConnectionDeleted provides a mock function with given fields: _a0
 func (_m *EC2API) WaitUntilVpcPeeringConnectionDeleted(_a0 *ec2.DescribeVpcPeeringConnectionsInput) error {
 	ret := _m.Called(_a0)
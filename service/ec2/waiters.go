@@ -0,0 +1,433 @@
+/*
+Copyright 2018 The Avi Networks.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package ec2
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	aws "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/client/metadata"
+	"github.com/aws/aws-sdk-go/aws/request"
+	ec2 "github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/google/uuid"
+)
+
+// SetWaiterDelay makes the WaitUntil methods poll with the delay, measured on
+// the mock's Clock, instead of the sdk's delay of each waiter. Zero polls
+// without sleeping at all. request.WithWaiterDelay still takes precedence.
+func (_m *EC2API) SetWaiterDelay(delay time.Duration) {
+	_m.mutex.Lock()
+	defer _m.mutex.Unlock()
+	_m.waiterDelay = &delay
+}
+
+// waiterAttempt is the describe call a waiter polls with.
+type waiterAttempt struct {
+	operation string
+	params    interface{}
+	send      func(ctx aws.Context) (interface{}, error)
+}
+
+// wait runs a request.Waiter, and so the sdk's acceptor, attempt and delay
+// semantics, against the mock. Every attempt is a request standing in for the
+// sdk's, its send handler answers through the describe call of the mock.
+func (_m *EC2API) wait(ctx aws.Context, name string, maxAttempts int, delay time.Duration, acceptors []request.WaiterAcceptor, attempt waiterAttempt, opts []request.WaiterOption) error {
+	_m.mutex.Lock()
+	clock := _m.clock
+	if _m.waiterDelay != nil {
+		delay = *_m.waiterDelay
+	}
+	_m.mutex.Unlock()
+	w := request.Waiter{
+		Name:             name,
+		MaxAttempts:      maxAttempts,
+		Delay:            request.ConstantWaiterDelay(delay),
+		Acceptors:        acceptors,
+		SleepWithContext: clock.SleepWithContext,
+		NewRequest: func(opts []request.Option) (*request.Request, error) {
+			r := request.New(aws.Config{}, metadata.ClientInfo{ServiceName: "ec2", Endpoint: "https://ec2.amazonaws.com"}, request.Handlers{}, nil, &request.Operation{
+				Name:       attempt.operation,
+				HTTPMethod: "POST",
+				HTTPPath:   "/",
+			}, attempt.params, nil)
+			r.Handlers.Send.PushBack(func(r *request.Request) {
+				r.Data, r.Error = attempt.send(r.Context())
+				statusCode := http.StatusOK
+				r.RequestID = uuid.New().String()
+				if failure, ok := r.Error.(awserr.RequestFailure); ok {
+					statusCode = failure.StatusCode()
+					r.RequestID = failure.RequestID()
+				}
+				r.HTTPResponse = &http.Response{
+					StatusCode: statusCode,
+					Header:     http.Header{"X-Amzn-Requestid": []string{r.RequestID}},
+					Body:       ioutil.NopCloser(strings.NewReader("")),
+				}
+			})
+			r.SetContext(ctx)
+			r.ApplyOptions(opts...)
+			return r, nil
+		},
+	}
+	w.ApplyOptions(opts...)
+	return w.WaitWithContext(ctx)
+}
+
+func (_m *EC2API) describeInstancesAttempt(input *ec2.DescribeInstancesInput) waiterAttempt {
+	return waiterAttempt{
+		operation: "DescribeInstances",
+		params:    input,
+		send: func(ctx aws.Context) (interface{}, error) {
+			inCpy := &ec2.DescribeInstancesInput{}
+			if input != nil {
+				*inCpy = *input
+			}
+			return _m.DescribeInstancesWithContext(ctx, inCpy)
+		},
+	}
+}
+
+func (_m *EC2API) describeNatGatewaysAttempt(input *ec2.DescribeNatGatewaysInput) waiterAttempt {
+	return waiterAttempt{
+		operation: "DescribeNatGateways",
+		params:    input,
+		send: func(ctx aws.Context) (interface{}, error) {
+			inCpy := &ec2.DescribeNatGatewaysInput{}
+			if input != nil {
+				*inCpy = *input
+			}
+			return _m.DescribeNatGatewaysWithContext(ctx, inCpy)
+		},
+	}
+}
+
+func (_m *EC2API) describeNetworkInterfacesAttempt(input *ec2.DescribeNetworkInterfacesInput) waiterAttempt {
+	return waiterAttempt{
+		operation: "DescribeNetworkInterfaces",
+		params:    input,
+		send: func(ctx aws.Context) (interface{}, error) {
+			inCpy := &ec2.DescribeNetworkInterfacesInput{}
+			if input != nil {
+				*inCpy = *input
+			}
+			return _m.DescribeNetworkInterfacesWithContext(ctx, inCpy)
+		},
+	}
+}
+
+func (_m *EC2API) describeSubnetsAttempt(input *ec2.DescribeSubnetsInput) waiterAttempt {
+	return waiterAttempt{
+		operation: "DescribeSubnets",
+		params:    input,
+		send: func(ctx aws.Context) (interface{}, error) {
+			inCpy := &ec2.DescribeSubnetsInput{}
+			if input != nil {
+				*inCpy = *input
+			}
+			return _m.DescribeSubnetsWithContext(ctx, inCpy)
+		},
+	}
+}
+
+func (_m *EC2API) describeVolumesAttempt(input *ec2.DescribeVolumesInput) waiterAttempt {
+	return waiterAttempt{
+		operation: "DescribeVolumes",
+		params:    input,
+		send: func(ctx aws.Context) (interface{}, error) {
+			inCpy := &ec2.DescribeVolumesInput{}
+			if input != nil {
+				*inCpy = *input
+			}
+			return _m.DescribeVolumesWithContext(ctx, inCpy)
+		},
+	}
+}
+
+func (_m *EC2API) describeVpcsAttempt(input *ec2.DescribeVpcsInput) waiterAttempt {
+	return waiterAttempt{
+		operation: "DescribeVpcs",
+		params:    input,
+		send: func(ctx aws.Context) (interface{}, error) {
+			inCpy := &ec2.DescribeVpcsInput{}
+			if input != nil {
+				*inCpy = *input
+			}
+			return _m.DescribeVpcsWithContext(ctx, inCpy)
+		},
+	}
+}
+
+// WaitUntilInstanceExists provides a mock function with given fields: _a0
+func (_m *EC2API) WaitUntilInstanceExists(_a0 *ec2.DescribeInstancesInput) error {
+	return _m.WaitUntilInstanceExistsWithContext(aws.BackgroundContext(), _a0)
+}
+
+// WaitUntilInstanceExistsWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) WaitUntilInstanceExistsWithContext(ctx aws.Context, _a0 *ec2.DescribeInstancesInput, opts ...request.WaiterOption) error {
+	return _m.wait(ctx, "WaitUntilInstanceExists", 40, 5*time.Second, []request.WaiterAcceptor{
+		{
+			State:   request.SuccessWaiterState,
+			Matcher: request.PathWaiterMatch, Argument: "length(Reservations[]) > `0`",
+			Expected: true,
+		},
+		{
+			State:    request.RetryWaiterState,
+			Matcher:  request.ErrorWaiterMatch,
+			Expected: "InvalidInstanceID.NotFound",
+		},
+	}, _m.describeInstancesAttempt(_a0), opts)
+}
+
+// WaitUntilInstanceRunning provides a mock function with given fields: _a0
+func (_m *EC2API) WaitUntilInstanceRunning(_a0 *ec2.DescribeInstancesInput) error {
+	return _m.WaitUntilInstanceRunningWithContext(aws.BackgroundContext(), _a0)
+}
+
+// WaitUntilInstanceRunningWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) WaitUntilInstanceRunningWithContext(ctx aws.Context, _a0 *ec2.DescribeInstancesInput, opts ...request.WaiterOption) error {
+	return _m.wait(ctx, "WaitUntilInstanceRunning", 40, 15*time.Second, []request.WaiterAcceptor{
+		{
+			State:   request.SuccessWaiterState,
+			Matcher: request.PathAllWaiterMatch, Argument: "Reservations[].Instances[].State.Name",
+			Expected: "running",
+		},
+		{
+			State:   request.FailureWaiterState,
+			Matcher: request.PathAnyWaiterMatch, Argument: "Reservations[].Instances[].State.Name",
+			Expected: "shutting-down",
+		},
+		{
+			State:   request.FailureWaiterState,
+			Matcher: request.PathAnyWaiterMatch, Argument: "Reservations[].Instances[].State.Name",
+			Expected: "terminated",
+		},
+		{
+			State:   request.FailureWaiterState,
+			Matcher: request.PathAnyWaiterMatch, Argument: "Reservations[].Instances[].State.Name",
+			Expected: "stopping",
+		},
+		{
+			State:    request.RetryWaiterState,
+			Matcher:  request.ErrorWaiterMatch,
+			Expected: "InvalidInstanceID.NotFound",
+		},
+	}, _m.describeInstancesAttempt(_a0), opts)
+}
+
+// WaitUntilInstanceStopped provides a mock function with given fields: _a0
+func (_m *EC2API) WaitUntilInstanceStopped(_a0 *ec2.DescribeInstancesInput) error {
+	return _m.WaitUntilInstanceStoppedWithContext(aws.BackgroundContext(), _a0)
+}
+
+// WaitUntilInstanceStoppedWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) WaitUntilInstanceStoppedWithContext(ctx aws.Context, _a0 *ec2.DescribeInstancesInput, opts ...request.WaiterOption) error {
+	return _m.wait(ctx, "WaitUntilInstanceStopped", 40, 15*time.Second, []request.WaiterAcceptor{
+		{
+			State:   request.SuccessWaiterState,
+			Matcher: request.PathAllWaiterMatch, Argument: "Reservations[].Instances[].State.Name",
+			Expected: "stopped",
+		},
+		{
+			State:   request.FailureWaiterState,
+			Matcher: request.PathAnyWaiterMatch, Argument: "Reservations[].Instances[].State.Name",
+			Expected: "pending",
+		},
+		{
+			State:   request.FailureWaiterState,
+			Matcher: request.PathAnyWaiterMatch, Argument: "Reservations[].Instances[].State.Name",
+			Expected: "terminated",
+		},
+	}, _m.describeInstancesAttempt(_a0), opts)
+}
+
+// WaitUntilInstanceTerminated provides a mock function with given fields: _a0
+func (_m *EC2API) WaitUntilInstanceTerminated(_a0 *ec2.DescribeInstancesInput) error {
+	return _m.WaitUntilInstanceTerminatedWithContext(aws.BackgroundContext(), _a0)
+}
+
+// WaitUntilInstanceTerminatedWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) WaitUntilInstanceTerminatedWithContext(ctx aws.Context, _a0 *ec2.DescribeInstancesInput, opts ...request.WaiterOption) error {
+	return _m.wait(ctx, "WaitUntilInstanceTerminated", 40, 15*time.Second, []request.WaiterAcceptor{
+		{
+			State:   request.SuccessWaiterState,
+			Matcher: request.PathAllWaiterMatch, Argument: "Reservations[].Instances[].State.Name",
+			Expected: "terminated",
+		},
+		{
+			State:   request.FailureWaiterState,
+			Matcher: request.PathAnyWaiterMatch, Argument: "Reservations[].Instances[].State.Name",
+			Expected: "pending",
+		},
+		{
+			State:   request.FailureWaiterState,
+			Matcher: request.PathAnyWaiterMatch, Argument: "Reservations[].Instances[].State.Name",
+			Expected: "stopping",
+		},
+	}, _m.describeInstancesAttempt(_a0), opts)
+}
+
+// WaitUntilNatGatewayAvailable provides a mock function with given fields: _a0
+func (_m *EC2API) WaitUntilNatGatewayAvailable(_a0 *ec2.DescribeNatGatewaysInput) error {
+	return _m.WaitUntilNatGatewayAvailableWithContext(aws.BackgroundContext(), _a0)
+}
+
+// WaitUntilNatGatewayAvailableWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) WaitUntilNatGatewayAvailableWithContext(ctx aws.Context, _a0 *ec2.DescribeNatGatewaysInput, opts ...request.WaiterOption) error {
+	return _m.wait(ctx, "WaitUntilNatGatewayAvailable", 40, 15*time.Second, []request.WaiterAcceptor{
+		{
+			State:   request.SuccessWaiterState,
+			Matcher: request.PathAllWaiterMatch, Argument: "NatGateways[].State",
+			Expected: "available",
+		},
+		{
+			State:   request.FailureWaiterState,
+			Matcher: request.PathAnyWaiterMatch, Argument: "NatGateways[].State",
+			Expected: "failed",
+		},
+		{
+			State:   request.FailureWaiterState,
+			Matcher: request.PathAnyWaiterMatch, Argument: "NatGateways[].State",
+			Expected: "deleting",
+		},
+		{
+			State:   request.FailureWaiterState,
+			Matcher: request.PathAnyWaiterMatch, Argument: "NatGateways[].State",
+			Expected: "deleted",
+		},
+		{
+			State:    request.RetryWaiterState,
+			Matcher:  request.ErrorWaiterMatch,
+			Expected: "NatGatewayNotFound",
+		},
+	}, _m.describeNatGatewaysAttempt(_a0), opts)
+}
+
+// WaitUntilNetworkInterfaceAvailable provides a mock function with given fields: _a0
+func (_m *EC2API) WaitUntilNetworkInterfaceAvailable(_a0 *ec2.DescribeNetworkInterfacesInput) error {
+	return _m.WaitUntilNetworkInterfaceAvailableWithContext(aws.BackgroundContext(), _a0)
+}
+
+// WaitUntilNetworkInterfaceAvailableWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) WaitUntilNetworkInterfaceAvailableWithContext(ctx aws.Context, _a0 *ec2.DescribeNetworkInterfacesInput, opts ...request.WaiterOption) error {
+	return _m.wait(ctx, "WaitUntilNetworkInterfaceAvailable", 10, 20*time.Second, []request.WaiterAcceptor{
+		{
+			State:   request.SuccessWaiterState,
+			Matcher: request.PathAllWaiterMatch, Argument: "NetworkInterfaces[].Status",
+			Expected: "available",
+		},
+		{
+			State:    request.FailureWaiterState,
+			Matcher:  request.ErrorWaiterMatch,
+			Expected: "InvalidNetworkInterfaceID.NotFound",
+		},
+	}, _m.describeNetworkInterfacesAttempt(_a0), opts)
+}
+
+// WaitUntilSubnetAvailable provides a mock function with given fields: _a0
+func (_m *EC2API) WaitUntilSubnetAvailable(_a0 *ec2.DescribeSubnetsInput) error {
+	return _m.WaitUntilSubnetAvailableWithContext(aws.BackgroundContext(), _a0)
+}
+
+// WaitUntilSubnetAvailableWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) WaitUntilSubnetAvailableWithContext(ctx aws.Context, _a0 *ec2.DescribeSubnetsInput, opts ...request.WaiterOption) error {
+	return _m.wait(ctx, "WaitUntilSubnetAvailable", 40, 15*time.Second, []request.WaiterAcceptor{
+		{
+			State:   request.SuccessWaiterState,
+			Matcher: request.PathAllWaiterMatch, Argument: "Subnets[].State",
+			Expected: "available",
+		},
+	}, _m.describeSubnetsAttempt(_a0), opts)
+}
+
+// WaitUntilVolumeAvailable provides a mock function with given fields: _a0
+func (_m *EC2API) WaitUntilVolumeAvailable(_a0 *ec2.DescribeVolumesInput) error {
+	return _m.WaitUntilVolumeAvailableWithContext(aws.BackgroundContext(), _a0)
+}
+
+// WaitUntilVolumeAvailableWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) WaitUntilVolumeAvailableWithContext(ctx aws.Context, _a0 *ec2.DescribeVolumesInput, opts ...request.WaiterOption) error {
+	return _m.wait(ctx, "WaitUntilVolumeAvailable", 40, 15*time.Second, []request.WaiterAcceptor{
+		{
+			State:   request.SuccessWaiterState,
+			Matcher: request.PathAllWaiterMatch, Argument: "Volumes[].State",
+			Expected: "available",
+		},
+		{
+			State:   request.FailureWaiterState,
+			Matcher: request.PathAnyWaiterMatch, Argument: "Volumes[].State",
+			Expected: "deleted",
+		},
+	}, _m.describeVolumesAttempt(_a0), opts)
+}
+
+// WaitUntilVolumeDeleted provides a mock function with given fields: _a0
+func (_m *EC2API) WaitUntilVolumeDeleted(_a0 *ec2.DescribeVolumesInput) error {
+	return _m.WaitUntilVolumeDeletedWithContext(aws.BackgroundContext(), _a0)
+}
+
+// WaitUntilVolumeDeletedWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) WaitUntilVolumeDeletedWithContext(ctx aws.Context, _a0 *ec2.DescribeVolumesInput, opts ...request.WaiterOption) error {
+	return _m.wait(ctx, "WaitUntilVolumeDeleted", 40, 15*time.Second, []request.WaiterAcceptor{
+		{
+			State:   request.SuccessWaiterState,
+			Matcher: request.PathAllWaiterMatch, Argument: "Volumes[].State",
+			Expected: "deleted",
+		},
+		{
+			State:    request.SuccessWaiterState,
+			Matcher:  request.ErrorWaiterMatch,
+			Expected: "InvalidVolume.NotFound",
+		},
+	}, _m.describeVolumesAttempt(_a0), opts)
+}
+
+// WaitUntilVpcAvailable provides a mock function with given fields: _a0
+func (_m *EC2API) WaitUntilVpcAvailable(_a0 *ec2.DescribeVpcsInput) error {
+	return _m.WaitUntilVpcAvailableWithContext(aws.BackgroundContext(), _a0)
+}
+
+// WaitUntilVpcAvailableWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) WaitUntilVpcAvailableWithContext(ctx aws.Context, _a0 *ec2.DescribeVpcsInput, opts ...request.WaiterOption) error {
+	return _m.wait(ctx, "WaitUntilVpcAvailable", 40, 15*time.Second, []request.WaiterAcceptor{
+		{
+			State:   request.SuccessWaiterState,
+			Matcher: request.PathAllWaiterMatch, Argument: "Vpcs[].State",
+			Expected: "available",
+		},
+	}, _m.describeVpcsAttempt(_a0), opts)
+}
+
+// WaitUntilVpcExists provides a mock function with given fields: _a0
+func (_m *EC2API) WaitUntilVpcExists(_a0 *ec2.DescribeVpcsInput) error {
+	return _m.WaitUntilVpcExistsWithContext(aws.BackgroundContext(), _a0)
+}
+
+// WaitUntilVpcExistsWithContext provides a mock function with given fields: ctx, _a0, opts
+func (_m *EC2API) WaitUntilVpcExistsWithContext(ctx aws.Context, _a0 *ec2.DescribeVpcsInput, opts ...request.WaiterOption) error {
+	return _m.wait(ctx, "WaitUntilVpcExists", 5, 1*time.Second, []request.WaiterAcceptor{
+		{
+			State:    request.SuccessWaiterState,
+			Matcher:  request.StatusWaiterMatch,
+			Expected: 200,
+		},
+		{
+			State:    request.RetryWaiterState,
+			Matcher:  request.ErrorWaiterMatch,
+			Expected: "InvalidVpcID.NotFound",
+		},
+	}, _m.describeVpcsAttempt(_a0), opts)
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2018 The Avi Networks.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package ec2
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	aws "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	ec2 "github.com/aws/aws-sdk-go/service/ec2"
+)
+
+func TestWaitUntilInstanceRunningFollowsTheClock(t *testing.T) {
+	m := newSeededMock(t)
+	m.SetTransitionDelay(InstancePending, 2*time.Minute)
+	instance := runTestInstances(t, m, aws.String(m.GetDefaultSubnetID()), 1)[0]
+	input := &ec2.DescribeInstancesInput{InstanceIds: []*string{instance.InstanceId}}
+
+	// 15s between attempts never gets to the transition in 3 attempts
+	err := m.WaitUntilInstanceRunningWithContext(aws.BackgroundContext(), input, request.WithWaiterMaxAttempts(3))
+	expectErrorCode(t, err, request.WaiterResourceNotReadyErrorCode)
+	if err := m.WaitUntilInstanceRunning(input); err != nil {
+		t.Fatalf("WaitUntilInstanceRunning: %v", err)
+	}
+	if state := describeTestInstance(t, m, instance.InstanceId).State; aws.StringValue(state.Name) != "running" {
+		t.Fatalf("instance is %s after the waiter", aws.StringValue(state.Name))
+	}
+}
+
+func TestWaitUntilInstanceStoppedFailsOnTermination(t *testing.T) {
+	m := newSeededMock(t)
+	instance := runTestInstances(t, m, aws.String(m.GetDefaultSubnetID()), 1)[0]
+	input := &ec2.DescribeInstancesInput{InstanceIds: []*string{instance.InstanceId}}
+	if _, err := m.TerminateInstances(&ec2.TerminateInstancesInput{InstanceIds: input.InstanceIds}); err != nil {
+		t.Fatalf("TerminateInstances: %v", err)
+	}
+	expectErrorCode(t, m.WaitUntilInstanceStopped(input), request.WaiterResourceNotReadyErrorCode)
+	if err := m.WaitUntilInstanceTerminated(input); err != nil {
+		t.Fatalf("WaitUntilInstanceTerminated: %v", err)
+	}
+}
+
+func TestWaitUntilInstanceExistsNeedsAMatch(t *testing.T) {
+	m := newSeededMock(t)
+	runTestInstances(t, m, aws.String(m.GetDefaultSubnetID()), 1)
+	missing := &ec2.DescribeInstancesInput{Filters: []*ec2.Filter{filter("tag:Name", "does-not-exist")}}
+	output, err := m.DescribeInstances(missing)
+	if err != nil {
+		t.Fatalf("DescribeInstances: %v", err)
+	}
+	if len(output.Reservations) != 0 {
+		t.Fatalf("DescribeInstances matching nothing gave %d reservations", len(output.Reservations))
+	}
+	expectErrorCode(t, m.WaitUntilInstanceExists(missing), request.WaiterResourceNotReadyErrorCode)
+	if err := m.WaitUntilInstanceExists(&ec2.DescribeInstancesInput{}); err != nil {
+		t.Fatalf("WaitUntilInstanceExists: %v", err)
+	}
+}
+
+func TestWaitersStopWithTheContext(t *testing.T) {
+	m := newSeededMock(t)
+	m.SetTransitionDelay(InstancePending, time.Hour)
+	instance := runTestInstances(t, m, aws.String(m.GetDefaultSubnetID()), 1)[0]
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := m.WaitUntilInstanceRunningWithContext(ctx, &ec2.DescribeInstancesInput{InstanceIds: []*string{instance.InstanceId}})
+	expectErrorCode(t, err, request.CanceledErrorCode)
+}
+
+func TestDescribeInstancesGroupsByReservation(t *testing.T) {
+	m := newSeededMock(t)
+	pair, err := m.RunInstances(&ec2.RunInstancesInput{
+		ImageId:  aws.String("ami-pair"),
+		MinCount: aws.Int64(2),
+		MaxCount: aws.Int64(2),
+	})
+	if err != nil {
+		t.Fatalf("RunInstances: %v", err)
+	}
+	single, err := m.RunInstances(&ec2.RunInstancesInput{
+		ImageId:  aws.String("ami-single"),
+		MinCount: aws.Int64(1),
+		MaxCount: aws.Int64(1),
+	})
+	if err != nil {
+		t.Fatalf("RunInstances: %v", err)
+	}
+	output, err := m.DescribeInstances(&ec2.DescribeInstancesInput{Filters: []*ec2.Filter{filter("image-id", "ami-pair", "ami-single")}})
+	if err != nil {
+		t.Fatalf("DescribeInstances: %v", err)
+	}
+	want := map[string]int{*pair.ReservationId: 2, *single.ReservationId: 1}
+	if len(output.Reservations) != len(want) {
+		t.Fatalf("got %d reservations, want %d", len(output.Reservations), len(want))
+	}
+	for _, reservation := range output.Reservations {
+		if len(reservation.Instances) != want[aws.StringValue(reservation.ReservationId)] {
+			t.Errorf("reservation %s has %d instances", aws.StringValue(reservation.ReservationId), len(reservation.Instances))
+		}
+	}
+}